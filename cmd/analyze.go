@@ -2,27 +2,76 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/getplumber/plumber/configuration"
 	"github.com/getplumber/plumber/control"
+	"github.com/getplumber/plumber/utils"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Flags for analyze command
-	gitlabURL     string
-	projectPath   string
-	defaultBranch string
-	outputFile    string
-	printOutput   bool
-	configFile    string
-	threshold     float64
+	gitlabURL        string
+	projectPath      string
+	defaultBranch    string
+	outputFile       string
+	outputFormat     string
+	printOutput      bool
+	configFile       string
+	threshold        float64
+	precision        int
+	simulateRef      string
+	simulateSource   string
+	webhookURL       string
+	webhookFormat    string
+	webhookOnFailure bool
+	fixtureDumpDir   string
+	includeArchived  bool
+	includeInventory bool
+	outputDetail     string
+	noFail           bool
+	wideOutput       bool
+	outputWidth      int
+	enableControls   []string
+	disableControls  []string
+	historyFile      string
+	junitFile        string
+	markdownFile     string
+	codeQualityFile  string
+	strictCI         bool
+	noCICache        bool
 )
 
+// Formats of the JSON written by --output
+const (
+	// outputFormatFull writes the full analysis result
+	outputFormatFull = "full"
+	// outputFormatJSONIssues writes a flat array of the issues of all controls
+	outputFormatJSONIssues = "json-issues"
+)
+
+// Levels of detail of the text report, set with --detail
+const (
+	// detailMinimal prints the summary tables only
+	detailMinimal = "minimal"
+	// detailNormal prints each control with its metrics and issues
+	detailNormal = "normal"
+	// detailFull also prints diagnostics, the pipeline composition and the job inventory
+	detailFull = "full"
+)
+
+// stdoutPath is the output path writing to stdout instead of a file
+const stdoutPath = "-"
+
 var analyzeCmd = &cobra.Command{
 	Use:          "analyze",
 	Short:        "Analyze a GitLab project's CI/CD pipeline",
@@ -41,17 +90,91 @@ Required environment variables:
 Required flags:
   --gitlab-url    GitLab instance URL
   --project       Full path of the project
-  --config        Path to .plumber.yaml config file
+  --config        Path to .plumber.yaml config file (.json and .toml files are read as JSON and TOML)
   --threshold     Minimum compliance percentage to pass (0-100)
 
 Optional flags:
   --branch        Branch to analyze (defaults to project's default branch)
   --print         Print text output to stdout (default: true)
   --output        Write JSON results to file (optional)
+  --format        Format of the JSON written by --output: full or json-issues (default: full)
+  --precision     Number of decimals used to round compliance (default: 1)
+  --simulate-ref     Evaluate workflow and job rules for this ref (use refs/tags/<tag> for tags)
+  --simulate-source  Evaluate workflow and job rules for this pipeline source (default: push)
+  --webhook          POST a summary to this URL when the analysis completes
+  --webhook-format   Webhook payload format: slack or generic (default: generic)
+  --webhook-on-failure  Only send the webhook when compliance is below threshold
+  --fixture-dump     Dump collected data as JSON fixtures in this directory
+  --no-ci-cache      Don't reuse identical merged CI configurations (for debugging)
+  --no-fail          Report but exit 0 even if compliance is below threshold
+  --wide             Show the first issue of each control in the Issues table
+  --width            Width of the wide Issues table (default: $COLUMNS, or 120)
+  --offline          Only contact the GitLab instance (disables --webhook)
+  --enable           Enable a control, overriding the config file (repeatable)
+  --disable          Disable a control, overriding the config file (repeatable)
+  --junit            Write a JUnit XML report with a test case per control
+  --markdown         Write a markdown report (e.g., to post as a merge request note)
+  --history          Append the compliance of this run to a JSONL file (see plumber trend)
+  --strict-ci        Fail when the CI configuration is missing or invalid
+
+When --simulate-ref or --simulate-source is set, workflow:rules and job
+rules (or only/except) are evaluated and image controls only consider jobs
+that would run. Jobs excluded by rules are reported.
+
+When --webhook is set, a JSON summary (project, compliance, pass/fail and
+the first issues) is sent once the analysis completes. Webhook failures are
+reported as warnings and never change the exit code.
+
+Compliance is rounded to --precision decimals before being displayed and
+compared to the threshold, so the printed value always matches the outcome
+(e.g., 99.95% is shown and evaluated as 100.0%).
+
+When --offline is set, only the GitLab instance is contacted: features
+needing other outbound calls (e.g., --webhook) are disabled with a note.
+
+When --enable or --disable is set, the enabled field of the named controls
+(keys of the controls section, e.g. branchMustBeProtected) is overridden
+after the config file is loaded. A control enabled this way without a
+section in the file runs with its default settings. Controls with required
+settings (e.g., the allowed domains of environmentUrlAllowlist) need their
+section, --enable rejects them otherwise.
+
+When --junit is set, a JUnit XML report is written in addition to any
+--output JSON: each control is a test case, failing with its issues when its
+compliance is below the threshold, or skipped when it didn't run. Declare it
+as artifacts:reports:junit to show controls in the merge request test report.
+
+When --markdown is set, a markdown report is written (- for stdout, which
+disables --print): a table of the controls with their compliance and status,
+then the issues of each control in collapsible sections. It has no color
+codes and can be posted as is as a merge request note.
+
+--output, --markdown, --junit and --codequality accept - for stdout, which
+disables --print. Only one of them can be written to stdout.
+
+When --history is set, a JSON line with the overall and per control
+compliance of the run is appended to the file. Print the trend of the
+recorded runs with plumber trend.
+
+A missing or invalid CI configuration stops the analysis before any control
+runs, pipeline, protection and project controls alike: compliance is 0, so
+the analysis fails any threshold above 0 unless --no-fail is set. When
+--strict-ci is set (or strictCi: true in the config file), it fails with the
+reason (missing or invalid CI), whatever the threshold and --no-fail.
+
+A control can set its own threshold in the config file (threshold: 0-100
+in its section): the analysis fails when the control is below it, whatever
+the overall compliance, and the error names the failing controls. The
+--threshold flag applies to the overall compliance, and to each control
+without its own threshold.
+
+When --no-fail is set, the full analysis runs and all outputs are written,
+but a compliance below the threshold doesn't fail the command. Use it to
+roll out Plumber in monitor mode before enforcing the threshold.
 
 Exit codes:
-  0  Analysis passed (compliance >= threshold)
-  1  Analysis failed (compliance < threshold or error occurred)
+  0  Analysis passed (compliance >= threshold, or any compliance with --no-fail)
+  1  Analysis failed (compliance < threshold, a control below its own threshold, CI missing or invalid with --strict-ci, or error occurred)
 
 Examples:
   # Set token via environment variable
@@ -81,7 +204,30 @@ func init() {
 	// Optional flags
 	analyzeCmd.Flags().StringVar(&defaultBranch, "branch", "", "Branch to analyze (defaults to project's default branch)")
 	analyzeCmd.Flags().BoolVar(&printOutput, "print", true, "Print text output to stdout")
-	analyzeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write JSON results to file")
+	analyzeCmd.Flags().StringVar(&outputDetail, "detail", detailNormal, "Detail of the text output: minimal (summary only), normal or full (with diagnostics, composition and job inventory)")
+	analyzeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write JSON results to file (- for stdout, disables --print)")
+	analyzeCmd.Flags().StringVar(&outputFormat, "format", outputFormatFull, "Format of the JSON written by --output: full or json-issues (flat array of issues)")
+	analyzeCmd.Flags().StringVar(&junitFile, "junit", "", "Write a JUnit XML report to file (- for stdout, disables --print), with a test case per control (e.g., for the GitLab merge request test report)")
+	analyzeCmd.Flags().StringVar(&codeQualityFile, "codequality", "", "Write a GitLab Code Quality JSON report to file (- for stdout, disables --print), with an entry per issue (e.g., gl-code-quality-report.json for the merge request widget)")
+	analyzeCmd.Flags().StringVar(&markdownFile, "markdown", "", "Write a markdown report to file (- for stdout, disables --print), e.g. to post as a merge request note")
+	analyzeCmd.Flags().IntVar(&precision, "precision", configuration.DefaultCompliancePrecision, "Number of decimals used to round compliance for display and threshold comparison")
+	analyzeCmd.Flags().StringVar(&simulateRef, "simulate-ref", "", "Evaluate workflow and job rules for this ref (use refs/tags/<tag> for tags)")
+	analyzeCmd.Flags().StringVar(&simulateSource, "simulate-source", "", "Evaluate workflow and job rules for this pipeline source (e.g., push, merge_request_event, schedule)")
+	analyzeCmd.Flags().StringVar(&webhookURL, "webhook", "", "POST a summary (compliance, pass/fail, top issues) to this URL when the analysis completes")
+	analyzeCmd.Flags().StringVar(&webhookFormat, "webhook-format", webhookFormatGeneric, "Webhook payload format: slack or generic")
+	analyzeCmd.Flags().BoolVar(&webhookOnFailure, "webhook-on-failure", false, "Only send the webhook when compliance is below threshold")
+	analyzeCmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Analyze the project even if it is archived (archived projects are skipped by default)")
+	analyzeCmd.Flags().BoolVar(&includeInventory, "include-inventory", false, "Add the list of jobs with their origin and image to the JSON written by --output (full format)")
+	analyzeCmd.Flags().BoolVar(&noFail, "no-fail", false, "Report results but exit 0 even if compliance is below threshold (errors still fail)")
+	analyzeCmd.Flags().BoolVar(&wideOutput, "wide", false, "Show the first issue of each control in the Issues table")
+	analyzeCmd.Flags().IntVar(&outputWidth, "width", 0, "Width of the wide Issues table in characters (defaults to $COLUMNS, or 120)")
+	analyzeCmd.Flags().StringSliceVar(&enableControls, "enable", nil, "Enable a control, overriding the config file (repeatable, e.g. --enable branchMustBeProtected)")
+	analyzeCmd.Flags().StringSliceVar(&disableControls, "disable", nil, "Disable a control, overriding the config file (repeatable)")
+	analyzeCmd.Flags().BoolVar(&strictCI, "strict-ci", false, "Fail the analysis when the CI configuration is missing or invalid")
+	analyzeCmd.Flags().StringVar(&historyFile, "history", "", "Append the overall and per control compliance of this run to a JSONL file, printed by plumber trend")
+
+	analyzeCmd.Flags().StringVar(&fixtureDumpDir, "fixture-dump", "", "Dump collected data (secrets redacted) as JSON fixtures in this directory, to reproduce issues")
+	analyzeCmd.Flags().BoolVar(&noCICache, "no-ci-cache", false, "Request every merged CI configuration from GitLab instead of reusing identical merges of the analysis (for debugging)")
 
 	// Mark required flags
 	_ = analyzeCmd.MarkFlagRequired("gitlab-url")
@@ -111,8 +257,46 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("threshold must be between 0 and 100")
 	}
 
-	// Clean up URL
-	cleanGitlabURL := strings.TrimSuffix(gitlabURL, "/")
+	if outputWidth < 0 {
+		return fmt.Errorf("width must be a positive number")
+	}
+
+	// Validate precision
+	if precision < 0 {
+		return fmt.Errorf("precision must be a non-negative number")
+	}
+
+	// Validate simulated pipeline source
+	if simulateSource != "" && !isValidPipelineSource(simulateSource) {
+		return fmt.Errorf("invalid --simulate-source '%s', valid values are: %s", simulateSource, strings.Join(validPipelineSources, ", "))
+	}
+
+	// Validate output format
+	if outputFormat != outputFormatFull && outputFormat != outputFormatJSONIssues {
+		return fmt.Errorf("invalid --format '%s', valid values are: %s, %s", outputFormat, outputFormatFull, outputFormatJSONIssues)
+	}
+
+	// Validate detail level
+	if outputDetail != detailMinimal && outputDetail != detailNormal && outputDetail != detailFull {
+		return fmt.Errorf("invalid --detail '%s', valid values are: %s, %s, %s", outputDetail, detailMinimal, detailNormal, detailFull)
+	}
+
+	// Only one report can go to stdout, they would be mixed otherwise
+	stdoutReport, err := stdoutReportFlag(outputFile, markdownFile, junitFile, codeQualityFile)
+	if err != nil {
+		return err
+	}
+
+	// Validate webhook format
+	if webhookFormat != webhookFormatGeneric && webhookFormat != webhookFormatSlack {
+		return fmt.Errorf("invalid --webhook-format '%s', valid values are: %s, %s", webhookFormat, webhookFormatGeneric, webhookFormatSlack)
+	}
+
+	// Validate and clean up URL
+	cleanGitlabURL, err := configuration.NormalizeGitlabURL(gitlabURL)
+	if err != nil {
+		return err
+	}
 
 	// Load Plumber configuration (required)
 	plumberConfig, configPath, err := configuration.LoadPlumberConfig(configFile)
@@ -120,6 +304,16 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("configuration error: %w", err)
 	}
 
+	// Override the enabled field of controls named on the command line
+	if err := overrideControls(plumberConfig, enableControls, disableControls); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Report all control configuration problems at once, before any GitLab call
+	if err := control.ValidateControlConfigs(plumberConfig); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
 	fmt.Fprintf(os.Stderr, "Using configuration: %s\n", configPath)
 
 	// Create configuration
@@ -128,7 +322,22 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	conf.GitlabToken = gitlabToken
 	conf.ProjectPath = projectPath
 	conf.Branch = defaultBranch
+	conf.SimulateRef = simulateRef
+	conf.SimulateSource = simulateSource
+	conf.FixtureDumpDir = fixtureDumpDir
+	conf.DisableMergedCIConfCache = noCICache
+	conf.IncludeArchived = includeArchived
+	// The full text report lists the job inventory, collect it even if it's
+	// not requested in the JSON output
+	conf.IncludeInventory = includeInventory || (printOutput && outputDetail == detailFull)
+	conf.Offline = offline
 	conf.PlumberConfig = plumberConfig
+	if len(plumberConfig.OfficialCatalogNamespaces) > 0 {
+		conf.OfficialCatalogNamespaces = plumberConfig.OfficialCatalogNamespaces
+	}
+	conf.CompliancePrecision = precision
+	strictCI = strictCI || plumberConfig.StrictCi
+	conf.StrictCI = strictCI
 
 	if verbose {
 		conf.LogLevel = logrus.DebugLevel
@@ -142,73 +351,240 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("analysis failed: %w", err)
 	}
 
-	// Calculate overall compliance (average of all enabled controls)
-	var complianceSum float64 = 0
-	controlCount := 0
+	// Round the compliance of each control like the overall compliance, for
+	// the JSON output to match the text report and the thresholds
+	result.RoundCompliance(conf.CompliancePrecision)
 
-	if result.ImageForbiddenTagsResult != nil && !result.ImageForbiddenTagsResult.Skipped {
-		complianceSum += result.ImageForbiddenTagsResult.Compliance
-		controlCount++
+	// Archived projects are skipped: nothing to enforce, the threshold doesn't apply
+	if result.SkippedArchived {
+		fmt.Fprintf(os.Stderr, "Skipped (archived): %s (use --include-archived to analyze it)\n", projectPath)
+		if outputFile != "" {
+			if err := writeJSONToFile(result, threshold, 0, outputFile); err != nil {
+				return err
+			}
+			printResultsWritten(outputFile)
+		}
+		if junitFile != "" {
+			if err := writeJUnitToFile(result, conf.CompliancePrecision, junitFile); err != nil {
+				return err
+			}
+			printJUnitWritten(junitFile)
+		}
+		if codeQualityFile != "" {
+			if err := writeCodeQualityToFile(result, codeQualityFile); err != nil {
+				return err
+			}
+			printCodeQualityWritten(codeQualityFile)
+		}
+		if markdownFile != "" {
+			if err := writeMarkdownToFile(result, threshold, 0, conf.CompliancePrecision, markdownFile); err != nil {
+				return err
+			}
+			printMarkdownWritten(markdownFile)
+		}
+		return nil
 	}
 
-	if result.ImageAuthorizedSourcesResult != nil && !result.ImageAuthorizedSourcesResult.Skipped {
-		complianceSum += result.ImageAuthorizedSourcesResult.Compliance
-		controlCount++
-	}
+	// Calculate overall compliance (average of all enabled controls). If no
+	// control ran (e.g., data collection failed), compliance is 0%: nothing
+	// could be verified.
+	compliance, controlCount := result.OverallCompliance()
 
-	if result.BranchProtectionResult != nil && !result.BranchProtectionResult.Skipped {
-		complianceSum += result.BranchProtectionResult.Compliance
-		controlCount++
-	}
+	// Round compliance so that the displayed value and the threshold
+	// comparison always agree (e.g., 99.95 is shown and evaluated as 100.0)
+	compliance = utils.RoundToPrecision(compliance, conf.CompliancePrecision)
 
-	// Calculate average compliance
-	// If no controls ran (e.g., data collection failed), compliance is 0% - we can't verify anything
-	var compliance float64 = 0
-	if controlCount > 0 {
-		compliance = complianceSum / float64(controlCount)
+	// With strict CI, a missing or invalid CI configuration can't pass
+	if result.StrictCiFailure != "" {
+		compliance = 0
 	}
 
-	// Print text output to stdout if enabled
-	if printOutput {
-		if err := outputText(result, threshold, compliance, controlCount); err != nil {
+	// A control below the threshold of its configuration, or below the global
+	// threshold when it has none, fails the analysis whatever the overall
+	// compliance. All the outputs read these failures.
+	result.ThresholdFailures = control.ControlThresholdFailures(result, plumberConfig, threshold, conf.CompliancePrecision)
+
+	// Print text output to stdout if enabled. It is disabled when a report
+	// goes to stdout, so that it can be piped (e.g., --output - | jq).
+	if printOutput && stdoutReport == "" {
+		if err := outputText(result, threshold, compliance, controlCount, conf.CompliancePrecision); err != nil {
 			return err
 		}
 	}
 
 	// Write JSON to file if specified
 	if outputFile != "" {
-		if err := writeJSONToFile(result, threshold, compliance, outputFile); err != nil {
+		// The inventory may only have been collected for the text report
+		if !includeInventory {
+			result.Inventory = nil
+		}
+
+		var err error
+		if outputFormat == outputFormatJSONIssues {
+			err = writeJSONIssuesToFile(result, outputFile)
+		} else {
+			err = writeJSONToFile(result, threshold, compliance, outputFile)
+		}
+		if err != nil {
+			return err
+		}
+		printResultsWritten(outputFile)
+	}
+
+	// Write the JUnit report, alongside the JSON results if both are requested
+	if junitFile != "" {
+		if err := writeJUnitToFile(result, conf.CompliancePrecision, junitFile); err != nil {
+			return err
+		}
+		printJUnitWritten(junitFile)
+	}
+
+	// Write the Code Quality report
+	if codeQualityFile != "" {
+		if err := writeCodeQualityToFile(result, codeQualityFile); err != nil {
 			return err
 		}
-		fmt.Fprintf(os.Stderr, "Results written to: %s\n", outputFile)
+		printCodeQualityWritten(codeQualityFile)
+	}
+
+	// Write the markdown report
+	if markdownFile != "" {
+		if err := writeMarkdownToFile(result, threshold, compliance, conf.CompliancePrecision, markdownFile); err != nil {
+			return err
+		}
+		printMarkdownWritten(markdownFile)
+	}
+
+	if fixtureDumpDir != "" {
+		fmt.Fprintf(os.Stderr, "Fixtures written to: %s\n", fixtureDumpDir)
+	}
+
+	// Record the compliance of this run for plumber trend
+	if historyFile != "" {
+		record, err := buildHistoryRecord(result, compliance, conf.CompliancePrecision)
+		if err == nil {
+			err = appendHistoryRecord(historyFile, record)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "History recorded in: %s\n", historyFile)
+	}
+
+	// Send webhook notification if requested (never changes the exit code)
+	if webhookURL != "" {
+		notifyWebhook(conf, webhookURL, webhookFormat, webhookOnFailure, result, threshold, compliance)
+	}
+
+	// A strict CI failure fails the command even with --no-fail
+	if result.StrictCiFailure != "" {
+		return fmt.Errorf("%s (--strict-ci)", result.StrictCiFailure)
+	}
+
+	// Check compliance against the global and per control thresholds
+	if !analysisPassed(result, threshold, compliance) {
+		reason := thresholdFailureReason(result, threshold, compliance, conf.CompliancePrecision)
+		if noFail {
+			fmt.Fprintf(os.Stderr, "%s, not failing (--no-fail)\n", strings.ToUpper(reason[:1])+reason[1:])
+			return nil
+		}
+		return errors.New(reason)
 	}
 
-	// Check compliance against threshold
+	return nil
+}
+
+// analysisPassed reports whether the analysis passes: the overall compliance
+// reaches the threshold, no control is below its own threshold and, with
+// --strict-ci, the CI configuration is valid
+func analysisPassed(result *control.AnalysisResult, threshold, compliance float64) bool {
+	return compliance >= threshold && len(result.ThresholdFailures) == 0 && result.StrictCiFailure == ""
+}
+
+// thresholdFailureReason explains why the compliance fails, naming the
+// controls below their own threshold
+// (e.g., compliance 80.0% is below threshold 90.0%; branchMustBeProtected 50.0% < 100.0%)
+func thresholdFailureReason(result *control.AnalysisResult, threshold, compliance float64, precision int) string {
+	reasons := []string{}
 	if compliance < threshold {
-		return fmt.Errorf("compliance %.1f%% is below threshold %.1f%%", compliance, threshold)
+		reasons = append(reasons, fmt.Sprintf("compliance %s is below threshold %s", formatCompliance(compliance, precision), formatCompliance(threshold, precision)))
+	}
+	if len(result.ThresholdFailures) > 0 {
+		failures := make([]string, 0, len(result.ThresholdFailures))
+		for _, failure := range result.ThresholdFailures {
+			failures = append(failures, fmt.Sprintf("%s %s < %s", failure.Control, formatCompliance(failure.Compliance, precision), formatCompliance(failure.Threshold, precision)))
+		}
+		reasons = append(reasons, "controls below their threshold: "+strings.Join(failures, ", "))
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// overrideControls enables then disables the named controls of a loaded
+// configuration. A control can't be both enabled and disabled, nor enabled
+// without a section in the file when it has required settings.
+func overrideControls(plumberConfig *configuration.PlumberConfig, enable, disable []string) error {
+	enabled := map[string]bool{}
+	for _, name := range enable {
+		enabled[name] = true
+	}
+	for _, name := range disable {
+		if enabled[name] {
+			return fmt.Errorf("control '%s' is both enabled and disabled on the command line", name)
+		}
 	}
 
+	for _, name := range enable {
+		hasSection := plumberConfig.HasControlConfig(name)
+		if err := plumberConfig.SetControlEnabled(name, true); err != nil {
+			return err
+		}
+		// Settings without default (e.g., the allowed domains of
+		// environmentUrlAllowlist) can only come from the file
+		if !hasSection {
+			if problems := control.ControlConfigProblems(plumberConfig, name); len(problems) > 0 {
+				return fmt.Errorf("control '%s' can't be enabled without its section in the config file, add %s under controls: %s", name, name, problems[0])
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Control enabled by --enable: %s\n", name)
+	}
+	for _, name := range disable {
+		if err := plumberConfig.SetControlEnabled(name, false); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Control disabled by --disable: %s\n", name)
+	}
 	return nil
 }
 
+// JSONSchemaVersion is the version of the JSON report structure written by --output.
+// Consumers can rely on it to detect format changes: the major version is bumped
+// when fields are removed, renamed or change type, the minor version when fields
+// are added.
+const JSONSchemaVersion = "1.0"
+
 func writeJSONToFile(result *control.AnalysisResult, threshold, compliance float64, filePath string) error {
-	// Create output with threshold info
+	// Create output with schema and threshold info
 	output := struct {
+		SchemaVersion  string    `json:"schemaVersion"`
+		GeneratedAt    time.Time `json:"generatedAt"`
+		PlumberVersion string    `json:"version"`
 		*control.AnalysisResult
 		Threshold  float64 `json:"threshold"`
 		Compliance float64 `json:"compliance"`
 		Passed     bool    `json:"passed"`
 	}{
+		SchemaVersion:  JSONSchemaVersion,
+		GeneratedAt:    time.Now().UTC(),
+		PlumberVersion: Version,
 		AnalysisResult: result,
 		Threshold:      threshold,
 		Compliance:     compliance,
-		Passed:         compliance >= threshold,
+		Passed:         analysisPassed(result, threshold, compliance) || result.SkippedArchived,
 	}
 
-	// Create/overwrite the file
-	file, err := os.Create(filePath)
+	file, err := createOutputFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+		return err
 	}
 	defer file.Close()
 
@@ -217,6 +593,78 @@ func writeJSONToFile(result *control.AnalysisResult, threshold, compliance float
 	return encoder.Encode(output)
 }
 
+// writeJSONIssuesToFile writes the issues of all controls as a flat JSON
+// array, easier to filter with jq than the full result
+// (e.g., jq 'map(select(.severity == "high"))')
+func writeJSONIssuesToFile(result *control.AnalysisResult, filePath string) error {
+	file, err := createOutputFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result.ControlIssues())
+}
+
+// stdoutReportFlag returns the flag of the report written to stdout (e.g.,
+// --output for --output -), empty if all reports go to files. More than one
+// report on stdout is an error.
+func stdoutReportFlag(outputFile, markdownFile, junitFile, codeQualityFile string) (string, error) {
+	reports := []struct {
+		flag string
+		path string
+	}{
+		{"--output", outputFile},
+		{"--markdown", markdownFile},
+		{"--junit", junitFile},
+		{"--codequality", codeQualityFile},
+	}
+
+	flags := []string{}
+	for _, report := range reports {
+		if report.path == stdoutPath {
+			flags = append(flags, report.flag)
+		}
+	}
+	if len(flags) > 1 {
+		return "", fmt.Errorf("only one report can be written to stdout (-), got %s", strings.Join(flags, ", "))
+	}
+	if len(flags) == 0 {
+		return "", nil
+	}
+	return flags[0], nil
+}
+
+// createOutputFile creates (or overwrites) an output file. The "-" path
+// returns stdout, which is left open when the result is closed.
+func createOutputFile(filePath string) (io.WriteCloser, error) {
+	if filePath == stdoutPath {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return file, nil
+}
+
+// nopWriteCloser is a writer whose Close does nothing
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// printResultsWritten tells on stderr where the results were written
+func printResultsWritten(filePath string) {
+	if filePath == stdoutPath {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Results written to: %s\n", filePath)
+}
+
 // ANSI color codes
 const (
 	colorReset  = "\033[0m"
@@ -230,130 +678,81 @@ const (
 
 // controlSummary holds summary data for a control
 type controlSummary struct {
+	key        string // Control name in .plumber.yaml, used to find its issues
 	name       string
 	compliance float64
 	issues     int
 	skipped    bool
+	firstIssue string // Message of the first issue, shown in the wide Issues table
 }
 
-func outputText(result *control.AnalysisResult, threshold, compliance float64, controlCount int) error {
-	// Collect control summaries for tables
-	var controls []controlSummary
+// defaultOutputWidth is the width of the wide Issues table when neither
+// --width nor $COLUMNS is set
+const defaultOutputWidth = 120
 
+// minFirstIssueWidth is the minimum width of the "First Issue" column
+const minFirstIssueWidth = 20
+
+func outputText(result *control.AnalysisResult, threshold, compliance float64, controlCount, precision int) error {
 	// Header
 	fmt.Printf("\n%sProject: %s%s\n\n", colorBold, result.ProjectPath, colorReset)
 
+	// CI configuration status, the analysis fails on it with --strict-ci
+	if strictCI {
+		printCiStatus(result)
+	}
+
 	// Warning if no controls could be evaluated
-	if controlCount == 0 {
+	if controlCount == 0 && result.StrictCiFailure == "" {
 		fmt.Printf("  %s⚠ WARNING: No controls could be evaluated!%s\n", colorRed, colorReset)
 		fmt.Printf("  %sData collection failed - compliance defaults to 0%%.%s\n", colorDim, colorReset)
 		fmt.Printf("  %sCheck the logs above for details (use --verbose for more info).%s\n\n", colorDim, colorReset)
 	}
 
-	// Control 1: Container images must not use forbidden tags
-	if result.ImageForbiddenTagsResult != nil {
-		ctrl := controlSummary{
-			name:       "Container images must not use forbidden tags",
-			compliance: result.ImageForbiddenTagsResult.Compliance,
-			issues:     len(result.ImageForbiddenTagsResult.Issues),
-			skipped:    result.ImageForbiddenTagsResult.Skipped,
-		}
-		controls = append(controls, ctrl)
-
-		printControlHeader("Container images must not use forbidden tags", result.ImageForbiddenTagsResult.Compliance, result.ImageForbiddenTagsResult.Skipped)
+	// Controls that failed unexpectedly, always shown as their compliance is 0
+	if len(result.ControlErrors) > 0 {
+		printControlErrors(result.ControlErrors)
+	}
 
-		if result.ImageForbiddenTagsResult.Skipped {
-			fmt.Printf("  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	// Diagnostics (details only with --detail full, --verbose logs them too)
+	if len(result.Diagnostics) > 0 {
+		if outputDetail == detailFull {
+			printDiagnostics(result.Diagnostics)
 		} else {
-			fmt.Printf("  Total Images: %d\n", result.ImageForbiddenTagsResult.Metrics.Total)
-			fmt.Printf("  Using Forbidden Tags: %d\n", result.ImageForbiddenTagsResult.Metrics.UsingForbiddenTags)
-
-			if len(result.ImageForbiddenTagsResult.Issues) > 0 {
-				fmt.Printf("\n  %sForbidden Tags Found:%s\n", colorYellow, colorReset)
-				for _, issue := range result.ImageForbiddenTagsResult.Issues {
-					fmt.Printf("    %s•%s Job '%s' uses forbidden tag '%s' (image: %s)\n", colorYellow, colorReset, issue.Job, issue.Tag, issue.Link)
-				}
-			}
+			fmt.Printf("  %s%d diagnostic(s) found while collecting data (use --detail full to display them).%s\n\n", colorDim, len(result.Diagnostics), colorReset)
 		}
-		fmt.Println()
 	}
 
-	// Control 2: Container images must come from authorized sources
-	if result.ImageAuthorizedSourcesResult != nil {
-		ctrl := controlSummary{
-			name:       "Container images must come from authorized sources",
-			compliance: result.ImageAuthorizedSourcesResult.Compliance,
-			issues:     len(result.ImageAuthorizedSourcesResult.Issues),
-			skipped:    result.ImageAuthorizedSourcesResult.Skipped,
-		}
-		controls = append(controls, ctrl)
-
-		printControlHeader("Container images must come from authorized sources", result.ImageAuthorizedSourcesResult.Compliance, result.ImageAuthorizedSourcesResult.Skipped)
-
-		if result.ImageAuthorizedSourcesResult.Skipped {
-			fmt.Printf("  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
-		} else {
-			fmt.Printf("  Total Images: %d\n", result.ImageAuthorizedSourcesResult.Metrics.Total)
-			fmt.Printf("  Authorized: %d\n", result.ImageAuthorizedSourcesResult.Metrics.Authorized)
-			fmt.Printf("  Unauthorized: %d\n", result.ImageAuthorizedSourcesResult.Metrics.Unauthorized)
-
-			if len(result.ImageAuthorizedSourcesResult.Issues) > 0 {
-				fmt.Printf("\n  %sUnauthorized Images Found:%s\n", colorYellow, colorReset)
-				for _, issue := range result.ImageAuthorizedSourcesResult.Issues {
-					fmt.Printf("    %s•%s Job '%s' uses unauthorized image: %s\n", colorYellow, colorReset, issue.Job, issue.Link)
-				}
-			}
-		}
-		fmt.Println()
+	// Pipeline composition and job inventory
+	if outputDetail == detailFull {
+		printComposition(result)
+		printInventory(result.Inventory)
 	}
 
-	// Control 3: Branch must be protected
-	if result.BranchProtectionResult != nil {
-		ctrl := controlSummary{
-			name:       "Branch must be protected",
-			compliance: result.BranchProtectionResult.Compliance,
-			issues:     len(result.BranchProtectionResult.Issues),
-			skipped:    result.BranchProtectionResult.Skipped,
-		}
-		controls = append(controls, ctrl)
+	// Rules simulation
+	if result.RulesSimulation != nil && outputDetail != detailMinimal {
+		printRulesSimulation(result.RulesSimulation)
+	}
 
-		printControlHeader("Branch must be protected", result.BranchProtectionResult.Compliance, result.BranchProtectionResult.Skipped)
+	// Controls are only listed in the summary tables with --detail minimal
+	var details io.Writer = os.Stdout
+	if outputDetail == detailMinimal {
+		details = io.Discard
+	}
 
-		if result.BranchProtectionResult.Skipped {
-			fmt.Printf("  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
-		} else {
-			if result.BranchProtectionResult.Metrics != nil {
-				fmt.Printf("  Total Branches: %d\n", result.BranchProtectionResult.Metrics.Branches)
-				fmt.Printf("  Branches to Protect: %d\n", result.BranchProtectionResult.Metrics.BranchesToProtect)
-				fmt.Printf("  Protected Branches: %d\n", result.BranchProtectionResult.Metrics.TotalProtectedBranches)
-				fmt.Printf("  Unprotected: %d\n", result.BranchProtectionResult.Metrics.UnprotectedBranches)
-				fmt.Printf("  Non-Compliant: %d\n", result.BranchProtectionResult.Metrics.NonCompliantBranches)
-			}
+	controls := printControls(details, result, precision)
 
-			if len(result.BranchProtectionResult.Issues) > 0 {
-				fmt.Printf("\n  %sIssues Found:%s\n", colorYellow, colorReset)
-				for _, issue := range result.BranchProtectionResult.Issues {
-					if issue.Type == "unprotected" {
-						fmt.Printf("    %s•%s Branch '%s' is not protected\n", colorYellow, colorReset, issue.BranchName)
-					} else {
-						fmt.Printf("    %s•%s Branch '%s' has non-compliant protection settings\n", colorYellow, colorReset, issue.BranchName)
-						if issue.AllowForcePushDisplay {
-							fmt.Printf("      └─ Force push is allowed (should be disabled)\n")
-						}
-						if issue.CodeOwnerApprovalRequiredDisplay {
-							fmt.Printf("      └─ Code owner approval is not required\n")
-						}
-						if issue.MinMergeAccessLevelDisplay {
-							fmt.Printf("      └─ Merge access level is too low (%d, minimum: %d)\n", issue.MinMergeAccessLevel, issue.AuthorizedMinMergeAccessLevel)
-						}
-						if issue.MinPushAccessLevelDisplay {
-							fmt.Printf("      └─ Push access level is too low (%d, minimum: %d)\n", issue.MinPushAccessLevel, issue.AuthorizedMinPushAccessLevel)
-						}
-					}
-				}
+	// The wide Issues table shows the first issue of each control
+	if wideOutput {
+		firstIssues := map[string]string{}
+		for _, issue := range result.ControlIssues() {
+			if _, found := firstIssues[issue.Control]; !found {
+				firstIssues[issue.Control] = issue.Message
 			}
 		}
-		fmt.Println()
+		for i := range controls {
+			controls[i].firstIssue = firstIssues[controls[i].key]
+		}
 	}
 
 	// Summary Section
@@ -361,39 +760,189 @@ func outputText(result *control.AnalysisResult, threshold, compliance float64, c
 	fmt.Println()
 
 	// Status
-	if compliance >= threshold {
+	if result.StrictCiFailure != "" {
+		fmt.Printf("  Status: %s%sFAILED ✗%s %s(%s, --strict-ci)%s\n\n", colorBold, colorRed, colorReset, colorDim, result.StrictCiFailure, colorReset)
+	} else if analysisPassed(result, threshold, compliance) {
 		fmt.Printf("  Status: %s%sPASSED ✓%s\n\n", colorBold, colorGreen, colorReset)
+	} else if len(result.ThresholdFailures) > 0 {
+		fmt.Printf("  Status: %s%sFAILED ✗%s %s(%s)%s\n\n", colorBold, colorRed, colorReset, colorDim, thresholdFailureReason(result, threshold, compliance, precision), colorReset)
 	} else {
 		fmt.Printf("  Status: %s%sFAILED ✗%s\n\n", colorBold, colorRed, colorReset)
 	}
+	if noFail {
+		fmt.Printf("  %sFail on threshold: disabled (--no-fail), exit code is always 0%s\n\n", colorDim, colorReset)
+	}
 
 	// Issues Table
 	printIssuesTable(controls)
 	fmt.Println()
 
 	// Compliance Table
-	printComplianceTable(controls, compliance, threshold)
+	printComplianceTable(controls, compliance, threshold, precision)
 	fmt.Println()
 
 	return nil
 }
 
-func printControlHeader(name string, compliance float64, skipped bool) {
+// validPipelineSources are the pipeline sources accepted by --simulate-source
+var validPipelineSources = []string{
+	"api", "chat", "external", "external_pull_request_event", "merge_request_event",
+	"parent_pipeline", "pipeline", "push", "schedule", "trigger", "web", "webide",
+}
+
+func isValidPipelineSource(source string) bool {
+	for _, valid := range validPipelineSources {
+		if source == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// printCiStatus prints the status of the CI configuration, failing the
+// analysis with --strict-ci when it is missing or invalid
+func printCiStatus(result *control.AnalysisResult) {
+	switch {
+	case result.CiMissing:
+		fmt.Printf("  CI Configuration: %s%sMISSING ✗%s\n", colorBold, colorRed, colorReset)
+	case !result.CiValid:
+		fmt.Printf("  CI Configuration: %s%sINVALID ✗%s\n", colorBold, colorRed, colorReset)
+	default:
+		fmt.Printf("  CI Configuration: %sVALID ✓%s\n\n", colorGreen, colorReset)
+		return
+	}
+	if result.StrictCiFailure != "" {
+		fmt.Printf("  %sNo control could run, the analysis fails (--strict-ci).%s\n", colorRed, colorReset)
+	}
+	fmt.Println()
+}
+
+// printControlErrors lists the controls that failed unexpectedly
+func printControlErrors(controlErrors []control.ControlError) {
+	fmt.Printf("  %s⚠ WARNING: %d control(s) failed unexpectedly, their compliance is 0%%:%s\n", colorRed, len(controlErrors), colorReset)
+	for _, controlError := range controlErrors {
+		fmt.Printf("    %s•%s %s: %s\n", colorRed, colorReset, controlError.Control, controlError.Error)
+	}
+	fmt.Printf("  %sPlease report it with the output of --verbose, which includes the stack trace.%s\n\n", colorDim, colorReset)
+}
+
+func printDiagnostics(diagnostics []string) {
+	line := strings.Repeat("─", 50)
+	fmt.Printf("%s%s%s\n", colorDim, line, colorReset)
+	fmt.Printf("%sDiagnostics%s %s(%d)%s\n", colorBold, colorReset, colorDim, len(diagnostics), colorReset)
+	fmt.Printf("%s%s%s\n", colorDim, line, colorReset)
+	fmt.Printf("  %sThe origin map may be incomplete. Please report reproducible cases.%s\n", colorDim, colorReset)
+	for _, diagnostic := range diagnostics {
+		fmt.Printf("    %s•%s %s\n", colorYellow, colorReset, diagnostic)
+	}
+	fmt.Println()
+}
+
+func printComposition(result *control.AnalysisResult) {
+	if result.PipelineOriginMetrics == nil {
+		return
+	}
+	metrics := result.PipelineOriginMetrics
+
+	line := strings.Repeat("─", 50)
+	fmt.Printf("%s%s%s\n", colorDim, line, colorReset)
+	fmt.Printf("%sPipeline composition%s\n", colorBold, colorReset)
+	fmt.Printf("%s%s%s\n", colorDim, line, colorReset)
+	fmt.Printf("  Jobs: %d (%d hardcoded)\n", metrics.JobTotal, metrics.JobHardcoded)
+	fmt.Printf("  Origins: %d\n", metrics.OriginTotal)
+	fmt.Printf("    Components: %d (%d from the GitLab catalog, %d official)\n", metrics.OriginComponent, metrics.OriginGitLabCatalog, metrics.OriginOfficial)
+	fmt.Printf("    Project Files: %d\n", metrics.OriginProject)
+	fmt.Printf("    Local Files: %d\n", metrics.OriginLocal)
+	fmt.Printf("    Remote Files: %d\n", metrics.OriginRemote)
+	fmt.Printf("    Templates: %d\n", metrics.OriginTemplate)
+	fmt.Printf("  Outdated Origins: %d\n", metrics.OriginOutdated)
+	if result.PipelineImageMetrics != nil {
+		fmt.Printf("  Images: %d\n", result.PipelineImageMetrics.Total)
+	}
+	fmt.Println()
+}
+
+func printInventory(inventory []control.JobInventoryEntry) {
+	if len(inventory) == 0 {
+		return
+	}
+
 	line := strings.Repeat("─", 50)
 	fmt.Printf("%s%s%s\n", colorDim, line, colorReset)
+	fmt.Printf("%sJob inventory%s %s(%d)%s\n", colorBold, colorReset, colorDim, len(inventory), colorReset)
+	fmt.Printf("%s%s%s\n", colorDim, line, colorReset)
+	for _, job := range inventory {
+		origin := job.Origin.Type
+		if job.Origin.Location != "" {
+			origin += " " + job.Origin.Location
+		}
+		if job.Overridden {
+			origin += ", overridden"
+		}
+		image := "no image"
+		if job.Image != nil {
+			image = job.Image.Link
+		}
+		fmt.Printf("    • %s %s(%s)%s → %s\n", job.Name, colorDim, origin, colorReset, image)
+	}
+	fmt.Println()
+}
+
+func printRulesSimulation(simulation *control.RulesSimulationResult) {
+	line := strings.Repeat("─", 50)
+	fmt.Printf("%s%s%s\n", colorDim, line, colorReset)
+	fmt.Printf("%sRules simulation%s %s(ref: %s, source: %s)%s\n", colorBold, colorReset, colorDim, simulation.Ref, simulation.Source, colorReset)
+	fmt.Printf("%s%s%s\n", colorDim, line, colorReset)
+
+	if !simulation.WorkflowRuns {
+		fmt.Printf("  %sPipeline would not run: workflow rules, %s%s\n", colorYellow, simulation.WorkflowReason, colorReset)
+	}
+	if simulation.WorkflowError != "" {
+		fmt.Printf("  %sWorkflow rules could not be evaluated, the pipeline is considered to run: %s%s\n", colorYellow, simulation.WorkflowError, colorReset)
+	}
+	fmt.Printf("  Jobs That Would Run: %d\n", len(simulation.IncludedJobs))
+	fmt.Printf("  Jobs Excluded By Rules: %d\n", len(simulation.ExcludedJobs))
+	if len(simulation.UnevaluableJobs) > 0 {
+		fmt.Printf("  Jobs With Unevaluable Rules: %d\n", len(simulation.UnevaluableJobs))
+	}
+
+	if simulation.WorkflowRuns && len(simulation.ExcludedJobs) > 0 {
+		fmt.Printf("\n  %sExcluded Jobs:%s\n", colorDim, colorReset)
+		for _, job := range simulation.ExcludedJobs {
+			fmt.Printf("    • Job '%s': %s\n", job.Job, job.Reason)
+		}
+	}
+
+	if len(simulation.UnevaluableJobs) > 0 {
+		fmt.Printf("\n  %sUnevaluable Jobs (kept as if they would run):%s\n", colorYellow, colorReset)
+		for _, job := range simulation.UnevaluableJobs {
+			fmt.Printf("    • Job '%s': %s\n", job.Job, job.Error)
+		}
+	}
+	fmt.Println()
+}
+
+func printControlHeader(w io.Writer, name string, compliance float64, skipped bool, precision int) {
+	line := strings.Repeat("─", 50)
+	fmt.Fprintf(w, "%s%s%s\n", colorDim, line, colorReset)
 	if skipped {
-		fmt.Printf("%s%s%s %s(skipped)%s\n", colorBold, name, colorReset, colorDim, colorReset)
+		fmt.Fprintf(w, "%s%s%s %s(skipped)%s\n", colorBold, name, colorReset, colorDim, colorReset)
 	} else {
 		compColor := colorGreen
-		if compliance < 100 {
+		if utils.RoundToPrecision(compliance, precision) < 100 {
 			compColor = colorYellow
 		}
 		if compliance == 0 {
 			compColor = colorRed
 		}
-		fmt.Printf("%s%s%s %s(%.1f%% compliant)%s\n", colorBold, name, colorReset, compColor, compliance, colorReset)
+		fmt.Fprintf(w, "%s%s%s %s(%s compliant)%s\n", colorBold, name, colorReset, compColor, formatCompliance(compliance, precision), colorReset)
 	}
-	fmt.Printf("%s%s%s\n", colorDim, line, colorReset)
+	fmt.Fprintf(w, "%s%s%s\n", colorDim, line, colorReset)
+}
+
+// formatCompliance formats a compliance percentage with the given number of decimals
+func formatCompliance(compliance float64, precision int) string {
+	return fmt.Sprintf("%.*f%%", precision, utils.RoundToPrecision(compliance, precision))
 }
 
 func printSectionHeader(name string) {
@@ -404,6 +953,11 @@ func printSectionHeader(name string) {
 }
 
 func printIssuesTable(controls []controlSummary) {
+	if wideOutput {
+		printWideIssuesTable(controls)
+		return
+	}
+
 	fmt.Printf("  %sIssues%s\n", colorBold, colorReset)
 
 	// Calculate column widths
@@ -446,9 +1000,9 @@ func printIssuesTable(controls []controlSummary) {
 			issueColor = colorRed
 		}
 
-		fmt.Printf("  %s║%s %-*s %s│%s %s%*s%s %s║%s\n",
+		fmt.Printf("  %s║%s %s %s│%s %s%*s%s %s║%s\n",
 			colorCyan, colorReset,
-			controlWidth-2, ctrl.name,
+			padText(ctrl.name, controlWidth-2),
 			colorCyan, colorReset,
 			issueColor, issuesWidth-2, issueStr, colorReset,
 			colorCyan, colorReset)
@@ -462,7 +1016,172 @@ func printIssuesTable(controls []controlSummary) {
 		colorReset)
 }
 
-func printComplianceTable(controls []controlSummary, overallCompliance, threshold float64) {
+// printWideIssuesTable prints the Issues table with the first issue of each
+// control, truncated so that the table fits the output width
+func printWideIssuesTable(controls []controlSummary) {
+	fmt.Printf("  %sIssues%s\n", colorBold, colorReset)
+
+	// Calculate column widths, the first issue column takes the remaining width
+	controlWidth := 52
+	issuesWidth := 10
+	// 2 spaces of indentation and 4 borders
+	firstIssueWidth := getOutputWidth() - 2 - 4 - controlWidth - issuesWidth
+	if firstIssueWidth < minFirstIssueWidth {
+		firstIssueWidth = minFirstIssueWidth
+	}
+
+	// Top border
+	fmt.Printf("  %s╔%s╤%s╤%s╗%s\n",
+		colorCyan,
+		strings.Repeat("═", controlWidth),
+		strings.Repeat("═", issuesWidth),
+		strings.Repeat("═", firstIssueWidth),
+		colorReset)
+
+	// Header row
+	fmt.Printf("  %s║%s %-*s %s│%s %*s %s│%s %-*s %s║%s\n",
+		colorCyan, colorReset,
+		controlWidth-2, "Control",
+		colorCyan, colorReset,
+		issuesWidth-2, "Issues",
+		colorCyan, colorReset,
+		firstIssueWidth-2, "First Issue",
+		colorCyan, colorReset)
+
+	// Header separator
+	fmt.Printf("  %s╟%s┼%s┼%s╢%s\n",
+		colorCyan,
+		strings.Repeat("─", controlWidth),
+		strings.Repeat("─", issuesWidth),
+		strings.Repeat("─", firstIssueWidth),
+		colorReset)
+
+	// Data rows
+	for _, ctrl := range controls {
+		issueStr := "-"
+		if !ctrl.skipped {
+			issueStr = fmt.Sprintf("%d", ctrl.issues)
+		}
+
+		issueColor := colorReset
+		if ctrl.issues > 0 {
+			issueColor = colorRed
+		}
+
+		fmt.Printf("  %s║%s %s %s│%s %s%*s%s %s│%s %s %s║%s\n",
+			colorCyan, colorReset,
+			padText(ctrl.name, controlWidth-2),
+			colorCyan, colorReset,
+			issueColor, issuesWidth-2, issueStr, colorReset,
+			colorCyan, colorReset,
+			padText(ctrl.firstIssue, firstIssueWidth-2),
+			colorCyan, colorReset)
+	}
+
+	// Bottom border
+	fmt.Printf("  %s╚%s╧%s╧%s╝%s\n",
+		colorCyan,
+		strings.Repeat("═", controlWidth),
+		strings.Repeat("═", issuesWidth),
+		strings.Repeat("═", firstIssueWidth),
+		colorReset)
+}
+
+// getOutputWidth returns the width of the wide output: --width if set,
+// otherwise $COLUMNS, otherwise defaultOutputWidth
+func getOutputWidth() int {
+	if outputWidth > 0 {
+		return outputWidth
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return defaultOutputWidth
+}
+
+// truncateText shortens text to at most width terminal columns, ending with
+// "…" when it is truncated
+func truncateText(text string, width int) string {
+	if textWidth(text) <= width {
+		return text
+	}
+
+	// Keep a column for the ellipsis
+	limit := width - 1
+	if width <= 1 {
+		limit = width
+	}
+	truncated := strings.Builder{}
+	used := 0
+	for _, r := range text {
+		runeColumns := runeWidth(r)
+		if used+runeColumns > limit {
+			break
+		}
+		truncated.WriteRune(r)
+		used += runeColumns
+	}
+	if width <= 1 {
+		return truncated.String()
+	}
+	return truncated.String() + "…"
+}
+
+// padText truncates text to width terminal columns and left-aligns it with
+// spaces. It replaces "%-*s" for text that may contain wide characters, as
+// fmt pads by rune count and not by displayed width.
+func padText(text string, width int) string {
+	text = truncateText(text, width)
+	return text + strings.Repeat(" ", max(width-textWidth(text), 0))
+}
+
+// textWidth returns the number of terminal columns used to display text
+func textWidth(text string) int {
+	width := 0
+	for _, r := range text {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// runeWidth returns the number of terminal columns used to display a rune:
+// 0 for combining marks and format characters, 2 for East Asian wide and
+// fullwidth characters and emojis, 1 otherwise
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	for _, wide := range wideRuneRanges {
+		if r >= wide[0] && r <= wide[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// wideRuneRanges are the ranges of East Asian wide and fullwidth characters
+// and of emojis, displayed on two columns by terminals
+var wideRuneRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x2E80, 0x303E},   // CJK radicals, Kangxi radicals, CJK symbols and punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, Bopomofo, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK unified ideographs extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi syllables and radicals
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x1F300, 0x1F64F}, // Miscellaneous symbols and pictographs, emoticons
+	{0x1F680, 0x1F6FF}, // Transport and map symbols
+	{0x1F900, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x1FA70, 0x1FAFF}, // Symbols and pictographs extended-A
+	{0x20000, 0x2FFFD}, // CJK unified ideographs extension B and beyond
+	{0x30000, 0x3FFFD}, // CJK unified ideographs extension G and beyond
+}
+
+func printComplianceTable(controls []controlSummary, overallCompliance, threshold float64, precision int) {
 	fmt.Printf("  %sCompliance%s\n", colorBold, colorReset)
 
 	// Calculate column widths
@@ -504,8 +1223,8 @@ func printComplianceTable(controls []controlSummary, overallCompliance, threshol
 		statusColor := colorDim
 
 		if !ctrl.skipped {
-			compStr = fmt.Sprintf("%.1f%%", ctrl.compliance)
-			if ctrl.compliance >= 100 {
+			compStr = formatCompliance(ctrl.compliance, precision)
+			if utils.RoundToPrecision(ctrl.compliance, precision) >= 100 {
 				compColor = colorGreen
 				statusColor = colorGreen
 				statusStr = "✓"
@@ -516,9 +1235,9 @@ func printComplianceTable(controls []controlSummary, overallCompliance, threshol
 			}
 		}
 
-		fmt.Printf("  %s║%s %-*s %s│%s %s%*s%s %s│%s %s%*s%s %s║%s\n",
+		fmt.Printf("  %s║%s %s %s│%s %s%*s%s %s│%s %s%*s%s %s║%s\n",
 			colorCyan, colorReset,
-			controlWidth-2, ctrl.name,
+			padText(ctrl.name, controlWidth-2),
 			colorCyan, colorReset,
 			compColor, complianceWidth-2, compStr, colorReset,
 			colorCyan, colorReset,
@@ -535,7 +1254,7 @@ func printComplianceTable(controls []controlSummary, overallCompliance, threshol
 		colorReset)
 
 	// Total row
-	totalCompStr := fmt.Sprintf("%.1f%%", overallCompliance)
+	totalCompStr := formatCompliance(overallCompliance, precision)
 	totalStatus := "✓"
 	totalCompColor := colorGreen
 	totalStatusColor := colorGreen
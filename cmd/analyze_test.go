@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/control"
+	"github.com/getplumber/plumber/utils"
+)
+
+func TestComplianceRoundedAgainstThreshold(t *testing.T) {
+	tests := []struct {
+		name       string
+		compliance float64
+		precision  int
+		threshold  float64
+		want       float64
+		wantPassed bool
+	}{
+		{name: "99.95 rounds up to 100", compliance: 99.95, precision: 1, threshold: 100, want: 100, wantPassed: true},
+		{name: "99.94 rounds down", compliance: 99.94, precision: 1, threshold: 100, want: 99.9, wantPassed: false},
+		{name: "99.95 kept with precision 2", compliance: 99.95, precision: 2, threshold: 100, want: 99.95, wantPassed: false},
+		{name: "99.95 rounds to 100 with precision 0", compliance: 99.95, precision: 0, threshold: 100, want: 100, wantPassed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &control.AnalysisResult{
+				ImageForbiddenTagsResult: &control.GitlabImageForbiddenTagsResult{Compliance: tt.compliance},
+			}
+			result.RoundCompliance(tt.precision)
+			compliance := utils.RoundToPrecision(result.ImageForbiddenTagsResult.Compliance, tt.precision)
+
+			if passed := analysisPassed(result, tt.threshold, compliance); passed != tt.wantPassed {
+				t.Errorf("analysisPassed() = %v, want %v (compliance %v)", passed, tt.wantPassed, compliance)
+			}
+
+			// The JSON output shows the rounded compliance of the control
+			outputFile := filepath.Join(t.TempDir(), "result.json")
+			if err := writeJSONToFile(result, tt.threshold, compliance, outputFile); err != nil {
+				t.Fatalf("writeJSONToFile() error = %v", err)
+			}
+			data, err := os.ReadFile(outputFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var output struct {
+				Compliance               float64 `json:"compliance"`
+				Passed                   bool    `json:"passed"`
+				ImageForbiddenTagsResult struct {
+					Compliance float64 `json:"compliance"`
+				} `json:"imageForbiddenTagsResult"`
+			}
+			if err := json.Unmarshal(data, &output); err != nil {
+				t.Fatalf("invalid JSON output: %v", err)
+			}
+			if output.ImageForbiddenTagsResult.Compliance != tt.want {
+				t.Errorf("control compliance = %v, want %v", output.ImageForbiddenTagsResult.Compliance, tt.want)
+			}
+			if output.Compliance != tt.want {
+				t.Errorf("overall compliance = %v, want %v", output.Compliance, tt.want)
+			}
+			if output.Passed != tt.wantPassed {
+				t.Errorf("passed = %v, want %v", output.Passed, tt.wantPassed)
+			}
+		})
+	}
+}
+
+func TestOutputsAgreeOnThresholdFailures(t *testing.T) {
+	// The average (85.0%) is above the global threshold, the forbidden tags
+	// control, without a threshold of its own, is below it
+	result := &control.AnalysisResult{
+		ImageForbiddenTagsResult:     &control.GitlabImageForbiddenTagsResult{Compliance: 70},
+		ImageAuthorizedSourcesResult: &control.GitlabImageAuthorizedSourcesResult{Compliance: 100},
+	}
+	threshold := 80.0
+	compliance, _ := result.OverallCompliance()
+	result.ThresholdFailures = control.ControlThresholdFailures(result, &configuration.PlumberConfig{}, threshold, 1)
+
+	if analysisPassed(result, threshold, compliance) {
+		t.Errorf("analysisPassed() = true, want false")
+	}
+
+	report, err := buildJUnitReport(result, 1)
+	if err != nil {
+		t.Fatalf("buildJUnitReport() error = %v", err)
+	}
+	failed := map[string]bool{}
+	for _, testCase := range report.Suites[0].TestCases {
+		failed[testCase.Name] = testCase.Failure != nil
+	}
+	if want := map[string]bool{"imageForbiddenTags": true, "imageAuthorizedSources": false}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("JUnit failures = %v, want %v", failed, want)
+	}
+
+	markdown := buildMarkdownReport(result, threshold, compliance, 1)
+	for _, want := range []string{
+		"| Container images must not use forbidden tags | 70.0% | 0 | " + markdownFailed + " |",
+		"| Container images must come from authorized sources | 100.0% | 0 | " + markdownPassed + " |",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown report doesn't contain %q:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestReportsUsePrecision(t *testing.T) {
+	tests := []struct {
+		precision int
+		want      string
+	}{
+		{precision: 0, want: "| Container images must not use forbidden tags | 100% |"},
+		{precision: 1, want: "| Container images must not use forbidden tags | 100.0% |"},
+		{precision: 2, want: "| Container images must not use forbidden tags | 99.95% |"},
+	}
+
+	for _, tt := range tests {
+		result := &control.AnalysisResult{
+			ImageForbiddenTagsResult: &control.GitlabImageForbiddenTagsResult{Compliance: 99.95},
+		}
+		report := buildMarkdownReport(result, 0, 99.95, tt.precision)
+		if !strings.Contains(report, tt.want) {
+			t.Errorf("precision %d: markdown report doesn't contain %q:\n%s", tt.precision, tt.want, report)
+		}
+	}
+}
+
+func TestWriteJSONToFileSchemaVersion(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "result.json")
+	if err := writeJSONToFile(&control.AnalysisResult{}, 100, 100, outputFile); err != nil {
+		t.Fatalf("writeJSONToFile() error = %v", err)
+	}
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output map[string]interface{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if output["schemaVersion"] != JSONSchemaVersion {
+		t.Errorf("schemaVersion = %v, want %q", output["schemaVersion"], JSONSchemaVersion)
+	}
+	if output["version"] != Version {
+		t.Errorf("version = %v, want %q", output["version"], Version)
+	}
+	if generatedAt, ok := output["generatedAt"].(string); !ok || generatedAt == "" {
+		t.Errorf("generatedAt = %v, want a timestamp", output["generatedAt"])
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{name: "fits", text: "abc", width: 5, want: "abc"},
+		{name: "ascii", text: "abcdef", width: 4, want: "abc…"},
+		{name: "CJK fits", text: "日本語", width: 6, want: "日本語"},
+		{name: "CJK truncated on a wide character", text: "日本語テキスト", width: 6, want: "日本…"},
+		{name: "CJK truncated", text: "日本語テキスト", width: 7, want: "日本語…"},
+		{name: "emoji", text: "🚀 deploy", width: 4, want: "🚀 …"},
+		{name: "emoji not split", text: "🚀🚀🚀", width: 4, want: "🚀…"},
+		{name: "combining mark has no width", text: "café", width: 4, want: "café"},
+		{name: "wide character wider than the cell", text: "日本", width: 1, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateText(tt.text, tt.width)
+			if got != tt.want {
+				t.Errorf("truncateText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+			if textWidth(got) > tt.width {
+				t.Errorf("truncateText(%q, %d) is %d columns wide", tt.text, tt.width, textWidth(got))
+			}
+		})
+	}
+}
+
+func TestPadTextWidth(t *testing.T) {
+	texts := []string{"abc", "日本語テキスト", "🚀 deploy", "café", "Container images must not use forbidden tags"}
+	for _, text := range texts {
+		for _, width := range []int{2, 5, 8, 20} {
+			if got := padText(text, width); textWidth(got) != width {
+				t.Errorf("padText(%q, %d) = %q is %d columns wide", text, width, got, textWidth(got))
+			}
+		}
+	}
+}
+
+// testPlumberConfig is a configuration enabling the forbidden tags control
+// and disabling the hardcoded jobs one
+const testPlumberConfig = `version: "1.0"
+controls:
+  containerImageMustNotUseForbiddenTags:
+    enabled: true
+    tags: [latest]
+  containerImageMustComeFromAuthorizedSources:
+    enabled: true
+  hardcodedJobs:
+    enabled: false
+`
+
+func TestOverrideControls(t *testing.T) {
+	tests := []struct {
+		name    string
+		enable  []string
+		disable []string
+		want    map[string]bool
+		wantErr string
+	}{
+		{
+			name: "config file is kept without overrides",
+			want: map[string]bool{"containerImageMustNotUseForbiddenTags": true, "hardcodedJobs": false, "branchMustBeProtected": false},
+		},
+		{
+			name:    "enable and disable override the config file",
+			enable:  []string{"hardcodedJobs"},
+			disable: []string{"containerImageMustNotUseForbiddenTags"},
+			want:    map[string]bool{"containerImageMustNotUseForbiddenTags": false, "hardcodedJobs": true},
+		},
+		{
+			name:   "enable a control missing from the config file",
+			enable: []string{"branchMustBeProtected"},
+			want:   map[string]bool{"branchMustBeProtected": true, "containerImageMustNotUseForbiddenTags": true},
+		},
+		{
+			name:    "enabled and disabled",
+			enable:  []string{"hardcodedJobs"},
+			disable: []string{"hardcodedJobs"},
+			wantErr: "both enabled and disabled",
+		},
+		{
+			name:    "unknown control",
+			disable: []string{"noSuchControl"},
+			wantErr: "unknown control 'noSuchControl'",
+		},
+		{
+			name:    "enable a control with required settings missing from the config file",
+			enable:  []string{"environmentUrlAllowlist"},
+			wantErr: "add environmentUrlAllowlist under controls: environmentUrlAllowlist.allowedDomains",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".plumber.yaml")
+			if err := os.WriteFile(configPath, []byte(testPlumberConfig), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			plumberConfig, _, err := configuration.LoadPlumberConfig(configPath)
+			if err != nil {
+				t.Fatalf("LoadPlumberConfig() error = %v", err)
+			}
+
+			err = overrideControls(plumberConfig, tt.enable, tt.disable)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("overrideControls() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("overrideControls() error = %v", err)
+			}
+
+			enabled := map[string]bool{
+				"containerImageMustNotUseForbiddenTags": plumberConfig.GetContainerImageMustNotUseForbiddenTagsConfig().IsEnabled(),
+				"hardcodedJobs":                         plumberConfig.GetHardcodedJobsConfig().IsEnabled(),
+				"branchMustBeProtected":                 plumberConfig.GetBranchMustBeProtectedConfig().IsEnabled(),
+			}
+			for name, want := range tt.want {
+				if enabled[name] != want {
+					t.Errorf("%s enabled = %v, want %v", name, enabled[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestControlReportsCoverAllControls(t *testing.T) {
+	for _, name := range configuration.ControlNames() {
+		report, ok := controlReports[name]
+		if !ok {
+			t.Errorf("control %s has no report", name)
+			continue
+		}
+		if report.name == "" || report.details == nil {
+			t.Errorf("control %s has an incomplete report", name)
+		}
+	}
+	if len(controlReports) != len(configuration.ControlNames()) {
+		t.Errorf("controlReports has %d entries, want %d", len(controlReports), len(configuration.ControlNames()))
+	}
+}
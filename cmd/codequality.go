@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/getplumber/plumber/control"
+	"github.com/getplumber/plumber/utils"
+)
+
+// codeQualityPath is the file the Code Quality issues point at. Issues are
+// not tied to a line: they concern the CI configuration as a whole.
+const codeQualityPath = ".gitlab-ci.yml"
+
+// Severities of the GitLab Code Quality report
+const (
+	codeQualityInfo     = "info"
+	codeQualityMinor    = "minor"
+	codeQualityMajor    = "major"
+	codeQualityCritical = "critical"
+)
+
+// codeQualityControlSeverities overrides the severity of the issues of some
+// controls, the others derive it from the severity of the control
+var codeQualityControlSeverities = map[string]string{
+	"containerImageMustNotUseForbiddenTags":       codeQualityMajor,
+	"containerImageMustComeFromAuthorizedSources": codeQualityMajor,
+	"branchMustBeProtected":                       codeQualityCritical,
+}
+
+// codeQualityIssue is an entry of a GitLab Code Quality report
+type codeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+// codeQualityLocation is the file and line of a Code Quality entry
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+// codeQualityLines is the first line of a Code Quality entry
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// buildCodeQualityReport maps the issues of an analysis to GitLab Code
+// Quality entries. The check name is the control key of the issue.
+func buildCodeQualityReport(result *control.AnalysisResult) []codeQualityIssue {
+	report := []codeQualityIssue{}
+	for _, issue := range result.ControlIssues() {
+		report = append(report, codeQualityIssue{
+			Description: issue.Message,
+			CheckName:   issue.Control,
+			Fingerprint: codeQualityFingerprint(issue),
+			Severity:    codeQualitySeverity(issue),
+			Location: codeQualityLocation{
+				Path:  codeQualityPath,
+				Lines: codeQualityLines{Begin: 1},
+			},
+		})
+	}
+	return report
+}
+
+// codeQualityFingerprint identifies an issue across runs, for GitLab to tell
+// new issues from resolved ones. It hashes the control, job, resource (e.g.,
+// the image link) and branch of the issue, not its message which may hold
+// metrics changing between runs.
+func codeQualityFingerprint(issue control.ControlIssue) string {
+	parts := []string{issue.Control, issue.Job, issue.Resource, issue.Branch}
+	return fmt.Sprintf("%016x", utils.GenerateFNVHash([]byte(strings.Join(parts, "\x00"))))
+}
+
+// codeQualitySeverity returns the Code Quality severity of an issue
+func codeQualitySeverity(issue control.ControlIssue) string {
+	if severity, ok := codeQualityControlSeverities[issue.Control]; ok {
+		return severity
+	}
+	switch issue.Severity {
+	case control.SeverityHigh:
+		return codeQualityMajor
+	case control.SeverityLow:
+		return codeQualityInfo
+	default:
+		return codeQualityMinor
+	}
+}
+
+// writeCodeQualityToFile writes the GitLab Code Quality report of an analysis
+func writeCodeQualityToFile(result *control.AnalysisResult, filePath string) error {
+	file, err := createOutputFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(buildCodeQualityReport(result)); err != nil {
+		return fmt.Errorf("unable to write Code Quality report: %w", err)
+	}
+	return nil
+}
+
+// printCodeQualityWritten tells on stderr where the Code Quality report was written
+func printCodeQualityWritten(filePath string) {
+	if filePath == stdoutPath {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Code Quality report written to: %s\n", filePath)
+}
@@ -0,0 +1,1287 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/getplumber/plumber/control"
+)
+
+// controlReport is how the text and markdown reports show a control
+type controlReport struct {
+	// name is the title of the control in the reports
+	name string
+
+	// details prints the status, metrics and issues of the control
+	details func(details io.Writer, result *control.AnalysisResult)
+}
+
+// controlReports are the reports of the controls, by name in .plumber.yaml
+var controlReports = map[string]controlReport{
+	"containerImageMustNotUseForbiddenTags":       {name: "Container images must not use forbidden tags", details: printImageForbiddenTagsDetails},
+	"containerImageMustComeFromAuthorizedSources": {name: "Container images must come from authorized sources", details: printImageAuthorizedSourcesDetails},
+	"branchMustBeProtected":                       {name: "Branch must be protected", details: printBranchProtectionDetails},
+	"dependencyPinning":                           {name: "Dependency installs must be pinned", details: printDependencyPinningDetails},
+	"environmentUrlAllowlist":                     {name: "Environment URLs must use approved domains", details: printEnvironmentUrlAllowlistDetails},
+	"cacheKeyIsolation":                           {name: "Cache keys must be isolated per branch", details: printCacheKeyIsolationDetails},
+	"minimumMaintainers":                          {name: "Project must have enough maintainers", details: printMinimumMaintainersDetails},
+	"triggerAllowlist":                            {name: "Trigger jobs must target allowed projects", details: printTriggerAllowlistDetails},
+	"securityJobChangeRules":                      {name: "Security jobs must not use rules:changes", details: printSecurityJobChangeRulesDetails},
+	"oidcPreferred":                               {name: "Deploy jobs should use OIDC (id_tokens)", details: printOidcPreferredDetails},
+	"secretsManagerRequired":                      {name: "Sensitive variables must use secrets:", details: printSecretsManagerRequiredDetails},
+	"componentInputsValid":                        {name: "Component inputs must match spec", details: printComponentInputsValidDetails},
+	"pipelineComplexityBudget":                    {name: "Pipeline must stay within size budget", details: printPipelineComplexityBudgetDetails},
+	"defaultBranchName":                           {name: "Default branch name must be allowed", details: printDefaultBranchNameDetails},
+	"pipelineSchedules":                           {name: "Pipeline schedules must be safe", details: printPipelineSchedulesDetails},
+	"webhookAllowlist":                            {name: "Webhooks must target allowed hosts", details: printWebhookAllowlistDetails},
+	"deployTokens":                                {name: "Deploy tokens must be short-lived", details: printDeployTokensDetails},
+	"consistentComponentVersions":                 {name: "Components must use a single version", details: printConsistentComponentVersionsDetails},
+	"rootUserDiscouraged":                         {name: "Jobs should not run as root", details: printRootUserDiscouragedDetails},
+	"rulesOverOnlyExcept":                         {name: "Jobs must use rules over only/except", details: printRulesOverOnlyExceptDetails},
+	"readmeRequired":                              {name: "Project must have a README", details: printReadmeRequiredDetails},
+	"mergeAccessGroups":                           {name: "Merges restricted to approved groups", details: printMergeAccessGroupsDetails},
+	"variableExpansionPolicy":                     {name: "Variables must follow expansion policy", details: printVariableExpansionPolicyDetails},
+	"noInsecureTransport":                         {name: "Jobs must not disable TLS verification", details: printNoInsecureTransportDetails},
+	"artifactsMustBePrivate":                      {name: "Artifacts must be private", details: printArtifactsMustBePrivateDetails},
+	"retryPolicy":                                 {name: "Retry policy", details: printRetryPolicyDetails},
+	"registryPushGating":                          {name: "Registry push gating", details: printRegistryPushGatingDetails},
+	"jobTimeoutPolicy":                            {name: "Job timeout policy", details: printJobTimeoutPolicyDetails},
+	"debugTraceForbidden":                         {name: "Debug trace forbidden", details: printDebugTraceForbiddenDetails},
+	"maxIncludes":                                 {name: "Max includes", details: printMaxIncludesDetails},
+	"noDirectElevatedMembers":                     {name: "No direct elevated members", details: printNoDirectElevatedMembersDetails},
+	"mergeTrainApprovals":                         {name: "Merge train approvals", details: printMergeTrainApprovalsDetails},
+	"componentsMustBeReleased":                    {name: "Components must be released", details: printComponentsMustBeReleasedDetails},
+	"variableCountBudget":                         {name: "Variable count budget", details: printVariableCountBudgetDetails},
+	"manualJobAccess":                             {name: "Manual job access", details: printManualJobAccessDetails},
+	"pushRulesPolicy":                             {name: "Push rules policy", details: printPushRulesPolicyDetails},
+	"securityPolicyFileRequired":                  {name: "Project must have a security policy file", details: printSecurityPolicyFileRequiredDetails},
+	"runnerFeatureFlags":                          {name: "Runner feature flags", details: printRunnerFeatureFlagsDetails},
+	"trustedIncludeProjects":                      {name: "Trusted include projects", details: printTrustedIncludeProjectsDetails},
+	"protectedEnvironments":                       {name: "Protected environments", details: printProtectedEnvironmentsDetails},
+	"deprecatedJwtUsage":                          {name: "Deprecated CI_JOB_JWT usage", details: printDeprecatedJwtUsageDetails},
+	"localIncludeGlobs":                           {name: "Local includes must not use globs", details: printLocalIncludeGlobsDetails},
+	"deadJobs":                                    {name: "Pipeline must not contain dead jobs", details: printDeadJobsDetails},
+	"repoStructure":                               {name: "Repository must have the required structure", details: printRepoStructureDetails},
+	"tagMustNotBeBranchName":                      {name: "Image tags must not be branch names", details: printTagMustNotBeBranchNameDetails},
+	"ruleConditionSafety":                         {name: "Privileged jobs must not be gated on commit content", details: printRuleConditionSafetyDetails},
+	"sameInstanceIncludesOnly":                    {name: "Includes must come from the same GitLab instance", details: printSameInstanceIncludesOnlyDetails},
+	"imageNameAllowlist":                          {name: "Container images must be allowlisted", details: printImageNameAllowlistDetails},
+	"pathScopedApprovals":                         {name: "Sensitive paths must require approvals", details: printPathScopedApprovalsDetails},
+	"noPublicCatalogComponents":                   {name: "Components must not come from the public catalog", details: printNoPublicCatalogComponentsDetails},
+	"packageRegistryAllowlist":                    {name: "Package registries must be allowed", details: printPackageRegistryAllowlistDetails},
+	"jobImageOverrideTrust":                       {name: "Job images must not override the default with untrusted ones", details: printJobImageOverrideTrustDetails},
+	"separationOfDuties":                          {name: "Merge requests must be approved by someone else", details: printSeparationOfDutiesDetails},
+	"defaultBranchNoDeletion":                     {name: "Default branch must not be deletable", details: printDefaultBranchNoDeletionDetails},
+	"mergeRequestApproval":                        {name: "Merge requests must follow approval rules", details: printMergeRequestApprovalDetails},
+	"hardcodedJobs":                               {name: "Jobs must come from includes", details: printHardcodedJobsDetails},
+}
+
+// controlSummaries returns the summary of each control of the analysis, in
+// the order of the text report
+func controlSummaries(result *control.AnalysisResult) []controlSummary {
+	controls := []controlSummary{}
+	for _, controlResult := range result.ControlResults() {
+		name := controlResult.Control
+		if report, ok := controlReports[controlResult.Control]; ok {
+			name = report.name
+		}
+		controls = append(controls, controlSummary{
+			key:        controlResult.Control,
+			name:       name,
+			compliance: controlResult.Compliance,
+			issues:     controlResult.IssueCount,
+			skipped:    controlResult.Skipped,
+		})
+	}
+	return controls
+}
+
+// printControls prints the metrics and issues of each control that ran to
+// details, and returns their summaries for the tables
+func printControls(details io.Writer, result *control.AnalysisResult, precision int) []controlSummary {
+	controls := controlSummaries(result)
+	for _, ctrl := range controls {
+		printControlHeader(details, ctrl.name, ctrl.compliance, ctrl.skipped, precision)
+		if report, ok := controlReports[ctrl.key]; ok {
+			report.details(details, result)
+		}
+		fmt.Fprintln(details)
+	}
+	return controls
+}
+
+// printImageForbiddenTagsDetails prints the status, metrics and issues of the containerImageMustNotUseForbiddenTags control
+func printImageForbiddenTagsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.ImageForbiddenTagsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Images: %d\n", result.ImageForbiddenTagsResult.Metrics.Total)
+		fmt.Fprintf(details, "  Using Forbidden Tags: %d\n", result.ImageForbiddenTagsResult.Metrics.UsingForbiddenTags)
+		if result.ImageForbiddenTagsResult.Metrics.DigestPinned > 0 {
+			fmt.Fprintf(details, "  Pinned by Digest: %d\n", result.ImageForbiddenTagsResult.Metrics.DigestPinned)
+		}
+		if result.ImageForbiddenTagsResult.Metrics.UnresolvedTags > 0 {
+			fmt.Fprintf(details, "  Unresolved Tag Variables: %d\n", result.ImageForbiddenTagsResult.Metrics.UnresolvedTags)
+		}
+
+		if len(result.ImageForbiddenTagsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sForbidden Tags Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.ImageForbiddenTagsResult.Issues {
+				if issue.Unresolved {
+					fmt.Fprintf(details, "    %s•%s Job '%s' uses tag variable '%s' that could not be resolved, the tag cannot be checked (image: %s)\n", colorYellow, colorReset, issue.Job, issue.UnresolvedTag, issue.Link)
+					continue
+				}
+				if issue.FromVariable {
+					fmt.Fprintf(details, "    %s•%s Job '%s' uses forbidden tag '%s' from variable '%s' (image: %s)\n", colorYellow, colorReset, issue.Job, issue.Tag, issue.UnresolvedTag, issue.Link)
+					continue
+				}
+				fmt.Fprintf(details, "    %s•%s Job '%s' uses forbidden tag '%s' (image: %s)\n", colorYellow, colorReset, issue.Job, issue.Tag, issue.Link)
+			}
+		}
+	}
+}
+
+// printImageAuthorizedSourcesDetails prints the status, metrics and issues of the containerImageMustComeFromAuthorizedSources control
+func printImageAuthorizedSourcesDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.ImageAuthorizedSourcesResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Images: %d\n", result.ImageAuthorizedSourcesResult.Metrics.Total)
+		fmt.Fprintf(details, "  Authorized: %d\n", result.ImageAuthorizedSourcesResult.Metrics.Authorized)
+		fmt.Fprintf(details, "  Unauthorized: %d\n", result.ImageAuthorizedSourcesResult.Metrics.Unauthorized)
+
+		if len(result.ImageAuthorizedSourcesResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sUnauthorized Images Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.ImageAuthorizedSourcesResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' uses unauthorized image: %s\n", colorYellow, colorReset, issue.Job, issue.Link)
+			}
+		}
+	}
+}
+
+// printBranchProtectionDetails prints the status, metrics and issues of the branchMustBeProtected control
+func printBranchProtectionDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.BranchProtectionResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		if result.BranchProtectionResult.Metrics != nil {
+			fmt.Fprintf(details, "  Total Branches: %d\n", result.BranchProtectionResult.Metrics.Branches)
+			fmt.Fprintf(details, "  Branches to Protect: %d\n", result.BranchProtectionResult.Metrics.BranchesToProtect)
+			fmt.Fprintf(details, "  Protected Branches: %d\n", result.BranchProtectionResult.Metrics.TotalProtectedBranches)
+			fmt.Fprintf(details, "  Unprotected: %d\n", result.BranchProtectionResult.Metrics.UnprotectedBranches)
+			fmt.Fprintf(details, "  Non-Compliant: %d\n", result.BranchProtectionResult.Metrics.NonCompliantBranches)
+		}
+
+		if len(result.BranchProtectionResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sIssues Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.BranchProtectionResult.Issues {
+				if issue.Type == "unprotected" {
+					fmt.Fprintf(details, "    %s•%s Branch '%s' is not protected\n", colorYellow, colorReset, issue.BranchName)
+				} else {
+					fmt.Fprintf(details, "    %s•%s Branch '%s' has non-compliant protection settings\n", colorYellow, colorReset, issue.BranchName)
+					if issue.AllowForcePushDisplay {
+						fmt.Fprintf(details, "      └─ Force push is allowed (should be disabled)\n")
+					}
+					if issue.CodeOwnerApprovalRequiredDisplay {
+						fmt.Fprintf(details, "      └─ Code owner approval is not required\n")
+					}
+					if issue.MinMergeAccessLevelDisplay {
+						fmt.Fprintf(details, "      └─ Merge access level is too low (%d, minimum: %d)\n", issue.MinMergeAccessLevel, issue.AuthorizedMinMergeAccessLevel)
+					}
+					if issue.MinPushAccessLevelDisplay {
+						fmt.Fprintf(details, "      └─ Push access level is too low (%d, minimum: %d)\n", issue.MinPushAccessLevel, issue.AuthorizedMinPushAccessLevel)
+					}
+				}
+			}
+		}
+	}
+}
+
+// printDependencyPinningDetails prints the status, metrics and issues of the dependencyPinning control
+func printDependencyPinningDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.DependencyPinningResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Jobs: %d\n", result.DependencyPinningResult.Metrics.TotalJobs)
+		fmt.Fprintf(details, "  Jobs With Unpinned Installs: %d\n", result.DependencyPinningResult.Metrics.JobsWithUnpinnedInstalls)
+		fmt.Fprintf(details, "  Unpinned Installs: %d\n", result.DependencyPinningResult.Metrics.UnpinnedInstalls)
+
+		if len(result.DependencyPinningResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sUnpinned Installs Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.DependencyPinningResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' installs unpinned %s package '%s'\n", colorYellow, colorReset, issue.Job, issue.Manager, issue.Package)
+				fmt.Fprintf(details, "      └─ %s\n", issue.Line)
+			}
+		}
+	}
+}
+
+// printEnvironmentUrlAllowlistDetails prints the status, metrics and issues of the environmentUrlAllowlist control
+func printEnvironmentUrlAllowlistDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.EnvironmentUrlAllowlistResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Environment URLs: %d\n", result.EnvironmentUrlAllowlistResult.Metrics.Total)
+		fmt.Fprintf(details, "  Authorized: %d\n", result.EnvironmentUrlAllowlistResult.Metrics.Authorized)
+		fmt.Fprintf(details, "  Unauthorized: %d\n", result.EnvironmentUrlAllowlistResult.Metrics.Unauthorized)
+
+		if len(result.EnvironmentUrlAllowlistResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sUnauthorized Environment URLs Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.EnvironmentUrlAllowlistResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' environment '%s' points to: %s\n", colorYellow, colorReset, issue.Job, issue.Environment, issue.URL)
+			}
+		}
+	}
+}
+
+// printCacheKeyIsolationDetails prints the status, metrics and issues of the cacheKeyIsolation control
+func printCacheKeyIsolationDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.CacheKeyIsolationResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Caches: %d\n", result.CacheKeyIsolationResult.Metrics.Total)
+		fmt.Fprintf(details, "  Isolated: %d\n", result.CacheKeyIsolationResult.Metrics.Isolated)
+		fmt.Fprintf(details, "  Not Isolated: %d\n", result.CacheKeyIsolationResult.Metrics.NotIsolated)
+
+		if len(result.CacheKeyIsolationResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sShared Cache Keys Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.CacheKeyIsolationResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' uses a cache key shared across branches: %s\n", colorYellow, colorReset, issue.Job, issue.Key)
+			}
+		}
+	}
+}
+
+// printMinimumMaintainersDetails prints the status, metrics and issues of the minimumMaintainers control
+func printMinimumMaintainersDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.MinimumMaintainersResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Maintainers: %d (minimum: %d)\n", result.MinimumMaintainersResult.Metrics.Maintainers, result.MinimumMaintainersResult.Metrics.MinCount)
+		if len(result.MinimumMaintainersResult.Maintainers) > 0 {
+			fmt.Fprintf(details, "  Qualifying Members: %s\n", strings.Join(result.MinimumMaintainersResult.Maintainers, ", "))
+		}
+
+		if len(result.MinimumMaintainersResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sIssues Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.MinimumMaintainersResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Project has %d maintainer(s), at least %d required\n", colorYellow, colorReset, issue.Maintainers, issue.MinCount)
+			}
+		}
+	}
+}
+
+// printTriggerAllowlistDetails prints the status, metrics and issues of the triggerAllowlist control
+func printTriggerAllowlistDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.TriggerAllowlistResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Trigger Targets: %d\n", result.TriggerAllowlistResult.Metrics.Total)
+		fmt.Fprintf(details, "  Authorized: %d\n", result.TriggerAllowlistResult.Metrics.Authorized)
+		fmt.Fprintf(details, "  Unauthorized: %d\n", result.TriggerAllowlistResult.Metrics.Unauthorized)
+
+		if len(result.TriggerAllowlistResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sUnauthorized Trigger Targets Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.TriggerAllowlistResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' triggers %s: %s\n", colorYellow, colorReset, issue.Job, issue.TargetType, issue.Target)
+			}
+		}
+	}
+}
+
+// printSecurityJobChangeRulesDetails prints the status, metrics and issues of the securityJobChangeRules control
+func printSecurityJobChangeRulesDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.SecurityJobChangeRulesResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Security Jobs: %d\n", result.SecurityJobChangeRulesResult.Metrics.SecurityJobs)
+		fmt.Fprintf(details, "  With Changes Rules: %d\n", result.SecurityJobChangeRulesResult.Metrics.WithChangesRules)
+
+		if len(result.SecurityJobChangeRulesResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sSecurity Jobs Restricted by Changes Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.SecurityJobChangeRulesResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' only runs on changes to: %s\n", colorYellow, colorReset, issue.Job, strings.Join(issue.Changes, ", "))
+			}
+		}
+	}
+}
+
+// printOidcPreferredDetails prints the status, metrics and issues of the oidcPreferred control
+func printOidcPreferredDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.OidcPreferredResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Deploy Jobs: %d\n", result.OidcPreferredResult.Metrics.DeployJobs)
+		fmt.Fprintf(details, "  Using id_tokens: %d\n", result.OidcPreferredResult.Metrics.UsingIdTokens)
+		fmt.Fprintf(details, "  Using Static Credentials: %d\n", result.OidcPreferredResult.Metrics.UsingStaticKeys)
+
+		if len(result.OidcPreferredResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sDeploy Jobs Using Static Credentials Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.OidcPreferredResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' uses %s without id_tokens\n", colorYellow, colorReset, issue.Job, strings.Join(issue.Variables, ", "))
+			}
+		}
+	}
+}
+
+// printSecretsManagerRequiredDetails prints the status, metrics and issues of the secretsManagerRequired control
+func printSecretsManagerRequiredDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.SecretsManagerRequiredResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Sensitive Variables: %d\n", result.SecretsManagerRequiredResult.Metrics.SensitiveVariables)
+		fmt.Fprintf(details, "  From Secrets Manager: %d\n", result.SecretsManagerRequiredResult.Metrics.FromSecretsManager)
+		fmt.Fprintf(details, "  Inline: %d\n", result.SecretsManagerRequiredResult.Metrics.Inline)
+
+		if len(result.SecretsManagerRequiredResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sInline Sensitive Variables Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.SecretsManagerRequiredResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' declares '%s' in variables instead of secrets\n", colorYellow, colorReset, issue.Job, issue.Variable)
+			}
+		}
+	}
+}
+
+// printComponentInputsValidDetails prints the status, metrics and issues of the componentInputsValid control
+func printComponentInputsValidDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.ComponentInputsValidResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Components: %d\n", result.ComponentInputsValidResult.Metrics.Components)
+		fmt.Fprintf(details, "  Spec Unavailable: %d\n", result.ComponentInputsValidResult.Metrics.SpecUnavailable)
+		fmt.Fprintf(details, "  Unknown Inputs: %d\n", result.ComponentInputsValidResult.Metrics.UnknownInputs)
+		fmt.Fprintf(details, "  Missing Required Inputs: %d\n", result.ComponentInputsValidResult.Metrics.MissingInputs)
+
+		if len(result.ComponentInputsValidResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sInvalid Component Inputs Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.ComponentInputsValidResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s input '%s' (component: %s)\n", colorYellow, colorReset, issue.Problem, issue.Input, issue.Component)
+			}
+		}
+	}
+}
+
+// printPipelineComplexityBudgetDetails prints the status, metrics and issues of the pipelineComplexityBudget control
+func printPipelineComplexityBudgetDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.PipelineComplexityBudgetResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Jobs: %d\n", result.PipelineComplexityBudgetResult.Metrics.Jobs)
+		fmt.Fprintf(details, "  Total Lines: %d (max %d)\n", result.PipelineComplexityBudgetResult.Metrics.TotalLines, result.PipelineComplexityBudgetResult.Metrics.MaxTotalLines)
+		fmt.Fprintf(details, "  Jobs Over %d Lines: %d\n", result.PipelineComplexityBudgetResult.Metrics.MaxJobLines, result.PipelineComplexityBudgetResult.Metrics.OversizedJobs)
+
+		if len(result.PipelineComplexityBudgetResult.LargestJobs) > 0 {
+			fmt.Fprintf(details, "\n  Largest Jobs:\n")
+			for _, job := range result.PipelineComplexityBudgetResult.LargestJobs {
+				fmt.Fprintf(details, "    • %s: %d lines\n", job.Job, job.Lines)
+			}
+		}
+
+		if len(result.PipelineComplexityBudgetResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sBudget Exceeded:%s\n", colorYellow, colorReset)
+			for _, issue := range result.PipelineComplexityBudgetResult.Issues {
+				if issue.Job == "" {
+					fmt.Fprintf(details, "    %s•%s Pipeline has %d lines (max %d)\n", colorYellow, colorReset, issue.Lines, issue.Limit)
+				} else {
+					fmt.Fprintf(details, "    %s•%s Job '%s' has %d lines (max %d)\n", colorYellow, colorReset, issue.Job, issue.Lines, issue.Limit)
+				}
+			}
+		}
+	}
+}
+
+// printDefaultBranchNameDetails prints the status, metrics and issues of the defaultBranchName control
+func printDefaultBranchNameDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.DefaultBranchNameResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Default Branch: %s\n", result.DefaultBranchNameResult.DefaultBranch)
+		fmt.Fprintf(details, "  Allowed Names: %s\n", strings.Join(result.DefaultBranchNameResult.AllowedNames, ", "))
+
+		if len(result.DefaultBranchNameResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sIssues Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.DefaultBranchNameResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Default branch '%s' is not an allowed name\n", colorYellow, colorReset, issue.DefaultBranch)
+			}
+		}
+	}
+}
+
+// printPipelineSchedulesDetails prints the status, metrics and issues of the pipelineSchedules control
+func printPipelineSchedulesDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.PipelineSchedulesResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Schedules: %d (%d active)\n", result.PipelineSchedulesResult.Metrics.Schedules, result.PipelineSchedulesResult.Metrics.Active)
+		fmt.Fprintf(details, "  Overprivileged Owners: %d\n", result.PipelineSchedulesResult.Metrics.OverprivilegedOwners)
+		fmt.Fprintf(details, "  Unprotected Refs: %d\n", result.PipelineSchedulesResult.Metrics.UnprotectedRefs)
+
+		if len(result.PipelineSchedulesResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sRisky Schedules Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.PipelineSchedulesResult.Issues {
+				if issue.OwnerAccessLevel > 0 {
+					fmt.Fprintf(details, "    %s•%s Schedule '%s' (%s on %s) runs as '%s' with access level %d\n", colorYellow, colorReset, issue.Description, issue.Cron, issue.Ref, issue.Owner, issue.OwnerAccessLevel)
+				} else {
+					fmt.Fprintf(details, "    %s•%s Schedule '%s' (%s) targets unprotected ref '%s'\n", colorYellow, colorReset, issue.Description, issue.Cron, issue.Ref)
+				}
+			}
+		}
+	}
+}
+
+// printWebhookAllowlistDetails prints the status, metrics and issues of the webhookAllowlist control
+func printWebhookAllowlistDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.WebhookAllowlistResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Webhooks: %d\n", result.WebhookAllowlistResult.Metrics.Webhooks)
+		fmt.Fprintf(details, "  Unauthorized Hosts: %d\n", result.WebhookAllowlistResult.Metrics.UnauthorizedHosts)
+		fmt.Fprintf(details, "  Without SSL Verification: %d\n", result.WebhookAllowlistResult.Metrics.SSLVerificationDisabled)
+
+		if len(result.WebhookAllowlistResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sRisky Webhooks Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.WebhookAllowlistResult.Issues {
+				if issue.Type == "sslVerificationDisabled" {
+					fmt.Fprintf(details, "    %s•%s Webhook #%d to %s doesn't verify SSL\n", colorYellow, colorReset, issue.WebhookID, issue.Host)
+				} else {
+					fmt.Fprintf(details, "    %s•%s Webhook #%d sends events to unauthorized host %s\n", colorYellow, colorReset, issue.WebhookID, issue.Host)
+				}
+			}
+		}
+	}
+}
+
+// printDeployTokensDetails prints the status, metrics and issues of the deployTokens control
+func printDeployTokensDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.DeployTokensResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Deploy Tokens: %d (%d active)\n", result.DeployTokensResult.Metrics.Tokens, result.DeployTokensResult.Metrics.Active)
+		fmt.Fprintf(details, "  Never Expiring: %d\n", result.DeployTokensResult.Metrics.NoExpiration)
+		fmt.Fprintf(details, "  Valid Over %d Days: %d\n", result.DeployTokensResult.Metrics.MaxAgeDays, result.DeployTokensResult.Metrics.ExpiresTooLate)
+		fmt.Fprintf(details, "  Forbidden Scopes: %d\n", result.DeployTokensResult.Metrics.ForbiddenScopes)
+
+		if len(result.DeployTokensResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sRisky Deploy Tokens Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.DeployTokensResult.Issues {
+				switch issue.Type {
+				case "noExpiration":
+					fmt.Fprintf(details, "    %s•%s Token '%s' (%s) never expires\n", colorYellow, colorReset, issue.Name, strings.Join(issue.Scopes, ", "))
+				case "expiresTooLate":
+					fmt.Fprintf(details, "    %s•%s Token '%s' (%s) is valid for %d more days\n", colorYellow, colorReset, issue.Name, strings.Join(issue.Scopes, ", "), issue.ValidDays)
+				default:
+					fmt.Fprintf(details, "    %s•%s Token '%s' has forbidden scope '%s'\n", colorYellow, colorReset, issue.Name, issue.Scope)
+				}
+			}
+		}
+	}
+}
+
+// printConsistentComponentVersionsDetails prints the status, metrics and issues of the consistentComponentVersions control
+func printConsistentComponentVersionsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.ConsistentComponentVersionsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Components: %d\n", result.ConsistentComponentVersionsResult.Metrics.Components)
+		fmt.Fprintf(details, "  Consistent: %d\n", result.ConsistentComponentVersionsResult.Metrics.Consistent)
+		fmt.Fprintf(details, "  Inconsistent: %d\n", result.ConsistentComponentVersionsResult.Metrics.Inconsistent)
+
+		if len(result.ConsistentComponentVersionsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sComponents With Several Versions:%s\n", colorYellow, colorReset)
+			for _, issue := range result.ConsistentComponentVersionsResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s (versions: %s)\n", colorYellow, colorReset, issue.Component, strings.Join(issue.Versions, ", "))
+			}
+		}
+	}
+}
+
+// printRootUserDiscouragedDetails prints the status, metrics and issues of the rootUserDiscouraged control
+func printRootUserDiscouragedDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.RootUserDiscouragedResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Images: %d\n", result.RootUserDiscouragedResult.Metrics.Total)
+		fmt.Fprintf(details, "  Root Base Images: %d\n", result.RootUserDiscouragedResult.Metrics.RootImages)
+		fmt.Fprintf(details, "  Running As Non-Root: %d\n", result.RootUserDiscouragedResult.Metrics.NonRoot)
+		fmt.Fprintf(details, "  Likely Running As Root: %d\n", result.RootUserDiscouragedResult.Metrics.Root)
+
+		if len(result.RootUserDiscouragedResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sJobs Likely Running As Root:%s\n", colorYellow, colorReset)
+			for _, issue := range result.RootUserDiscouragedResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' uses root image %s without a non-root user\n", colorYellow, colorReset, issue.Job, issue.Link)
+			}
+		}
+	}
+}
+
+// printRulesOverOnlyExceptDetails prints the status, metrics and issues of the rulesOverOnlyExcept control
+func printRulesOverOnlyExceptDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.RulesOverOnlyExceptResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Jobs: %d\n", result.RulesOverOnlyExceptResult.Metrics.Jobs)
+		fmt.Fprintf(details, "  Using rules: %d\n", result.RulesOverOnlyExceptResult.Metrics.UsingRules)
+		fmt.Fprintf(details, "  Using only/except: %d\n", result.RulesOverOnlyExceptResult.Metrics.DeprecatedJobs)
+
+		if len(result.RulesOverOnlyExceptResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sJobs Using only/except:%s\n", colorYellow, colorReset)
+			for _, issue := range result.RulesOverOnlyExceptResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' uses %s\n", colorYellow, colorReset, issue.Job, strings.Join(issue.Keywords, " and "))
+			}
+		}
+	}
+}
+
+// printReadmeRequiredDetails prints the status, metrics and issues of the readmeRequired control
+func printReadmeRequiredDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.ReadmeRequiredResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Checked Paths: %s\n", strings.Join(result.ReadmeRequiredResult.CheckedPaths, ", "))
+		if result.ReadmeRequiredResult.FoundPath != "" {
+			fmt.Fprintf(details, "  Found: %s\n", result.ReadmeRequiredResult.FoundPath)
+		}
+
+		if len(result.ReadmeRequiredResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sIssues Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.ReadmeRequiredResult.Issues {
+				fmt.Fprintf(details, "    %s•%s No README found on branch '%s'\n", colorYellow, colorReset, issue.Ref)
+			}
+		}
+	}
+}
+
+// printMergeAccessGroupsDetails prints the status, metrics and issues of the mergeAccessGroups control
+func printMergeAccessGroupsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.MergeAccessGroupsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration or not available)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Protected Branches: %d\n", result.MergeAccessGroupsResult.Metrics.ProtectedBranches)
+		fmt.Fprintf(details, "  Restricted To Approved Groups: %d\n", result.MergeAccessGroupsResult.Metrics.Restricted)
+		fmt.Fprintf(details, "  Not Restricted: %d\n", result.MergeAccessGroupsResult.Metrics.NotRestricted)
+
+		if len(result.MergeAccessGroupsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sBranches Not Restricted To Approved Groups:%s\n", colorYellow, colorReset)
+			for _, issue := range result.MergeAccessGroupsResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s (allowed to merge: %s)\n", colorYellow, colorReset, issue.Branch, strings.Join(issue.MergeAccess, ", "))
+			}
+		}
+	}
+}
+
+// printVariableExpansionPolicyDetails prints the status, metrics and issues of the variableExpansionPolicy control
+func printVariableExpansionPolicyDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.VariableExpansionPolicyResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Variables With References: %d\n", result.VariableExpansionPolicyResult.Metrics.Variables)
+		fmt.Fprintf(details, "  Expanded, Should Not Be: %d\n", result.VariableExpansionPolicyResult.Metrics.UnexpectedExpand)
+		fmt.Fprintf(details, "  Not Expanded, Should Be: %d\n", result.VariableExpansionPolicyResult.Metrics.UnexpectedRaw)
+
+		if len(result.VariableExpansionPolicyResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sVariables Not Following The Policy:%s\n", colorYellow, colorReset)
+			for _, issue := range result.VariableExpansionPolicyResult.Issues {
+				scope := "global"
+				if issue.Job != "" {
+					scope = "job '" + issue.Job + "'"
+				}
+				fmt.Fprintf(details, "    %s•%s %s in %s (expand: %t, expected: %t)\n", colorYellow, colorReset, issue.Variable, scope, issue.Expand, issue.ExpectedExpand)
+			}
+		}
+	}
+}
+
+// printNoInsecureTransportDetails prints the status, metrics and issues of the noInsecureTransport control
+func printNoInsecureTransportDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.NoInsecureTransportResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Jobs: %d\n", result.NoInsecureTransportResult.Metrics.TotalJobs)
+		fmt.Fprintf(details, "  Jobs Disabling TLS Verification: %d\n", result.NoInsecureTransportResult.Metrics.InsecureJobs)
+		fmt.Fprintf(details, "  Allowed Jobs: %d\n", result.NoInsecureTransportResult.Metrics.AllowedJobs)
+
+		if len(result.NoInsecureTransportResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sDisabled TLS Verification:%s\n", colorYellow, colorReset)
+			for _, issue := range result.NoInsecureTransportResult.Issues {
+				scope := "global variables"
+				if issue.Job != "" {
+					scope = "job '" + issue.Job + "'"
+				}
+				fmt.Fprintf(details, "    %s•%s %s in %s %s\n", colorYellow, colorReset, issue.Token, scope, issue.Source)
+			}
+		}
+	}
+}
+
+// printArtifactsMustBePrivateDetails prints the status, metrics and issues of the artifactsMustBePrivate control
+func printArtifactsMustBePrivateDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.ArtifactsMustBePrivateResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Jobs With Artifacts: %d\n", result.ArtifactsMustBePrivateResult.Metrics.JobsArtifacts)
+		fmt.Fprintf(details, "  Private: %d\n", result.ArtifactsMustBePrivateResult.Metrics.Private)
+		fmt.Fprintf(details, "  Public: %d\n", result.ArtifactsMustBePrivateResult.Metrics.Public)
+
+		if len(result.ArtifactsMustBePrivateResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sJobs With Public Artifacts:%s\n", colorYellow, colorReset)
+			for _, issue := range result.ArtifactsMustBePrivateResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s'\n", colorYellow, colorReset, issue.Job)
+			}
+		}
+	}
+}
+
+// printRetryPolicyDetails prints the status, metrics and issues of the retryPolicy control
+func printRetryPolicyDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.RetryPolicyResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Max Retries: %d\n", result.RetryPolicyResult.Metrics.MaxRetries)
+		fmt.Fprintf(details, "  Jobs With Retries: %d\n", result.RetryPolicyResult.Metrics.JobsRetry)
+		fmt.Fprintf(details, "  Above Maximum: %d\n", result.RetryPolicyResult.Metrics.AboveMaximum)
+
+		if len(result.RetryPolicyResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sJobs Retrying Too Much:%s\n", colorYellow, colorReset)
+			for _, issue := range result.RetryPolicyResult.Issues {
+				source := ""
+				if issue.FromDefault {
+					source = " (from default)"
+				}
+				fmt.Fprintf(details, "    %s•%s Job '%s': %d retries%s\n", colorYellow, colorReset, issue.Job, issue.Retries, source)
+			}
+		}
+	}
+}
+
+// printRegistryPushGatingDetails prints the status, metrics and issues of the registryPushGating control
+func printRegistryPushGatingDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.RegistryPushGatingResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Push Jobs: %d\n", result.RegistryPushGatingResult.Metrics.PushJobs)
+		fmt.Fprintf(details, "  Gated: %d\n", result.RegistryPushGatingResult.Metrics.Gated)
+		fmt.Fprintf(details, "  Ungated: %d\n", result.RegistryPushGatingResult.Metrics.Ungated)
+		if result.RegistryPushGatingResult.Metrics.Unevaluated > 0 {
+			fmt.Fprintf(details, "  Unevaluated: %d\n", result.RegistryPushGatingResult.Metrics.Unevaluated)
+		}
+
+		if len(result.RegistryPushGatingResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sPush Jobs Running On Unprotected Refs:%s\n", colorYellow, colorReset)
+			for _, issue := range result.RegistryPushGatingResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' (%s) runs on %s: %s\n", colorYellow, colorReset, issue.Job, issue.Command, issue.RunsOn, issue.Reason)
+			}
+		}
+	}
+}
+
+// printJobTimeoutPolicyDetails prints the status, metrics and issues of the jobTimeoutPolicy control
+func printJobTimeoutPolicyDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.JobTimeoutPolicyResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Max Timeout: %d minutes\n", result.JobTimeoutPolicyResult.Metrics.MaxTimeoutMinutes)
+		fmt.Fprintf(details, "  Jobs With Timeout: %d\n", result.JobTimeoutPolicyResult.Metrics.JobsTimeout)
+		fmt.Fprintf(details, "  Too Long: %d\n", result.JobTimeoutPolicyResult.Metrics.TooLong)
+		fmt.Fprintf(details, "  Missing: %d\n", result.JobTimeoutPolicyResult.Metrics.Missing)
+		if result.JobTimeoutPolicyResult.Metrics.Invalid > 0 {
+			fmt.Fprintf(details, "  Invalid: %d\n", result.JobTimeoutPolicyResult.Metrics.Invalid)
+		}
+
+		if len(result.JobTimeoutPolicyResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sJob Timeout Issues:%s\n", colorYellow, colorReset)
+			for _, issue := range result.JobTimeoutPolicyResult.Issues {
+				switch issue.Type {
+				case "tooLong":
+					fmt.Fprintf(details, "    %s•%s Job '%s': timeout '%s' (%d minutes)\n", colorYellow, colorReset, issue.Job, issue.Timeout, issue.TimeoutMinutes)
+				case "invalid":
+					fmt.Fprintf(details, "    %s•%s Job '%s': invalid timeout '%s'\n", colorYellow, colorReset, issue.Job, issue.Timeout)
+				default:
+					fmt.Fprintf(details, "    %s•%s Job '%s': no timeout\n", colorYellow, colorReset, issue.Job)
+				}
+			}
+		}
+	}
+}
+
+// printDebugTraceForbiddenDetails prints the status, metrics and issues of the debugTraceForbidden control
+func printDebugTraceForbiddenDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.DebugTraceForbiddenResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Debug Trace Enabled: %d\n", result.DebugTraceForbiddenResult.Metrics.Enabled)
+
+		if len(result.DebugTraceForbiddenResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sDebug Trace Variables:%s\n", colorYellow, colorReset)
+			for _, issue := range result.DebugTraceForbiddenResult.Issues {
+				if issue.Job != "" {
+					fmt.Fprintf(details, "    %s•%s %s in job '%s'\n", colorYellow, colorReset, issue.Variable, issue.Job)
+				} else {
+					fmt.Fprintf(details, "    %s•%s %s in %s variables\n", colorYellow, colorReset, issue.Variable, issue.Scope)
+				}
+			}
+		}
+	}
+}
+
+// printMaxIncludesDetails prints the status, metrics and issues of the maxIncludes control
+func printMaxIncludesDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.MaxIncludesResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Includes: %d\n", result.MaxIncludesResult.Metrics.Includes)
+		fmt.Fprintf(details, "  Max Count: %d\n", result.MaxIncludesResult.Metrics.MaxCount)
+
+		for _, issue := range result.MaxIncludesResult.Issues {
+			fmt.Fprintf(details, "\n  %sToo Many Includes (%d > %d):%s\n", colorYellow, issue.Count, issue.MaxCount, colorReset)
+			for _, include := range issue.Includes {
+				fmt.Fprintf(details, "    %s•%s %s: %s\n", colorYellow, colorReset, include.Type, include.Location)
+			}
+		}
+	}
+}
+
+// printNoDirectElevatedMembersDetails prints the status, metrics and issues of the noDirectElevatedMembers control
+func printNoDirectElevatedMembersDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.NoDirectElevatedMembersResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Direct Members: %d (of %d members)\n", result.NoDirectElevatedMembersResult.Metrics.DirectMembers, result.NoDirectElevatedMembersResult.Metrics.Members)
+		fmt.Fprintf(details, "  Max Direct Access Level: %d\n", result.NoDirectElevatedMembersResult.Metrics.MaxDirectAccessLevel)
+
+		if len(result.NoDirectElevatedMembersResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sElevated Direct Members:%s\n", colorYellow, colorReset)
+			for _, issue := range result.NoDirectElevatedMembersResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s (access level %d)\n", colorYellow, colorReset, issue.Username, issue.AccessLevel)
+			}
+		}
+	}
+}
+
+// printMergeTrainApprovalsDetails prints the status, metrics and issues of the mergeTrainApprovals control
+func printMergeTrainApprovalsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.MergeTrainApprovalsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Merge Trains Enabled: %t\n", result.MergeTrainApprovalsResult.Metrics.MergeTrainsEnabled)
+		fmt.Fprintf(details, "  Approvals Required: %d (minimum: %d)\n", result.MergeTrainApprovalsResult.Metrics.ApprovalsRequired, result.MergeTrainApprovalsResult.Metrics.MinApprovals)
+
+		if len(result.MergeTrainApprovalsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sIssues Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.MergeTrainApprovalsResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Merge trains are enabled with %d required approval(s), at least %d required\n", colorYellow, colorReset, issue.ApprovalsRequired, issue.MinApprovals)
+				if len(issue.ApprovalRules) > 0 {
+					fmt.Fprintf(details, "      └─ Approval rules: %s\n", strings.Join(issue.ApprovalRules, ", "))
+				}
+				fmt.Fprintf(details, "      └─ Skip train allowed: %t, author approval: %t, reset approvals on push: %t\n", issue.SkipTrainAllowed, issue.AuthorApproval, issue.ResetApprovalsOnPush)
+			}
+		}
+	}
+}
+
+// printComponentsMustBeReleasedDetails prints the status, metrics and issues of the componentsMustBeReleased control
+func printComponentsMustBeReleasedDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.ComponentsMustBeReleasedResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Components: %d\n", result.ComponentsMustBeReleasedResult.Metrics.Components)
+		fmt.Fprintf(details, "  Released: %d\n", result.ComponentsMustBeReleasedResult.Metrics.Released)
+		fmt.Fprintf(details, "  Not In Catalog: %d\n", result.ComponentsMustBeReleasedResult.Metrics.NotInCatalog)
+
+		if len(result.ComponentsMustBeReleasedResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sUnreleased Components:%s\n", colorYellow, colorReset)
+			for _, issue := range result.ComponentsMustBeReleasedResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s (catalog resource %s has no released version)\n", colorYellow, colorReset, issue.Component, issue.CatalogResource)
+			}
+		}
+	}
+}
+
+// printVariableCountBudgetDetails prints the status, metrics and issues of the variableCountBudget control
+func printVariableCountBudgetDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.VariableCountBudgetResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Project Variables: %d (maximum: %d)\n", result.VariableCountBudgetResult.Metrics.ProjectVariables, result.VariableCountBudgetResult.Metrics.MaxProjectVariables)
+		fmt.Fprintf(details, "  Group Variables: %d\n", result.VariableCountBudgetResult.Metrics.GroupVariables)
+		fmt.Fprintf(details, "  Instance Variables: %d\n", result.VariableCountBudgetResult.Metrics.InstanceVariables)
+
+		for _, issue := range result.VariableCountBudgetResult.Issues {
+			fmt.Fprintf(details, "\n  %sToo Many Project Variables (%d > %d):%s\n", colorYellow, issue.Count, issue.MaxCount, colorReset)
+			fmt.Fprintf(details, "    %s\n", strings.Join(issue.Variables, ", "))
+		}
+	}
+}
+
+// printManualJobAccessDetails prints the status, metrics and issues of the manualJobAccess control
+func printManualJobAccessDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.ManualJobAccessResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration or protected environments not available)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Manual Jobs: %d\n", result.ManualJobAccessResult.Metrics.ManualJobs)
+		fmt.Fprintf(details, "  Protected Environments: %d\n", result.ManualJobAccessResult.Metrics.ProtectedEnvironments)
+		fmt.Fprintf(details, "  Unrestricted: %d\n", result.ManualJobAccessResult.Metrics.Unrestricted)
+
+		if len(result.ManualJobAccessResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sManual Jobs Deploying Without Access Restriction:%s\n", colorYellow, colorReset)
+			for _, issue := range result.ManualJobAccessResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s → %s\n", colorYellow, colorReset, issue.Job, issue.Environment)
+				if len(issue.DeployAccessLevels) > 0 {
+					fmt.Fprintf(details, "      └─ Allowed to deploy: %s\n", strings.Join(issue.DeployAccessLevels, ", "))
+				}
+			}
+		}
+	}
+}
+
+// printPushRulesPolicyDetails prints the status, metrics and issues of the pushRulesPolicy control
+func printPushRulesPolicyDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.PushRulesPolicyResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration or push rules not available)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Enforced Push Rules: %d/%d\n", result.PushRulesPolicyResult.Metrics.EnforcedRules, result.PushRulesPolicyResult.Metrics.RequiredRules)
+
+		if len(result.PushRulesPolicyResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sIssues Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.PushRulesPolicyResult.Issues {
+				if issue.Type == "missing" {
+					fmt.Fprintf(details, "    %s•%s %s is not set\n", colorYellow, colorReset, issue.Rule)
+				} else {
+					fmt.Fprintf(details, "    %s•%s %s is '%s'\n", colorYellow, colorReset, issue.Rule, issue.Current)
+				}
+				fmt.Fprintf(details, "      └─ Required: %s\n", issue.Required)
+			}
+		}
+	}
+}
+
+// printSecurityPolicyFileRequiredDetails prints the status, metrics and issues of the securityPolicyFileRequired control
+func printSecurityPolicyFileRequiredDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.SecurityPolicyFileRequiredResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Checked Paths: %s\n", strings.Join(result.SecurityPolicyFileRequiredResult.CheckedPaths, ", "))
+		if result.SecurityPolicyFileRequiredResult.FoundPath != "" {
+			fmt.Fprintf(details, "  Found: %s\n", result.SecurityPolicyFileRequiredResult.FoundPath)
+		}
+
+		if len(result.SecurityPolicyFileRequiredResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sIssues Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.SecurityPolicyFileRequiredResult.Issues {
+				fmt.Fprintf(details, "    %s•%s No security policy file found on branch '%s'\n", colorYellow, colorReset, issue.Ref)
+			}
+		}
+	}
+}
+
+// printRunnerFeatureFlagsDetails prints the status, metrics and issues of the runnerFeatureFlags control
+func printRunnerFeatureFlagsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.RunnerFeatureFlagsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Forbidden Feature Flags Set: %d\n", result.RunnerFeatureFlagsResult.Metrics.Forbidden)
+
+		if len(result.RunnerFeatureFlagsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sForbidden Feature Flags:%s\n", colorYellow, colorReset)
+			for _, issue := range result.RunnerFeatureFlagsResult.Issues {
+				if issue.Job != "" {
+					fmt.Fprintf(details, "    %s•%s %s in job '%s'\n", colorYellow, colorReset, issue.Flag, issue.Job)
+				} else {
+					fmt.Fprintf(details, "    %s•%s %s in %s variables\n", colorYellow, colorReset, issue.Flag, issue.Scope)
+				}
+			}
+		}
+	}
+}
+
+// printTrustedIncludeProjectsDetails prints the status, metrics and issues of the trustedIncludeProjects control
+func printTrustedIncludeProjectsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.TrustedIncludeProjectsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Project Includes: %d\n", result.TrustedIncludeProjectsResult.Metrics.Total)
+		fmt.Fprintf(details, "  Authorized: %d\n", result.TrustedIncludeProjectsResult.Metrics.Authorized)
+		fmt.Fprintf(details, "  Unauthorized: %d\n", result.TrustedIncludeProjectsResult.Metrics.Unauthorized)
+
+		if len(result.TrustedIncludeProjectsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sUntrusted Project Includes:%s\n", colorYellow, colorReset)
+			for _, issue := range result.TrustedIncludeProjectsResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s from %s\n", colorYellow, colorReset, issue.Location, issue.Project)
+			}
+		}
+	}
+}
+
+// printProtectedEnvironmentsDetails prints the status, metrics and issues of the protectedEnvironments control
+func printProtectedEnvironmentsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.ProtectedEnvironmentsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration or protected environments not available)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Required Environments: %d\n", result.ProtectedEnvironmentsResult.Metrics.RequiredEnvironments)
+		fmt.Fprintf(details, "  Compliant: %d\n", result.ProtectedEnvironmentsResult.Metrics.CompliantEnvironments)
+		fmt.Fprintf(details, "  Unprotected: %d\n", result.ProtectedEnvironmentsResult.Metrics.Unprotected)
+		fmt.Fprintf(details, "  Permissive: %d\n", result.ProtectedEnvironmentsResult.Metrics.Permissive)
+
+		if len(result.ProtectedEnvironmentsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sEnvironments Not Protected As Required:%s\n", colorYellow, colorReset)
+			for _, issue := range result.ProtectedEnvironmentsResult.Issues {
+				if issue.Type == "unprotected" {
+					fmt.Fprintf(details, "    %s•%s %s (not protected)\n", colorYellow, colorReset, issue.Environment)
+				} else {
+					fmt.Fprintf(details, "    %s•%s %s (%s can deploy, %s required)\n", colorYellow, colorReset, issue.Environment, issue.DeployAccessLevelText, issue.MinDeployAccessLevelText)
+				}
+			}
+		}
+	}
+}
+
+// printDeprecatedJwtUsageDetails prints the status, metrics and issues of the deprecatedJwtUsage control
+func printDeprecatedJwtUsageDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.DeprecatedJwtUsageResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Jobs: %d\n", result.DeprecatedJwtUsageResult.Metrics.Jobs)
+		fmt.Fprintf(details, "  Jobs Using CI_JOB_JWT: %d\n", result.DeprecatedJwtUsageResult.Metrics.DeprecatedJobs)
+
+		if len(result.DeprecatedJwtUsageResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sDeprecated CI_JOB_JWT References (use id_tokens):%s\n", colorYellow, colorReset)
+			for _, issue := range result.DeprecatedJwtUsageResult.Issues {
+				scope := "global variables"
+				if issue.Job != "" {
+					scope = "job '" + issue.Job + "'"
+				}
+				fmt.Fprintf(details, "    %s•%s %s in %s %s\n", colorYellow, colorReset, issue.Variable, scope, issue.Source)
+			}
+		}
+	}
+}
+
+// printLocalIncludeGlobsDetails prints the status, metrics and issues of the localIncludeGlobs control
+func printLocalIncludeGlobsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.LocalIncludeGlobsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Local Includes: %d\n", result.LocalIncludeGlobsResult.Metrics.LocalIncludes)
+		fmt.Fprintf(details, "  Glob Patterns: %d\n", result.LocalIncludeGlobsResult.Metrics.Globs)
+		fmt.Fprintf(details, "  Allowed Glob Patterns: %d\n", result.LocalIncludeGlobsResult.Metrics.AllowedGlobs)
+
+		if len(result.LocalIncludeGlobsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sLocal Includes Using Globs:%s\n", colorYellow, colorReset)
+			for _, issue := range result.LocalIncludeGlobsResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s\n", colorYellow, colorReset, issue.Pattern)
+			}
+		}
+	}
+}
+
+// printDeadJobsDetails prints the status, metrics and issues of the deadJobs control
+func printDeadJobsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.DeadJobsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Jobs: %d\n", result.DeadJobsResult.Metrics.Jobs)
+		fmt.Fprintf(details, "  Dead Jobs: %d\n", result.DeadJobsResult.Metrics.DeadJobs)
+
+		if len(result.DeadJobsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sDead Jobs Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.DeadJobsResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s': %s\n", colorYellow, colorReset, issue.Job, issue.Reason)
+			}
+		}
+	}
+}
+
+// printRepoStructureDetails prints the status, metrics and issues of the repoStructure control
+func printRepoStructureDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.RepoStructureResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Required Folders: %d\n", result.RepoStructureResult.Metrics.RequiredFolders)
+		fmt.Fprintf(details, "  Required Files: %d\n", result.RepoStructureResult.Metrics.RequiredFiles)
+		fmt.Fprintf(details, "  Missing Folders: %d\n", result.RepoStructureResult.Metrics.MissingFolders)
+		fmt.Fprintf(details, "  Missing Files: %d\n", result.RepoStructureResult.Metrics.MissingFiles)
+
+		if len(result.RepoStructureResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sMissing Paths:%s\n", colorYellow, colorReset)
+			for _, issue := range result.RepoStructureResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s\n", colorYellow, colorReset, issue.Path)
+			}
+		}
+	}
+}
+
+// printTagMustNotBeBranchNameDetails prints the status, metrics and issues of the tagMustNotBeBranchName control
+func printTagMustNotBeBranchNameDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.TagMustNotBeBranchNameResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Images: %d\n", result.TagMustNotBeBranchNameResult.Metrics.Total)
+		fmt.Fprintf(details, "  Branches: %d\n", result.TagMustNotBeBranchNameResult.Metrics.Branches)
+		fmt.Fprintf(details, "  Pinned by Digest: %d\n", result.TagMustNotBeBranchNameResult.Metrics.DigestPinned)
+		fmt.Fprintf(details, "  Tagged with a Branch Name: %d\n", result.TagMustNotBeBranchNameResult.Metrics.BranchNamed)
+
+		if len(result.TagMustNotBeBranchNameResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sBranch-Named Tags Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.TagMustNotBeBranchNameResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' uses %s (branch '%s')\n", colorYellow, colorReset, issue.Job, issue.Link, issue.Branch)
+			}
+		}
+	}
+}
+
+// printRuleConditionSafetyDetails prints the status, metrics and issues of the ruleConditionSafety control
+func printRuleConditionSafetyDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.RuleConditionSafetyResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Privileged Jobs: %d\n", result.RuleConditionSafetyResult.Metrics.PrivilegedJobs)
+		fmt.Fprintf(details, "  Unsafe Conditions: %d\n", result.RuleConditionSafetyResult.Metrics.UnsafeConditions)
+
+		if len(result.RuleConditionSafetyResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sUnsafe Conditions Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.RuleConditionSafetyResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' uses %s\n", colorYellow, colorReset, issue.Job, strings.Join(issue.Variables, ", "))
+				fmt.Fprintf(details, "      └─ if: %s\n", issue.Condition)
+			}
+		}
+	}
+}
+
+// printSameInstanceIncludesOnlyDetails prints the status, metrics and issues of the sameInstanceIncludesOnly control
+func printSameInstanceIncludesOnlyDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.SameInstanceIncludesOnlyResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Component and Remote Includes: %d\n", result.SameInstanceIncludesOnlyResult.Metrics.Total)
+		fmt.Fprintf(details, "  Same Instance: %d\n", result.SameInstanceIncludesOnlyResult.Metrics.SameInstance)
+		fmt.Fprintf(details, "  Allowed Instances: %d\n", result.SameInstanceIncludesOnlyResult.Metrics.Allowed)
+		fmt.Fprintf(details, "  Other Instances: %d\n", result.SameInstanceIncludesOnlyResult.Metrics.Foreign)
+
+		if len(result.SameInstanceIncludesOnlyResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sCross-Instance Includes Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.SameInstanceIncludesOnlyResult.Issues {
+				nested := ""
+				if issue.Nested {
+					nested = " (nested)"
+				}
+				fmt.Fprintf(details, "    %s•%s %s (%s)%s\n", colorYellow, colorReset, issue.Location, issue.Type, nested)
+				fmt.Fprintf(details, "      └─ instance: %s\n", issue.Instance)
+			}
+		}
+	}
+}
+
+// printImageNameAllowlistDetails prints the status, metrics and issues of the imageNameAllowlist control
+func printImageNameAllowlistDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.ImageNameAllowlistResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Total Images: %d\n", result.ImageNameAllowlistResult.Metrics.Total)
+		fmt.Fprintf(details, "  Allowed: %d\n", result.ImageNameAllowlistResult.Metrics.Allowed)
+		fmt.Fprintf(details, "  Not Allowed: %d\n", result.ImageNameAllowlistResult.Metrics.NotAllowed)
+
+		if len(result.ImageNameAllowlistResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sImages Not Allowlisted:%s\n", colorYellow, colorReset)
+			for _, issue := range result.ImageNameAllowlistResult.Issues {
+				fmt.Fprintf(details, "    %s•%s Job '%s' uses '%s' (image: %s)\n", colorYellow, colorReset, issue.Job, issue.Image, issue.Link)
+			}
+		}
+	}
+}
+
+// printPathScopedApprovalsDetails prints the status, metrics and issues of the pathScopedApprovals control
+func printPathScopedApprovalsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.PathScopedApprovalsResult.Skipped {
+		if result.PathScopedApprovalsResult.Error != "" {
+			fmt.Fprintf(details, "  %sStatus: SKIPPED (%s)%s\n", colorDim, result.PathScopedApprovalsResult.Error, colorReset)
+		} else {
+			fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+		}
+	} else {
+		codeOwners := "not found"
+		if result.PathScopedApprovalsResult.CodeOwners != "" {
+			codeOwners = result.PathScopedApprovalsResult.CodeOwners
+		}
+		fmt.Fprintf(details, "  Approvals Required (all paths): %d\n", result.PathScopedApprovalsResult.Metrics.ApprovalsRequired)
+		fmt.Fprintf(details, "  Code Owner Approval Required: %t\n", result.PathScopedApprovalsResult.Metrics.CodeOwnerApprovalRequired)
+		fmt.Fprintf(details, "  CODEOWNERS: %s\n", codeOwners)
+		fmt.Fprintf(details, "  Sensitive Paths: %d\n", result.PathScopedApprovalsResult.Metrics.Requirements)
+		fmt.Fprintf(details, "  Uncovered: %d\n", result.PathScopedApprovalsResult.Metrics.Uncovered)
+
+		if len(result.PathScopedApprovalsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sUncovered Sensitive Paths:%s\n", colorYellow, colorReset)
+			for _, issue := range result.PathScopedApprovalsResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s: %d approval(s) required, minimum %d\n", colorYellow, colorReset, issue.Path, issue.Approvals, issue.MinApprovals)
+			}
+		}
+	}
+}
+
+// printNoPublicCatalogComponentsDetails prints the status, metrics and issues of the noPublicCatalogComponents control
+func printNoPublicCatalogComponentsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.NoPublicCatalogComponentsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else if !result.NoPublicCatalogComponentsResult.Metrics.SelfHosted && result.CiValid && !result.CiMissing {
+		fmt.Fprintf(details, "  %sAnalyzed instance is GitLab.com, public catalog components are allowed%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Components: %d\n", result.NoPublicCatalogComponentsResult.Metrics.Components)
+		fmt.Fprintf(details, "  From Other Instances: %d\n", result.NoPublicCatalogComponentsResult.Metrics.PublicComponents)
+
+		if len(result.NoPublicCatalogComponentsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sPublic Components Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.NoPublicCatalogComponentsResult.Issues {
+				version := ""
+				if issue.Version != "" {
+					version = "@" + issue.Version
+				}
+				fmt.Fprintf(details, "    %s•%s %s%s\n", colorYellow, colorReset, issue.Component, version)
+				fmt.Fprintf(details, "      └─ instance: %s\n", issue.Instance)
+			}
+		}
+	}
+}
+
+// printPackageRegistryAllowlistDetails prints the status, metrics and issues of the packageRegistryAllowlist control
+func printPackageRegistryAllowlistDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.PackageRegistryAllowlistResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Registry Variables: %d\n", result.PackageRegistryAllowlistResult.Metrics.Variables)
+		fmt.Fprintf(details, "  Hosts Not Allowed: %d\n", result.PackageRegistryAllowlistResult.Metrics.NotAllowed)
+		if result.PackageRegistryAllowlistResult.Metrics.Unresolved > 0 {
+			fmt.Fprintf(details, "  %sUnresolved URLs: %d (referencing other variables, not checked)%s\n", colorDim, result.PackageRegistryAllowlistResult.Metrics.Unresolved, colorReset)
+		}
+
+		if len(result.PackageRegistryAllowlistResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sRegistries Not Allowed:%s\n", colorYellow, colorReset)
+			for _, issue := range result.PackageRegistryAllowlistResult.Issues {
+				if issue.Job != "" {
+					fmt.Fprintf(details, "    %s•%s %s in job '%s'\n", colorYellow, colorReset, issue.Variable, issue.Job)
+				} else {
+					fmt.Fprintf(details, "    %s•%s %s in %s variables\n", colorYellow, colorReset, issue.Variable, issue.Scope)
+				}
+				fmt.Fprintf(details, "      └─ host: %s\n", issue.Host)
+			}
+		}
+	}
+}
+
+// printJobImageOverrideTrustDetails prints the status, metrics and issues of the jobImageOverrideTrust control
+func printJobImageOverrideTrustDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.JobImageOverrideTrustResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else if !result.JobImageOverrideTrustResult.Metrics.DefaultTrusted && result.CiValid && !result.CiMissing {
+		fmt.Fprintf(details, "  %sNo trusted default image, overrides are not checked%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Jobs: %d\n", result.JobImageOverrideTrustResult.Metrics.Jobs)
+		fmt.Fprintf(details, "  Image Overrides: %d\n", result.JobImageOverrideTrustResult.Metrics.Overrides)
+		fmt.Fprintf(details, "  Untrusted Overrides: %d\n", result.JobImageOverrideTrustResult.Metrics.UntrustedOverrides)
+
+		if len(result.JobImageOverrideTrustResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sUntrusted Overrides Found:%s\n", colorYellow, colorReset)
+			for _, issue := range result.JobImageOverrideTrustResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s (job: %s)\n", colorYellow, colorReset, issue.Link, issue.Job)
+				fmt.Fprintf(details, "      └─ default: %s\n", issue.DefaultImage)
+			}
+		}
+	}
+}
+
+// printSeparationOfDutiesDetails prints the status, metrics and issues of the separationOfDuties control
+func printSeparationOfDutiesDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.SeparationOfDutiesResult.Skipped {
+		if result.SeparationOfDutiesResult.Error != "" {
+			fmt.Fprintf(details, "  %sStatus: SKIPPED (%s)%s\n", colorDim, result.SeparationOfDutiesResult.Error, colorReset)
+		} else {
+			fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+		}
+	} else {
+		fmt.Fprintf(details, "  Approvals Required: %d\n", result.SeparationOfDutiesResult.Metrics.ApprovalsRequired)
+		fmt.Fprintf(details, "  Requirements Failed: %d/%d\n", result.SeparationOfDutiesResult.Metrics.Failed, result.SeparationOfDutiesResult.Metrics.Requirements)
+
+		if len(result.SeparationOfDutiesResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sRequirements Not Met:%s\n", colorYellow, colorReset)
+			for _, issue := range result.SeparationOfDutiesResult.Issues {
+				switch issue.Requirement {
+				case "authorApproval":
+					fmt.Fprintf(details, "    %s•%s Authors can approve their own merge requests\n", colorYellow, colorReset)
+				case "committersApproval":
+					fmt.Fprintf(details, "    %s•%s Committers can approve merge requests they contributed to\n", colorYellow, colorReset)
+				case "minApprovals":
+					fmt.Fprintf(details, "    %s•%s No approval required to merge into the default branch\n", colorYellow, colorReset)
+				case "directPush":
+					fmt.Fprintf(details, "    %s•%s Default branch can be pushed to without a merge request\n", colorYellow, colorReset)
+					fmt.Fprintf(details, "      └─ push access: %s\n", strings.Join(issue.PushAccess, ", "))
+				}
+			}
+		}
+	}
+}
+
+// printDefaultBranchNoDeletionDetails prints the status, metrics and issues of the defaultBranchNoDeletion control
+func printDefaultBranchNoDeletionDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.DefaultBranchNoDeletionResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Protections: %d\n", result.DefaultBranchNoDeletionResult.Metrics.Protections)
+
+		if len(result.DefaultBranchNoDeletionResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sDeletion Allowed:%s\n", colorYellow, colorReset)
+			for _, issue := range result.DefaultBranchNoDeletionResult.Issues {
+				if issue.Reason == "unprotectAccess" {
+					fmt.Fprintf(details, "    %s•%s %s (protection: %s)\n", colorYellow, colorReset, issue.Branch, issue.Pattern)
+					fmt.Fprintf(details, "      └─ can unprotect and delete: %s\n", strings.Join(issue.UnprotectAccess, ", "))
+				} else {
+					fmt.Fprintf(details, "    %s•%s %s is not protected\n", colorYellow, colorReset, issue.Branch)
+				}
+			}
+		}
+	}
+}
+
+// printMergeRequestApprovalDetails prints the status, metrics and issues of the mergeRequestApproval control
+func printMergeRequestApprovalDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.MergeRequestApprovalResult.Skipped {
+		if result.MergeRequestApprovalResult.Error != "" {
+			fmt.Fprintf(details, "  %sStatus: SKIPPED (%s)%s\n", colorDim, result.MergeRequestApprovalResult.Error, colorReset)
+		} else {
+			fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+		}
+	} else {
+		fmt.Fprintf(details, "  Approvals Required: %d\n", result.MergeRequestApprovalResult.Metrics.ApprovalsRequired)
+		fmt.Fprintf(details, "  Requirements Failed: %d/%d\n", result.MergeRequestApprovalResult.Metrics.Failed, result.MergeRequestApprovalResult.Metrics.Requirements)
+
+		if len(result.MergeRequestApprovalResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sRequirements Not Met:%s\n", colorYellow, colorReset)
+			for _, issue := range result.MergeRequestApprovalResult.Issues {
+				switch issue.Requirement {
+				case "minApprovers":
+					fmt.Fprintf(details, "    %s•%s %d approval(s) required to merge into the default branch, at least %d required\n", colorYellow, colorReset, issue.ApprovalsRequired, issue.MinApprovers)
+				case "preventAuthorApproval":
+					fmt.Fprintf(details, "    %s•%s Authors can approve their own merge requests\n", colorYellow, colorReset)
+				case "resetApprovalsOnPush":
+					fmt.Fprintf(details, "    %s•%s Approvals are not reset when a commit is added\n", colorYellow, colorReset)
+					fmt.Fprintf(details, "      └─ current setting: %s\n", issue.BehaviorWhenCommitIsAdded)
+				}
+			}
+		}
+	}
+}
+
+// printHardcodedJobsDetails prints the status, metrics and issues of the hardcodedJobs control
+func printHardcodedJobsDetails(details io.Writer, result *control.AnalysisResult) {
+	if result.HardcodedJobsResult.Skipped {
+		fmt.Fprintf(details, "  %sStatus: SKIPPED (disabled in configuration)%s\n", colorDim, colorReset)
+	} else {
+		fmt.Fprintf(details, "  Jobs: %d\n", result.HardcodedJobsResult.Metrics.Jobs)
+		fmt.Fprintf(details, "  Hardcoded Jobs: %d\n", result.HardcodedJobsResult.Metrics.Hardcoded)
+		fmt.Fprintf(details, "  Max Allowed: %d\n", result.HardcodedJobsResult.Metrics.MaxAllowed)
+
+		if len(result.HardcodedJobsResult.Issues) > 0 {
+			fmt.Fprintf(details, "\n  %sToo Many Hardcoded Jobs (%d > %d):%s\n", colorYellow, result.HardcodedJobsResult.Metrics.Hardcoded, result.HardcodedJobsResult.Metrics.MaxAllowed, colorReset)
+			for _, issue := range result.HardcodedJobsResult.Issues {
+				fmt.Fprintf(details, "    %s•%s %s\n", colorYellow, colorReset, issue.Job)
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for dump-ci command
+	dumpCIGitlabURL  string
+	dumpCIProject    string
+	dumpCIBranch     string
+	dumpCIOutputFile string
+)
+
+var dumpCICmd = &cobra.Command{
+	Use:          "dump-ci",
+	Short:        "Print the merged CI configuration of a GitLab project",
+	SilenceUsage: true,
+	Long: `Print the merged CI configuration analyzed by Plumber, to debug include
+resolution.
+
+The merged YAML is fetched from GitLab the same way as the analyze command.
+It is preceded by a comment listing the includes resolved by GitLab, with the
+origin type Plumber detects for each of them (component, project, local,
+remote or template) and whether the include is nested in another project.
+The output is valid YAML.
+
+Required environment variables:
+  GITLAB_TOKEN    GitLab API token (required)
+
+Required flags:
+  --gitlab-url    GitLab instance URL
+  --project       Full path of the project
+
+Optional flags:
+  --branch        Branch to dump (defaults to project's default branch)
+  --output        Write the merged configuration to this file instead of stdout
+
+Examples:
+  # Print the merged configuration of the default branch
+  plumber dump-ci --gitlab-url https://gitlab.com --project mygroup/myproject
+
+  # Write the merged configuration of a branch to a file
+  plumber dump-ci --gitlab-url https://gitlab.com --project mygroup/myproject --branch develop --output merged-ci.yml
+`,
+	RunE: runDumpCI,
+}
+
+func init() {
+	rootCmd.AddCommand(dumpCICmd)
+
+	// Required flags
+	dumpCICmd.Flags().StringVar(&dumpCIGitlabURL, "gitlab-url", "", "GitLab instance URL (required)")
+	dumpCICmd.Flags().StringVar(&dumpCIProject, "project", "", "Full path of the project (required)")
+
+	// Optional flags
+	dumpCICmd.Flags().StringVar(&dumpCIBranch, "branch", "", "Branch to dump (defaults to project's default branch)")
+	dumpCICmd.Flags().StringVarP(&dumpCIOutputFile, "output", "o", "", "Write the merged configuration to this file instead of stdout (- for stdout)")
+
+	// Mark required flags
+	_ = dumpCICmd.MarkFlagRequired("gitlab-url")
+	_ = dumpCICmd.MarkFlagRequired("project")
+}
+
+func runDumpCI(cmd *cobra.Command, args []string) error {
+	if verbose {
+		logrus.SetLevel(logrus.DebugLevel)
+	} else {
+		logrus.SetLevel(logrus.WarnLevel)
+	}
+
+	// Get token from environment variable (required)
+	gitlabToken := os.Getenv("GITLAB_TOKEN")
+	if gitlabToken == "" {
+		return fmt.Errorf("GITLAB_TOKEN environment variable is required")
+	}
+
+	cleanGitlabURL, err := configuration.NormalizeGitlabURL(dumpCIGitlabURL)
+	if err != nil {
+		return err
+	}
+
+	conf := configuration.NewDefaultConfiguration()
+	conf.GitlabURL = cleanGitlabURL
+	conf.GitlabToken = gitlabToken
+	conf.ProjectPath = dumpCIProject
+	conf.Branch = dumpCIBranch
+	conf.Offline = offline
+	if verbose {
+		conf.LogLevel = logrus.DebugLevel
+	}
+
+	project, err := gitlab.FetchProjectDetails(conf.ProjectPath, conf.GitlabToken, conf.GitlabURL, conf)
+	if err != nil {
+		return fmt.Errorf("unable to fetch project: %w", err)
+	}
+	projectInfo := project.ToProjectInfo()
+	if conf.Branch != "" {
+		projectInfo.AnalyzeBranch = conf.Branch
+	}
+
+	if projectInfo.Archived {
+		return fmt.Errorf("project %s is archived, its merged CI configuration can't be fetched", conf.ProjectPath)
+	}
+
+	fmt.Fprintf(os.Stderr, "Fetching merged CI configuration: %s (branch %s) on %s\n", conf.ProjectPath, projectInfo.AnalyzeBranch, conf.GitlabURL)
+
+	_, _, mergedResponse, _, mergedYaml, err := gitlab.GetFullGitlabCI(projectInfo, projectInfo.AnalyzeBranch, conf.GitlabToken, conf.GitlabURL, conf)
+	if mergedResponse == nil {
+		if err != nil {
+			return fmt.Errorf("unable to fetch the CI configuration: %w", err)
+		}
+		return fmt.Errorf("no CI configuration found for project %s", conf.ProjectPath)
+	}
+	if err != nil {
+		// The merged response is still useful to debug the configuration
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if mergedResponse.CiConfig.Status == "INVALID" {
+		fmt.Fprintf(os.Stderr, "Warning: the CI configuration is invalid\n")
+		for _, ciError := range mergedResponse.CiConfig.Errors {
+			fmt.Fprintf(os.Stderr, "  - %s\n", ciError)
+		}
+	}
+
+	output := formatMergedCI(projectInfo.Path, mergedResponse.CiConfig.Includes, mergedYaml)
+
+	if dumpCIOutputFile == "" || dumpCIOutputFile == stdoutPath {
+		fmt.Print(output)
+		return nil
+	}
+	if err := os.WriteFile(dumpCIOutputFile, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write merged configuration: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Merged configuration written to: %s\n", dumpCIOutputFile)
+	return nil
+}
+
+// formatMergedCI returns the merged YAML preceded by a comment listing the
+// resolved includes and their detected origin types
+func formatMergedCI(projectPath string, includes []gitlab.MergedCIConfResponseInclude, mergedYaml string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# Includes resolved by GitLab: %d\n", len(includes))
+	for _, include := range includes {
+		originType := collector.IncludeOriginType(include.Type)
+		if originType == "" {
+			originType = "unknown (" + include.Type + ")"
+		}
+		fmt.Fprintf(&sb, "#   - type: %s\n", originType)
+		fmt.Fprintf(&sb, "#     location: %s\n", include.Location)
+		if include.Extra.Project != "" {
+			fmt.Fprintf(&sb, "#     project: %s\n", include.Extra.Project)
+		}
+		if include.Extra.Ref != "" {
+			fmt.Fprintf(&sb, "#     ref: %s\n", include.Extra.Ref)
+		}
+		fmt.Fprintf(&sb, "#     contextProject: %s\n", include.ContextProject)
+		// Includes made from another project are nested, their jobs are
+		// attributed to the first-level include
+		if include.ContextProject != projectPath {
+			sb.WriteString("#     nested: true\n")
+		}
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(mergedYaml)
+	if !strings.HasSuffix(mergedYaml, "\n") {
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
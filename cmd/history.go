@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/getplumber/plumber/control"
+	"github.com/getplumber/plumber/utils"
+)
+
+const (
+	// historyLockTimeout is how long a run waits for another run appending
+	// to the same history file
+	historyLockTimeout = 10 * time.Second
+
+	// historyLockStaleAge is the age after which a lock file left by a
+	// crashed run is removed
+	historyLockStaleAge = time.Minute
+
+	// historyLockRetryDelay is the delay between two attempts to lock the history file
+	historyLockRetryDelay = 50 * time.Millisecond
+)
+
+// historyRecord is a line of the --history JSONL file
+type historyRecord struct {
+	Timestamp         time.Time          `json:"timestamp"`
+	Project           string             `json:"project"`
+	OverallCompliance float64            `json:"overallCompliance"`
+	PerControl        map[string]float64 `json:"perControl"` // Compliance of each control that ran, by result key without the Result suffix
+}
+
+// buildHistoryRecord derives a history record from an analysis result. The
+// controls are the result keys of the JSON output (e.g., branchProtectionResult
+// gives branchProtection), skipped controls are left out.
+func buildHistoryRecord(result *control.AnalysisResult, compliance float64, precision int) (historyRecord, error) {
+	record := historyRecord{
+		Timestamp:         time.Now().UTC(),
+		Project:           result.ProjectPath,
+		OverallCompliance: compliance,
+		PerControl:        map[string]float64{},
+	}
+
+	for _, controlResult := range result.ControlResults() {
+		if controlResult.Skipped {
+			continue
+		}
+		record.PerControl[strings.TrimSuffix(controlResult.Key, "Result")] = utils.RoundToPrecision(controlResult.Compliance, precision)
+	}
+
+	return record, nil
+}
+
+// appendHistoryRecord appends a record as a JSON line to the history file.
+// Runs appending to the same file are serialized with a lock file next to it.
+func appendHistoryRecord(path string, record historyRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("unable to encode history record: %w", err)
+	}
+
+	unlock, err := lockHistoryFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open history file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("unable to write history file: %w", err)
+	}
+	return nil
+}
+
+// lockHistoryFile creates the lock file of a history file, waiting for other
+// runs to release it, and returns the function releasing it
+func lockHistoryFile(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(historyLockTimeout)
+
+	for {
+		lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			lock.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("unable to lock history file: %w", err)
+		}
+
+		// A lock older than any append was left by a crashed run
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > historyLockStaleAge {
+			_ = os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("unable to lock history file: %s is held by another run", lockPath)
+		}
+		time.Sleep(historyLockRetryDelay)
+	}
+}
+
+// readHistoryRecords reads all records of a history file, in file order
+func readHistoryRecords(path string) ([]historyRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read history file: %w", err)
+	}
+
+	records := []historyRecord{}
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var record historyRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return nil, fmt.Errorf("invalid history record on line %d: %w", i+1, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/getplumber/plumber/control"
+)
+
+// junitSuiteName is the name of the test suite holding the controls
+const junitSuiteName = "plumber"
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite is the suite of the controls of an analysis
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a control. Controls are not timed, their time is 0 as
+// GitLab requires the attribute.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+// junitFailure is a control below threshold, its body lists the issues
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// junitSkipped is a control that didn't run
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// buildJUnitReport builds a JUnit report with a test case per control. The
+// controls are the result keys of the JSON output without the Result suffix
+// (e.g., branchProtectionResult gives branchProtection), as in the history
+// file. Controls in result.ThresholdFailures fail with their issues, like the
+// exit code.
+func buildJUnitReport(result *control.AnalysisResult, precision int) (junitTestSuites, error) {
+	suite := junitTestSuite{
+		Name:      junitSuiteName,
+		Time:      "0",
+		TestCases: []junitTestCase{},
+	}
+
+	// Controls are sorted by result key, as in the history file
+	controlResults := result.ControlResults()
+	sort.Slice(controlResults, func(i, j int) bool {
+		return controlResults[i].Key < controlResults[j].Key
+	})
+
+	for _, controlResult := range controlResults {
+		testCase := junitTestCase{
+			Name:      strings.TrimSuffix(controlResult.Key, "Result"),
+			ClassName: result.ProjectPath,
+			Time:      "0",
+		}
+		suite.Tests++
+
+		failure, failed := result.ThresholdFailure(controlResult.Control)
+
+		switch {
+		case controlResult.Skipped:
+			testCase.Skipped = &junitSkipped{Message: controlResult.Error}
+			suite.Skipped++
+		case failed:
+			body, err := junitFailureBody(controlResult.Error, controlResult.Issues)
+			if err != nil {
+				return junitTestSuites{}, err
+			}
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("compliance %s is below threshold %s (%d issue(s))", formatCompliance(failure.Compliance, precision), formatCompliance(failure.Threshold, precision), controlResult.IssueCount),
+				Type:    "compliance",
+				Body:    body,
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	return junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     "0",
+		Suites:   []junitTestSuite{suite},
+	}, nil
+}
+
+// junitFailureBody lists the error and the issues of a control, one issue
+// per line as its fields (e.g., job: build, link: alpine:latest)
+func junitFailureBody(controlError string, controlIssues interface{}) (string, error) {
+	lines := []string{}
+	if controlError != "" {
+		lines = append(lines, "error: "+controlError)
+	}
+
+	data, err := json.Marshal(controlIssues)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode control issues: %w", err)
+	}
+	issues := []json.RawMessage{}
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return "", fmt.Errorf("unable to decode control issues: %w", err)
+	}
+
+	for _, raw := range issues {
+		issue := map[string]interface{}{}
+		if err := json.Unmarshal(raw, &issue); err != nil {
+			lines = append(lines, string(raw))
+			continue
+		}
+
+		names := make([]string, 0, len(issue))
+		for name := range issue {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		values := make([]string, 0, len(names))
+		for _, name := range names {
+			value := issue[name]
+			if _, ok := value.(string); !ok {
+				encoded, _ := json.Marshal(value)
+				value = string(encoded)
+			}
+			values = append(values, fmt.Sprintf("%s: %v", name, value))
+		}
+		lines = append(lines, "- "+strings.Join(values, ", "))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// writeJUnitToFile writes the JUnit XML report of an analysis
+func writeJUnitToFile(result *control.AnalysisResult, precision int, filePath string) error {
+	report, err := buildJUnitReport(result, precision)
+	if err != nil {
+		return err
+	}
+
+	file, err := createOutputFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write([]byte(xml.Header)); err != nil {
+		return fmt.Errorf("unable to write JUnit report: %w", err)
+	}
+	encoder := xml.NewEncoder(file)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("unable to write JUnit report: %w", err)
+	}
+	_, err = file.Write([]byte("\n"))
+	return err
+}
+
+// printJUnitWritten tells on stderr where the JUnit report was written
+func printJUnitWritten(filePath string) {
+	if filePath == stdoutPath {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "JUnit report written to: %s\n", filePath)
+}
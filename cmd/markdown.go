@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/getplumber/plumber/control"
+)
+
+// Status emoji of the markdown report
+const (
+	markdownPassed  = "✅"
+	markdownFailed  = "❌"
+	markdownSkipped = "⏭️"
+)
+
+// buildMarkdownReport renders an analysis as GitLab flavored markdown, e.g.
+// to post as a merge request note: a table of the controls followed by their
+// issues in collapsible sections. Controls fail as in result.ThresholdFailures,
+// like the exit code.
+func buildMarkdownReport(result *control.AnalysisResult, threshold, compliance float64, precision int) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Plumber report: %s\n\n", markdownEscape(result.ProjectPath))
+
+	if result.SkippedArchived {
+		b.WriteString("The project is archived, the analysis was skipped.\n")
+		return b.String()
+	}
+
+	// Status
+	switch {
+	case result.StrictCiFailure != "":
+		fmt.Fprintf(&b, "**Status:** %s Failed (%s, --strict-ci)\n\n", markdownFailed, markdownEscape(result.StrictCiFailure))
+	case analysisPassed(result, threshold, compliance):
+		fmt.Fprintf(&b, "**Status:** %s Passed\n\n", markdownPassed)
+	default:
+		fmt.Fprintf(&b, "**Status:** %s Failed (%s)\n\n", markdownFailed, markdownEscape(thresholdFailureReason(result, threshold, compliance, precision)))
+	}
+	fmt.Fprintf(&b, "**Compliance:** %s (threshold %s)\n\n", formatCompliance(compliance, precision), formatCompliance(threshold, precision))
+
+	// The summaries are the ones of the text report, without printing it
+	controls := controlSummaries(result)
+	if len(controls) == 0 {
+		b.WriteString("No controls could be evaluated.\n")
+		return b.String()
+	}
+
+	// Summary table
+	b.WriteString("| Control | Compliance | Issues | Status |\n")
+	b.WriteString("|---|---:|---:|:---:|\n")
+	for _, ctrl := range controls {
+		if ctrl.skipped {
+			fmt.Fprintf(&b, "| %s | - | - | %s |\n", markdownEscape(ctrl.name), markdownSkipped)
+			continue
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s |\n", markdownEscape(ctrl.name), formatCompliance(ctrl.compliance, precision), ctrl.issues, markdownControlStatus(result, ctrl))
+	}
+
+	// Issues by control, in the order of the table
+	issuesByControl := map[string][]control.ControlIssue{}
+	for _, issue := range result.ControlIssues() {
+		issuesByControl[issue.Control] = append(issuesByControl[issue.Control], issue)
+	}
+	for _, ctrl := range controls {
+		issues := issuesByControl[ctrl.key]
+		if ctrl.skipped || len(issues) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n<details>\n<summary>%s %s (%d issue(s))</summary>\n\n", markdownControlStatus(result, ctrl), markdownEscape(ctrl.name), len(issues))
+		b.WriteString("| Severity | Job | Resource | Branch | Issue |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, issue := range issues {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+				issue.Severity,
+				markdownCode(issue.Job),
+				markdownCode(issue.Resource),
+				markdownCode(issue.Branch),
+				markdownEscape(issue.Message),
+			)
+		}
+		b.WriteString("\n</details>\n")
+	}
+
+	return b.String()
+}
+
+// markdownControlStatus returns the status emoji of a control that ran
+func markdownControlStatus(result *control.AnalysisResult, ctrl controlSummary) string {
+	if _, failed := result.ThresholdFailure(ctrl.key); failed {
+		return markdownFailed
+	}
+	return markdownPassed
+}
+
+// markdownEscape makes text safe in a markdown table cell
+func markdownEscape(text string) string {
+	text = strings.ReplaceAll(text, "\n", " ")
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "<", "&lt;")
+	return strings.ReplaceAll(text, ">", "&gt;")
+}
+
+// markdownCode renders text as inline code in a table cell, empty text as is
+func markdownCode(text string) string {
+	if text == "" {
+		return ""
+	}
+	text = strings.ReplaceAll(text, "\n", " ")
+	text = strings.ReplaceAll(text, "`", "'")
+	return "`" + strings.ReplaceAll(text, "|", "\\|") + "`"
+}
+
+// writeMarkdownToFile writes the markdown report of an analysis
+func writeMarkdownToFile(result *control.AnalysisResult, threshold, compliance float64, precision int, filePath string) error {
+	file, err := createOutputFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.WriteString(file, buildMarkdownReport(result, threshold, compliance, precision)); err != nil {
+		return fmt.Errorf("unable to write markdown report: %w", err)
+	}
+	return nil
+}
+
+// printMarkdownWritten tells on stderr where the markdown report was written
+func printMarkdownWritten(filePath string) {
+	if filePath == stdoutPath {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Markdown report written to: %s\n", filePath)
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/getplumber/plumber/control"
+)
+
+// captureStdout returns what fn writes to stdout
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+
+	fnErr := fn()
+	writer.Close()
+	os.Stdout = stdout
+	if fnErr != nil {
+		t.Fatalf("write error = %v", fnErr)
+	}
+	return <-output
+}
+
+func TestWriteToStdout(t *testing.T) {
+	result := &control.AnalysisResult{
+		ImageForbiddenTagsResult: &control.GitlabImageForbiddenTagsResult{
+			Issues:     []control.GitlabPipelineImageIssueTag{{Link: "docker.io/node:latest", Tag: "latest", Job: "build"}},
+			Compliance: 0,
+		},
+		ThresholdFailures: []control.ControlThresholdFailure{
+			{Control: "containerImageMustNotUseForbiddenTags", Compliance: 0, Threshold: 100},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		write func() error
+		check func(output string) error
+	}{
+		{
+			name:  "json",
+			write: func() error { return writeJSONToFile(result, 100, 0, stdoutPath) },
+			check: func(output string) error {
+				var v map[string]interface{}
+				return json.Unmarshal([]byte(output), &v)
+			},
+		},
+		{
+			name:  "json issues",
+			write: func() error { return writeJSONIssuesToFile(result, stdoutPath) },
+			check: func(output string) error {
+				var v []control.ControlIssue
+				return json.Unmarshal([]byte(output), &v)
+			},
+		},
+		{
+			name:  "code quality",
+			write: func() error { return writeCodeQualityToFile(result, stdoutPath) },
+			check: func(output string) error {
+				var v []codeQualityIssue
+				return json.Unmarshal([]byte(output), &v)
+			},
+		},
+		{
+			name:  "junit",
+			write: func() error { return writeJUnitToFile(result, 1, stdoutPath) },
+			check: func(output string) error {
+				var v interface{}
+				return xml.Unmarshal([]byte(output), &v)
+			},
+		},
+		{
+			name:  "markdown",
+			write: func() error { return writeMarkdownToFile(result, 100, 0, 1, stdoutPath) },
+			check: func(output string) error { return nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			output := captureStdout(t, tt.write)
+			if strings.TrimSpace(output) == "" {
+				t.Fatal("nothing written to stdout")
+			}
+			if !strings.Contains(output, "build") {
+				t.Errorf("stdout doesn't hold the issue of the build job:\n%s", output)
+			}
+			if err := tt.check(output); err != nil {
+				t.Errorf("invalid output: %v\n%s", err, output)
+			}
+			if _, err := os.Stat(stdoutPath); !os.IsNotExist(err) {
+				t.Errorf("a file named %q was created", stdoutPath)
+			}
+		})
+	}
+}
+
+func TestStdoutReportFlag(t *testing.T) {
+	tests := []struct {
+		name                                             string
+		outputFile, markdownFile, junitFile, codeQuality string
+		want                                             string
+		wantErr                                          string
+	}{
+		{name: "all reports to files", outputFile: "results.json", junitFile: "junit.xml"},
+		{name: "JSON to stdout", outputFile: "-", junitFile: "junit.xml", want: "--output"},
+		{name: "code quality to stdout", codeQuality: "-", want: "--codequality"},
+		{name: "JSON and markdown to stdout", outputFile: "-", markdownFile: "-", wantErr: "got --output, --markdown"},
+		{name: "JUnit and code quality to stdout", junitFile: "-", codeQuality: "-", wantErr: "got --junit, --codequality"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stdoutReportFlag(tt.outputFile, tt.markdownFile, tt.junitFile, tt.codeQuality)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("stdoutReportFlag() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("stdoutReportFlag() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("stdoutReportFlag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 var (
 	// Global flags
 	verbose bool
+	offline bool
 )
 
 var rootCmd = &cobra.Command{
@@ -28,4 +29,5 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Air-gapped mode: only contact the GitLab instance, features needing other outbound calls are disabled")
 }
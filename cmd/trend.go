@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getplumber/plumber/configuration"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for trend command
+	trendHistoryFile string
+	trendProject     string
+	trendLast        int
+)
+
+// sparklineLevels are the characters of a sparkline, from 0% to 100%
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+var trendCmd = &cobra.Command{
+	Use:          "trend",
+	Short:        "Print the compliance trend recorded in a history file",
+	SilenceUsage: true,
+	Long: `Print the compliance of the last runs recorded with analyze --history.
+
+For each project of the history file, the overall compliance of the last
+runs is printed as a sparkline and a table, followed by a sparkline per
+control. No GitLab access is needed.
+
+Required flags:
+  --history       Path of the JSONL history file written by analyze --history
+
+Optional flags:
+  --project       Only print this project
+  --last          Number of runs to print per project (default: 10)
+
+Examples:
+  # Record each analysis, then print the trend
+  plumber analyze --gitlab-url https://gitlab.com --project mygroup/myproject --config .plumber.yaml --threshold 100 --history plumber-history.jsonl
+  plumber trend --history plumber-history.jsonl
+`,
+	RunE: runTrend,
+}
+
+func init() {
+	rootCmd.AddCommand(trendCmd)
+
+	// Required flags
+	trendCmd.Flags().StringVar(&trendHistoryFile, "history", "", "Path of the JSONL history file written by analyze --history (required)")
+
+	// Optional flags
+	trendCmd.Flags().StringVar(&trendProject, "project", "", "Only print the trend of this project")
+	trendCmd.Flags().IntVar(&trendLast, "last", 10, "Number of runs to print per project")
+
+	// Mark required flags
+	_ = trendCmd.MarkFlagRequired("history")
+}
+
+func runTrend(cmd *cobra.Command, args []string) error {
+	if trendLast <= 0 {
+		return fmt.Errorf("last must be a positive number")
+	}
+
+	records, err := readHistoryRecords(trendHistoryFile)
+	if err != nil {
+		return err
+	}
+
+	// Group records by project, keeping the order of the runs
+	byProject := map[string][]historyRecord{}
+	for _, record := range records {
+		if trendProject != "" && record.Project != trendProject {
+			continue
+		}
+		byProject[record.Project] = append(byProject[record.Project], record)
+	}
+	if len(byProject) == 0 {
+		if trendProject != "" {
+			return fmt.Errorf("no runs of project %s in %s", trendProject, trendHistoryFile)
+		}
+		return fmt.Errorf("no runs in %s", trendHistoryFile)
+	}
+
+	projects := make([]string, 0, len(byProject))
+	for project := range byProject {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+
+	for _, project := range projects {
+		runs := byProject[project]
+		sort.SliceStable(runs, func(i, j int) bool {
+			return runs[i].Timestamp.Before(runs[j].Timestamp)
+		})
+		if len(runs) > trendLast {
+			runs = runs[len(runs)-trendLast:]
+		}
+		printProjectTrend(project, runs, configuration.DefaultCompliancePrecision)
+	}
+
+	return nil
+}
+
+// printProjectTrend prints the overall and per control trend of the runs of a project
+func printProjectTrend(project string, runs []historyRecord, precision int) {
+	fmt.Printf("\n%sProject: %s%s %s(%d runs)%s\n\n", colorBold, project, colorReset, colorDim, len(runs), colorReset)
+
+	overall := make([]float64, len(runs))
+	for i, run := range runs {
+		overall[i] = run.OverallCompliance
+	}
+	fmt.Printf("  Overall  %s  %s\n\n", sparkline(overall), formatTrendChange(overall, precision))
+
+	for _, run := range runs {
+		fmt.Printf("    %s  %8s\n", run.Timestamp.Local().Format("2006-01-02 15:04:05"), formatCompliance(run.OverallCompliance, precision))
+	}
+
+	// Controls of any of the runs, a control missing from a run (skipped
+	// or not enabled yet) is shown as a space
+	controlSet := map[string]bool{}
+	for _, run := range runs {
+		for name := range run.PerControl {
+			controlSet[name] = true
+		}
+	}
+	if len(controlSet) == 0 {
+		fmt.Println()
+		return
+	}
+
+	controls := make([]string, 0, len(controlSet))
+	width := 0
+	for name := range controlSet {
+		controls = append(controls, name)
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	sort.Strings(controls)
+
+	fmt.Printf("\n  %sControls%s\n", colorBold, colorReset)
+	for _, name := range controls {
+		line := make([]rune, len(runs))
+		values := []float64{}
+		for i, run := range runs {
+			compliance, ok := run.PerControl[name]
+			if !ok {
+				line[i] = ' '
+				continue
+			}
+			line[i] = sparklineLevel(compliance)
+			values = append(values, compliance)
+		}
+		fmt.Printf("    %-*s  %s  %s\n", width, name, string(line), formatTrendChange(values, precision))
+	}
+	fmt.Println()
+}
+
+// sparkline draws compliance values on a fixed 0-100% scale
+func sparkline(values []float64) string {
+	var b strings.Builder
+	for _, value := range values {
+		b.WriteRune(sparklineLevel(value))
+	}
+	return b.String()
+}
+
+// sparklineLevel returns the sparkline character of a compliance value
+func sparklineLevel(compliance float64) rune {
+	level := int(compliance / 100 * float64(len(sparklineLevels)-1))
+	if level < 0 {
+		level = 0
+	}
+	if level >= len(sparklineLevels) {
+		level = len(sparklineLevels) - 1
+	}
+	return sparklineLevels[level]
+}
+
+// formatTrendChange describes the change between the first and last values
+// (e.g., 62.5% → 100.0% (+37.5))
+func formatTrendChange(values []float64, precision int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	first, last := values[0], values[len(values)-1]
+	if len(values) == 1 {
+		return formatCompliance(last, precision)
+	}
+
+	delta := last - first
+	deltaColor := colorDim
+	if delta > 0 {
+		deltaColor = colorGreen
+	} else if delta < 0 {
+		deltaColor = colorRed
+	}
+	return fmt.Sprintf("%s → %s %s(%+.*f)%s", formatCompliance(first, precision), formatCompliance(last, precision), deltaColor, precision, delta, colorReset)
+}
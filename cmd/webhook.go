@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/control"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// webhookFormatGeneric sends a plain JSON body
+	webhookFormatGeneric = "generic"
+	// webhookFormatSlack sends a Slack message (blocks)
+	webhookFormatSlack = "slack"
+
+	// webhookMaxIssues is the number of issues included in a notification
+	webhookMaxIssues = 10
+)
+
+// webhookPayload is the generic JSON body sent to a webhook
+type webhookPayload struct {
+	Project    string                 `json:"project"`
+	ProjectID  int                    `json:"projectId"`
+	Compliance float64                `json:"compliance"`
+	Threshold  float64                `json:"threshold"`
+	Passed     bool                   `json:"passed"`
+	IssueCount int                    `json:"issueCount"`
+	Issues     []control.ControlIssue `json:"issues"`
+}
+
+// buildWebhookPayload builds the generic payload, keeping only the first issues
+func buildWebhookPayload(result *control.AnalysisResult, threshold, compliance float64) webhookPayload {
+	issues := result.ControlIssues()
+	payload := webhookPayload{
+		Project:    result.ProjectPath,
+		ProjectID:  result.ProjectID,
+		Compliance: compliance,
+		Threshold:  threshold,
+		Passed:     analysisPassed(result, threshold, compliance),
+		IssueCount: len(issues),
+		Issues:     issues,
+	}
+	if len(payload.Issues) > webhookMaxIssues {
+		payload.Issues = payload.Issues[:webhookMaxIssues]
+	}
+	return payload
+}
+
+// buildSlackMessage shapes the payload as a Slack message using blocks
+func buildSlackMessage(payload webhookPayload, precision int) map[string]interface{} {
+	status := ":white_check_mark: PASSED"
+	if !payload.Passed {
+		status = ":x: FAILED"
+	}
+	summary := fmt.Sprintf("Plumber analysis of *%s*: %s (compliance %s, required %s)",
+		payload.Project, status, formatCompliance(payload.Compliance, precision), formatCompliance(payload.Threshold, precision))
+
+	blocks := []map[string]interface{}{
+		{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": summary},
+		},
+	}
+
+	if len(payload.Issues) > 0 {
+		lines := []string{fmt.Sprintf("*%d issue(s) found*", payload.IssueCount)}
+		for _, issue := range payload.Issues {
+			lines = append(lines, fmt.Sprintf("• `%s` %s", issue.Control, issue.Message))
+		}
+		if payload.IssueCount > len(payload.Issues) {
+			lines = append(lines, fmt.Sprintf("_…and %d more_", payload.IssueCount-len(payload.Issues)))
+		}
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": strings.Join(lines, "\n")},
+		})
+	}
+
+	return map[string]interface{}{
+		"text":   summary,
+		"blocks": blocks,
+	}
+}
+
+// sendWebhook POSTs the analysis summary to a webhook URL
+func sendWebhook(conf *configuration.Configuration, webhookURL, format string, result *control.AnalysisResult, threshold, compliance float64) error {
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	// Webhook URLs often embed a secret, only log the host
+	l := logrus.WithFields(logrus.Fields{
+		"action":      "sendWebhook",
+		"webhookHost": parsedURL.Host,
+		"format":      format,
+	})
+
+	payload := buildWebhookPayload(result, threshold, compliance)
+
+	var body interface{} = payload
+	if format == webhookFormatSlack {
+		body = buildSlackMessage(payload, conf.CompliancePrecision)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	data = []byte(gitlab.MaskSensitiveData(string(data)))
+
+	l.Debug("Sending webhook notification")
+	resp, err := gitlab.GetHTTPClient(conf).Post(webhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		// Errors may contain the full URL
+		return fmt.Errorf("failed to send webhook to %s", parsedURL.Host)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook %s returned status %d", parsedURL.Host, resp.StatusCode)
+	}
+
+	l.Info("Webhook notification sent")
+	return nil
+}
+
+// notifyWebhook sends the webhook notification of an analysis, only on
+// failure with onFailure. It is disabled in offline mode, as the webhook is
+// not the GitLab instance. Errors are warnings: the notification never
+// changes the exit code.
+func notifyWebhook(conf *configuration.Configuration, webhookURL, format string, onFailure bool, result *control.AnalysisResult, threshold, compliance float64) {
+	if conf.Offline {
+		fmt.Fprintf(os.Stderr, "Webhook notification disabled in offline mode\n")
+		return
+	}
+	if onFailure && analysisPassed(result, threshold, compliance) {
+		return
+	}
+	if err := sendWebhook(conf, webhookURL, format, result, threshold, compliance); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: webhook notification failed: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Webhook notification sent\n")
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/control"
+)
+
+func TestNotifyWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		offline    bool
+		onFailure  bool
+		compliance float64
+		wantSent   bool
+	}{
+		{name: "sent", compliance: 100, wantSent: true},
+		{name: "offline suppresses the webhook", offline: true, compliance: 100, wantSent: false},
+		{name: "offline suppresses the webhook on failure", offline: true, onFailure: true, compliance: 50, wantSent: false},
+		{name: "on failure with a failed analysis", onFailure: true, compliance: 50, wantSent: true},
+		{name: "on failure with a passed analysis", onFailure: true, compliance: 100, wantSent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			conf := configuration.NewDefaultConfiguration()
+			conf.Offline = tt.offline
+			notifyWebhook(conf, server.URL, webhookFormatGeneric, tt.onFailure, &control.AnalysisResult{}, 100, tt.compliance)
+
+			if sent := requests.Load() > 0; sent != tt.wantSent {
+				t.Errorf("webhook sent = %v, want %v", sent, tt.wantSent)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package collector
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/getplumber/plumber/configuration"
@@ -17,6 +19,14 @@ const (
 	unknownRegistry = "unknown"
 )
 
+// imageDigestPattern matches a resolved image digest: an algorithm and its
+// hex encoded hash (e.g., sha256:4c3f...)
+var imageDigestPattern = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
+
+// DefaultImageJob is the job name the default (or global) image is attributed
+// to, so that it is analyzed on its own even when every job sets its image
+const DefaultImageJob = "<default>"
+
 ////////////////////////////
 // DataCollection results //
 ////////////////////////////
@@ -54,6 +64,40 @@ type GitlabPipelineImageInfo struct {
 	Tag      string `json:"tag"`
 	Registry string `json:"registry"`
 	Job      string `json:"job"`
+
+	// Image link and tag as written in the CI configuration, before variable resolution
+	UnresolvedLink string `json:"unresolvedLink,omitempty"`
+	UnresolvedTag  string `json:"unresolvedTag,omitempty"`
+
+	// Digest the image is pinned by (e.g., sha256:...), kept apart from the tag
+	Digest string `json:"digest,omitempty"`
+}
+
+// DigestPinned returns true if the image is referenced by digest (e.g.,
+// app@sha256:...), making it immutable whatever its tag. A digest that is
+// empty or still holds an unresolved variable (e.g., $IMAGE@$DIGEST) doesn't
+// pin the image.
+func (i *GitlabPipelineImageInfo) DigestPinned() bool {
+	return imageDigestPattern.MatchString(i.Digest)
+}
+
+// TagFromVariable returns true if the image tag is defined through a variable
+// in the CI configuration (e.g., app:$TAG)
+func (i *GitlabPipelineImageInfo) TagFromVariable() bool {
+	return strings.Contains(i.UnresolvedTag, "$")
+}
+
+// extractImageTag returns the tag of an image link without resolving
+// variables (e.g., "$REGISTRY/app:$TAG" gives "$TAG"). The digest is ignored.
+func extractImageTag(link string) string {
+	link = strings.SplitN(link, "@", 2)[0]
+	if lastSlash := strings.LastIndex(link, "/"); lastSlash != -1 {
+		link = link[lastSlash+1:]
+	}
+	if colon := strings.Index(link, ":"); colon != -1 {
+		return link[colon+1:]
+	}
+	return ""
 }
 
 ///////////////////////////////
@@ -155,17 +199,6 @@ func (i *GitlabPipelineImageInfo) handlePresenceOfVariables() {
 						namespacePath = remainingPath[:lastColon]
 					}
 				}
-			} else if strings.Contains(remainingPath, "@") {
-				// Handle @digest pattern
-				lastAt := strings.LastIndex(remainingPath, "@")
-				if lastAt > 0 {
-					afterAt := remainingPath[lastAt+1:]
-					// Check if digest is literal or single variable
-					if !strings.Contains(afterAt, "$") || strings.Count(afterAt, "$") == 1 {
-						tag = afterAt
-						namespacePath = remainingPath[:lastAt]
-					}
-				}
 			}
 
 			// For deep namespace paths, only extract the registry part before first slash
@@ -210,8 +243,8 @@ func (i *GitlabPipelineImageInfo) handlePresenceOfVariables() {
 				afterVar = i.Link[varPos+len(variable):]
 			}
 
-			// Pattern: registry/image:$TAG or registry/image@$DIGEST
-			if strings.HasSuffix(beforeVar, ":") || strings.HasSuffix(beforeVar, "@") {
+			// Pattern: registry/image:$TAG
+			if strings.HasSuffix(beforeVar, ":") {
 				beforeSeparator := beforeVar[:len(beforeVar)-1]
 				if strings.Contains(beforeSeparator, "/") {
 					lastSlash := strings.LastIndex(beforeSeparator, "/")
@@ -373,12 +406,6 @@ func (i *GitlabPipelineImageInfo) handlePresenceOfVariables() {
 					}
 					return
 				}
-			} else if betweenVars == "@" {
-				// $IMAGE@$DIGEST pattern
-				i.Registry = unknownRegistry
-				i.Name = firstVariable
-				i.Tag = secondVariable
-				return
 			} else if betweenVars == "" {
 				// Adjacent variables $VAR1$VAR2
 				i.Registry = unknownRegistry
@@ -414,25 +441,6 @@ func (i *GitlabPipelineImageInfo) handlePresenceOfVariables() {
 
 	// Handle three variable cases
 	if numberOfVariables == 3 {
-		// Handle special pattern $IMAGE:$TAG@$DIGEST
-		if strings.Contains(i.Link, ":") && strings.Contains(i.Link, "@") {
-			colonPos := strings.Index(i.Link, ":")
-			atPos := strings.Index(i.Link, "@")
-			if colonPos < atPos {
-				// Check if pattern is $VAR1:$VAR2@$VAR3
-				beforeColon := i.Link[:colonPos]
-				betweenColonAt := i.Link[colonPos+1 : atPos]
-				afterAt := i.Link[atPos+1:]
-
-				if strings.Count(beforeColon, "$") == 1 && strings.Count(betweenColonAt, "$") == 1 && strings.Count(afterAt, "$") == 1 {
-					i.Registry = unknownRegistry
-					i.Name = beforeColon
-					i.Tag = betweenColonAt + "@" + afterAt
-					return
-				}
-			}
-		}
-
 		// Extract tag if pattern ends with :$TAG
 		if strings.Contains(i.Link, ":") {
 			lastColon := strings.LastIndex(i.Link, ":")
@@ -448,21 +456,6 @@ func (i *GitlabPipelineImageInfo) handlePresenceOfVariables() {
 			}
 		}
 
-		// Extract tag if pattern ends with @$DIGEST
-		if strings.Contains(i.Link, "@") {
-			lastAt := strings.LastIndex(i.Link, "@")
-			if lastAt > 0 {
-				afterAt := i.Link[lastAt+1:]
-				// Check if the part after @ is a single variable
-				if strings.HasPrefix(afterAt, "$") && strings.Count(afterAt, "$") == 1 {
-					i.Registry = unknownRegistry
-					i.Name = i.Link[:lastAt]
-					i.Tag = afterAt
-					return
-				}
-			}
-		}
-
 		// Special case for registry:port/image pattern
 		if strings.Contains(i.Link, ":") && strings.Contains(i.Link, "/") {
 			colonPos := strings.Index(i.Link, ":")
@@ -560,6 +553,16 @@ func (i *GitlabPipelineImageInfo) handlePresenceOfVariables() {
 }
 
 func (i *GitlabPipelineImageInfo) parseImageLink(l *logrus.Entry) {
+	// The digest of a pinned image (app:1.2@sha256:... or $IMAGE@$DIGEST) is
+	// parsed apart, the link is then parsed without it and restored after
+	if at := strings.LastIndex(i.Link, "@"); at != -1 {
+		i.Digest = i.Link[at+1:]
+		i.Link = i.Link[:at]
+		i.Tag = ""
+		defer func() {
+			i.Link = i.Link + "@" + i.Digest
+		}()
+	}
 	originalLink := i.Link
 
 	// Check if it contains any unresolved variables
@@ -774,11 +777,13 @@ func (dc *GitlabPipelineImageDataCollection) Run(project *gitlab.ProjectInfo, to
 
 		// Init image data
 		image := GitlabPipelineImageInfo{
-			Link:     imageLink,
-			Name:     "",
-			Tag:      defaultTag,
-			Registry: "",
-			Job:      name,
+			Link:           imageLink,
+			Name:           "",
+			Tag:            defaultTag,
+			Registry:       "",
+			Job:            name,
+			UnresolvedLink: imageUnresolved,
+			UnresolvedTag:  extractImageTag(imageUnresolved),
 		}
 
 		// Parse image link
@@ -787,6 +792,32 @@ func (dc *GitlabPipelineImageDataCollection) Run(project *gitlab.ProjectInfo, to
 		data.Images = append(data.Images, image)
 	}
 
+	// Analyze the default image on its own, resolved without job variables
+	if data.DefaultImage != "" {
+		defaultLogger := l.WithField("jobName", DefaultImageJob)
+		image := GitlabPipelineImageInfo{
+			Link:           gitlab.ReplaceVariable(data.DefaultImage, data.ProjectVars, data.GroupVars, data.InstanceVars, nil, data.GlobalVars, predefinedVars),
+			Name:           "",
+			Tag:            defaultTag,
+			Registry:       "",
+			Job:            DefaultImageJob,
+			UnresolvedLink: data.DefaultImage,
+			UnresolvedTag:  extractImageTag(data.DefaultImage),
+		}
+		if image.Link != "" {
+			image.parseImageLink(defaultLogger)
+			data.Images = append(data.Images, image)
+		}
+	}
+
+	// Jobs are read from a map, sort images so that results are reproducible
+	sort.Slice(data.Images, func(i, j int) bool {
+		if data.Images[i].Job != data.Images[j].Job {
+			return data.Images[i].Job < data.Images[j].Job
+		}
+		return data.Images[i].Link < data.Images[j].Link
+	})
+
 	// Compute metrics
 	metrics.Total = uint(len(data.Images))
 
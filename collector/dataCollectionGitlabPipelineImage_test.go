@@ -0,0 +1,85 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+const testDigest = "sha256:4c3f0d1e8a2b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d"
+
+func TestParseImageLinkDigest(t *testing.T) {
+	tests := []struct {
+		name       string
+		link       string
+		wantName   string
+		wantTag    string
+		wantDigest string
+		wantPinned bool
+	}{
+		{
+			name:       "digest without tag",
+			link:       "nginx@" + testDigest,
+			wantName:   "nginx",
+			wantTag:    "",
+			wantDigest: testDigest,
+			wantPinned: true,
+		},
+		{
+			name:       "digest with tag",
+			link:       "app:1.2@" + testDigest,
+			wantName:   "app",
+			wantTag:    "1.2",
+			wantDigest: testDigest,
+			wantPinned: true,
+		},
+		{
+			name:       "digest from an unresolved variable",
+			link:       "$IMAGE@$DIGEST",
+			wantDigest: "$DIGEST",
+			wantPinned: false,
+		},
+		{
+			name:       "empty digest",
+			link:       "nginx:1.25@",
+			wantName:   "nginx",
+			wantTag:    "1.25",
+			wantDigest: "",
+			wantPinned: false,
+		},
+		{
+			name:       "digest that is not hex encoded",
+			link:       "nginx@sha256:latest",
+			wantName:   "nginx",
+			wantDigest: "sha256:latest",
+			wantPinned: false,
+		},
+		{
+			name:       "no digest",
+			link:       "nginx:1.25",
+			wantName:   "nginx",
+			wantTag:    "1.25",
+			wantPinned: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image := GitlabPipelineImageInfo{Link: tt.link, Tag: defaultTag}
+			image.parseImageLink(logrus.NewEntry(logrus.StandardLogger()))
+
+			if image.Digest != tt.wantDigest {
+				t.Errorf("digest = %q, want %q", image.Digest, tt.wantDigest)
+			}
+			if pinned := image.DigestPinned(); pinned != tt.wantPinned {
+				t.Errorf("DigestPinned() = %v, want %v", pinned, tt.wantPinned)
+			}
+			if tt.wantName != "" && image.Name != tt.wantName {
+				t.Errorf("name = %q, want %q", image.Name, tt.wantName)
+			}
+			if tt.wantName != "" && image.Tag != tt.wantTag {
+				t.Errorf("tag = %q, want %q", image.Tag, tt.wantTag)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package collector
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/getplumber/plumber/configuration"
 	"github.com/getplumber/plumber/gitlab"
@@ -40,6 +42,41 @@ const (
 	masterBranch = "master"
 )
 
+// OriginTypeComponent is the OriginType of CI/CD component origins
+const OriginTypeComponent = originComponent
+
+// OriginTypeProject is the OriginType of file includes from another project
+const OriginTypeProject = originProject
+
+// OriginTypeRemote is the OriginType of file includes fetched from a URL
+const OriginTypeRemote = originRemote
+
+// OriginTypeLocal is the OriginType of local file includes
+const OriginTypeLocal = originLocal
+
+// OriginTypeHardcoded is the OriginType of the origin grouping jobs defined
+// in the project CI configuration itself
+const OriginTypeHardcoded = originHardcoded
+
+// IncludeOriginType returns the origin type detected for an include type of
+// the merged CI configuration, or an empty string if the type is unknown
+func IncludeOriginType(includeType string) string {
+	switch includeType {
+	case glOriginComponent:
+		return originComponent
+	case glOriginProject:
+		return originProject
+	case glOriginLocal:
+		return originLocal
+	case glOriginRemote:
+		return originRemote
+	case glOriginTemplate:
+		return originTemplate
+	default:
+		return ""
+	}
+}
+
 ////////////////////////////
 // DataCollection results //
 ////////////////////////////
@@ -61,6 +98,7 @@ type GitlabPipelineOriginMetrics struct {
 	OriginTemplate      uint `json:"originTemplate"`
 	OriginGitLabCatalog uint `json:"originGitLabCatalog"`
 	OriginOutdated      uint `json:"originOutdated"`
+	OriginOfficial      uint `json:"originOfficial"`
 }
 
 type GitlabPipelineOriginData struct {
@@ -68,6 +106,7 @@ type GitlabPipelineOriginData struct {
 	// Gitlab CI catalog data
 	GitlabCatalogResources    []gitlab.CICatalogResource
 	GitlabCatalogComponentMap map[string]int      // path -> index in catalogResources
+	GitlabCatalogResourceMap  map[string]int      // full path -> index in catalogResources, released or not
 	VersionMap                map[string][]string // path -> []versions (newest first)
 
 	// Gitlab CI configuration
@@ -87,6 +126,15 @@ type GitlabPipelineOriginData struct {
 	JobExtendsMap       map[string][]string
 	JobHardcodedMap     map[string]bool
 	JobHardcodedContent map[string]interface{}
+
+	// Inputs passed to component includes, and the ones their spec declares.
+	// Only collected when the componentInputsValid control is enabled.
+	ComponentInputs []GitlabComponentInputsData
+
+	// Diagnostics are internal inconsistencies found during the collection
+	// (e.g., a job of an include missing from the merged configuration).
+	// They don't fail the analysis but explain an incomplete origin map.
+	Diagnostics []string
 }
 
 type GitlabPipelineOriginDataFull struct {
@@ -98,6 +146,8 @@ type GitlabPipelineOriginDataFull struct {
 type GitlabPipelineOriginDataGeneric struct {
 	OriginType          string                           `json:"originType"`
 	FromGitlabCatalog   bool                             `json:"fromGitlabCatalog"`
+	FromOfficialCatalog bool                             `json:"fromOfficialCatalog"` // Catalog resource in an official namespace
+	CatalogResourcePath string                           `json:"catalogResourcePath"` // Full path of the catalog resource of a component, released or not
 	GitlabIncludeOrigin gitlab.IncludeOriginWithoutRef   `json:"gitlabIncludeOrigin"`
 	GitlabComponent     GitlabPipelineJobGitlabComponent `json:"gitlabComponent"`
 	OriginHash          uint64                           `json:"originHash"`
@@ -121,6 +171,15 @@ type GitlabPipelineJobData struct {
 	IsOverridden bool     `json:"isOverridden"`
 }
 
+// GitlabComponentInputsData holds the inputs passed to a component include
+// and the inputs declared in the component spec
+type GitlabComponentInputsData struct {
+	Component  string                      `json:"component"`           // Include location
+	Inputs     []string                    `json:"inputs"`              // Names of the inputs passed by the include
+	SpecInputs []gitlab.ComponentSpecInput `json:"specInputs"`          // Inputs declared in the component spec:inputs
+	SpecError  string                      `json:"specError,omitempty"` // Set if the component spec could not be fetched
+}
+
 // GitlabPipelineJobGitlabComponent represents a GitLab component
 type GitlabPipelineJobGitlabComponent struct {
 	RepoFullPath           string `json:"repoFullPath"`
@@ -135,6 +194,41 @@ type GitlabPipelineJobGitlabComponent struct {
 // DataCollection functions //
 //////////////////////////////
 
+// parsedVersion is a version string parsed once for sorting
+type parsedVersion struct {
+	raw    string
+	semver *gover.Version // nil if the version is not a valid semantic version
+}
+
+// sortVersionsDescending sorts versions (newest first) using semantic
+// versioning comparison, falling back to string comparison for versions that
+// can't be parsed. Each version is parsed only once, as catalogs can hold
+// thousands of versions.
+func sortVersionsDescending(versions []string) []string {
+	parsed := make([]parsedVersion, len(versions))
+	for i, version := range versions {
+		parsed[i].raw = version
+		if v, err := gover.NewVersion(version); err == nil {
+			parsed[i].semver = v
+		}
+	}
+
+	sort.Slice(parsed, func(i, j int) bool {
+		// If both are valid semantic versions, compare them properly
+		if parsed[i].semver != nil && parsed[j].semver != nil {
+			return parsed[i].semver.GreaterThan(parsed[j].semver) // For descending order (newest first)
+		}
+
+		// Fall back to string comparison if not valid semantic versions
+		return parsed[i].raw > parsed[j].raw // Simple lexicographic sort for descending order
+	})
+
+	for i := range parsed {
+		versions[i] = parsed[i].raw
+	}
+	return versions
+}
+
 // ParseGitlabComponentPath parses a GitLab component path to extract:
 // 1. The instance (if any)
 // 2. The clean path without instance prefix
@@ -254,6 +348,30 @@ func extractInputsFromInclude(includeEntry interface{}, instanceURL string) (uin
 	return 0, nil, nil
 }
 
+// collectComponentInputs gets the inputs passed to a component include and the
+// inputs its spec declares
+func collectComponentInputs(include gitlab.MergedCIConfResponseInclude, inputs map[string]interface{}, token string, conf *configuration.Configuration) GitlabComponentInputsData {
+	componentInputs := GitlabComponentInputsData{
+		Component:  include.Location,
+		Inputs:     make([]string, 0, len(inputs)),
+		SpecInputs: []gitlab.ComponentSpecInput{},
+	}
+	for name := range inputs {
+		componentInputs.Inputs = append(componentInputs.Inputs, name)
+	}
+	sort.Strings(componentInputs.Inputs)
+
+	specInputs, err := gitlab.FetchComponentSpecInputs(include, token, conf.GitlabURL, conf)
+	if err != nil {
+		l.WithError(err).WithField("component", include.Location).Warn("Unable to get the spec inputs of the component")
+		componentInputs.SpecError = err.Error()
+		return componentInputs
+	}
+	componentInputs.SpecInputs = specInputs
+
+	return componentInputs
+}
+
 // generateIncludeHash generates a hash from an IncludeOriginWithoutRef
 // This uses the same logic as the main loop for consistency
 func generateIncludeHash(includeOrigin gitlab.IncludeOriginWithoutRef) (uint64, error) {
@@ -290,6 +408,67 @@ func buildIncludeInputsMap(gitlabConf *gitlab.GitlabCIConf, instanceURL string)
 	return includeInputsMap
 }
 
+// includeOriginHash returns the hash of the origin of an include, as
+// computed in the main loop: components are hashed without their version
+func includeOriginHash(include gitlab.MergedCIConfResponseInclude, instanceURL string) (uint64, error) {
+	includeOrigin := gitlab.IncludeOriginWithoutRef{
+		Location: include.Location,
+		Type:     include.Type,
+		Project:  include.Extra.Project,
+	}
+	if include.Type == glOriginComponent {
+		instance, cleanPath, _ := ParseGitlabComponentPath(includeOrigin.Location, instanceURL)
+		includeOrigin.Location = instance + "/" + cleanPath
+	}
+	return generateIncludeHash(includeOrigin)
+}
+
+// includeFetch is the result of fetching the jobs of an include
+type includeFetch struct {
+	jobs []string
+	err  error
+}
+
+// fetchIncludes fetches the jobs of the first-level includes concurrently,
+// at most conf.MaxConcurrentIncludeFetches at a time. Results are indexed as
+// the includes, so that they are consumed in the same order as serially.
+func fetchIncludes(includes []gitlab.MergedCIConfResponseInclude, project *gitlab.ProjectInfo, token string, conf *configuration.Configuration, includeInputsMap map[uint64]map[string]interface{}, stages []string) []includeFetch {
+	fetches := make([]includeFetch, len(includes))
+
+	maxConcurrent := conf.MaxConcurrentIncludeFetches
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	semaphore := make(chan struct{}, maxConcurrent)
+
+	var wg sync.WaitGroup
+	for index, include := range includes {
+		// Nested includes are not fetched, see the main loop
+		if include.ContextProject != project.Path {
+			continue
+		}
+
+		hash, err := includeOriginHash(include, conf.GitlabURL)
+		if err != nil {
+			fetches[index] = includeFetch{err: err}
+			continue
+		}
+		includeInputs := includeInputsMap[hash]
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(index int, include gitlab.MergedCIConfResponseInclude) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			jobs, err := gitlab.FetchGitlabInclude(include, project.Path, token, conf.GitlabURL, project.LatestHeadCommitSha, conf, includeInputs, stages)
+			fetches[index] = includeFetch{jobs: jobs, err: err}
+		}(index, include)
+	}
+	wg.Wait()
+
+	return fetches
+}
+
 ////////////////////////
 // DataCollection run //
 ////////////////////////
@@ -310,12 +489,14 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 	data.CiValid = true
 	data.CiMissing = false
 	data.LimitedAnalysis = false
+	data.Diagnostics = []string{}
 	data.JobMap = make(map[string]*GitlabPipelineJobData)
 	data.JobExtendsMap = make(map[string][]string)
 	data.JobHardcodedMap = make(map[string]bool)
 	data.JobHardcodedContent = make(map[string]interface{})
 	data.GitlabCatalogResources = []gitlab.CICatalogResource{}
 	data.GitlabCatalogComponentMap = make(map[string]int)
+	data.GitlabCatalogResourceMap = make(map[string]int)
 	data.VersionMap = make(map[string][]string)
 	data.Origins = []GitlabPipelineOriginDataFull{}
 	data.ConfString = ""
@@ -385,6 +566,10 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 
 	// Create maps to quickly lookup components and versions
 	for i, resource := range data.GitlabCatalogResources {
+		// Flag resources from the official namespaces
+		data.GitlabCatalogResources[i].IsOfficialCatalogResource = gitlab.CheckItemMatchToPatterns(resource.FullPath, conf.OfficialCatalogNamespaces)
+		data.GitlabCatalogResourceMap[resource.FullPath] = i
+
 		// Process each version and component
 		for _, version := range resource.Versions {
 			for _, component := range version.Components {
@@ -406,20 +591,7 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 
 	// Sort versions (newest first) - using semantic versioning comparison
 	for path, versions := range data.VersionMap {
-		sort.Slice(versions, func(i, j int) bool {
-			// Try to parse as semantic versions
-			v1, err1 := gover.NewVersion(versions[i])
-			v2, err2 := gover.NewVersion(versions[j])
-
-			// If both are valid semantic versions, compare them properly
-			if err1 == nil && err2 == nil {
-				return v1.GreaterThan(v2) // For descending order (newest first)
-			}
-
-			// Fall back to string comparison if not valid semantic versions
-			return versions[i] > versions[j] // Simple lexicographic sort for descending order
-		})
-		data.VersionMap[path] = versions
+		data.VersionMap[path] = sortVersionsDescending(versions)
 	}
 
 	////////////////////////////////////////////////////////
@@ -509,7 +681,11 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 	/////////////////////////////////////////////////////////////////////////
 
 	if data.MergedResponse != nil {
-		for _, include := range data.MergedResponse.CiConfig.Includes {
+		// Fetching includes takes a request each, they are fetched concurrently
+		// first and then processed in order below
+		includeFetches := fetchIncludes(data.MergedResponse.CiConfig.Includes, project, token, conf, includeInputsMap, data.MergedConf.Stages)
+
+		for includeIndex, include := range data.MergedResponse.CiConfig.Includes {
 
 			// Add logging info
 			lInclude := l.WithField("include", include)
@@ -605,6 +781,8 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 
 					// Mark as found in GitLab catalog
 					originData.FromGitlabCatalog = true
+					originData.FromOfficialCatalog = data.GitlabCatalogResources[resourceIndex].IsOfficialCatalogResource
+					originData.CatalogResourcePath = data.GitlabCatalogResources[resourceIndex].FullPath
 
 					// Get latest version from our pre-sorted version map
 					latestVersion := ""
@@ -649,6 +827,12 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 
 				// If component was not found, log a debug message
 				if !foundComponent {
+					// The resource may still be in the catalog without any released version
+					if index := strings.LastIndex(cleanPath, "/"); index > 0 {
+						if resourceIndex, exists := data.GitlabCatalogResourceMap[cleanPath[:index]]; exists {
+							originData.CatalogResourcePath = data.GitlabCatalogResources[resourceIndex].FullPath
+						}
+					}
 					lInclude.WithFields(logrus.Fields{
 						"componentIncludeLocation": originData.GitlabIncludeOrigin.Location,
 						"cleanComponentPath":       cleanPath,
@@ -679,6 +863,7 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 
 			default:
 				lInclude.WithField("include.Type", include.Type).Error("Unknown include type")
+				data.Diagnostics = append(data.Diagnostics, fmt.Sprintf("Include '%s' has an unknown type '%s'", include.Location, include.Type))
 			}
 
 			////////////////////////////////////////////////////////////////////////////////////
@@ -703,12 +888,17 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 				"inputs":     includeInputs,
 			}).Debug("Fetching include with inputs")
 
-			// Fetch the include with inputs and stages from the merged configuration
+			// Compare the inputs passed to components with their spec
+			if include.Type == glOriginComponent && conf.PlumberConfig.GetComponentInputsValidConfig().IsEnabled() {
+				data.ComponentInputs = append(data.ComponentInputs, collectComponentInputs(include, includeInputs, token, conf))
+			}
+
+			// The include was fetched with inputs and stages from the merged configuration
 			// Stages are needed because components may reference custom stages defined at the root level
-			var jobsFromInclude []string
-			jobsFromInclude, err = gitlab.FetchGitlabInclude(include, project.Path, token, conf.GitlabURL, project.LatestHeadCommitSha, conf, includeInputs, data.MergedConf.Stages)
+			jobsFromInclude, err := includeFetches[includeIndex].jobs, includeFetches[includeIndex].err
 			if err != nil {
 				lInclude.WithError(err).Error("Unable to fetch include from GitLab")
+				data.Diagnostics = append(data.Diagnostics, fmt.Sprintf("Include '%s' could not be fetched: %v", include.Location, err))
 				// If we cannot retrieve the include, next
 				continue
 			}
@@ -738,6 +928,7 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 							"jobExtendSource":         jobExtendSource,
 							"jobExtendMap":            data.JobExtendsMap[jobExtendSource],
 						}).Error("Job extended by a job from an include does not exist in merged final result")
+						data.Diagnostics = append(data.Diagnostics, fmt.Sprintf("Job '%s' extends '%s' from include '%s' but does not exist in the merged configuration", job, jobExtendSource, include.Location))
 						continue
 					}
 
@@ -770,6 +961,7 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 						"currentJobFromInclude":   job,
 						"allJobsFromMergedResult": data.JobMap,
 					}).Error("Job retrieved in include does not exist in merged final result")
+					data.Diagnostics = append(data.Diagnostics, fmt.Sprintf("Job '%s' from include '%s' does not exist in the merged configuration", job, include.Location))
 					continue
 				}
 
@@ -821,6 +1013,13 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 		data.Origins = append(data.Origins, originData)
 	}
 
+	// Jobs are read from maps, sort them so that results are reproducible
+	for _, origin := range data.Origins {
+		sort.Slice(origin.Jobs, func(i, j int) bool {
+			return origin.Jobs[i].Name < origin.Jobs[j].Name
+		})
+	}
+
 	// Compute metrics
 
 	// Job metrics
@@ -862,6 +1061,11 @@ func (dc *GitlabPipelineOriginDataCollection) Run(project *gitlab.ProjectInfo, t
 		if origin.FromGitlabCatalog && !origin.UpToDate {
 			metrics.OriginOutdated++
 		}
+
+		// Count origins from official catalog resources
+		if origin.FromOfficialCatalog {
+			metrics.OriginOfficial++
+		}
 	}
 
 	// Return the populated analysis data
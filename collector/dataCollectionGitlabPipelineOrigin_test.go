@@ -0,0 +1,114 @@
+package collector
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	gover "github.com/hashicorp/go-version"
+)
+
+// sortVersionsDescendingPerComparison is the former sort, parsing both
+// versions on each comparison, kept to benchmark sortVersionsDescending
+func sortVersionsDescendingPerComparison(versions []string) []string {
+	sort.Slice(versions, func(i, j int) bool {
+		v1, err1 := gover.NewVersion(versions[i])
+		v2, err2 := gover.NewVersion(versions[j])
+		if err1 == nil && err2 == nil {
+			return v1.GreaterThan(v2)
+		}
+		return versions[i] > versions[j]
+	})
+	return versions
+}
+
+// catalogVersions returns n shuffled versions of a catalog, a few of them
+// not being semantic versions
+func catalogVersions(n int) []string {
+	r := rand.New(rand.NewSource(1))
+	versions := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		switch {
+		case i%100 == 0:
+			versions = append(versions, fmt.Sprintf("release-%d", i))
+		case i%10 == 0:
+			versions = append(versions, fmt.Sprintf("%d.%d.%d-rc.%d", i/1000, i/100%10, i%100, i%7))
+		default:
+			versions = append(versions, fmt.Sprintf("%d.%d.%d", i/1000, i/100%10, i%100))
+		}
+	}
+	r.Shuffle(len(versions), func(i, j int) { versions[i], versions[j] = versions[j], versions[i] })
+	return versions
+}
+
+func TestSortVersionsDescending(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		want     []string
+	}{
+		{
+			name:     "semantic versions",
+			versions: []string{"1.2.0", "1.10.0", "1.9.1", "2.0.0-rc.1", "2.0.0"},
+			want:     []string{"2.0.0", "2.0.0-rc.1", "1.10.0", "1.9.1", "1.2.0"},
+		},
+		{
+			name:     "v prefix",
+			versions: []string{"v1.0.0", "v1.1.0", "v0.9.0"},
+			want:     []string{"v1.1.0", "v1.0.0", "v0.9.0"},
+		},
+		{
+			name:     "not semantic versions",
+			versions: []string{"main", "latest", "develop"},
+			want:     []string{"main", "latest", "develop"},
+		},
+		{
+			name:     "empty",
+			versions: []string{},
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sortVersionsDescending(tt.versions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortVersionsDescending() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSortVersionsDescendingMatchesPerComparison checks that parsing each
+// version once sorts a catalog like the former sort
+func TestSortVersionsDescendingMatchesPerComparison(t *testing.T) {
+	versions := catalogVersions(3000)
+	want := sortVersionsDescendingPerComparison(append([]string(nil), versions...))
+	if got := sortVersionsDescending(append([]string(nil), versions...)); !reflect.DeepEqual(got, want) {
+		t.Error("sortVersionsDescending() doesn't sort like parsing on each comparison")
+	}
+}
+
+func BenchmarkSortVersionsDescending(b *testing.B) {
+	sorts := []struct {
+		name string
+		sort func([]string) []string
+	}{
+		{name: "parseOnce", sort: sortVersionsDescending},
+		{name: "parsePerComparison", sort: sortVersionsDescendingPerComparison},
+	}
+
+	for _, n := range []int{100, 1000, 5000} {
+		versions := catalogVersions(n)
+		for _, s := range sorts {
+			b.Run(fmt.Sprintf("%s/%d", s.name, n), func(b *testing.B) {
+				input := make([]string, len(versions))
+				for i := 0; i < b.N; i++ {
+					copy(input, versions)
+					s.sort(input)
+				}
+			})
+		}
+	}
+}
@@ -10,7 +10,7 @@ import (
 )
 
 const (
-	DataCollectionTypeGitlabProtectionVersion = "0.2.0"
+	DataCollectionTypeGitlabProtectionVersion = "0.4.0"
 )
 
 // Behavior when commit is added constants
@@ -56,12 +56,22 @@ type GitlabProtectionDataBranch struct {
 
 // GitlabProtectionAnalysisData holds all the data needed by protection controls
 type GitlabProtectionAnalysisData struct {
-	Branches           []string                    `json:"branches"`
-	BranchProtections  []gitlab.BranchProtection   `json:"branchProtections"`
-	MRApprovalRules    []*glab.ProjectApprovalRule `json:"mrApprovalRules"`
-	MRApprovalSettings *glab.ProjectApprovals      `json:"mrApprovalSettings"`
-	MRSettings         *glab.Project               `json:"mrSettings"`
-	ProjectMembers     []gitlab.GitlabMemberInfo   `json:"projectMembers"`
+	Branches           []string                      `json:"branches"`
+	BranchProtections  []gitlab.BranchProtection     `json:"branchProtections"`
+	MRApprovalRules    []*glab.ProjectApprovalRule   `json:"mrApprovalRules"`
+	MRApprovalSettings *glab.ProjectApprovals        `json:"mrApprovalSettings"`
+	MRSettings         *glab.Project                 `json:"mrSettings"`
+	ProjectMembers     []gitlab.GitlabMemberInfo     `json:"projectMembers"`    // Direct and inherited members
+	DirectMembers      []gitlab.GitlabMemberInfo     `json:"directMembers"`     // Only collected when the noDirectElevatedMembers control is enabled
+	PipelineSchedules  []gitlab.PipelineScheduleInfo `json:"pipelineSchedules"` // Only collected when the pipelineSchedules control is enabled
+	Webhooks           []gitlab.WebhookInfo          `json:"webhooks"`          // Only collected when the webhookAllowlist control is enabled
+	DeployTokens       []gitlab.DeployTokenInfo      `json:"deployTokens"`      // Only collected when the deployTokens control is enabled
+
+	// Only collected when the manualJobAccess or protectedEnvironments control is enabled, nil if not available (e.g., GitLab CE or missing permissions)
+	ProtectedEnvironments []gitlab.ProtectedEnvironmentInfo `json:"protectedEnvironments"`
+
+	// Only collected when the pushRulesPolicy control is enabled, nil if not available (e.g., GitLab CE or missing permissions)
+	PushRules *gitlab.PushRulesInfo `json:"pushRules"`
 }
 
 // Run fetches all GitLab protection data needed by the controls
@@ -82,7 +92,7 @@ func (dc *GitlabProtectionDataCollection) Run(
 	metrics := &GitlabProtectionMetrics{}
 
 	// Get project branches and branch protections together
-	branches, branchProtections, err := gitlab.FetchProjectBranchData(project.Path, token, conf.GitlabURL, conf)
+	branches, branchProtections, err := gitlab.FetchBranchData(project.Path, token, conf.GitlabURL, conf)
 	if err != nil {
 		l.WithError(err).Error("Failed to fetch project branch data")
 		return nil, metrics, err
@@ -91,32 +101,39 @@ func (dc *GitlabProtectionDataCollection) Run(
 	returnedData.BranchProtections = branchProtections
 	metrics.Branches = len(branches)
 
-	// Get project MR approval rules (may fail with 403/404 on non-premium GitLab)
-	approvalRules, err := gitlab.FetchProjectMRApprovalRules(project.ID, token, conf.GitlabURL, conf)
-	if err != nil {
-		errStr := err.Error()
-		if !strings.Contains(errStr, "403") && !strings.Contains(errStr, "404") {
-			l.WithError(err).Error("Failed to fetch MR approval rules")
-			return nil, metrics, err
-		}
-		l.WithError(err).Warn("MR approval rules not available (may require premium)")
-		// If 403/404 error, MRApprovalRules will be nil which controls can handle
+	// MR approvals are EE features, don't query them on CE instances.
+	// If the edition is unknown, try anyway and handle 403/404 below.
+	enterprise, editionErr := gitlab.IsGitlabInstanceEnterprise(token, conf.GitlabURL, conf)
+	if editionErr == nil && !enterprise {
+		l.Info("MR approval rules and settings not available on GitLab CE, skipping")
 	} else {
-		returnedData.MRApprovalRules = approvalRules
-	}
+		// Get project MR approval rules (may fail with 403/404 on non-premium GitLab)
+		approvalRules, err := gitlab.FetchProjectMRApprovalRules(project.ID, token, conf.GitlabURL, conf)
+		if err != nil {
+			errStr := err.Error()
+			if !strings.Contains(errStr, "403") && !strings.Contains(errStr, "404") {
+				l.WithError(err).Error("Failed to fetch MR approval rules")
+				return nil, metrics, err
+			}
+			l.WithError(err).Warn("MR approval rules not available (may require premium)")
+			// If 403/404 error, MRApprovalRules will be nil which controls can handle
+		} else {
+			returnedData.MRApprovalRules = approvalRules
+		}
 
-	// Get project MR approval settings (may fail with 403/404 on non-premium GitLab)
-	approvalSettings, err := gitlab.FetchProjectMRApprovalSettings(project.ID, token, conf.GitlabURL, conf)
-	if err != nil {
-		errStr := err.Error()
-		if !strings.Contains(errStr, "403") && !strings.Contains(errStr, "404") {
-			l.WithError(err).Error("Failed to fetch MR approval settings")
-			return nil, metrics, err
+		// Get project MR approval settings (may fail with 403/404 on non-premium GitLab)
+		approvalSettings, err := gitlab.FetchProjectMRApprovalSettings(project.ID, token, conf.GitlabURL, conf)
+		if err != nil {
+			errStr := err.Error()
+			if !strings.Contains(errStr, "403") && !strings.Contains(errStr, "404") {
+				l.WithError(err).Error("Failed to fetch MR approval settings")
+				return nil, metrics, err
+			}
+			l.WithError(err).Warn("MR approval settings not available (may require premium)")
+			// If 403/404 error, MRApprovalSettings will be nil which controls can handle
+		} else {
+			returnedData.MRApprovalSettings = approvalSettings
 		}
-		l.WithError(err).Warn("MR approval settings not available (may require premium)")
-		// If 403/404 error, MRApprovalSettings will be nil which controls can handle
-	} else {
-		returnedData.MRApprovalSettings = approvalSettings
 	}
 
 	// Get project settings (includes MR settings like squash, merge method)
@@ -136,6 +153,77 @@ func (dc *GitlabProtectionDataCollection) Run(
 		returnedData.ProjectMembers = members
 	}
 
+	// Get members added directly to the project, without inherited ones
+	if conf.PlumberConfig.GetNoDirectElevatedMembersConfig().IsEnabled() {
+		directMembers, err := gitlab.FetchDirectProjectMembers(project.ID, token, conf.GitlabURL, conf)
+		if err != nil {
+			l.WithError(err).Warn("Failed to fetch direct project members")
+			// Continue without direct members
+		} else {
+			returnedData.DirectMembers = directMembers
+		}
+	}
+
+	// Get pipeline schedules (may fail with 403 if the token can't read them)
+	if conf.PlumberConfig.GetPipelineSchedulesConfig().IsEnabled() {
+		schedules, err := gitlab.FetchPipelineSchedules(project.ID, token, conf.GitlabURL, conf)
+		if err != nil {
+			l.WithError(err).Warn("Failed to fetch pipeline schedules")
+			// Continue without schedules
+		} else {
+			returnedData.PipelineSchedules = schedules
+		}
+	}
+
+	// Get project webhooks (requires Maintainer access, may fail with 403)
+	if conf.PlumberConfig.GetWebhookAllowlistConfig().IsEnabled() {
+		webhooks, err := gitlab.FetchProjectWebhooks(project.ID, token, conf.GitlabURL, conf)
+		if err != nil {
+			l.WithError(err).Warn("Failed to fetch project webhooks")
+			// Continue without webhooks
+		} else {
+			returnedData.Webhooks = webhooks
+		}
+	}
+
+	// Get deploy tokens (requires Maintainer access, may fail with 403)
+	if conf.PlumberConfig.GetDeployTokensConfig().IsEnabled() {
+		deployTokens, err := gitlab.FetchDeployTokens(project.ID, token, conf.GitlabURL, conf)
+		if err != nil {
+			l.WithError(err).Warn("Failed to fetch deploy tokens")
+			// Continue without deploy tokens
+		} else {
+			returnedData.DeployTokens = deployTokens
+		}
+	}
+
+	// Get protected environments (Premium feature, may fail with 403/404)
+	if conf.PlumberConfig.GetManualJobAccessConfig().IsEnabled() || conf.PlumberConfig.GetProtectedEnvironmentsConfig().IsEnabled() {
+		protectedEnvironments, err := gitlab.FetchProtectedEnvironments(project.ID, token, conf.GitlabURL, conf)
+		if err != nil {
+			l.WithError(err).Warn("Failed to fetch protected environments")
+			// Continue without protected environments
+		} else {
+			returnedData.ProtectedEnvironments = protectedEnvironments
+		}
+	}
+
+	// Get push rules (Premium feature, may fail with 403/404)
+	if conf.PlumberConfig.GetPushRulesPolicyConfig().IsEnabled() {
+		pushRules, err := gitlab.FetchProjectPushRules(project.ID, token, conf.GitlabURL, conf)
+		if err != nil {
+			l.WithError(err).Warn("Failed to fetch push rules")
+			// Continue without push rules
+		} else {
+			returnedData.PushRules = pushRules
+		}
+	}
+
+	// Resolve the groups allowed to merge (requires access to the groups)
+	if conf.PlumberConfig.GetMergeAccessGroupsConfig().IsEnabled() {
+		resolveMergeAccessGroups(returnedData.BranchProtections, token, conf)
+	}
+
 	l.WithFields(logrus.Fields{
 		"branchCount":           len(returnedData.Branches),
 		"branchProtectionCount": len(returnedData.BranchProtections),
@@ -144,3 +232,28 @@ func (dc *GitlabProtectionDataCollection) Run(
 
 	return returnedData, metrics, nil
 }
+
+// resolveMergeAccessGroups sets the full path of the groups allowed to merge
+// in branch protections. Groups that can't be fetched keep an empty path.
+func resolveMergeAccessGroups(branchProtections []gitlab.BranchProtection, token string, conf *configuration.Configuration) {
+	groupPaths := map[int]string{}
+	for i := range branchProtections {
+		for j := range branchProtections[i].MergeAccessLevels {
+			accessLevel := &branchProtections[i].MergeAccessLevels[j]
+			if accessLevel.GroupID == 0 {
+				continue
+			}
+
+			groupPath, resolved := groupPaths[accessLevel.GroupID]
+			if !resolved {
+				var err error
+				groupPath, err = gitlab.GetGroupFullPath(accessLevel.GroupID, token, conf.GitlabURL, conf)
+				if err != nil {
+					l.WithError(err).WithField("groupId", accessLevel.GroupID).Warn("Failed to resolve group allowed to merge")
+				}
+				groupPaths[accessLevel.GroupID] = groupPath
+			}
+			accessLevel.GroupFullPath = groupPath
+		}
+	}
+}
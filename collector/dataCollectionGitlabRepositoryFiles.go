@@ -0,0 +1,86 @@
+package collector
+
+import (
+	"strings"
+
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DataCollectionTypeGitlabRepositoryFilesVersion = "0.1.0"
+)
+
+// GitlabRepositoryFilesDataCollection handles repository files data collection
+type GitlabRepositoryFilesDataCollection struct{}
+
+// GitlabRepositoryFilesData holds the presence of files and folders in the repository
+type GitlabRepositoryFilesData struct {
+	Ref      string            `json:"ref"`
+	Files    map[string]bool   `json:"files"`              // Checked paths, true if the file exists
+	Folders  map[string]bool   `json:"folders,omitempty"`  // Checked folders, true if the folder exists and isn't empty
+	Contents map[string]string `json:"contents,omitempty"` // Content of the checked paths that exist
+}
+
+// Run checks which of the given paths and folders exist on the default
+// branch of the project. For files, a 404 means the file is missing, any
+// other error fails the collection. Folders are checked with RepoHasFolder.
+func (dc *GitlabRepositoryFilesDataCollection) Run(
+	project *gitlab.ProjectInfo,
+	paths []string,
+	folders []string,
+	token string,
+	conf *configuration.Configuration,
+) (*GitlabRepositoryFilesData, error) {
+
+	l := l.WithFields(logrus.Fields{
+		"dataCollection":        "GitlabRepositoryFiles",
+		"dataCollectionVersion": DataCollectionTypeGitlabRepositoryFilesVersion,
+		"project":               project.Path,
+	})
+	l.Info("Start data collection")
+
+	data := &GitlabRepositoryFilesData{
+		Ref:      project.DefaultBranch,
+		Files:    map[string]bool{},
+		Folders:  map[string]bool{},
+		Contents: map[string]string{},
+	}
+
+	for _, path := range paths {
+		if _, checked := data.Files[path]; checked {
+			continue
+		}
+
+		content, errPlatform, err := gitlab.FetchGitlabFile(project.Path, path, project.DefaultBranch, token, conf.GitlabURL, conf)
+		if err != nil {
+			l.WithError(err).WithField("path", path).Error("Unable to check repository file")
+			return nil, err
+		}
+		if errPlatform != nil {
+			if !strings.Contains(errPlatform.Error(), "404") {
+				l.WithError(errPlatform).WithField("path", path).Error("Unable to check repository file")
+				return nil, errPlatform
+			}
+			data.Files[path] = false
+			continue
+		}
+		data.Files[path] = true
+		data.Contents[path] = string(content)
+	}
+
+	for _, folder := range folders {
+		if _, checked := data.Folders[folder]; checked {
+			continue
+		}
+		data.Folders[folder] = gitlab.RepoHasFolder(project.Path, folder, token, conf.GitlabURL, conf)
+	}
+
+	l.WithFields(logrus.Fields{
+		"files":   data.Files,
+		"folders": data.Folders,
+	}).Info("Data collection completed")
+
+	return data, nil
+}
@@ -0,0 +1,186 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/getplumber/plumber/gitlab"
+)
+
+// Fixture file names written by --fixture-dump
+const (
+	FixturePipelineOriginFile = "pipeline-origin.json"
+	FixturePipelineImageFile  = "pipeline-image.json"
+	FixtureProtectionFile     = "protection.json"
+)
+
+// fixtureRedacted replaces secret values in fixtures
+const fixtureRedacted = "***REDACTED***"
+
+// Fixtures holds the data collected for a project, as dumped by --fixture-dump.
+// Any of the fields can be nil if the corresponding collection didn't run.
+type Fixtures struct {
+	PipelineOrigin *GitlabPipelineOriginData
+	PipelineImage  *GitlabPipelineImageData
+	Protection     *GitlabProtectionAnalysisData
+}
+
+// DumpPipelineOriginFixture writes pipeline origin data to a fixture file.
+// Parsed CI configurations are not written, they are rebuilt from their YAML
+// content when the fixture is loaded.
+func DumpPipelineOriginFixture(dir string, data *GitlabPipelineOriginData) error {
+	if data == nil {
+		return nil
+	}
+	fixture := *data
+	fixture.Conf = nil
+	fixture.MergedConf = nil
+	fixture.JobHardcodedContent = nil
+	return writeFixture(dir, FixturePipelineOriginFile, &fixture)
+}
+
+// DumpPipelineImageFixture writes pipeline image data to a fixture file.
+// CI/CD variable values are redacted.
+func DumpPipelineImageFixture(dir string, data *GitlabPipelineImageData) error {
+	if data == nil {
+		return nil
+	}
+	fixture := *data
+	fixture.MergedConf = nil
+	fixture.InstanceVars = redactVariables(data.InstanceVars)
+	fixture.GroupVars = redactVariables(data.GroupVars)
+	fixture.ProjectVars = redactVariables(data.ProjectVars)
+	return writeFixture(dir, FixturePipelineImageFile, &fixture)
+}
+
+// DumpProtectionFixture writes protection data to a fixture file.
+// Member emails are redacted.
+func DumpProtectionFixture(dir string, data *GitlabProtectionAnalysisData) error {
+	if data == nil {
+		return nil
+	}
+	fixture := *data
+	fixture.ProjectMembers = redactMemberEmails(data.ProjectMembers)
+	fixture.DirectMembers = redactMemberEmails(data.DirectMembers)
+	return writeFixture(dir, FixtureProtectionFile, &fixture)
+}
+
+// redactMemberEmails returns a copy of members with emails redacted
+func redactMemberEmails(members []gitlab.GitlabMemberInfo) []gitlab.GitlabMemberInfo {
+	if members == nil {
+		return nil
+	}
+	redacted := make([]gitlab.GitlabMemberInfo, len(members))
+	for i, member := range members {
+		if member.Email != "" {
+			member.Email = fixtureRedacted
+		}
+		redacted[i] = member
+	}
+	return redacted
+}
+
+// LoadFixtures reads the fixtures of a directory written by --fixture-dump and
+// rebuilds the collected data, so that controls can run without GitLab
+func LoadFixtures(dir string) (*Fixtures, error) {
+	fixtures := &Fixtures{}
+
+	origin := &GitlabPipelineOriginData{}
+	found, err := readFixture(dir, FixturePipelineOriginFile, origin)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		// Rebuild parsed CI configurations from their YAML content
+		if origin.ConfString != "" {
+			if origin.Conf, err = gitlab.ParseGitlabCI([]byte(origin.ConfString)); err != nil {
+				return nil, fmt.Errorf("unable to parse CI configuration from fixture: %w", err)
+			}
+		}
+		if origin.MergedResponse != nil && origin.MergedResponse.CiConfig.MergedYaml != "" {
+			if origin.MergedConf, err = gitlab.ParseGitlabCI([]byte(origin.MergedResponse.CiConfig.MergedYaml)); err != nil {
+				return nil, fmt.Errorf("unable to parse merged CI configuration from fixture: %w", err)
+			}
+		}
+		origin.JobHardcodedContent = map[string]interface{}{}
+		if origin.Conf != nil {
+			for name := range origin.JobHardcodedMap {
+				origin.JobHardcodedContent[name] = origin.Conf.GitlabJobs[name]
+			}
+		}
+		fixtures.PipelineOrigin = origin
+	}
+
+	image := &GitlabPipelineImageData{}
+	found, err = readFixture(dir, FixturePipelineImageFile, image)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		if fixtures.PipelineOrigin != nil {
+			image.MergedConf = fixtures.PipelineOrigin.MergedConf
+		}
+		fixtures.PipelineImage = image
+	}
+
+	protection := &GitlabProtectionAnalysisData{}
+	found, err = readFixture(dir, FixtureProtectionFile, protection)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		fixtures.Protection = protection
+	}
+
+	return fixtures, nil
+}
+
+// redactVariables returns a copy of variables with all values redacted
+func redactVariables(vars map[string]string) map[string]string {
+	if vars == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(vars))
+	for key := range vars {
+		redacted[key] = fixtureRedacted
+	}
+	return redacted
+}
+
+// writeFixture writes data as indented JSON, masking tokens it may contain
+func writeFixture(dir, fileName string, data interface{}) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("unable to create fixture directory: %w", err)
+	}
+
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode fixture %s: %w", fileName, err)
+	}
+	content = []byte(gitlab.MaskSensitiveData(string(content)))
+
+	if err := os.WriteFile(filepath.Join(dir, fileName), content, 0o600); err != nil {
+		return fmt.Errorf("unable to write fixture %s: %w", fileName, err)
+	}
+
+	l.WithField("fixture", filepath.Join(dir, fileName)).Info("Fixture written")
+	return nil
+}
+
+// readFixture reads a JSON fixture, returning false if the file doesn't exist
+func readFixture(dir, fileName string, data interface{}) (bool, error) {
+	content, err := os.ReadFile(filepath.Join(dir, fileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to read fixture %s: %w", fileName, err)
+	}
+
+	if err := json.Unmarshal(content, data); err != nil {
+		return false, fmt.Errorf("unable to decode fixture %s: %w", fileName, err)
+	}
+	return true, nil
+}
@@ -1,6 +1,10 @@
 package configuration
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -17,6 +21,19 @@ type Configuration struct {
 	ProjectID   int    // Project ID on GitLab
 	Branch      string // Branch to analyze (from --branch flag, defaults to project's default branch)
 
+	// IncludeArchived analyzes archived projects instead of skipping them (from --include-archived flag)
+	IncludeArchived bool
+
+	// IncludeInventory adds the list of jobs with their origin and image to the results (from --include-inventory flag)
+	IncludeInventory bool
+
+	// Rules simulation settings
+	SimulateRef    string // Ref used to evaluate workflow and job rules (from --simulate-ref flag, refs/tags/ prefix for tags)
+	SimulateSource string // Pipeline source used to evaluate workflow and job rules (from --simulate-source flag)
+
+	// Offline disables every outbound call to anything but the GitLab instance (from --offline flag)
+	Offline bool
+
 	// HTTP client settings
 	HTTPClientTimeout time.Duration // Timeout for HTTP clients (REST and GraphQL)
 
@@ -26,6 +43,23 @@ type Configuration struct {
 	GitlabRetryMaxBackoff     time.Duration // Maximum backoff time for GitLab API retries
 	GitlabRetryBackoffFactor  float64       // Backoff multiplication factor for exponential backoff
 
+	// Concurrency settings
+	MaxConcurrentIncludeFetches int // Maximum number of CI includes fetched at the same time
+
+	// Debug settings
+	FixtureDumpDir           string // Directory where collected data is dumped as JSON fixtures (from --fixture-dump flag)
+	DisableMergedCIConfCache bool   // Always request merged CI configurations from GitLab (from --no-ci-cache flag)
+
+	// Cache of the merged CI configurations of the analysis, by hash of project, content and sha
+	MergedCIConfCache *sync.Map
+
+	// Catalog settings
+	OfficialCatalogNamespaces []string // Namespaces of official CI/CD catalog resources (supports wildcards)
+
+	// Compliance settings
+	CompliancePrecision int  // Number of decimals used to round compliance for both display and threshold comparison
+	StrictCI            bool // Fail the analysis when the CI configuration is missing or invalid (from --strict-ci flag or strictCi)
+
 	// Logging
 	LogLevel logrus.Level
 
@@ -36,16 +70,64 @@ type Configuration struct {
 	PlumberConfig *PlumberConfig
 }
 
+// DefaultCompliancePrecision is the number of decimals compliance is rounded
+// to when --precision is not set
+const DefaultCompliancePrecision = 1
+
+// DefaultOfficialCatalogNamespaces are the namespaces of the CI/CD catalog
+// resources maintained by GitLab, used when officialCatalogNamespaces is not set
+var DefaultOfficialCatalogNamespaces = []string{
+	"components/*",
+	"gitlab-org/*",
+}
+
 // NewDefaultConfiguration creates a Configuration with sensible defaults
 func NewDefaultConfiguration() *Configuration {
 	return &Configuration{
-		GitlabURL:                 "https://gitlab.com",
-		HTTPClientTimeout:         30 * time.Second,
-		GitlabRetryMaxRetries:     3,
-		GitlabRetryInitialBackoff: 1 * time.Second,
-		GitlabRetryMaxBackoff:     30 * time.Second,
-		GitlabRetryBackoffFactor:  2.0,
-		LogLevel:                  logrus.WarnLevel,
-		Version:                   "0.1.0",
+		GitlabURL:                   "https://gitlab.com",
+		HTTPClientTimeout:           30 * time.Second,
+		GitlabRetryMaxRetries:       3,
+		GitlabRetryInitialBackoff:   1 * time.Second,
+		GitlabRetryMaxBackoff:       30 * time.Second,
+		GitlabRetryBackoffFactor:    2.0,
+		MaxConcurrentIncludeFetches: 8,
+		MergedCIConfCache:           &sync.Map{},
+		OfficialCatalogNamespaces:   DefaultOfficialCatalogNamespaces,
+		CompliancePrecision:         DefaultCompliancePrecision,
+		LogLevel:                    logrus.WarnLevel,
+		Version:                     "0.1.0",
 	}
 }
+
+// NormalizeGitlabURL validates a GitLab instance URL and returns it in the
+// form used everywhere else: https:// is added when the scheme is missing, and
+// trailing slashes and any /api/... suffix are removed (e.g.,
+// "gitlab.com/api/v4/" becomes "https://gitlab.com"). Instances served under
+// a relative path (e.g., https://example.com/gitlab) keep it.
+func NormalizeGitlabURL(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", fmt.Errorf("GitLab URL is empty")
+	}
+	if !strings.Contains(trimmed, "://") {
+		trimmed = "https://" + trimmed
+	}
+
+	parsedURL, err := url.Parse(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("invalid GitLab URL '%s': %w", raw, err)
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return "", fmt.Errorf("invalid GitLab URL '%s': scheme must be http or https", raw)
+	}
+	if parsedURL.Hostname() == "" {
+		return "", fmt.Errorf("invalid GitLab URL '%s': host is missing", raw)
+	}
+
+	path := strings.TrimRight(parsedURL.Path, "/")
+	if index := strings.Index(path+"/", "/api/"); index >= 0 {
+		path = path[:index]
+	}
+
+	return parsedURL.Scheme + "://" + parsedURL.Host + path, nil
+}
@@ -0,0 +1,51 @@
+package configuration
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeGitlabURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr string
+	}{
+		{name: "https URL", raw: "https://gitlab.com", want: "https://gitlab.com"},
+		{name: "http URL is kept", raw: "http://gitlab.local:8080", want: "http://gitlab.local:8080"},
+		{name: "trailing slash", raw: "https://gitlab.com/", want: "https://gitlab.com"},
+		{name: "surrounding spaces", raw: "  https://gitlab.com  ", want: "https://gitlab.com"},
+		{name: "missing scheme", raw: "gitlab.example.com", want: "https://gitlab.example.com"},
+		{name: "missing scheme with port", raw: "gitlab.example.com:8443", want: "https://gitlab.example.com:8443"},
+		{name: "API path", raw: "https://gitlab.com/api/v4", want: "https://gitlab.com"},
+		{name: "API path with trailing slash", raw: "https://gitlab.com/api/v4/", want: "https://gitlab.com"},
+		{name: "relative root", raw: "https://example.com/gitlab", want: "https://example.com/gitlab"},
+		{name: "relative root with trailing slash", raw: "https://example.com/gitlab/", want: "https://example.com/gitlab"},
+		{name: "relative root with API path", raw: "https://example.com/gitlab/api/v4/", want: "https://example.com/gitlab"},
+		{name: "path starting like api is kept", raw: "https://example.com/apis", want: "https://example.com/apis"},
+		{name: "query and fragment are dropped", raw: "https://gitlab.com/?private=1#top", want: "https://gitlab.com"},
+		{name: "empty", raw: "  ", wantErr: "GitLab URL is empty"},
+		{name: "bad scheme", raw: "ftp://gitlab.com", wantErr: "scheme must be http or https"},
+		{name: "missing host", raw: "https://", wantErr: "host is missing"},
+		{name: "unparsable", raw: "https://gitlab.com:port", wantErr: "invalid GitLab URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeGitlabURL(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NormalizeGitlabURL(%q) error = %v, want %q", tt.raw, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeGitlabURL(%q) error = %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeGitlabURL(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+
+	gover "github.com/hashicorp/go-version"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// configMigration upgrades a raw configuration file from one version of the
+// format to the next one
+type configMigration struct {
+	from        string
+	to          string
+	description string // Reported as a warning when the migration is applied
+	migrate     func(raw map[interface{}]interface{})
+}
+
+// configMigrations are the steps upgrading older configuration files to
+// CurrentConfigVersion, in order. A schema change bumps CurrentConfigVersion
+// and adds a step from the previous version (e.g., to rename a control key or
+// fill a new setting). The format didn't change yet, so older files only get
+// their version bumped.
+var configMigrations = []configMigration{}
+
+// MigrateConfig upgrades a raw configuration file of version fromVersion to
+// CurrentConfigVersion and decodes it. It returns the descriptions of the
+// steps applied, in order: a step applies to the files of its version or
+// older. The list is empty if no step applies, including for a file that is
+// already current or without version. The version must have been checked
+// with CheckConfigVersion.
+func MigrateConfig(raw map[interface{}]interface{}, fromVersion string) (*PlumberConfig, []string) {
+	l := logrus.WithFields(logrus.Fields{
+		"action":      "MigrateConfig",
+		"fromVersion": fromVersion,
+	})
+
+	applied := []string{}
+	if raw == nil {
+		raw = map[interface{}]interface{}{}
+	}
+
+	version, err := gover.NewVersion(strings.TrimSpace(fromVersion))
+	if err != nil {
+		version = gover.Must(gover.NewVersion(CurrentConfigVersion))
+	}
+	for _, migration := range configMigrations {
+		if version.GreaterThan(gover.Must(gover.NewVersion(migration.from))) {
+			continue
+		}
+		migration.migrate(raw)
+		applied = append(applied, fmt.Sprintf("%s -> %s: %s", migration.from, migration.to, migration.description))
+		version = gover.Must(gover.NewVersion(migration.to))
+	}
+
+	// An older file is read as the current version once migrated
+	if version.LessThan(gover.Must(gover.NewVersion(CurrentConfigVersion))) || len(applied) > 0 {
+		raw["version"] = CurrentConfigVersion
+	}
+
+	// The steps only rename keys and fill settings of a file that already
+	// decoded, a decoding error is a bug of a step
+	config := &PlumberConfig{}
+	data, err := yaml.Marshal(raw)
+	if err == nil {
+		err = yaml.Unmarshal(data, config)
+	}
+	if err != nil {
+		l.WithError(err).Error("Failed to decode migrated config")
+	}
+	return config, applied
+}
+
+// configVersionOlder returns whether a configuration file version is older
+// than CurrentConfigVersion. A file without version is considered current.
+func configVersionOlder(version string) bool {
+	parsed, err := gover.NewVersion(strings.TrimSpace(version))
+	if err != nil {
+		return false
+	}
+	return parsed.LessThan(gover.Must(gover.NewVersion(CurrentConfigVersion)))
+}
@@ -0,0 +1,149 @@
+package configuration
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testConfigMigrations are migration steps up to CurrentConfigVersion, the
+// format didn't change yet so configMigrations is empty
+var testConfigMigrations = []configMigration{
+	{
+		from:        "0.8",
+		to:          "0.9",
+		description: "renamed controls.legacyHardcodedJobs to controls.hardcodedJobs",
+		migrate: func(raw map[interface{}]interface{}) {
+			controls, ok := raw["controls"].(map[interface{}]interface{})
+			if !ok {
+				return
+			}
+			if legacy, found := controls["legacyHardcodedJobs"]; found {
+				controls["hardcodedJobs"] = legacy
+				delete(controls, "legacyHardcodedJobs")
+			}
+		},
+	},
+	{
+		from:        "0.9",
+		to:          "1.0",
+		description: "filled officialCatalogNamespaces",
+		migrate: func(raw map[interface{}]interface{}) {
+			if _, found := raw["officialCatalogNamespaces"]; !found {
+				raw["officialCatalogNamespaces"] = []interface{}{"components/*"}
+			}
+		},
+	},
+}
+
+// setConfigMigrations replaces the migration steps for the duration of a test
+func setConfigMigrations(t *testing.T, migrations []configMigration) {
+	t.Helper()
+	previous := configMigrations
+	configMigrations = migrations
+	t.Cleanup(func() { configMigrations = previous })
+}
+
+// testRawConfig returns a raw configuration file using the legacy control key
+func testRawConfig(version string) map[interface{}]interface{} {
+	raw := map[interface{}]interface{}{
+		"controls": map[interface{}]interface{}{
+			"legacyHardcodedJobs": map[interface{}]interface{}{"enabled": true},
+		},
+	}
+	if version != "" {
+		raw["version"] = version
+	}
+	return raw
+}
+
+func TestMigrateConfigSteps(t *testing.T) {
+	setConfigMigrations(t, testConfigMigrations)
+
+	tests := []struct {
+		name           string
+		fromVersion    string
+		wantApplied    []string
+		wantVersion    string
+		wantHardcoded  bool // controls.hardcodedJobs was renamed from the legacy key
+		wantNamespaces []string
+	}{
+		{
+			name:        "both steps from 0.8",
+			fromVersion: "0.8",
+			wantApplied: []string{
+				"0.8 -> 0.9: renamed controls.legacyHardcodedJobs to controls.hardcodedJobs",
+				"0.9 -> 1.0: filled officialCatalogNamespaces",
+			},
+			wantVersion:    CurrentConfigVersion,
+			wantHardcoded:  true,
+			wantNamespaces: []string{"components/*"},
+		},
+		{
+			name:        "both steps from a version older than the first step",
+			fromVersion: "0.7.2",
+			wantApplied: []string{
+				"0.8 -> 0.9: renamed controls.legacyHardcodedJobs to controls.hardcodedJobs",
+				"0.9 -> 1.0: filled officialCatalogNamespaces",
+			},
+			wantVersion:    CurrentConfigVersion,
+			wantHardcoded:  true,
+			wantNamespaces: []string{"components/*"},
+		},
+		{
+			name:           "last step from 0.9",
+			fromVersion:    "0.9",
+			wantApplied:    []string{"0.9 -> 1.0: filled officialCatalogNamespaces"},
+			wantVersion:    CurrentConfigVersion,
+			wantNamespaces: []string{"components/*"},
+		},
+		{
+			name:        "current version",
+			fromVersion: "1.0",
+			wantApplied: []string{},
+			wantVersion: "1.0",
+		},
+		{
+			name:        "no version",
+			wantApplied: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, applied := MigrateConfig(testRawConfig(tt.fromVersion), tt.fromVersion)
+
+			if !reflect.DeepEqual(applied, tt.wantApplied) {
+				t.Errorf("applied = %q, want %q", applied, tt.wantApplied)
+			}
+			if config.Version != tt.wantVersion {
+				t.Errorf("version = %q, want %q", config.Version, tt.wantVersion)
+			}
+			if got := config.GetHardcodedJobsConfig().IsEnabled(); got != tt.wantHardcoded {
+				t.Errorf("hardcodedJobs enabled = %v, want %v", got, tt.wantHardcoded)
+			}
+			if !reflect.DeepEqual(config.OfficialCatalogNamespaces, tt.wantNamespaces) {
+				t.Errorf("officialCatalogNamespaces = %q, want %q", config.OfficialCatalogNamespaces, tt.wantNamespaces)
+			}
+		})
+	}
+}
+
+func TestMigrateConfigWithoutSteps(t *testing.T) {
+	setConfigMigrations(t, []configMigration{})
+
+	raw := map[interface{}]interface{}{
+		"version":  "0.9",
+		"strictCi": true,
+	}
+	config, applied := MigrateConfig(raw, "0.9")
+
+	if len(applied) != 0 {
+		t.Errorf("applied = %q, want none", applied)
+	}
+	if config.Version != CurrentConfigVersion {
+		t.Errorf("version = %q, want %q", config.Version, CurrentConfigVersion)
+	}
+	if !config.StrictCi {
+		t.Error("strictCi was lost by the migration")
+	}
+}
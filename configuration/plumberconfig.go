@@ -1,9 +1,15 @@
 package configuration
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 )
@@ -13,6 +19,12 @@ type PlumberConfig struct {
 	// Version of the config file format
 	Version string `yaml:"version"`
 
+	// OfficialCatalogNamespaces overrides the namespaces of official CI/CD catalog resources
+	OfficialCatalogNamespaces []string `yaml:"officialCatalogNamespaces,omitempty"`
+
+	// StrictCi fails the analysis when the CI configuration is missing or invalid (same as --strict-ci)
+	StrictCi bool `yaml:"strictCi,omitempty"`
+
 	// Controls configuration
 	Controls ControlsConfig `yaml:"controls"`
 }
@@ -27,6 +39,165 @@ type ControlsConfig struct {
 
 	// BranchMustBeProtected control configuration
 	BranchMustBeProtected *BranchProtectionControlConfig `yaml:"branchMustBeProtected,omitempty"`
+
+	// DependencyPinning control configuration
+	DependencyPinning *DependencyPinningControlConfig `yaml:"dependencyPinning,omitempty"`
+
+	// EnvironmentUrlAllowlist control configuration
+	EnvironmentUrlAllowlist *EnvironmentUrlAllowlistControlConfig `yaml:"environmentUrlAllowlist,omitempty"`
+
+	// CacheKeyIsolation control configuration
+	CacheKeyIsolation *CacheKeyIsolationControlConfig `yaml:"cacheKeyIsolation,omitempty"`
+
+	// MinimumMaintainers control configuration
+	MinimumMaintainers *MinimumMaintainersControlConfig `yaml:"minimumMaintainers,omitempty"`
+
+	// TriggerAllowlist control configuration
+	TriggerAllowlist *TriggerAllowlistControlConfig `yaml:"triggerAllowlist,omitempty"`
+
+	// SecurityJobChangeRules control configuration
+	SecurityJobChangeRules *SecurityJobChangeRulesControlConfig `yaml:"securityJobChangeRules,omitempty"`
+
+	// OidcPreferred control configuration
+	OidcPreferred *OidcPreferredControlConfig `yaml:"oidcPreferred,omitempty"`
+
+	// SecretsManagerRequired control configuration
+	SecretsManagerRequired *SecretsManagerRequiredControlConfig `yaml:"secretsManagerRequired,omitempty"`
+
+	// ComponentInputsValid control configuration
+	ComponentInputsValid *ComponentInputsValidControlConfig `yaml:"componentInputsValid,omitempty"`
+
+	// PipelineComplexityBudget control configuration
+	PipelineComplexityBudget *PipelineComplexityBudgetControlConfig `yaml:"pipelineComplexityBudget,omitempty"`
+
+	// DefaultBranchName control configuration
+	DefaultBranchName *DefaultBranchNameControlConfig `yaml:"defaultBranchName,omitempty"`
+
+	// PipelineSchedules control configuration
+	PipelineSchedules *PipelineSchedulesControlConfig `yaml:"pipelineSchedules,omitempty"`
+
+	// WebhookAllowlist control configuration
+	WebhookAllowlist *WebhookAllowlistControlConfig `yaml:"webhookAllowlist,omitempty"`
+
+	// DeployTokens control configuration
+	DeployTokens *DeployTokensControlConfig `yaml:"deployTokens,omitempty"`
+
+	// ConsistentComponentVersions control configuration
+	ConsistentComponentVersions *ConsistentComponentVersionsControlConfig `yaml:"consistentComponentVersions,omitempty"`
+
+	// RootUserDiscouraged control configuration
+	RootUserDiscouraged *RootUserDiscouragedControlConfig `yaml:"rootUserDiscouraged,omitempty"`
+
+	// RulesOverOnlyExcept control configuration
+	RulesOverOnlyExcept *RulesOverOnlyExceptControlConfig `yaml:"rulesOverOnlyExcept,omitempty"`
+
+	// ReadmeRequired control configuration
+	ReadmeRequired *ReadmeRequiredControlConfig `yaml:"readmeRequired,omitempty"`
+
+	// MergeAccessGroups control configuration
+	MergeAccessGroups *MergeAccessGroupsControlConfig `yaml:"mergeAccessGroups,omitempty"`
+
+	// VariableExpansionPolicy control configuration
+	VariableExpansionPolicy *VariableExpansionPolicyControlConfig `yaml:"variableExpansionPolicy,omitempty"`
+
+	// NoInsecureTransport control configuration
+	NoInsecureTransport *NoInsecureTransportControlConfig `yaml:"noInsecureTransport,omitempty"`
+
+	// ArtifactsMustBePrivate control configuration
+	ArtifactsMustBePrivate *ArtifactsMustBePrivateControlConfig `yaml:"artifactsMustBePrivate,omitempty"`
+
+	// RetryPolicy control configuration
+	RetryPolicy *RetryPolicyControlConfig `yaml:"retryPolicy,omitempty"`
+
+	// RegistryPushGating control configuration
+	RegistryPushGating *RegistryPushGatingControlConfig `yaml:"registryPushGating,omitempty"`
+
+	// JobTimeoutPolicy control configuration
+	JobTimeoutPolicy *JobTimeoutPolicyControlConfig `yaml:"jobTimeoutPolicy,omitempty"`
+
+	// DebugTraceForbidden control configuration
+	DebugTraceForbidden *DebugTraceForbiddenControlConfig `yaml:"debugTraceForbidden,omitempty"`
+
+	// MaxIncludes control configuration
+	MaxIncludes *MaxIncludesControlConfig `yaml:"maxIncludes,omitempty"`
+
+	// NoDirectElevatedMembers control configuration
+	NoDirectElevatedMembers *NoDirectElevatedMembersControlConfig `yaml:"noDirectElevatedMembers,omitempty"`
+
+	// MergeTrainApprovals control configuration
+	MergeTrainApprovals *MergeTrainApprovalsControlConfig `yaml:"mergeTrainApprovals,omitempty"`
+
+	// ComponentsMustBeReleased control configuration
+	ComponentsMustBeReleased *ComponentsMustBeReleasedControlConfig `yaml:"componentsMustBeReleased,omitempty"`
+
+	// VariableCountBudget control configuration
+	VariableCountBudget *VariableCountBudgetControlConfig `yaml:"variableCountBudget,omitempty"`
+
+	// ManualJobAccess control configuration
+	ManualJobAccess *ManualJobAccessControlConfig `yaml:"manualJobAccess,omitempty"`
+
+	// PushRulesPolicy control configuration
+	PushRulesPolicy *PushRulesPolicyControlConfig `yaml:"pushRulesPolicy,omitempty"`
+
+	// SecurityPolicyFileRequired control configuration
+	SecurityPolicyFileRequired *SecurityPolicyFileRequiredControlConfig `yaml:"securityPolicyFileRequired,omitempty"`
+
+	// RunnerFeatureFlags control configuration
+	RunnerFeatureFlags *RunnerFeatureFlagsControlConfig `yaml:"runnerFeatureFlags,omitempty"`
+
+	// TrustedIncludeProjects control configuration
+	TrustedIncludeProjects *TrustedIncludeProjectsControlConfig `yaml:"trustedIncludeProjects,omitempty"`
+
+	// ProtectedEnvironments control configuration
+	ProtectedEnvironments *ProtectedEnvironmentsControlConfig `yaml:"protectedEnvironments,omitempty"`
+
+	// DeprecatedJwtUsage control configuration
+	DeprecatedJwtUsage *DeprecatedJwtUsageControlConfig `yaml:"deprecatedJwtUsage,omitempty"`
+
+	// LocalIncludeGlobs control configuration
+	LocalIncludeGlobs *LocalIncludeGlobsControlConfig `yaml:"localIncludeGlobs,omitempty"`
+
+	// DeadJobs control configuration
+	DeadJobs *DeadJobsControlConfig `yaml:"deadJobs,omitempty"`
+
+	// RepoStructure control configuration
+	RepoStructure *RepoStructureControlConfig `yaml:"repoStructure,omitempty"`
+
+	// TagMustNotBeBranchName control configuration
+	TagMustNotBeBranchName *TagMustNotBeBranchNameControlConfig `yaml:"tagMustNotBeBranchName,omitempty"`
+
+	// RuleConditionSafety control configuration
+	RuleConditionSafety *RuleConditionSafetyControlConfig `yaml:"ruleConditionSafety,omitempty"`
+
+	// SameInstanceIncludesOnly control configuration
+	SameInstanceIncludesOnly *SameInstanceIncludesOnlyControlConfig `yaml:"sameInstanceIncludesOnly,omitempty"`
+
+	// ImageNameAllowlist control configuration
+	ImageNameAllowlist *ImageNameAllowlistControlConfig `yaml:"imageNameAllowlist,omitempty"`
+
+	// PathScopedApprovals control configuration
+	PathScopedApprovals *PathScopedApprovalsControlConfig `yaml:"pathScopedApprovals,omitempty"`
+
+	// NoPublicCatalogComponents control configuration
+	NoPublicCatalogComponents *NoPublicCatalogComponentsControlConfig `yaml:"noPublicCatalogComponents,omitempty"`
+
+	// PackageRegistryAllowlist control configuration
+	PackageRegistryAllowlist *PackageRegistryAllowlistControlConfig `yaml:"packageRegistryAllowlist,omitempty"`
+
+	// JobImageOverrideTrust control configuration
+	JobImageOverrideTrust *JobImageOverrideTrustControlConfig `yaml:"jobImageOverrideTrust,omitempty"`
+
+	// SeparationOfDuties control configuration
+	SeparationOfDuties *SeparationOfDutiesControlConfig `yaml:"separationOfDuties,omitempty"`
+
+	// DefaultBranchNoDeletion control configuration
+	DefaultBranchNoDeletion *DefaultBranchNoDeletionControlConfig `yaml:"defaultBranchNoDeletion,omitempty"`
+
+	// MergeRequestApproval control configuration
+	MergeRequestApproval *MergeRequestApprovalControlConfig `yaml:"mergeRequestApproval,omitempty"`
+
+	// HardcodedJobs control configuration
+	HardcodedJobs *HardcodedJobsControlConfig `yaml:"hardcodedJobs,omitempty"`
 }
 
 // ImageForbiddenTagsControlConfig configuration for the forbidden image tags control
@@ -34,8 +205,15 @@ type ImageForbiddenTagsControlConfig struct {
 	// Enabled controls whether this check runs
 	Enabled *bool `yaml:"enabled,omitempty"`
 
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
 	// Tags is a list of forbidden tags (e.g., latest, dev)
 	Tags []string `yaml:"tags,omitempty"`
+
+	// MatchMode is how tags are compared to the forbidden ones: wildcard (default), substring or regex
+	MatchMode string `yaml:"matchMode,omitempty"`
 }
 
 // ImageAuthorizedSourcesControlConfig configuration for the authorized image sources control
@@ -43,6 +221,10 @@ type ImageAuthorizedSourcesControlConfig struct {
 	// Enabled controls whether this check runs
 	Enabled *bool `yaml:"enabled,omitempty"`
 
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
 	// TrustedUrls is a list of trusted registry URLs/patterns (supports wildcards)
 	TrustedUrls []string `yaml:"trustedUrls,omitempty"`
 
@@ -55,6 +237,10 @@ type BranchProtectionControlConfig struct {
 	// Enabled controls whether this check runs
 	Enabled *bool `yaml:"enabled,omitempty"`
 
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
 	// NamePatterns is a list of branch name patterns that must be protected (supports wildcards)
 	NamePatterns []string `yaml:"namePatterns,omitempty"`
 
@@ -74,87 +260,1900 @@ type BranchProtectionControlConfig struct {
 	MinPushAccessLevel *int `yaml:"minPushAccessLevel,omitempty"`
 }
 
-// LoadPlumberConfig loads configuration from a file path
-// The config file path is required - returns error if empty or not found
-func LoadPlumberConfig(configPath string) (*PlumberConfig, string, error) {
-	l := logrus.WithField("action", "LoadPlumberConfig")
+// DependencyPinningControlConfig configuration for the dependency pinning control
+type DependencyPinningControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
 
-	if configPath == "" {
-		return nil, "", fmt.Errorf("config file path is required")
-	}
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
 
-	l = l.WithField("configPath", configPath)
-	l.Info("Loading configuration from file")
+	// Patterns is a list of install patterns to check (overrides the default pip, npm, yarn and go patterns)
+	Patterns []DependencyPinningPattern `yaml:"patterns,omitempty"`
+}
 
-	// Read the file
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, configPath, fmt.Errorf("config file not found: %s", configPath)
-		}
-		l.WithError(err).Error("Failed to read config file")
-		return nil, configPath, err
-	}
+// DependencyPinningPattern describes how to detect unpinned installs for a package manager
+type DependencyPinningPattern struct {
+	// Name of the package manager (e.g., pip, npm)
+	Name string `yaml:"name" json:"name"`
 
-	// Parse YAML
-	config := &PlumberConfig{}
-	if err := yaml.Unmarshal(data, config); err != nil {
-		l.WithError(err).Error("Failed to parse config file")
-		return nil, configPath, err
-	}
+	// Command is a regex matching the install command (e.g., \bpip3?\s+install\b)
+	Command string `yaml:"command" json:"command"`
 
-	l.WithField("config", config).Debug("Configuration loaded successfully")
-	return config, configPath, nil
+	// Pinned is a regex that each installed package argument must match to be considered pinned (e.g., ==)
+	Pinned string `yaml:"pinned" json:"pinned"`
 }
 
-// GetContainerImageMustNotUseForbiddenTagsConfig returns the control configuration
-// Returns nil if not configured
-func (c *PlumberConfig) GetContainerImageMustNotUseForbiddenTagsConfig() *ImageForbiddenTagsControlConfig {
-	if c == nil {
-		return nil
-	}
-	return c.Controls.ContainerImageMustNotUseForbiddenTags
+// EnvironmentUrlAllowlistControlConfig configuration for the environment URL allowlist control
+type EnvironmentUrlAllowlistControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// AllowedDomains is a list of domains environment URLs may point to (supports wildcards, e.g., *.example.com)
+	AllowedDomains []string `yaml:"allowedDomains,omitempty"`
 }
 
-// GetContainerImageMustComeFromAuthorizedSourcesConfig returns the control configuration
-// Returns nil if not configured
-func (c *PlumberConfig) GetContainerImageMustComeFromAuthorizedSourcesConfig() *ImageAuthorizedSourcesControlConfig {
-	if c == nil {
-		return nil
-	}
-	return c.Controls.ContainerImageMustComeFromAuthorizedSources
+// CacheKeyIsolationControlConfig configuration for the cache key isolation control
+type CacheKeyIsolationControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
 }
 
-// IsEnabled returns whether the control is enabled
-// Returns false if not properly configured
-func (c *ImageForbiddenTagsControlConfig) IsEnabled() bool {
-	if c == nil || c.Enabled == nil {
-		return false
-	}
-	return *c.Enabled
+// MinimumMaintainersControlConfig configuration for the minimum maintainers control
+type MinimumMaintainersControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MinCount minimum number of members with Maintainer (40) access level or above
+	MinCount *int `yaml:"minCount,omitempty"`
 }
 
-// IsEnabled returns whether the control is enabled
-// Returns false if not properly configured
-func (c *ImageAuthorizedSourcesControlConfig) IsEnabled() bool {
-	if c == nil || c.Enabled == nil {
-		return false
-	}
-	return *c.Enabled
+// TriggerAllowlistControlConfig configuration for the trigger allowlist control
+type TriggerAllowlistControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// AllowedProjects is a list of projects (or remote URLs) trigger jobs may target (supports wildcards)
+	AllowedProjects []string `yaml:"allowedProjects,omitempty"`
 }
 
-// GetBranchMustBeProtectedConfig returns the control configuration
-// Returns nil if not configured
-func (c *PlumberConfig) GetBranchMustBeProtectedConfig() *BranchProtectionControlConfig {
-	if c == nil {
-		return nil
-	}
-	return c.Controls.BranchMustBeProtected
+// ProtectedEnvironmentsControlConfig configuration for the protected environments control
+type ProtectedEnvironmentsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// RequiredEnvironments is a list of environments that must be protected
+	RequiredEnvironments []string `yaml:"requiredEnvironments,omitempty"`
+
+	// MinDeployAccessLevel minimum access level required to deploy (30=Developer, 40=Maintainer, 60=Admin)
+	MinDeployAccessLevel *int `yaml:"minDeployAccessLevel,omitempty"`
 }
 
-// IsEnabled returns whether the control is enabled
-// Returns false if not properly configured
-func (c *BranchProtectionControlConfig) IsEnabled() bool {
+// DeprecatedJwtUsageControlConfig configuration for the deprecated CI_JOB_JWT control
+type DeprecatedJwtUsageControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// LocalIncludeGlobsControlConfig configuration for the local include globs control
+type LocalIncludeGlobsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// AllowedPatterns is a list of glob patterns allowed in local includes
+	AllowedPatterns []string `yaml:"allowedPatterns,omitempty"`
+}
+
+// DeadJobsControlConfig configuration for the dead jobs control
+type DeadJobsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// RepoStructureControlConfig configuration for the repository structure control
+type RepoStructureControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// RequiredFolders folders that must exist on the default branch (e.g., .gitlab/issue_templates/)
+	RequiredFolders []string `yaml:"requiredFolders,omitempty"`
+
+	// RequiredFiles files that must exist on the default branch (e.g., .gitlab/CODEOWNERS)
+	RequiredFiles []string `yaml:"requiredFiles,omitempty"`
+}
+
+// TagMustNotBeBranchNameControlConfig configuration for the branch-named image tags control
+type TagMustNotBeBranchNameControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// RuleConditionSafetyControlConfig configuration for the rule condition safety control
+type RuleConditionSafetyControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// JobPatterns is a list of job name patterns identifying privileged jobs (supports wildcards)
+	// Defaults to *deploy*, *release* and *publish* if empty
+	JobPatterns []string `yaml:"jobPatterns,omitempty"`
+
+	// UnsafeVariables is a list of variable name patterns that must not gate privileged jobs (supports wildcards)
+	// Defaults to CI_COMMIT_MESSAGE, CI_COMMIT_TITLE and CI_COMMIT_DESCRIPTION if empty
+	UnsafeVariables []string `yaml:"unsafeVariables,omitempty"`
+}
+
+// SameInstanceIncludesOnlyControlConfig configuration for the same instance includes control
+type SameInstanceIncludesOnlyControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// AllowedInstances is a list of other GitLab instances includes may be fetched from (supports wildcards)
+	AllowedInstances []string `yaml:"allowedInstances,omitempty"`
+}
+
+// ImageNameAllowlistControlConfig configuration for the image name allowlist control
+type ImageNameAllowlistControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// AllowedImages is a list of allowed registry/name patterns, whatever the tag (supports wildcards)
+	AllowedImages []string `yaml:"allowedImages,omitempty"`
+}
+
+// PathScopedApprovalsControlConfig configuration for the path scoped approvals control
+type PathScopedApprovalsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// Requirements is a list of sensitive paths with the approvals their changes need
+	Requirements []PathScopedApprovalRequirement `yaml:"requirements,omitempty"`
+}
+
+// PathScopedApprovalRequirement describes the approvals required for changes to a sensitive path
+type PathScopedApprovalRequirement struct {
+	// PathPattern is the sensitive path, as written in CODEOWNERS (e.g., *.tf, Dockerfile, .gitlab-ci.yml)
+	PathPattern string `yaml:"pathPattern" json:"pathPattern"`
+
+	// MinApprovals minimum number of approvals required for changes to the path (defaults to 1)
+	MinApprovals *int `yaml:"minApprovals,omitempty" json:"minApprovals,omitempty"`
+}
+
+// NoPublicCatalogComponentsControlConfig configuration for the public catalog components control
+type NoPublicCatalogComponentsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// PackageRegistryAllowlistControlConfig configuration for the package registry allowlist control
+type PackageRegistryAllowlistControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// Variables is a list of variable names configuring package registries (supports wildcards)
+	// Defaults to PIP_INDEX_URL, PIP_EXTRA_INDEX_URL, UV_INDEX_URL, UV_EXTRA_INDEX_URL,
+	// NPM_CONFIG_REGISTRY, YARN_REGISTRY and GOPROXY if empty
+	Variables []string `yaml:"variables,omitempty"`
+
+	// AllowedHosts is a list of hosts package registries may be served from (supports wildcards)
+	AllowedHosts []string `yaml:"allowedHosts,omitempty"`
+}
+
+// JobImageOverrideTrustControlConfig configuration for the job image override trust control
+// Trusted sources are the ones of containerImageMustComeFromAuthorizedSources
+type JobImageOverrideTrustControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// SeparationOfDutiesControlConfig configuration for the separation of duties control
+type SeparationOfDutiesControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// DefaultBranchNoDeletionControlConfig configuration for the default branch deletion control
+type DefaultBranchNoDeletionControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// MergeRequestApprovalControlConfig configuration for the merge request approval control
+type MergeRequestApprovalControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MinApprovers is the minimum number of approvals required to merge into the default branch
+	// Defaults to 1 if not set
+	MinApprovers *int `yaml:"minApprovers,omitempty"`
+
+	// PreventAuthorApproval requires authors not to be able to approve their own merge requests
+	// Defaults to true if not set
+	PreventAuthorApproval *bool `yaml:"preventAuthorApproval,omitempty"`
+
+	// ResetApprovalsOnPush requires all approvals to be removed when a commit is added
+	// Defaults to true if not set
+	ResetApprovalsOnPush *bool `yaml:"resetApprovalsOnPush,omitempty"`
+}
+
+// HardcodedJobsControlConfig configuration for the hardcoded jobs control
+type HardcodedJobsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MaxAllowed maximum number of jobs defined in the project CI configuration instead of an include
+	// Defaults to 0 if not set
+	MaxAllowed *int `yaml:"maxAllowed,omitempty"`
+}
+
+// SecurityJobChangeRulesControlConfig configuration for the security job change rules control
+type SecurityJobChangeRulesControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// JobPatterns is a list of job name patterns identifying security jobs (supports wildcards)
+	// Defaults to the names of GitLab security scanning jobs if empty
+	JobPatterns []string `yaml:"jobPatterns,omitempty"`
+}
+
+// OidcPreferredControlConfig configuration for the OIDC preferred control
+type OidcPreferredControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// JobPatterns is a list of job name patterns identifying deploy jobs (supports wildcards)
+	// Defaults to *deploy* if empty
+	JobPatterns []string `yaml:"jobPatterns,omitempty"`
+
+	// CredentialVariables is a list of variable name patterns holding long-lived
+	// cloud credentials (supports wildcards, e.g., AWS_SECRET_*)
+	// Defaults to common AWS, GCP and Azure credential variables if empty
+	CredentialVariables []string `yaml:"credentialVariables,omitempty"`
+}
+
+// SecretsManagerRequiredControlConfig configuration for the secrets manager required control
+type SecretsManagerRequiredControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// SensitiveNamePatterns is a list of variable name patterns considered sensitive (supports wildcards)
+	// Defaults to common password, secret, token and key names if empty
+	SensitiveNamePatterns []string `yaml:"sensitiveNamePatterns,omitempty"`
+}
+
+// ComponentInputsValidControlConfig configuration for the component inputs validity control
+type ComponentInputsValidControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// PipelineComplexityBudgetControlConfig configuration for the pipeline complexity budget control
+type PipelineComplexityBudgetControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MaxTotalLines maximum number of lines of all jobs of the merged pipeline
+	MaxTotalLines *int `yaml:"maxTotalLines,omitempty"`
+
+	// MaxJobLines maximum number of lines of a single job
+	MaxJobLines *int `yaml:"maxJobLines,omitempty"`
+}
+
+// DefaultBranchNameControlConfig configuration for the default branch name control
+type DefaultBranchNameControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// AllowedNames names the project default branch may have (defaults to ["main"])
+	AllowedNames []string `yaml:"allowedNames,omitempty"`
+}
+
+// PipelineSchedulesControlConfig configuration for the pipeline schedules control
+type PipelineSchedulesControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MaxOwnerAccessLevel maximum access level of a schedule owner
+	// (default: 40 = Maintainer, schedules owned by Owners are flagged)
+	MaxOwnerAccessLevel *int `yaml:"maxOwnerAccessLevel,omitempty"`
+}
+
+// WebhookAllowlistControlConfig configuration for the webhook allowlist control
+type WebhookAllowlistControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// AllowedHosts hosts project webhooks may send events to (supports wildcards)
+	AllowedHosts []string `yaml:"allowedHosts,omitempty"`
+}
+
+// DeployTokensControlConfig configuration for the deploy tokens control
+type DeployTokensControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MaxAgeDays maximum number of days a deploy token may remain valid
+	MaxAgeDays *int `yaml:"maxAgeDays,omitempty"`
+
+	// ForbiddenScopes scopes deploy tokens must not have
+	ForbiddenScopes []string `yaml:"forbiddenScopes,omitempty"`
+}
+
+// ConsistentComponentVersionsControlConfig configuration for the component version consistency control
+type ConsistentComponentVersionsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// RootUserDiscouragedControlConfig configuration for the root user control
+type RootUserDiscouragedControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// RootImagePatterns images known to run as root by default (supports wildcards)
+	RootImagePatterns []string `yaml:"rootImagePatterns,omitempty"`
+
+	// NonRootVariables variable names marking a job as running as a non-root user (supports wildcards)
+	NonRootVariables []string `yaml:"nonRootVariables,omitempty"`
+}
+
+// RulesOverOnlyExceptControlConfig configuration for the deprecated only/except control
+type RulesOverOnlyExceptControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// ReadmeRequiredControlConfig configuration for the README required control
+type ReadmeRequiredControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// Paths README paths to look for, at least one must exist
+	Paths []string `yaml:"paths,omitempty"`
+}
+
+// MergeAccessGroupsControlConfig configuration for the merge access groups control
+type MergeAccessGroupsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// RequiredMergeGroups full paths of the groups allowed to merge into protected branches
+	RequiredMergeGroups []string `yaml:"requiredMergeGroups,omitempty"`
+}
+
+// VariableExpansionPolicyControlConfig configuration for the variable expansion control
+type VariableExpansionPolicyControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// NoExpandPatterns variable names that must set 'expand: false'
+	NoExpandPatterns []string `yaml:"noExpandPatterns,omitempty"`
+
+	// ExpandPatterns variable names that must not set 'expand: false'
+	ExpandPatterns []string `yaml:"expandPatterns,omitempty"`
+}
+
+// NoInsecureTransportControlConfig configuration for the insecure transport control
+type NoInsecureTransportControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// ForbiddenPatterns regexes matching a disabled TLS verification in scripts and variables
+	ForbiddenPatterns []string `yaml:"forbiddenPatterns,omitempty"`
+
+	// AllowedJobs job names allowed to disable TLS verification
+	AllowedJobs []string `yaml:"allowedJobs,omitempty"`
+
+	// AllowedPatterns regexes of allowed script lines or variables
+	AllowedPatterns []string `yaml:"allowedPatterns,omitempty"`
+}
+
+// ArtifactsMustBePrivateControlConfig configuration for the public artifacts control
+type ArtifactsMustBePrivateControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// RetryPolicyControlConfig configuration for the retry policy control
+type RetryPolicyControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MaxRetries maximum number of retries a job may configure
+	MaxRetries *int `yaml:"maxRetries,omitempty"`
+}
+
+// RegistryPushGatingControlConfig configuration for the registry push gating control
+type RegistryPushGatingControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// PushPatterns regexes matching script lines pushing images to a registry
+	PushPatterns []string `yaml:"pushPatterns,omitempty"`
+}
+
+// JobTimeoutPolicyControlConfig configuration for the job timeout policy control
+type JobTimeoutPolicyControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MaxTimeoutMinutes maximum timeout of a job, in minutes
+	MaxTimeoutMinutes *int `yaml:"maxTimeoutMinutes,omitempty"`
+
+	// RequireTimeout flags jobs without timeout
+	RequireTimeout *bool `yaml:"requireTimeout,omitempty"`
+}
+
+// DebugTraceForbiddenControlConfig configuration for the debug trace control
+type DebugTraceForbiddenControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// MaxIncludesControlConfig configuration for the maximum includes control
+type MaxIncludesControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MaxCount maximum number of includes of the pipeline
+	MaxCount *int `yaml:"maxCount,omitempty"`
+}
+
+// NoDirectElevatedMembersControlConfig configuration for the direct members control
+type NoDirectElevatedMembersControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MaxDirectAccessLevel highest access level a member added directly to the project may have
+	MaxDirectAccessLevel *int `yaml:"maxDirectAccessLevel,omitempty"`
+}
+
+// MergeTrainApprovalsControlConfig configuration for the merge train approvals control
+type MergeTrainApprovalsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MinApprovals minimum number of approvals required when merge trains are enabled
+	MinApprovals *int `yaml:"minApprovals,omitempty"`
+}
+
+// ComponentsMustBeReleasedControlConfig configuration for the released components control
+type ComponentsMustBeReleasedControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// VariableCountBudgetControlConfig configuration for the variable count budget control
+type VariableCountBudgetControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// MaxProjectVariables maximum number of CI/CD variables of the project
+	MaxProjectVariables *int `yaml:"maxProjectVariables,omitempty"`
+}
+
+// ManualJobAccessControlConfig configuration for the manual job access control
+type ManualJobAccessControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// PushRulesPolicyControlConfig configuration for the push rules policy control
+type PushRulesPolicyControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// RequiredAuthorEmailRegex regex the push rules must enforce on commit author emails
+	RequiredAuthorEmailRegex string `yaml:"requiredAuthorEmailRegex,omitempty"`
+
+	// RequiredCommitMessageRegex regex the push rules must enforce on commit messages
+	RequiredCommitMessageRegex string `yaml:"requiredCommitMessageRegex,omitempty"`
+}
+
+// SecurityPolicyFileRequiredControlConfig configuration for the security policy file required control
+type SecurityPolicyFileRequiredControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+}
+
+// RunnerFeatureFlagsControlConfig configuration for the runner feature flags control
+type RunnerFeatureFlagsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// ForbiddenFlags feature flag variable names that must not be set (supports wildcards)
+	ForbiddenFlags []string `yaml:"forbiddenFlags,omitempty"`
+}
+
+// TrustedIncludeProjectsControlConfig configuration for the trusted include projects control
+type TrustedIncludeProjectsControlConfig struct {
+	// Enabled controls whether this check runs
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Threshold is the minimum compliance of this control, 0-100 (optional)
+	// The analysis fails if the control is below it, whatever the overall compliance
+	Threshold *float64 `yaml:"threshold,omitempty"`
+
+	// AllowedProjects is a list of projects files may be included from (supports wildcards)
+	AllowedProjects []string `yaml:"allowedProjects,omitempty"`
+}
+
+// LoadPlumberConfig loads configuration from a file path
+// The config file path is required - returns error if empty or not found
+func LoadPlumberConfig(configPath string) (*PlumberConfig, string, error) {
+	l := logrus.WithField("action", "LoadPlumberConfig")
+
+	if configPath == "" {
+		return nil, "", fmt.Errorf("config file path is required")
+	}
+
+	l = l.WithField("configPath", configPath)
+	l.Info("Loading configuration from file")
+
+	// Read the file
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, configPath, fmt.Errorf("config file not found: %s", configPath)
+		}
+		l.WithError(err).Error("Failed to read config file")
+		return nil, configPath, err
+	}
+
+	// Parse the file according to its extension, YAML by default
+	config := &PlumberConfig{}
+	if err := unmarshalPlumberConfig(configPath, data, config); err != nil {
+		l.WithError(err).Error("Failed to parse config file")
+		return nil, configPath, err
+	}
+
+	// Reject files of a format this release can't read
+	if err := CheckConfigVersion(config.Version); err != nil {
+		l.WithError(err).Error("Failed to load config file")
+		return nil, configPath, err
+	}
+
+	// Upgrade files of an older version of the format
+	if fromVersion := config.Version; configVersionOlder(fromVersion) {
+		raw := map[interface{}]interface{}{}
+		if err := unmarshalPlumberConfig(configPath, data, &raw); err != nil {
+			l.WithError(err).Error("Failed to parse config file")
+			return nil, configPath, err
+		}
+		var migrations []string
+		config, migrations = MigrateConfig(raw, fromVersion)
+		for _, migration := range migrations {
+			l.WithField("migration", migration).Warn("Config file migrated from an older version")
+		}
+		l.WithFields(logrus.Fields{
+			"fromVersion": fromVersion,
+			"toVersion":   CurrentConfigVersion,
+		}).Warn("Config file has an older version and was migrated in memory, please update its version")
+	}
+
+	l.WithField("config", config).Debug("Configuration loaded successfully")
+	return config, configPath, nil
+}
+
+// unmarshalPlumberConfig decodes a configuration file into config based on
+// its extension. JSON and TOML files are decoded then converted to YAML, so
+// that the yaml tags of PlumberConfig remain the only definition of the format.
+func unmarshalPlumberConfig(configPath string, data []byte, config interface{}) error {
+	switch strings.ToLower(filepath.Ext(configPath)) {
+	case ".json":
+		var raw interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("invalid JSON config file: %w", err)
+		}
+		data, err := yaml.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("unable to convert JSON config file: %w", err)
+		}
+		return yaml.Unmarshal(data, config)
+	case ".toml":
+		raw := map[string]interface{}{}
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("invalid TOML config file: %w", err)
+		}
+		data, err := yaml.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("unable to convert TOML config file: %w", err)
+		}
+		return yaml.Unmarshal(data, config)
+	default:
+		return yaml.Unmarshal(data, config)
+	}
+}
+
+// ControlNames returns the names of all controls, as written in the
+// controls section of the configuration file
+func ControlNames() []string {
+	names := []string{}
+	controlsType := reflect.TypeOf(ControlsConfig{})
+	for i := 0; i < controlsType.NumField(); i++ {
+		names = append(names, controlFieldName(controlsType.Field(i)))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// controlFieldName returns the name of a control from the yaml tag of its
+// ControlsConfig field
+func controlFieldName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("yaml"), ",")[0]
+}
+
+// HasControlConfig returns whether the configuration file has a section for
+// a control. The name is the key of the control in the configuration file.
+func (c *PlumberConfig) HasControlConfig(name string) bool {
+	controls := reflect.ValueOf(&c.Controls).Elem()
+	for i := 0; i < controls.NumField(); i++ {
+		if controlFieldName(controls.Type().Field(i)) == name {
+			return !controls.Field(i).IsNil()
+		}
+	}
+	return false
+}
+
+// SetControlEnabled overrides the enabled field of a control, creating its
+// configuration if the file doesn't have one. The name is the key of the
+// control in the configuration file (e.g., branchMustBeProtected).
+func (c *PlumberConfig) SetControlEnabled(name string, enabled bool) error {
+	controls := reflect.ValueOf(&c.Controls).Elem()
+	for i := 0; i < controls.NumField(); i++ {
+		if controlFieldName(controls.Type().Field(i)) != name {
+			continue
+		}
+
+		controlConfig := controls.Field(i)
+		if controlConfig.IsNil() {
+			controlConfig.Set(reflect.New(controlConfig.Type().Elem()))
+		}
+		enabledField := controlConfig.Elem().FieldByName("Enabled")
+		if !enabledField.IsValid() {
+			return fmt.Errorf("control '%s' can't be enabled or disabled", name)
+		}
+		enabledField.Set(reflect.ValueOf(&enabled))
+
+		logrus.WithFields(logrus.Fields{
+			"action":  "SetControlEnabled",
+			"control": name,
+			"enabled": enabled,
+		}).Info("Control enabled field overridden")
+		return nil
+	}
+	return fmt.Errorf("unknown control '%s', valid controls are: %s", name, strings.Join(ControlNames(), ", "))
+}
+
+// ControlThresholds returns the threshold of each control setting one, by
+// name of the control in the configuration file (e.g., branchMustBeProtected)
+func (c *PlumberConfig) ControlThresholds() map[string]float64 {
+	thresholds := map[string]float64{}
+	if c == nil {
+		return thresholds
+	}
+
+	controls := reflect.ValueOf(&c.Controls).Elem()
+	for i := 0; i < controls.NumField(); i++ {
+		controlConfig := controls.Field(i)
+		if controlConfig.IsNil() {
+			continue
+		}
+		thresholdField := controlConfig.Elem().FieldByName("Threshold")
+		if !thresholdField.IsValid() || thresholdField.IsNil() {
+			continue
+		}
+		thresholds[controlFieldName(controls.Type().Field(i))] = thresholdField.Elem().Float()
+	}
+	return thresholds
+}
+
+// GetContainerImageMustNotUseForbiddenTagsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetContainerImageMustNotUseForbiddenTagsConfig() *ImageForbiddenTagsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.ContainerImageMustNotUseForbiddenTags
+}
+
+// GetContainerImageMustComeFromAuthorizedSourcesConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetContainerImageMustComeFromAuthorizedSourcesConfig() *ImageAuthorizedSourcesControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.ContainerImageMustComeFromAuthorizedSources
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *ImageForbiddenTagsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *ImageAuthorizedSourcesControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetBranchMustBeProtectedConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetBranchMustBeProtectedConfig() *BranchProtectionControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.BranchMustBeProtected
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *BranchProtectionControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetDependencyPinningConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetDependencyPinningConfig() *DependencyPinningControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.DependencyPinning
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *DependencyPinningControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetEnvironmentUrlAllowlistConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetEnvironmentUrlAllowlistConfig() *EnvironmentUrlAllowlistControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.EnvironmentUrlAllowlist
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *EnvironmentUrlAllowlistControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetCacheKeyIsolationConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetCacheKeyIsolationConfig() *CacheKeyIsolationControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.CacheKeyIsolation
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *CacheKeyIsolationControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetMinimumMaintainersConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetMinimumMaintainersConfig() *MinimumMaintainersControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.MinimumMaintainers
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *MinimumMaintainersControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetTriggerAllowlistConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetTriggerAllowlistConfig() *TriggerAllowlistControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.TriggerAllowlist
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *TriggerAllowlistControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetSecurityJobChangeRulesConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetSecurityJobChangeRulesConfig() *SecurityJobChangeRulesControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.SecurityJobChangeRules
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *SecurityJobChangeRulesControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetOidcPreferredConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetOidcPreferredConfig() *OidcPreferredControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.OidcPreferred
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *OidcPreferredControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetSecretsManagerRequiredConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetSecretsManagerRequiredConfig() *SecretsManagerRequiredControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.SecretsManagerRequired
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *SecretsManagerRequiredControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetComponentInputsValidConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetComponentInputsValidConfig() *ComponentInputsValidControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.ComponentInputsValid
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *ComponentInputsValidControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetPipelineComplexityBudgetConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetPipelineComplexityBudgetConfig() *PipelineComplexityBudgetControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.PipelineComplexityBudget
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *PipelineComplexityBudgetControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetDefaultBranchNameConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetDefaultBranchNameConfig() *DefaultBranchNameControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.DefaultBranchName
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *DefaultBranchNameControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetPipelineSchedulesConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetPipelineSchedulesConfig() *PipelineSchedulesControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.PipelineSchedules
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *PipelineSchedulesControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetWebhookAllowlistConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetWebhookAllowlistConfig() *WebhookAllowlistControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.WebhookAllowlist
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *WebhookAllowlistControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetDeployTokensConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetDeployTokensConfig() *DeployTokensControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.DeployTokens
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *DeployTokensControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetConsistentComponentVersionsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetConsistentComponentVersionsConfig() *ConsistentComponentVersionsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.ConsistentComponentVersions
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *ConsistentComponentVersionsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetRootUserDiscouragedConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetRootUserDiscouragedConfig() *RootUserDiscouragedControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.RootUserDiscouraged
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *RootUserDiscouragedControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetRulesOverOnlyExceptConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetRulesOverOnlyExceptConfig() *RulesOverOnlyExceptControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.RulesOverOnlyExcept
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *RulesOverOnlyExceptControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetReadmeRequiredConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetReadmeRequiredConfig() *ReadmeRequiredControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.ReadmeRequired
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *ReadmeRequiredControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetMergeAccessGroupsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetMergeAccessGroupsConfig() *MergeAccessGroupsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.MergeAccessGroups
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *MergeAccessGroupsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetVariableExpansionPolicyConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetVariableExpansionPolicyConfig() *VariableExpansionPolicyControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.VariableExpansionPolicy
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *VariableExpansionPolicyControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetNoInsecureTransportConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetNoInsecureTransportConfig() *NoInsecureTransportControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.NoInsecureTransport
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *NoInsecureTransportControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetArtifactsMustBePrivateConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetArtifactsMustBePrivateConfig() *ArtifactsMustBePrivateControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.ArtifactsMustBePrivate
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *ArtifactsMustBePrivateControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetRetryPolicyConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetRetryPolicyConfig() *RetryPolicyControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.RetryPolicy
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *RetryPolicyControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetRegistryPushGatingConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetRegistryPushGatingConfig() *RegistryPushGatingControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.RegistryPushGating
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *RegistryPushGatingControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetJobTimeoutPolicyConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetJobTimeoutPolicyConfig() *JobTimeoutPolicyControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.JobTimeoutPolicy
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *JobTimeoutPolicyControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetDebugTraceForbiddenConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetDebugTraceForbiddenConfig() *DebugTraceForbiddenControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.DebugTraceForbidden
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *DebugTraceForbiddenControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetMaxIncludesConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetMaxIncludesConfig() *MaxIncludesControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.MaxIncludes
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *MaxIncludesControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetNoDirectElevatedMembersConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetNoDirectElevatedMembersConfig() *NoDirectElevatedMembersControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.NoDirectElevatedMembers
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *NoDirectElevatedMembersControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetMergeTrainApprovalsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetMergeTrainApprovalsConfig() *MergeTrainApprovalsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.MergeTrainApprovals
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *MergeTrainApprovalsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetComponentsMustBeReleasedConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetComponentsMustBeReleasedConfig() *ComponentsMustBeReleasedControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.ComponentsMustBeReleased
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *ComponentsMustBeReleasedControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetVariableCountBudgetConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetVariableCountBudgetConfig() *VariableCountBudgetControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.VariableCountBudget
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *VariableCountBudgetControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetManualJobAccessConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetManualJobAccessConfig() *ManualJobAccessControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.ManualJobAccess
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *ManualJobAccessControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetPushRulesPolicyConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetPushRulesPolicyConfig() *PushRulesPolicyControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.PushRulesPolicy
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *PushRulesPolicyControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetSecurityPolicyFileRequiredConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetSecurityPolicyFileRequiredConfig() *SecurityPolicyFileRequiredControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.SecurityPolicyFileRequired
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *SecurityPolicyFileRequiredControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetRunnerFeatureFlagsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetRunnerFeatureFlagsConfig() *RunnerFeatureFlagsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.RunnerFeatureFlags
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *RunnerFeatureFlagsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetTrustedIncludeProjectsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetTrustedIncludeProjectsConfig() *TrustedIncludeProjectsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.TrustedIncludeProjects
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *TrustedIncludeProjectsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetProtectedEnvironmentsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetProtectedEnvironmentsConfig() *ProtectedEnvironmentsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.ProtectedEnvironments
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *ProtectedEnvironmentsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetDeprecatedJwtUsageConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetDeprecatedJwtUsageConfig() *DeprecatedJwtUsageControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.DeprecatedJwtUsage
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *DeprecatedJwtUsageControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetLocalIncludeGlobsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetLocalIncludeGlobsConfig() *LocalIncludeGlobsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.LocalIncludeGlobs
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *LocalIncludeGlobsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetDeadJobsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetDeadJobsConfig() *DeadJobsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.DeadJobs
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *DeadJobsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetRepoStructureConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetRepoStructureConfig() *RepoStructureControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.RepoStructure
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *RepoStructureControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetTagMustNotBeBranchNameConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetTagMustNotBeBranchNameConfig() *TagMustNotBeBranchNameControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.TagMustNotBeBranchName
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *TagMustNotBeBranchNameControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetRuleConditionSafetyConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetRuleConditionSafetyConfig() *RuleConditionSafetyControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.RuleConditionSafety
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *RuleConditionSafetyControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetSameInstanceIncludesOnlyConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetSameInstanceIncludesOnlyConfig() *SameInstanceIncludesOnlyControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.SameInstanceIncludesOnly
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *SameInstanceIncludesOnlyControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetImageNameAllowlistConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetImageNameAllowlistConfig() *ImageNameAllowlistControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.ImageNameAllowlist
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *ImageNameAllowlistControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetPathScopedApprovalsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetPathScopedApprovalsConfig() *PathScopedApprovalsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.PathScopedApprovals
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *PathScopedApprovalsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetNoPublicCatalogComponentsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetNoPublicCatalogComponentsConfig() *NoPublicCatalogComponentsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.NoPublicCatalogComponents
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *NoPublicCatalogComponentsControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetPackageRegistryAllowlistConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetPackageRegistryAllowlistConfig() *PackageRegistryAllowlistControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.PackageRegistryAllowlist
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *PackageRegistryAllowlistControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetJobImageOverrideTrustConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetJobImageOverrideTrustConfig() *JobImageOverrideTrustControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.JobImageOverrideTrust
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *JobImageOverrideTrustControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetSeparationOfDutiesConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetSeparationOfDutiesConfig() *SeparationOfDutiesControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.SeparationOfDuties
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *SeparationOfDutiesControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetDefaultBranchNoDeletionConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetDefaultBranchNoDeletionConfig() *DefaultBranchNoDeletionControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.DefaultBranchNoDeletion
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *DefaultBranchNoDeletionControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetMergeRequestApprovalConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetMergeRequestApprovalConfig() *MergeRequestApprovalControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.MergeRequestApproval
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *MergeRequestApprovalControlConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetHardcodedJobsConfig returns the control configuration
+// Returns nil if not configured
+func (c *PlumberConfig) GetHardcodedJobsConfig() *HardcodedJobsControlConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Controls.HardcodedJobs
+}
+
+// IsEnabled returns whether the control is enabled
+// Returns false if not properly configured
+func (c *HardcodedJobsControlConfig) IsEnabled() bool {
 	if c == nil || c.Enabled == nil {
 		return false
 	}
@@ -0,0 +1,47 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadPlumberConfigFormats(t *testing.T) {
+	expected, _, err := LoadPlumberConfig(filepath.Join("testdata", "plumber.yaml"))
+	if err != nil {
+		t.Fatalf("unable to load YAML config: %v", err)
+	}
+
+	// Check the YAML reference itself, so that equal but empty configs don't pass
+	forbiddenTags := expected.GetContainerImageMustNotUseForbiddenTagsConfig()
+	if !forbiddenTags.IsEnabled() || forbiddenTags.Threshold == nil || *forbiddenTags.Threshold != 80.5 || !reflect.DeepEqual(forbiddenTags.Tags, []string{"latest", "dev"}) {
+		t.Fatalf("unexpected YAML forbidden tags config: %+v", forbiddenTags)
+	}
+	branchProtection := expected.GetBranchMustBeProtectedConfig()
+	if branchProtection.MinPushAccessLevel == nil || *branchProtection.MinPushAccessLevel != 30 {
+		t.Fatalf("unexpected YAML branch protection config: %+v", branchProtection)
+	}
+
+	for _, file := range []string{"plumber.json", "plumber.toml"} {
+		t.Run(file, func(t *testing.T) {
+			config, _, err := LoadPlumberConfig(filepath.Join("testdata", file))
+			if err != nil {
+				t.Fatalf("unable to load config: %v", err)
+			}
+			if !reflect.DeepEqual(config, expected) {
+				t.Errorf("config differs from the YAML one:\n got: %+v\nwant: %+v", config, expected)
+			}
+		})
+	}
+}
+
+func TestLoadPlumberConfigInvalidTOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plumber.toml")
+	if err := os.WriteFile(path, []byte("controls = [unclosed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := LoadPlumberConfig(path); err == nil {
+		t.Error("expected an error for an invalid TOML file")
+	}
+}
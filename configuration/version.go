@@ -0,0 +1,33 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+
+	gover "github.com/hashicorp/go-version"
+)
+
+// CurrentConfigVersion is the version of the configuration file format
+// read by this release. Files of older versions are migrated in memory
+// when loaded, see MigrateConfig.
+const CurrentConfigVersion = "1.0"
+
+// CheckConfigVersion returns an error if a configuration file version can't
+// be loaded by this release. A file without version is considered current,
+// older versions are migrated. Versions newer than CurrentConfigVersion, or
+// that aren't a version number, are an error.
+func CheckConfigVersion(version string) error {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return nil
+	}
+
+	parsed, err := gover.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("invalid config version '%s', the current version is %s", version, CurrentConfigVersion)
+	}
+	if parsed.GreaterThan(gover.Must(gover.NewVersion(CurrentConfigVersion))) {
+		return fmt.Errorf("config version '%s' is newer than the latest supported version %s, please upgrade plumber", version, CurrentConfigVersion)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckConfigVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		err     string // Part of the expected error, empty if the version is supported
+	}{
+		{"", ""},
+		{"1.0", ""},
+		{"1", ""},
+		{"1.0.0", ""},
+		{" 1.0 ", ""},
+		{"2.0", "newer than the latest supported version"},
+		{"1.1", "newer than the latest supported version"},
+		{"0.9", ""},
+		{"0.1.0", ""},
+		{"latest", "invalid config version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			err := CheckConfigVersion(tt.version)
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("error = %v, want an error containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestLoadPlumberConfigVersion(t *testing.T) {
+	tests := []struct {
+		file    string
+		content string
+		valid   bool
+		version string // Version of the loaded configuration
+	}{
+		{"plumber.yaml", "version: \"1.0\"\ncontrols: {}\n", true, "1.0"},
+		{"plumber.yaml", "version: 1.0\ncontrols: {}\n", true, "1.0"},
+		{"plumber.yaml", "controls: {}\n", true, ""},
+		{"plumber.json", `{"version": 1.0, "controls": {}}`, true, "1"},
+		{"plumber.yaml", "version: \"2.0\"\ncontrols: {}\n", false, ""},
+		{"plumber.toml", "version = \"0.9\"\n", true, CurrentConfigVersion},
+		{"plumber.yaml", "version: \"0.9\"\ncontrols:\n  hardcodedJobs:\n    enabled: true\n", true, CurrentConfigVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file+" "+tt.content, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			config, _, err := LoadPlumberConfig(path)
+			if !tt.valid {
+				if err == nil {
+					t.Error("expected an error for the version")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if config.Version != tt.version {
+				t.Errorf("version = %q, want %q", config.Version, tt.version)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package control
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+)
+
+// ConfigProblem is a problem found in the configuration of a control
+type ConfigProblem struct {
+	// Field is the path of the field in .plumber.yaml (e.g., containerImageMustNotUseForbiddenTags.tags)
+	Field string `json:"field"`
+
+	// Problem describes what is wrong with the field
+	Problem string `json:"problem"`
+
+	// Expected describes a valid value for the field
+	Expected string `json:"expected"`
+}
+
+// String returns the problem as a single line
+func (p ConfigProblem) String() string {
+	return fmt.Sprintf("%s: %s (expected: %s)", p.Field, p.Problem, p.Expected)
+}
+
+// ConfigValidationError holds all the problems found in the configuration of controls
+type ConfigValidationError struct {
+	Problems []ConfigProblem
+}
+
+// Error lists all the problems, one per line
+func (e *ConfigValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0].String()
+	}
+	lines := []string{fmt.Sprintf("%d problems found in .plumber.yaml config file:", len(e.Problems))}
+	for _, problem := range e.Problems {
+		lines = append(lines, "  - "+problem.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// configValidator collects the problems found while validating configurations
+type configValidator struct {
+	problems []ConfigProblem
+}
+
+// add records a problem on a field
+func (v *configValidator) add(field, problem, expected string) {
+	v.problems = append(v.problems, ConfigProblem{Field: field, Problem: problem, Expected: expected})
+}
+
+// err returns the problems found as a ConfigValidationError, or nil if there are none
+func (v *configValidator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ConfigValidationError{Problems: v.problems}
+}
+
+// controlConfigValidation validates the configuration of a control, adding every problem found
+type controlConfigValidation func(plumberConfig *configuration.PlumberConfig, v *configValidator)
+
+// controlConfigValidations are the validations of all controls. A control
+// with constraints on its configuration registers its validation here and
+// calls validateControlConfig from its GetConf.
+var controlConfigValidations = []controlConfigValidation{
+	validateImageForbiddenTagsConfig,
+	validateImageAuthorizedSourcesConfig,
+	validateBranchProtectionConfig,
+	validateDependencyPinningConfig,
+	validateEnvironmentUrlConfig,
+	validateMinimumMaintainersConfig,
+	validatePipelineComplexityBudgetConfig,
+	validatePipelineSchedulesConfig,
+	validateWebhookAllowlistConfig,
+	validateDeployTokensConfig,
+	validateMergeAccessGroupsConfig,
+	validateNoInsecureTransportConfig,
+	validateRetryPolicyConfig,
+	validateRegistryPushGatingConfig,
+	validateJobTimeoutPolicyConfig,
+	validateMaxIncludesConfig,
+	validateNoDirectElevatedMembersConfig,
+	validateMergeTrainApprovalsConfig,
+	validateVariableCountBudgetConfig,
+	validatePushRulesPolicyConfig,
+	validateTrustedIncludeProjectsConfig,
+	validateProtectedEnvironmentsConfig,
+	validateRepoStructureConfig,
+	validateImageNameAllowlistConfig,
+	validatePathScopedApprovalsConfig,
+	validatePackageRegistryAllowlistConfig,
+	validateMRApprovalConfig,
+	validateHardcodedJobsConfig,
+	validateControlThresholds,
+}
+
+// ValidateControlConfigs validates the configuration of all controls and
+// returns all the problems found at once, as a *ConfigValidationError.
+// It doesn't need GitLab and should run before any API call.
+func ValidateControlConfigs(plumberConfig *configuration.PlumberConfig) error {
+	if plumberConfig == nil {
+		return fmt.Errorf("Plumber config is required but not provided")
+	}
+
+	v := &configValidator{}
+	for _, validation := range controlConfigValidations {
+		validation(plumberConfig, v)
+	}
+	return v.err()
+}
+
+// ControlConfigProblems returns the problems found in the configuration of a
+// single control, by name of the control in the configuration file (e.g., a
+// required setting missing from the section of an enabled control)
+func ControlConfigProblems(plumberConfig *configuration.PlumberConfig, name string) []ConfigProblem {
+	v := &configValidator{}
+	for _, validation := range controlConfigValidations {
+		validation(plumberConfig, v)
+	}
+
+	problems := []ConfigProblem{}
+	for _, problem := range v.problems {
+		if problem.Field == name || strings.HasPrefix(problem.Field, name+".") {
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+// validateControlConfig runs the validation of a single control configuration
+func validateControlConfig(plumberConfig *configuration.PlumberConfig, validation controlConfigValidation) error {
+	if plumberConfig == nil {
+		return fmt.Errorf("Plumber config is required but not provided")
+	}
+
+	v := &configValidator{}
+	validation(plumberConfig, v)
+	return v.err()
+}
+
+// validateBranchProtectionConfig validates the branchMustBeProtected configuration
+func validateBranchProtectionConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	branchConfig := plumberConfig.GetBranchMustBeProtectedConfig()
+	if !branchConfig.IsEnabled() {
+		return
+	}
+
+	validAccessLevel := func(level int) bool {
+		// Access levels GitLab accepts on protected branches
+		switch level {
+		case gitlab.AccessLevelNo, gitlab.AccessLevelDeveloper, gitlab.AccessLevelMaintainer, gitlab.AccessLevelAdmin:
+			return true
+		}
+		return false
+	}
+	if branchConfig.MinMergeAccessLevel != nil && !validAccessLevel(*branchConfig.MinMergeAccessLevel) {
+		v.add("branchMustBeProtected.minMergeAccessLevel", fmt.Sprintf("invalid access level %d", *branchConfig.MinMergeAccessLevel), "0 (No one), 30 (Developer), 40 (Maintainer) or 60 (Admin)")
+	}
+	if branchConfig.MinPushAccessLevel != nil && !validAccessLevel(*branchConfig.MinPushAccessLevel) {
+		v.add("branchMustBeProtected.minPushAccessLevel", fmt.Sprintf("invalid access level %d", *branchConfig.MinPushAccessLevel), "0 (No one), 30 (Developer), 40 (Maintainer) or 60 (Admin)")
+	}
+}
+
+// validateMinimumMaintainersConfig validates the minimumMaintainers configuration
+func validateMinimumMaintainersConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	maintainersConfig := plumberConfig.GetMinimumMaintainersConfig()
+	if !maintainersConfig.IsEnabled() {
+		return
+	}
+
+	if maintainersConfig.MinCount != nil && *maintainersConfig.MinCount < 1 {
+		v.add("minimumMaintainers.minCount", fmt.Sprintf("invalid count %d", *maintainersConfig.MinCount), "a number greater than or equal to 1")
+	}
+}
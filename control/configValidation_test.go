@@ -0,0 +1,126 @@
+package control
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getplumber/plumber/configuration"
+)
+
+// loadTestPlumberConfig loads a .plumber.yaml holding the given controls
+func loadTestPlumberConfig(t *testing.T, controls string) *configuration.PlumberConfig {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), ".plumber.yaml")
+	if err := os.WriteFile(configPath, []byte("version: \"1.0\"\ncontrols:\n"+controls), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	plumberConfig, _, err := configuration.LoadPlumberConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadPlumberConfig() error = %v", err)
+	}
+	return plumberConfig
+}
+
+func TestValidateControlConfigs(t *testing.T) {
+	tests := []struct {
+		name     string
+		controls string
+		want     []ConfigProblem
+	}{
+		{
+			name: "valid configuration",
+			controls: `
+  containerImageMustNotUseForbiddenTags:
+    enabled: true
+    tags: [latest]
+  containerImageMustComeFromAuthorizedSources:
+    enabled: true
+`,
+		},
+		{
+			name:     "missing required controls",
+			controls: "  {}\n",
+			want: []ConfigProblem{
+				{Field: "containerImageMustNotUseForbiddenTags", Problem: "control configuration is missing", Expected: "a containerImageMustNotUseForbiddenTags section under controls"},
+				{Field: "containerImageMustComeFromAuthorizedSources", Problem: "control configuration is missing", Expected: "a containerImageMustComeFromAuthorizedSources section under controls"},
+			},
+		},
+		{
+			name: "every problem is reported",
+			controls: `
+  containerImageMustNotUseForbiddenTags:
+    matchMode: fuzzy
+  containerImageMustComeFromAuthorizedSources:
+    enabled: true
+    threshold: 150
+  hardcodedJobs:
+    enabled: true
+    maxAllowed: -1
+`,
+			want: []ConfigProblem{
+				{Field: "containerImageMustNotUseForbiddenTags.enabled", Problem: "field is required", Expected: "true or false"},
+				{Field: "containerImageMustNotUseForbiddenTags.tags", Problem: "field is required", Expected: "a list of forbidden tags"},
+				{Field: "containerImageMustNotUseForbiddenTags.matchMode", Problem: "invalid value 'fuzzy'", Expected: "wildcard, substring or regex"},
+				{Field: "hardcodedJobs.maxAllowed", Problem: "invalid number of jobs -1", Expected: "a number greater than or equal to 0"},
+				{Field: "containerImageMustComeFromAuthorizedSources.threshold", Problem: "invalid threshold 150", Expected: "a compliance percentage between 0 and 100"},
+			},
+		},
+		{
+			name: "disabled controls are not validated",
+			controls: `
+  containerImageMustNotUseForbiddenTags:
+    enabled: true
+    tags: [latest]
+  containerImageMustComeFromAuthorizedSources:
+    enabled: true
+  hardcodedJobs:
+    enabled: false
+    maxAllowed: -1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateControlConfigs(loadTestPlumberConfig(t, tt.controls))
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidateControlConfigs() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var validationErr *ConfigValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("ValidateControlConfigs() error = %v, want a *ConfigValidationError", err)
+			}
+			if !reflect.DeepEqual(validationErr.Problems, tt.want) {
+				t.Errorf("problems = %+v, want %+v", validationErr.Problems, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigValidationErrorMessage(t *testing.T) {
+	single := &ConfigValidationError{Problems: []ConfigProblem{
+		{Field: "hardcodedJobs.maxAllowed", Problem: "invalid number of jobs -1", Expected: "a number greater than or equal to 0"},
+	}}
+	want := "hardcodedJobs.maxAllowed: invalid number of jobs -1 (expected: a number greater than or equal to 0)"
+	if single.Error() != want {
+		t.Errorf("Error() = %q, want %q", single.Error(), want)
+	}
+
+	multiple := &ConfigValidationError{Problems: append(single.Problems, ConfigProblem{Field: "a.b", Problem: "bad", Expected: "good"})}
+	lines := strings.Split(multiple.Error(), "\n")
+	if len(lines) != 3 || lines[0] != "2 problems found in .plumber.yaml config file:" || lines[2] != "  - a.b: bad (expected: good)" {
+		t.Errorf("Error() = %q, want a header and one line per problem", multiple.Error())
+	}
+
+	if err := ValidateControlConfigs(nil); err == nil {
+		t.Error("ValidateControlConfigs(nil) error = nil, want an error")
+	}
+}
@@ -0,0 +1,178 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabImageJobOverrideTrustVersion = "0.1.0"
+
+// GitlabImageJobOverrideTrustConf holds the configuration for untrusted job image override detection
+type GitlabImageJobOverrideTrustConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// TrustedUrls is a list of authorized registry URLs/patterns, from containerImageMustComeFromAuthorizedSources
+	TrustedUrls []string `json:"trustedUrls"`
+
+	// TrustDockerHubOfficialImages trusts official Docker Hub images, from containerImageMustComeFromAuthorizedSources
+	TrustDockerHubOfficialImages bool `json:"trustDockerHubOfficialImages"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured. Trusted sources are the ones
+// of the containerImageMustComeFromAuthorizedSources control.
+func (p *GitlabImageJobOverrideTrustConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	overrideConfig := plumberConfig.GetJobImageOverrideTrustConfig()
+	if overrideConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = overrideConfig.IsEnabled()
+	if imgConfig := plumberConfig.GetContainerImageMustComeFromAuthorizedSourcesConfig(); imgConfig != nil {
+		p.TrustedUrls = imgConfig.TrustedUrls
+		if imgConfig.TrustDockerHubOfficialImages != nil {
+			p.TrustDockerHubOfficialImages = *imgConfig.TrustDockerHubOfficialImages
+		}
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":                      p.Enabled,
+		"trustedUrls":                  p.TrustedUrls,
+		"trustDockerHubOfficialImages": p.TrustDockerHubOfficialImages,
+	}).Debug("jobImageOverrideTrust control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabImageJobOverrideTrustMetrics holds metrics about job image overrides
+type GitlabImageJobOverrideTrustMetrics struct {
+	DefaultTrusted     bool `json:"defaultTrusted"` // False without a default image or with an untrusted one, overrides are then not checked
+	Jobs               uint `json:"jobs"`
+	Overrides          uint `json:"overrides"`
+	UntrustedOverrides uint `json:"untrustedOverrides"`
+	CiInvalid          uint `json:"ciInvalid"`
+	CiMissing          uint `json:"ciMissing"`
+}
+
+// GitlabImageJobOverrideTrustResult holds the result of the job image override trust control
+type GitlabImageJobOverrideTrustResult struct {
+	Issues     []GitlabImageJobOverrideTrustIssue `json:"issues"`
+	Metrics    GitlabImageJobOverrideTrustMetrics `json:"metrics"`
+	Compliance float64                            `json:"compliance"`
+	Version    string                             `json:"version"`
+	CiValid    bool                               `json:"ciValid"`
+	CiMissing  bool                               `json:"ciMissing"`
+	Skipped    bool                               `json:"skipped"`         // True if control was disabled
+	Error      string                             `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabImageJobOverrideTrustIssue represents a job overriding a trusted
+// default image with an untrusted one
+type GitlabImageJobOverrideTrustIssue struct {
+	Job          string `json:"job"`
+	Link         string `json:"link"`
+	DefaultImage string `json:"defaultImage"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the job image override trust control. Only jobs setting an
+// image other than a trusted default image are checked: an untrusted or
+// missing default image is reported by containerImageMustComeFromAuthorizedSources.
+func (p *GitlabImageJobOverrideTrustConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabImageJobOverrideTrustResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabImageJobOverrideTrust",
+		"controlVersion": ControlTypeGitlabImageJobOverrideTrustVersion,
+	})
+	l.Info("Start job image override trust control")
+
+	result := &GitlabImageJobOverrideTrustResult{
+		Issues:     []GitlabImageJobOverrideTrustIssue{},
+		Metrics:    GitlabImageJobOverrideTrustMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabImageJobOverrideTrustVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Job image override trust control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	// The default image is analyzed on its own by the collector
+	var defaultImage *collector.GitlabPipelineImageInfo
+	for i := range pipelineImageData.Images {
+		if pipelineImageData.Images[i].Job == collector.DefaultImageJob {
+			defaultImage = &pipelineImageData.Images[i]
+			break
+		}
+	}
+	if defaultImage == nil || checkImageAuthorizationStatus(defaultImage, p.TrustedUrls, p.TrustDockerHubOfficialImages) != authorizedStatus {
+		l.Info("No trusted default image, job image overrides are not checked")
+		return result
+	}
+	result.Metrics.DefaultTrusted = true
+
+	for _, image := range pipelineImageData.Images {
+		if image.Job == collector.DefaultImageJob {
+			continue
+		}
+		result.Metrics.Jobs++
+
+		// Jobs without an image of their own inherit the default image
+		if image.UnresolvedLink == pipelineImageData.DefaultImage {
+			continue
+		}
+		result.Metrics.Overrides++
+
+		if checkImageAuthorizationStatus(&image, p.TrustedUrls, p.TrustDockerHubOfficialImages) == authorizedStatus {
+			continue
+		}
+		result.Issues = append(result.Issues, GitlabImageJobOverrideTrustIssue{
+			Job:          image.Job,
+			Link:         image.Link,
+			DefaultImage: defaultImage.Link,
+		})
+		result.Metrics.UntrustedOverrides++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"overrides":          result.Metrics.Overrides,
+		"untrustedOverrides": result.Metrics.UntrustedOverrides,
+		"compliance":         result.Compliance,
+	}).Info("Job image override trust control completed")
+
+	return result
+}
@@ -2,6 +2,8 @@ package control
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/getplumber/plumber/collector"
 	"github.com/getplumber/plumber/configuration"
@@ -9,7 +11,17 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-const ControlTypeGitlabImageForbiddenTagsVersion = "0.2.0"
+const ControlTypeGitlabImageForbiddenTagsVersion = "0.6.0"
+
+// Forbidden tags match modes
+const (
+	// TagMatchModeWildcard matches tags with wildcard patterns (e.g., dev* matches dev-123)
+	TagMatchModeWildcard = "wildcard"
+	// TagMatchModeSubstring matches tags containing a forbidden value (e.g., dev matches dev-123)
+	TagMatchModeSubstring = "substring"
+	// TagMatchModeRegex matches tags against regexes anchored on the whole tag
+	TagMatchModeRegex = "regex"
+)
 
 // GitlabImageForbiddenTagsConf holds the configuration for forbidden tag detection
 type GitlabImageForbiddenTagsConf struct {
@@ -18,39 +30,70 @@ type GitlabImageForbiddenTagsConf struct {
 
 	// ForbiddenTags is a list of tags considered forbidden (e.g., latest, dev)
 	ForbiddenTags []string `json:"forbiddenTags"`
-}
 
-// GetConf loads configuration from PlumberConfig
-// Returns error if config is missing or incomplete
-func (p *GitlabImageForbiddenTagsConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
-	// Plumber config is required
-	if plumberConfig == nil {
-		return fmt.Errorf("Plumber config is required but not provided")
-	}
+	// MatchMode is how tags are compared to ForbiddenTags: wildcard, substring or regex
+	MatchMode string `json:"matchMode"`
 
-	// Get control config from PlumberConfig
+	// forbiddenTagRegexes are the compiled ForbiddenTags in regex mode
+	forbiddenTagRegexes []*regexp.Regexp
+}
+
+// validateImageForbiddenTagsConfig validates the containerImageMustNotUseForbiddenTags configuration
+func validateImageForbiddenTagsConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
 	imgConfig := plumberConfig.GetContainerImageMustNotUseForbiddenTagsConfig()
 	if imgConfig == nil {
-		return fmt.Errorf("containerImageMustNotUseForbiddenTags control configuration is missing from .plumber.yaml config file")
+		v.add("containerImageMustNotUseForbiddenTags", "control configuration is missing", "a containerImageMustNotUseForbiddenTags section under controls")
+		return
 	}
 
-	// Check if enabled field is set
 	if imgConfig.Enabled == nil {
-		return fmt.Errorf("containerImageMustNotUseForbiddenTags.enabled field is required in .plumber.yaml config file")
+		v.add("containerImageMustNotUseForbiddenTags.enabled", "field is required", "true or false")
 	}
-
-	// Check if tags field is set
 	if imgConfig.Tags == nil {
-		return fmt.Errorf("containerImageMustNotUseForbiddenTags.tags field is required in .plumber.yaml config file")
+		v.add("containerImageMustNotUseForbiddenTags.tags", "field is required", "a list of forbidden tags")
+	}
+
+	switch imgConfig.MatchMode {
+	case "", TagMatchModeWildcard, TagMatchModeSubstring:
+	case TagMatchModeRegex:
+		for _, tag := range imgConfig.Tags {
+			if _, err := regexp.Compile(tag); err != nil {
+				v.add("containerImageMustNotUseForbiddenTags.tags", fmt.Sprintf("entry '%s' is not a valid regex (%v)", tag, err), "valid regexes when matchMode is regex")
+			}
+		}
+	default:
+		v.add("containerImageMustNotUseForbiddenTags.matchMode", fmt.Sprintf("invalid value '%s'", imgConfig.MatchMode), fmt.Sprintf("%s, %s or %s", TagMatchModeWildcard, TagMatchModeSubstring, TagMatchModeRegex))
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// Returns error if config is missing or incomplete
+func (p *GitlabImageForbiddenTagsConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	if err := validateControlConfig(plumberConfig, validateImageForbiddenTagsConfig); err != nil {
+		return err
 	}
+	imgConfig := plumberConfig.GetContainerImageMustNotUseForbiddenTagsConfig()
 
 	// Apply configuration
 	p.Enabled = imgConfig.IsEnabled()
 	p.ForbiddenTags = imgConfig.Tags
+	p.MatchMode = imgConfig.MatchMode
+	if p.MatchMode == "" {
+		p.MatchMode = TagMatchModeWildcard
+	}
+
+	// Compile patterns, anchored so that they match the whole tag
+	if p.MatchMode == TagMatchModeRegex {
+		p.forbiddenTagRegexes = []*regexp.Regexp{}
+		for _, tag := range p.ForbiddenTags {
+			p.forbiddenTagRegexes = append(p.forbiddenTagRegexes, regexp.MustCompile("^(?:"+tag+")$"))
+		}
+	}
 
 	l.WithFields(logrus.Fields{
 		"enabled":       p.Enabled,
 		"forbiddenTags": p.ForbiddenTags,
+		"matchMode":     p.MatchMode,
 	}).Debug("containerImageMustNotUseForbiddenTags control configuration loaded from .plumber.yaml file")
 
 	return nil
@@ -60,6 +103,8 @@ func (p *GitlabImageForbiddenTagsConf) GetConf(plumberConfig *configuration.Plum
 type GitlabImageForbiddenTagsMetrics struct {
 	Total              uint `json:"total"`
 	UsingForbiddenTags uint `json:"usingForbiddenTags"`
+	UnresolvedTags     uint `json:"unresolvedTags"` // Tags defined by a variable that couldn't be resolved
+	DigestPinned       uint `json:"digestPinned"`   // Images pinned by digest, immutable whatever their tag
 	CiInvalid          uint `json:"ciInvalid"`
 	CiMissing          uint `json:"ciMissing"`
 }
@@ -85,6 +130,15 @@ type GitlabPipelineImageIssueTag struct {
 	Link string `json:"link"`
 	Tag  string `json:"tag"`
 	Job  string `json:"job"`
+
+	// FromVariable is true if the forbidden tag comes from a resolved variable (e.g., app:$TAG with TAG=latest)
+	FromVariable  bool   `json:"fromVariable,omitempty"`
+	UnresolvedTag string `json:"unresolvedTag,omitempty"`
+
+	// Unresolved is true if the tag variable couldn't be resolved, so the tag
+	// can't be checked. It is reported but doesn't lower the compliance, as
+	// variables only known when the pipeline runs are not resolved.
+	Unresolved bool `json:"unresolved,omitempty"`
 }
 
 ///////////////////////
@@ -130,8 +184,33 @@ func (p *GitlabImageForbiddenTagsConf) Run(pipelineImageData *collector.GitlabPi
 
 	// Loop over all images to check for forbidden tags
 	for _, image := range pipelineImageData.Images {
-		// Check tag against forbidden patterns
-		isForbiddenTag := gitlab.CheckItemMatchToPatterns(image.Tag, p.ForbiddenTags)
+		// An image pinned by digest is immutable, its tag is not used
+		if image.DigestPinned() {
+			result.Metrics.DigestPinned++
+			continue
+		}
+
+		// A tag still containing a variable couldn't be resolved and can't be
+		// checked, it is reported for the tag to be reviewed
+		if image.TagFromVariable() && (image.Tag == "" || strings.Contains(image.Tag, "$")) {
+			l.WithFields(logrus.Fields{
+				"job":           image.Job,
+				"unresolvedTag": image.UnresolvedTag,
+			}).Warn("Image tag variable could not be resolved, tag cannot be checked")
+			result.Issues = append(result.Issues, GitlabPipelineImageIssueTag{
+				Link:          image.Link,
+				Tag:           image.Tag,
+				Job:           image.Job,
+				FromVariable:  true,
+				UnresolvedTag: image.UnresolvedTag,
+				Unresolved:    true,
+			})
+			result.Metrics.UnresolvedTags++
+			continue
+		}
+
+		// Check the resolved tag against forbidden patterns
+		isForbiddenTag := p.isForbiddenTag(image.Tag)
 
 		if isForbiddenTag {
 			issue := GitlabPipelineImageIssueTag{
@@ -139,15 +218,19 @@ func (p *GitlabImageForbiddenTagsConf) Run(pipelineImageData *collector.GitlabPi
 				Tag:  image.Tag,
 				Job:  image.Job,
 			}
+			if image.TagFromVariable() {
+				issue.FromVariable = true
+				issue.UnresolvedTag = image.UnresolvedTag
+			}
 			result.Issues = append(result.Issues, issue)
 			result.Metrics.UsingForbiddenTags++
 		}
 	}
 
-	// Calculate compliance based on issues
-	if len(result.Issues) > 0 {
+	// Calculate compliance based on forbidden tags, unresolved tags don't lower it
+	if result.Metrics.UsingForbiddenTags > 0 {
 		result.Compliance = 0.0
-		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found forbidden tags, setting compliance to 0")
 	}
 
 	// Set metrics
@@ -161,3 +244,28 @@ func (p *GitlabImageForbiddenTagsConf) Run(pipelineImageData *collector.GitlabPi
 
 	return result
 }
+
+// isForbiddenTag reports whether a tag matches one of the forbidden tags, using the configured match mode
+func (p *GitlabImageForbiddenTagsConf) isForbiddenTag(tag string) bool {
+	switch p.MatchMode {
+	case TagMatchModeSubstring:
+		if tag == "" {
+			return false
+		}
+		for _, forbidden := range p.ForbiddenTags {
+			if forbidden != "" && strings.Contains(tag, forbidden) {
+				return true
+			}
+		}
+		return false
+	case TagMatchModeRegex:
+		for _, re := range p.forbiddenTagRegexes {
+			if re.MatchString(tag) {
+				return true
+			}
+		}
+		return false
+	default:
+		return gitlab.CheckItemMatchToPatterns(tag, p.ForbiddenTags)
+	}
+}
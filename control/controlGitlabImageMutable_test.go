@@ -0,0 +1,217 @@
+package control
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+)
+
+// resolvedImage returns a job image resolved against the global variables
+// of a CI configuration, as the image data collection does
+func resolvedImage(t *testing.T, ciConf, job, unresolvedLink string) collector.GitlabPipelineImageInfo {
+	t.Helper()
+	conf, err := gitlab.ParseGitlabCI([]byte(ciConf))
+	if err != nil {
+		t.Fatalf("invalid CI configuration: %v", err)
+	}
+	globalVars, err := gitlab.ParseGlobalVariables(conf)
+	if err != nil {
+		t.Fatalf("unable to parse global variables: %v", err)
+	}
+	link := gitlab.ReplaceVariable(unresolvedLink, nil, nil, nil, nil, globalVars, nil)
+	tag := ""
+	if i := strings.LastIndex(link, ":"); i != -1 {
+		tag = link[i+1:]
+	}
+	return collector.GitlabPipelineImageInfo{
+		Link:           link,
+		Tag:            tag,
+		Job:            job,
+		UnresolvedLink: unresolvedLink,
+		UnresolvedTag:  unresolvedLink[strings.LastIndex(unresolvedLink, ":")+1:],
+	}
+}
+
+func TestImageForbiddenTagsFromVariable(t *testing.T) {
+	tests := []struct {
+		name           string
+		ciConf         string
+		wantIssues     []GitlabPipelineImageIssueTag
+		wantCompliance float64
+		wantUnresolved uint
+	}{
+		{
+			name: "global variable resolved to a forbidden tag",
+			ciConf: `
+variables:
+  TAG: latest
+build:
+  image: app:$TAG
+  script: make
+`,
+			wantIssues: []GitlabPipelineImageIssueTag{
+				{Link: "app:latest", Tag: "latest", Job: "build", FromVariable: true, UnresolvedTag: "$TAG"},
+			},
+			wantCompliance: 0,
+		},
+		{
+			name: "global variable resolved to an allowed tag",
+			ciConf: `
+variables:
+  TAG: "1.2.3"
+build:
+  image: app:$TAG
+  script: make
+`,
+			wantIssues:     []GitlabPipelineImageIssueTag{},
+			wantCompliance: 100,
+		},
+		{
+			name: "unresolved variable is reported without lowering compliance",
+			ciConf: `
+build:
+  image: app:$TAG
+  script: make
+`,
+			wantIssues: []GitlabPipelineImageIssueTag{
+				{Link: "app:$TAG", Tag: "$TAG", Job: "build", FromVariable: true, UnresolvedTag: "$TAG", Unresolved: true},
+			},
+			wantCompliance: 100,
+			wantUnresolved: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &collector.GitlabPipelineImageData{CiValid: true}
+			data.Images = []collector.GitlabPipelineImageInfo{resolvedImage(t, tt.ciConf, "build", "app:$TAG")}
+
+			conf := &GitlabImageForbiddenTagsConf{
+				Enabled:       true,
+				ForbiddenTags: []string{"latest", "dev"},
+				MatchMode:     TagMatchModeWildcard,
+			}
+			result := conf.Run(data)
+
+			if !reflect.DeepEqual(result.Issues, tt.wantIssues) {
+				t.Errorf("issues = %+v, want %+v", result.Issues, tt.wantIssues)
+			}
+			if result.Compliance != tt.wantCompliance {
+				t.Errorf("compliance = %v, want %v", result.Compliance, tt.wantCompliance)
+			}
+			if result.Metrics.UnresolvedTags != tt.wantUnresolved {
+				t.Errorf("unresolved tags = %d, want %d", result.Metrics.UnresolvedTags, tt.wantUnresolved)
+			}
+		})
+	}
+}
+
+func TestImageForbiddenTagsUnresolvedIssueOutput(t *testing.T) {
+	result := &AnalysisResult{
+		ImageForbiddenTagsResult: &GitlabImageForbiddenTagsResult{
+			Issues: []GitlabPipelineImageIssueTag{
+				{Link: "app:$TAG", Job: "build", FromVariable: true, UnresolvedTag: "$TAG", Unresolved: true},
+			},
+		},
+	}
+
+	issues := result.ControlIssues()
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1", len(issues))
+	}
+	if !strings.Contains(issues[0].Message, "'$TAG' that could not be resolved") {
+		t.Errorf("message = %q, want it to name the unresolved variable", issues[0].Message)
+	}
+}
+
+// forbiddenTagsConf loads the forbidden tags control from its configuration
+func forbiddenTagsConf(tags []string, matchMode string) (*GitlabImageForbiddenTagsConf, error) {
+	enabled := true
+	plumberConfig := &configuration.PlumberConfig{}
+	plumberConfig.Controls.ContainerImageMustNotUseForbiddenTags = &configuration.ImageForbiddenTagsControlConfig{
+		Enabled:   &enabled,
+		Tags:      tags,
+		MatchMode: matchMode,
+	}
+	conf := &GitlabImageForbiddenTagsConf{}
+	return conf, conf.GetConf(plumberConfig)
+}
+
+func TestIsForbiddenTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		matchMode string
+		tags      []string
+		want      map[string]bool
+	}{
+		{
+			name:      "wildcard patterns",
+			matchMode: TagMatchModeWildcard,
+			tags:      []string{"dev*", "*-rc"},
+			want:      map[string]bool{"dev-123": true, "1.2-rc": true, "1.2": false},
+		},
+		{
+			name:      "wildcard without a wildcard matches the whole tag",
+			matchMode: TagMatchModeWildcard,
+			tags:      []string{"dev", "rc"},
+			want:      map[string]bool{"dev-123": false, "1.2-rc": false, "dev": true},
+		},
+		{
+			name:      "wildcard is the default mode",
+			matchMode: "",
+			tags:      []string{"dev*"},
+			want:      map[string]bool{"dev-123": true, "1.2-rc": false},
+		},
+		{
+			name:      "substring",
+			matchMode: TagMatchModeSubstring,
+			tags:      []string{"dev", "rc"},
+			want:      map[string]bool{"dev-123": true, "1.2-rc": true, "1.2": false, "": false},
+		},
+		{
+			name:      "regex",
+			matchMode: TagMatchModeRegex,
+			tags:      []string{`dev-\d+`, `\d+\.\d+-rc`},
+			want:      map[string]bool{"dev-123": true, "1.2-rc": true, "dev-abc": false, "1.2": false},
+		},
+		{
+			name:      "regex is anchored on the whole tag",
+			matchMode: TagMatchModeRegex,
+			tags:      []string{"dev", "rc"},
+			want:      map[string]bool{"dev-123": false, "1.2-rc": false, "dev": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf, err := forbiddenTagsConf(tt.tags, tt.matchMode)
+			if err != nil {
+				t.Fatalf("GetConf() error = %v", err)
+			}
+			for tag, want := range tt.want {
+				if got := conf.isForbiddenTag(tag); got != want {
+					t.Errorf("isForbiddenTag(%q) = %v, want %v", tag, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestForbiddenTagsInvalidRegex(t *testing.T) {
+	_, err := forbiddenTagsConf([]string{`dev-\d+`, "1.2-rc("}, TagMatchModeRegex)
+	if err == nil {
+		t.Fatal("GetConf() error = nil, want an invalid regex error")
+	}
+	if !strings.Contains(err.Error(), "containerImageMustNotUseForbiddenTags.tags") || !strings.Contains(err.Error(), "'1.2-rc('") {
+		t.Errorf("error = %q, want it to name the tags field and the invalid regex", err)
+	}
+
+	// The same tags are valid in wildcard mode
+	if _, err := forbiddenTagsConf([]string{"1.2-rc("}, TagMatchModeWildcard); err != nil {
+		t.Errorf("GetConf() in wildcard mode error = %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabImageNameAllowlistVersion = "0.1.0"
+
+// GitlabImageNameAllowlistConf holds the configuration for image name allowlisting
+type GitlabImageNameAllowlistConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// AllowedImages is a list of allowed registry/name patterns, tags are ignored (supports wildcards)
+	AllowedImages []string `json:"allowedImages"`
+}
+
+// validateImageNameAllowlistConfig validates the imageNameAllowlist configuration
+func validateImageNameAllowlistConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	allowlistConfig := plumberConfig.GetImageNameAllowlistConfig()
+	if !allowlistConfig.IsEnabled() {
+		return
+	}
+
+	if len(allowlistConfig.AllowedImages) == 0 {
+		v.add("imageNameAllowlist.allowedImages", "field is required when the control is enabled", "a list of allowed registry/name patterns")
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabImageNameAllowlistConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	allowlistConfig := plumberConfig.GetImageNameAllowlistConfig()
+	if allowlistConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateImageNameAllowlistConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = allowlistConfig.IsEnabled()
+	p.AllowedImages = allowlistConfig.AllowedImages
+
+	l.WithFields(logrus.Fields{
+		"enabled":       p.Enabled,
+		"allowedImages": p.AllowedImages,
+	}).Debug("imageNameAllowlist control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabImageNameAllowlistMetrics holds metrics about image names
+type GitlabImageNameAllowlistMetrics struct {
+	Total      uint `json:"total"`
+	Allowed    uint `json:"allowed"`
+	NotAllowed uint `json:"notAllowed"`
+	CiInvalid  uint `json:"ciInvalid"`
+	CiMissing  uint `json:"ciMissing"`
+}
+
+// GitlabImageNameAllowlistResult holds the result of the image name allowlist control
+type GitlabImageNameAllowlistResult struct {
+	Issues     []GitlabImageNameAllowlistIssue `json:"issues"`
+	Metrics    GitlabImageNameAllowlistMetrics `json:"metrics"`
+	Compliance float64                         `json:"compliance"`
+	Version    string                          `json:"version"`
+	CiValid    bool                            `json:"ciValid"`
+	CiMissing  bool                            `json:"ciMissing"`
+	Skipped    bool                            `json:"skipped"`         // True if control was disabled
+	Error      string                          `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabImageNameAllowlistIssue represents an image whose registry/name is not allowlisted
+type GitlabImageNameAllowlistIssue struct {
+	Job   string `json:"job"`
+	Link  string `json:"link"`
+	Image string `json:"image"` // registry/name of the image, without tag
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// imageFullName returns the registry/name of an image, without its tag or
+// digest. Images whose registry couldn't be parsed only have their name.
+func imageFullName(image *collector.GitlabPipelineImageInfo) string {
+	name := strings.SplitN(image.Name, "@", 2)[0]
+	if image.Registry == unknownRegistry || image.Registry == "" {
+		return strings.Trim(name, "/")
+	}
+	return strings.Trim(image.Registry+"/"+name, "/")
+}
+
+// Run executes the image name allowlist control. Images are matched on their
+// registry/name whatever their tag.
+func (p *GitlabImageNameAllowlistConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabImageNameAllowlistResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabImageNameAllowlist",
+		"controlVersion": ControlTypeGitlabImageNameAllowlistVersion,
+	})
+	l.Info("Start image name allowlist control")
+
+	result := &GitlabImageNameAllowlistResult{
+		Issues:     []GitlabImageNameAllowlistIssue{},
+		Metrics:    GitlabImageNameAllowlistMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabImageNameAllowlistVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Image name allowlist control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	for _, image := range pipelineImageData.Images {
+		result.Metrics.Total++
+
+		fullName := imageFullName(&image)
+		if fullName != "" && gitlab.CheckItemMatchToPatterns(fullName, p.AllowedImages) {
+			result.Metrics.Allowed++
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabImageNameAllowlistIssue{
+			Job:   image.Job,
+			Link:  image.Link,
+			Image: fullName,
+		})
+		result.Metrics.NotAllowed++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"totalImages": result.Metrics.Total,
+		"notAllowed":  result.Metrics.NotAllowed,
+		"compliance":  result.Compliance,
+	}).Info("Image name allowlist control completed")
+
+	return result
+}
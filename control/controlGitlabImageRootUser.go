@@ -0,0 +1,248 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabImageRootUserVersion = "0.1.0"
+
+// DefaultRootImagePatterns are common base images running as root by default,
+// used when rootImagePatterns is not set
+var DefaultRootImagePatterns = []string{
+	"docker.io/alpine",
+	"docker.io/debian",
+	"docker.io/ubuntu",
+	"docker.io/centos",
+	"docker.io/fedora",
+	"docker.io/busybox",
+	"docker.io/node",
+	"docker.io/python",
+	"docker.io/golang",
+	"docker.io/ruby",
+	"docker.io/openjdk",
+	"docker.io/maven",
+	"docker.io/gradle",
+}
+
+// GitlabImageRootUserConf holds the configuration for root user detection
+type GitlabImageRootUserConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// RootImagePatterns are images known to run as root by default (supports wildcards)
+	RootImagePatterns []string `json:"rootImagePatterns"`
+
+	// NonRootVariables are variable names marking a job as running as a non-root user (supports wildcards)
+	NonRootVariables []string `json:"nonRootVariables"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabImageRootUserConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	rootConfig := plumberConfig.GetRootUserDiscouragedConfig()
+	if rootConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = rootConfig.IsEnabled()
+	p.RootImagePatterns = rootConfig.RootImagePatterns
+	if len(p.RootImagePatterns) == 0 {
+		p.RootImagePatterns = DefaultRootImagePatterns
+	}
+	p.NonRootVariables = rootConfig.NonRootVariables
+	if p.NonRootVariables == nil {
+		p.NonRootVariables = []string{}
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":           p.Enabled,
+		"rootImagePatterns": p.RootImagePatterns,
+		"nonRootVariables":  p.NonRootVariables,
+	}).Debug("rootUserDiscouraged control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabImageRootUserMetrics holds metrics about jobs running as root
+type GitlabImageRootUserMetrics struct {
+	Total      uint `json:"total"`
+	RootImages uint `json:"rootImages"`
+	NonRoot    uint `json:"nonRoot"` // Jobs using a root image with a non-root indicator
+	Root       uint `json:"root"`
+	CiInvalid  uint `json:"ciInvalid"`
+	CiMissing  uint `json:"ciMissing"`
+}
+
+// GitlabImageRootUserResult holds the result of the root user control
+type GitlabImageRootUserResult struct {
+	Issues     []GitlabImageRootUserIssue `json:"issues"`
+	Metrics    GitlabImageRootUserMetrics `json:"metrics"`
+	Compliance float64                    `json:"compliance"`
+	Version    string                     `json:"version"`
+	CiValid    bool                       `json:"ciValid"`
+	CiMissing  bool                       `json:"ciMissing"`
+	Skipped    bool                       `json:"skipped"`         // True if control was disabled
+	Error      string                     `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabImageRootUserIssue represents a job likely running as root
+type GitlabImageRootUserIssue struct {
+	Job  string `json:"job"`
+	Link string `json:"link"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the root user control. The detection is heuristic: a job is
+// flagged if its image is known to run as root by default, unless the image
+// sets a non-root 'docker:user' or the job defines a non-root variable.
+func (p *GitlabImageRootUserConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabImageRootUserResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabImageRootUser",
+		"controlVersion": ControlTypeGitlabImageRootUserVersion,
+	})
+	l.Info("Start root user control")
+
+	result := &GitlabImageRootUserResult{
+		Issues:     []GitlabImageRootUserIssue{},
+		Metrics:    GitlabImageRootUserMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabImageRootUserVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Root user control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+	jobsByName := make(map[string]*gitlab.GitlabJob, len(jobs))
+	for _, job := range jobs {
+		jobsByName[job.Name] = job.Job
+	}
+
+	// The default image applies to jobs without image
+	defaultImage := pipelineImageData.MergedConf.Default.Image
+	if defaultImage == nil {
+		defaultImage = pipelineImageData.MergedConf.Image
+	}
+
+	for _, image := range pipelineImageData.Images {
+		result.Metrics.Total++
+
+		imageRef := image.Name
+		if image.Registry == dockerHubDomain {
+			// Official images can be written docker.io/library/<name>
+			imageRef = dockerHubDomain + "/" + strings.TrimPrefix(image.Name, "library/")
+		} else if image.Registry != unknownRegistry {
+			imageRef = image.Registry + "/" + image.Name
+		}
+		if !gitlab.CheckItemMatchToPatterns(imageRef, p.RootImagePatterns) {
+			continue
+		}
+		result.Metrics.RootImages++
+
+		job := jobsByName[image.Job]
+		if image.Job == collector.DefaultImageJob {
+			// A job without image nor variables, running the default image
+			job = &gitlab.GitlabJob{}
+		}
+		if job != nil && p.hasNonRootIndicator(job, defaultImage, pipelineImageData.GlobalVars) {
+			result.Metrics.NonRoot++
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabImageRootUserIssue{
+			Job:  image.Job,
+			Link: image.Link,
+		})
+		result.Metrics.Root++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"totalImages": result.Metrics.Total,
+		"rootImages":  result.Metrics.RootImages,
+		"root":        result.Metrics.Root,
+		"compliance":  result.Compliance,
+	}).Info("Root user control completed")
+
+	return result
+}
+
+// hasNonRootIndicator reports whether a job runs as a non-root user: its
+// image (or the default image) sets a non-root 'docker:user', or the job or
+// global variables define one of the non-root variables
+func (p *GitlabImageRootUserConf) hasNonRootIndicator(job *gitlab.GitlabJob, defaultImage interface{}, globalVars map[string]string) bool {
+	imageInterface := job.Image
+	if imageInterface == nil {
+		imageInterface = defaultImage
+	}
+	if image, err := gitlab.GetImage(imageInterface); err == nil && image.Docker != nil && isNonRootUser(image.Docker.User) {
+		return true
+	}
+
+	if len(p.NonRootVariables) == 0 {
+		return false
+	}
+	for name := range job.Variables {
+		if gitlab.CheckItemMatchToPatterns(name, p.NonRootVariables) {
+			return true
+		}
+	}
+	for name := range globalVars {
+		if gitlab.CheckItemMatchToPatterns(name, p.NonRootVariables) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNonRootUser reports whether a 'docker:user' value (user or user:group)
+// designates a user other than root
+func isNonRootUser(user string) bool {
+	user = strings.TrimSpace(strings.SplitN(user, ":", 2)[0])
+	return user != "" && user != "root" && user != "0"
+}
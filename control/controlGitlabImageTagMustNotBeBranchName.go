@@ -0,0 +1,160 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabImageTagMustNotBeBranchNameVersion = "0.1.0"
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabTagMustNotBeBranchNameControl handles branch-named image tags compliance checking
+type GitlabTagMustNotBeBranchNameControl struct {
+	config *configuration.TagMustNotBeBranchNameControlConfig
+}
+
+// NewGitlabTagMustNotBeBranchNameControl creates a new branch-named image tags control instance
+func NewGitlabTagMustNotBeBranchNameControl(config *configuration.TagMustNotBeBranchNameControlConfig) *GitlabTagMustNotBeBranchNameControl {
+	return &GitlabTagMustNotBeBranchNameControl{
+		config: config,
+	}
+}
+
+// GitlabTagMustNotBeBranchNameMetrics holds metrics for the branch-named image tags control
+type GitlabTagMustNotBeBranchNameMetrics struct {
+	Total          uint `json:"total"`
+	Branches       uint `json:"branches"`
+	DigestPinned   uint `json:"digestPinned"`   // Images pinned by digest, not checked
+	UnresolvedTags uint `json:"unresolvedTags"` // Tags whose variables couldn't be resolved, not checked
+	BranchNamed    uint `json:"branchNamed"`
+	CiInvalid      uint `json:"ciInvalid"`
+	CiMissing      uint `json:"ciMissing"`
+}
+
+// GitlabTagMustNotBeBranchNameResult holds the result of the branch-named image tags control
+type GitlabTagMustNotBeBranchNameResult struct {
+	Issues     []GitlabTagMustNotBeBranchNameIssue `json:"issues"`
+	Metrics    GitlabTagMustNotBeBranchNameMetrics `json:"metrics"`
+	Compliance float64                             `json:"compliance"`
+	Version    string                              `json:"version"`
+	Skipped    bool                                `json:"skipped"`         // True if control was disabled
+	Error      string                              `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabTagMustNotBeBranchNameIssue represents an image tagged with the name
+// of a branch of the project, which moves with the branch
+type GitlabTagMustNotBeBranchNameIssue struct {
+	Job    string `json:"job"`
+	Link   string `json:"link"`
+	Tag    string `json:"tag"`
+	Branch string `json:"branch"`
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the branch-named image tags compliance check. Tags are
+// compared exactly to the branches of the project; images pinned by digest
+// are immutable and skipped.
+func (c *GitlabTagMustNotBeBranchNameControl) Run(
+	pipelineImageData *collector.GitlabPipelineImageData,
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabTagMustNotBeBranchNameResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabTagMustNotBeBranchName",
+		"controlVersion": ControlTypeGitlabImageTagMustNotBeBranchNameVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabTagMustNotBeBranchNameResult{
+		Issues:     []GitlabTagMustNotBeBranchNameIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabImageTagMustNotBeBranchNameVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Branch-named image tags control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start branch-named image tags control")
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	// Branches could not be fetched, the control can't be evaluated
+	if protectionData == nil {
+		logger.Warn("Branches are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "branches are not available"
+		return result
+	}
+
+	branches := map[string]bool{}
+	for _, branch := range protectionData.Branches {
+		branches[branch] = true
+	}
+	result.Metrics.Branches = uint(len(branches))
+
+	for _, image := range pipelineImageData.Images {
+		result.Metrics.Total++
+
+		if image.DigestPinned() {
+			result.Metrics.DigestPinned++
+			continue
+		}
+		if image.TagFromVariable() && (image.Tag == "" || strings.Contains(image.Tag, "$")) {
+			result.Metrics.UnresolvedTags++
+			continue
+		}
+
+		if image.Tag != "" && branches[image.Tag] {
+			result.Issues = append(result.Issues, GitlabTagMustNotBeBranchNameIssue{
+				Job:    image.Job,
+				Link:   image.Link,
+				Tag:    image.Tag,
+				Branch: image.Tag,
+			})
+			result.Metrics.BranchNamed++
+		}
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"branchNamed": result.Metrics.BranchNamed,
+		"compliance":  result.Compliance,
+	}).Info("Branch-named image tags control completed")
+
+	return result
+}
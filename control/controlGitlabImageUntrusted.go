@@ -1,7 +1,6 @@
 package control
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 
@@ -33,24 +32,26 @@ type GitlabImageAuthorizedSourcesConf struct {
 	TrustDockerHubOfficialImages bool `json:"trustDockerHubOfficialImages"`
 }
 
-// GetConf loads configuration from PlumberConfig
-// Returns error if config is missing or incomplete
-func (p *GitlabImageAuthorizedSourcesConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
-	// Plumber config is required
-	if plumberConfig == nil {
-		return fmt.Errorf("Plumber config is required but not provided")
-	}
-
-	// Get control config from PlumberConfig
+// validateImageAuthorizedSourcesConfig validates the containerImageMustComeFromAuthorizedSources configuration
+func validateImageAuthorizedSourcesConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
 	imgConfig := plumberConfig.GetContainerImageMustComeFromAuthorizedSourcesConfig()
 	if imgConfig == nil {
-		return fmt.Errorf("containerImageMustComeFromAuthorizedSources control configuration is missing from .plumber.yaml config file")
+		v.add("containerImageMustComeFromAuthorizedSources", "control configuration is missing", "a containerImageMustComeFromAuthorizedSources section under controls")
+		return
 	}
 
-	// Check if enabled field is set
 	if imgConfig.Enabled == nil {
-		return fmt.Errorf("containerImageMustComeFromAuthorizedSources.enabled field is required in .plumber.yaml config file")
+		v.add("containerImageMustComeFromAuthorizedSources.enabled", "field is required", "true or false")
 	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// Returns error if config is missing or incomplete
+func (p *GitlabImageAuthorizedSourcesConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	if err := validateControlConfig(plumberConfig, validateImageAuthorizedSourcesConfig); err != nil {
+		return err
+	}
+	imgConfig := plumberConfig.GetContainerImageMustComeFromAuthorizedSourcesConfig()
 
 	// Apply configuration
 	p.Enabled = imgConfig.IsEnabled()
@@ -129,10 +130,13 @@ func checkImageAuthorizationStatus(image *collector.GitlabPipelineImageInfo, tru
 		imageUrl = image.Registry + "/" + image.Name
 	}
 
-	// Include tag in the URL for pattern matching (if tag is present)
+	// Include tag and digest in the URL for pattern matching (if present)
 	if image.Tag != "" {
 		imageUrl = imageUrl + ":" + image.Tag
 	}
+	if image.DigestPinned() {
+		imageUrl = imageUrl + "@" + image.Digest
+	}
 
 	imageUrlSanitized := strings.Trim(imageUrl, "/")
 	if imageUrlSanitized == "" {
@@ -0,0 +1,78 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/getplumber/plumber/collector"
+)
+
+// TestImageAllowlistsDefaultImage checks that the default image is allowed
+// or flagged like the same image set on a job
+func TestImageAllowlistsDefaultImage(t *testing.T) {
+	tests := []struct {
+		name        string
+		image       collector.GitlabPipelineImageInfo
+		wantFlagged bool
+	}{
+		{
+			name:        "trusted registry",
+			image:       collector.GitlabPipelineImageInfo{Link: "registry.example.com/tools/app:1.2", Registry: "registry.example.com", Name: "tools/app", Tag: "1.2"},
+			wantFlagged: false,
+		},
+		{
+			name:        "Docker Hub official image",
+			image:       collector.GitlabPipelineImageInfo{Link: "docker.io/node:20", Registry: "docker.io", Name: "node", Tag: "20"},
+			wantFlagged: false,
+		},
+		{
+			name:        "untrusted registry",
+			image:       collector.GitlabPipelineImageInfo{Link: "ghcr.io/someone/app:1.2", Registry: "ghcr.io", Name: "someone/app", Tag: "1.2"},
+			wantFlagged: true,
+		},
+		{
+			name:        "Docker Hub user image",
+			image:       collector.GitlabPipelineImageInfo{Link: "docker.io/someone/node:20", Registry: "docker.io", Name: "someone/node", Tag: "20"},
+			wantFlagged: true,
+		},
+	}
+
+	authorizedSources := &GitlabImageAuthorizedSourcesConf{
+		Enabled:                      true,
+		TrustedUrls:                  []string{"registry.example.com/*"},
+		TrustDockerHubOfficialImages: true,
+	}
+	nameAllowlist := &GitlabImageNameAllowlistConf{
+		Enabled:       true,
+		AllowedImages: []string{"registry.example.com/*", "docker.io/node"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobImage := tt.image
+			jobImage.Job = "build"
+			defaultImage := tt.image
+			defaultImage.Job = collector.DefaultImageJob
+
+			data := &collector.GitlabPipelineImageData{CiValid: true}
+			data.Images = []collector.GitlabPipelineImageInfo{jobImage, defaultImage}
+
+			flaggedSources := map[string]bool{}
+			for _, issue := range authorizedSources.Run(data).Issues {
+				flaggedSources[issue.Job] = true
+			}
+			flaggedNames := map[string]bool{}
+			for _, issue := range nameAllowlist.Run(data).Issues {
+				flaggedNames[issue.Job] = true
+			}
+
+			for _, job := range []string{"build", collector.DefaultImageJob} {
+				if flaggedSources[job] != tt.wantFlagged {
+					t.Errorf("authorized sources: %s flagged = %v, want %v", job, flaggedSources[job], tt.wantFlagged)
+				}
+				if flaggedNames[job] != tt.wantFlagged {
+					t.Errorf("name allowlist: %s flagged = %v, want %v", job, flaggedNames[job], tt.wantFlagged)
+				}
+			}
+		})
+	}
+}
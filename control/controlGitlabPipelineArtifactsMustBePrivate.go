@@ -0,0 +1,155 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineArtifactsMustBePrivateVersion = "0.1.0"
+
+// GitlabPipelineArtifactsMustBePrivateConf holds the configuration for public artifacts detection
+type GitlabPipelineArtifactsMustBePrivateConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineArtifactsMustBePrivateConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	artifactsConfig := plumberConfig.GetArtifactsMustBePrivateConfig()
+	if artifactsConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = artifactsConfig.IsEnabled()
+
+	l.WithFields(logrus.Fields{
+		"enabled": p.Enabled,
+	}).Debug("artifactsMustBePrivate control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineArtifactsMustBePrivateMetrics holds metrics about artifacts visibility
+type GitlabPipelineArtifactsMustBePrivateMetrics struct {
+	TotalJobs     uint `json:"totalJobs"`
+	JobsArtifacts uint `json:"jobsArtifacts"` // Jobs with artifacts
+	Private       uint `json:"private"`
+	Public        uint `json:"public"`
+	CiInvalid     uint `json:"ciInvalid"`
+	CiMissing     uint `json:"ciMissing"`
+}
+
+// GitlabPipelineArtifactsMustBePrivateResult holds the result of the public artifacts control
+type GitlabPipelineArtifactsMustBePrivateResult struct {
+	Issues     []GitlabPipelineArtifactsMustBePrivateIssue `json:"issues"`
+	Metrics    GitlabPipelineArtifactsMustBePrivateMetrics `json:"metrics"`
+	Compliance float64                                     `json:"compliance"`
+	Version    string                                      `json:"version"`
+	CiValid    bool                                        `json:"ciValid"`
+	CiMissing  bool                                        `json:"ciMissing"`
+	Skipped    bool                                        `json:"skipped"`         // True if control was disabled
+	Error      string                                      `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineArtifactsMustBePrivateIssue represents a job with public artifacts
+type GitlabPipelineArtifactsMustBePrivateIssue struct {
+	Job string `json:"job"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the public artifacts control. Jobs without artifacts are skipped.
+func (p *GitlabPipelineArtifactsMustBePrivateConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineArtifactsMustBePrivateResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineArtifactsMustBePrivate",
+		"controlVersion": ControlTypeGitlabPipelineArtifactsMustBePrivateVersion,
+	})
+	l.Info("Start public artifacts control")
+
+	result := &GitlabPipelineArtifactsMustBePrivateResult{
+		Issues:     []GitlabPipelineArtifactsMustBePrivateIssue{},
+		Metrics:    GitlabPipelineArtifactsMustBePrivateMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineArtifactsMustBePrivateVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Public artifacts control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	for _, job := range jobs {
+		result.Metrics.TotalJobs++
+
+		// Jobs without artifacts inherit the default ones
+		artifactsInterface := job.Job.Artifacts
+		if artifactsInterface == nil {
+			artifactsInterface = pipelineImageData.MergedConf.Default.Artifacts
+		}
+		artifacts, err := gitlab.GetArtifacts(artifactsInterface)
+		if err != nil || artifacts == nil {
+			continue
+		}
+		result.Metrics.JobsArtifacts++
+
+		if artifacts.IsPrivate() {
+			result.Metrics.Private++
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabPipelineArtifactsMustBePrivateIssue{
+			Job: job.Name,
+		})
+		result.Metrics.Public++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"jobsArtifacts": result.Metrics.JobsArtifacts,
+		"public":        result.Metrics.Public,
+		"compliance":    result.Compliance,
+	}).Info("Public artifacts control completed")
+
+	return result
+}
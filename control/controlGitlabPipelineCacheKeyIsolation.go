@@ -0,0 +1,213 @@
+package control
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineCacheKeyIsolationVersion = "0.1.0"
+
+// defaultCacheKey is the key GitLab uses when a cache doesn't define one
+const defaultCacheKey = "default"
+
+// cacheKeyIsolatingVariables are the variables that scope a cache key to a ref
+var cacheKeyIsolatingVariables = []string{
+	"CI_COMMIT_REF_SLUG",
+	"CI_COMMIT_REF_NAME",
+	"CI_COMMIT_BRANCH",
+}
+
+// GitlabPipelineCacheKeyIsolationConf holds the configuration for cache key isolation detection
+type GitlabPipelineCacheKeyIsolationConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineCacheKeyIsolationConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	cacheConfig := plumberConfig.GetCacheKeyIsolationConfig()
+	if cacheConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = cacheConfig.IsEnabled()
+
+	l.WithFields(logrus.Fields{
+		"enabled": p.Enabled,
+	}).Debug("cacheKeyIsolation control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineCacheKeyIsolationMetrics holds metrics about cache keys
+type GitlabPipelineCacheKeyIsolationMetrics struct {
+	Total       uint `json:"total"`
+	Isolated    uint `json:"isolated"`
+	NotIsolated uint `json:"notIsolated"`
+	CiInvalid   uint `json:"ciInvalid"`
+	CiMissing   uint `json:"ciMissing"`
+}
+
+// GitlabPipelineCacheKeyIsolationResult holds the result of the cache key isolation control
+type GitlabPipelineCacheKeyIsolationResult struct {
+	Issues     []GitlabPipelineCacheKeyIsolationIssue `json:"issues"`
+	Metrics    GitlabPipelineCacheKeyIsolationMetrics `json:"metrics"`
+	Compliance float64                                `json:"compliance"`
+	Version    string                                 `json:"version"`
+	CiValid    bool                                   `json:"ciValid"`
+	CiMissing  bool                                   `json:"ciMissing"`
+	Skipped    bool                                   `json:"skipped"`         // True if control was disabled
+	Error      string                                 `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineCacheKeyIsolationIssue represents a job cache whose key is shared across branches
+type GitlabPipelineCacheKeyIsolationIssue struct {
+	Job string `json:"job"`
+	Key string `json:"key"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the cache key isolation control
+func (p *GitlabPipelineCacheKeyIsolationConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineCacheKeyIsolationResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineCacheKeyIsolation",
+		"controlVersion": ControlTypeGitlabPipelineCacheKeyIsolationVersion,
+	})
+	l.Info("Start cache key isolation control")
+
+	result := &GitlabPipelineCacheKeyIsolationResult{
+		Issues:     []GitlabPipelineCacheKeyIsolationIssue{},
+		Metrics:    GitlabPipelineCacheKeyIsolationMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineCacheKeyIsolationVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Cache key isolation control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	// Loop over all jobs to check their cache keys
+	for _, job := range jobs {
+		// Jobs without cache inherit the default (or root level) one
+		cacheInterface := job.Job.Cache
+		if cacheInterface == nil {
+			cacheInterface = pipelineImageData.MergedConf.Default.Cache
+		}
+		if cacheInterface == nil {
+			cacheInterface = pipelineImageData.MergedConf.Cache
+		}
+
+		caches, err := gitlab.GetCaches(cacheInterface)
+		if err != nil {
+			l.WithError(err).WithField("job", job.Name).Error("Unable to parse job cache")
+			result.Compliance = 0.0
+			result.Error = err.Error()
+			return result
+		}
+
+		for _, cache := range caches {
+			result.Metrics.Total++
+			key, isolated := cacheKeyIsIsolated(cache.Key)
+			if isolated {
+				result.Metrics.Isolated++
+				continue
+			}
+
+			result.Issues = append(result.Issues, GitlabPipelineCacheKeyIsolationIssue{
+				Job: job.Name,
+				Key: key,
+			})
+			result.Metrics.NotIsolated++
+		}
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"totalCaches": result.Metrics.Total,
+		"notIsolated": result.Metrics.NotIsolated,
+		"compliance":  result.Compliance,
+	}).Info("Cache key isolation control completed")
+
+	return result
+}
+
+// cacheKeyIsIsolated returns a printable form of a cache key and whether it
+// is isolated, i.e. scoped to a ref or computed from files (key:files)
+func cacheKeyIsIsolated(key interface{}) (string, bool) {
+	switch k := key.(type) {
+	case nil:
+		return defaultCacheKey, false
+	case string:
+		if k == "" {
+			return defaultCacheKey, false
+		}
+		return k, containsIsolatingVariable(k)
+	case map[interface{}]interface{}:
+		prefix, _ := k["prefix"].(string)
+		if files, ok := k["files"]; ok && files != nil {
+			return fmt.Sprintf("files: %v", files), true
+		}
+		if prefix == "" {
+			return defaultCacheKey, false
+		}
+		return prefix, containsIsolatingVariable(prefix)
+	}
+
+	return fmt.Sprintf("%v", key), false
+}
+
+// containsIsolatingVariable reports whether a cache key references a ref-scoped variable
+func containsIsolatingVariable(key string) bool {
+	for _, variable := range cacheKeyIsolatingVariables {
+		if strings.Contains(key, "$"+variable) || strings.Contains(key, "${"+variable+"}") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package control
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineComplexityBudgetVersion = "0.1.0"
+
+// Default budgets when maxTotalLines or maxJobLines are not set
+const (
+	DefaultMaxTotalLines = 2000
+	DefaultMaxJobLines   = 150
+)
+
+// complexityLargestJobsCount is the number of largest jobs reported
+const complexityLargestJobsCount = 5
+
+// Complexity budget issue types
+const (
+	complexityIssueTotal = "total"
+	complexityIssueJob   = "job"
+)
+
+// GitlabPipelineComplexityBudgetConf holds the configuration for pipeline complexity budget detection
+type GitlabPipelineComplexityBudgetConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// MaxTotalLines is the maximum number of lines of all jobs together
+	MaxTotalLines int `json:"maxTotalLines"`
+
+	// MaxJobLines is the maximum number of lines of a single job
+	MaxJobLines int `json:"maxJobLines"`
+}
+
+// validatePipelineComplexityBudgetConfig validates the pipelineComplexityBudget configuration
+func validatePipelineComplexityBudgetConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	budgetConfig := plumberConfig.GetPipelineComplexityBudgetConfig()
+	if !budgetConfig.IsEnabled() {
+		return
+	}
+
+	if budgetConfig.MaxTotalLines != nil && *budgetConfig.MaxTotalLines < 1 {
+		v.add("pipelineComplexityBudget.maxTotalLines", fmt.Sprintf("invalid line count %d", *budgetConfig.MaxTotalLines), "a number greater than or equal to 1")
+	}
+	if budgetConfig.MaxJobLines != nil && *budgetConfig.MaxJobLines < 1 {
+		v.add("pipelineComplexityBudget.maxJobLines", fmt.Sprintf("invalid line count %d", *budgetConfig.MaxJobLines), "a number greater than or equal to 1")
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineComplexityBudgetConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	budgetConfig := plumberConfig.GetPipelineComplexityBudgetConfig()
+	if budgetConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validatePipelineComplexityBudgetConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = budgetConfig.IsEnabled()
+	p.MaxTotalLines = DefaultMaxTotalLines
+	if budgetConfig.MaxTotalLines != nil {
+		p.MaxTotalLines = *budgetConfig.MaxTotalLines
+	}
+	p.MaxJobLines = DefaultMaxJobLines
+	if budgetConfig.MaxJobLines != nil {
+		p.MaxJobLines = *budgetConfig.MaxJobLines
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":       p.Enabled,
+		"maxTotalLines": p.MaxTotalLines,
+		"maxJobLines":   p.MaxJobLines,
+	}).Debug("pipelineComplexityBudget control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineComplexityBudgetMetrics holds metrics about the pipeline size
+type GitlabPipelineComplexityBudgetMetrics struct {
+	Jobs          uint `json:"jobs"`
+	TotalLines    uint `json:"totalLines"`
+	MaxTotalLines uint `json:"maxTotalLines"`
+	MaxJobLines   uint `json:"maxJobLines"`
+	OversizedJobs uint `json:"oversizedJobs"`
+	CiInvalid     uint `json:"ciInvalid"`
+	CiMissing     uint `json:"ciMissing"`
+}
+
+// GitlabPipelineComplexityBudgetResult holds the result of the pipeline complexity budget control
+type GitlabPipelineComplexityBudgetResult struct {
+	Issues      []GitlabPipelineComplexityBudgetIssue `json:"issues"`
+	Metrics     GitlabPipelineComplexityBudgetMetrics `json:"metrics"`
+	LargestJobs []GitlabPipelineJobSize               `json:"largestJobs"` // Largest jobs, biggest first
+	Compliance  float64                               `json:"compliance"`
+	Version     string                                `json:"version"`
+	CiValid     bool                                  `json:"ciValid"`
+	CiMissing   bool                                  `json:"ciMissing"`
+	Skipped     bool                                  `json:"skipped"`         // True if control was disabled
+	Error       string                                `json:"error,omitempty"` // Error message if data collection failed
+}
+
+// GitlabPipelineJobSize is the number of lines of a job
+type GitlabPipelineJobSize struct {
+	Job   string `json:"job"`
+	Lines int    `json:"lines"`
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineComplexityBudgetIssue represents a pipeline or a job exceeding its line budget
+type GitlabPipelineComplexityBudgetIssue struct {
+	Type  string `json:"type"`          // "total" (whole pipeline) or "job"
+	Job   string `json:"job,omitempty"` // Set for "job" issues
+	Lines int    `json:"lines"`
+	Limit int    `json:"limit"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the pipeline complexity budget control
+func (p *GitlabPipelineComplexityBudgetConf) Run(pipelineOriginData *collector.GitlabPipelineOriginData) *GitlabPipelineComplexityBudgetResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineComplexityBudget",
+		"controlVersion": ControlTypeGitlabPipelineComplexityBudgetVersion,
+	})
+	l.Info("Start pipeline complexity budget control")
+
+	result := &GitlabPipelineComplexityBudgetResult{
+		Issues:      []GitlabPipelineComplexityBudgetIssue{},
+		Metrics:     GitlabPipelineComplexityBudgetMetrics{},
+		LargestJobs: []GitlabPipelineJobSize{},
+		Compliance:  100.0,
+		Version:     ControlTypeGitlabPipelineComplexityBudgetVersion,
+		CiValid:     pipelineOriginData.CiValid,
+		CiMissing:   pipelineOriginData.CiMissing,
+		Skipped:     false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Pipeline complexity budget control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineOriginData.CiValid || pipelineOriginData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineOriginData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineOriginData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	result.Metrics.MaxTotalLines = uint(p.MaxTotalLines)
+	result.Metrics.MaxJobLines = uint(p.MaxJobLines)
+
+	// Sort jobs by size, biggest first, then by name for a stable output
+	jobSizes := make([]GitlabPipelineJobSize, 0, len(pipelineOriginData.JobMap))
+	for name, job := range pipelineOriginData.JobMap {
+		jobSizes = append(jobSizes, GitlabPipelineJobSize{Job: name, Lines: job.Lines})
+	}
+	sort.Slice(jobSizes, func(i, j int) bool {
+		if jobSizes[i].Lines != jobSizes[j].Lines {
+			return jobSizes[i].Lines > jobSizes[j].Lines
+		}
+		return jobSizes[i].Job < jobSizes[j].Job
+	})
+
+	totalLines := 0
+	for _, jobSize := range jobSizes {
+		totalLines += jobSize.Lines
+		if jobSize.Lines > p.MaxJobLines {
+			result.Issues = append(result.Issues, GitlabPipelineComplexityBudgetIssue{
+				Type:  complexityIssueJob,
+				Job:   jobSize.Job,
+				Lines: jobSize.Lines,
+				Limit: p.MaxJobLines,
+			})
+			result.Metrics.OversizedJobs++
+		}
+	}
+	result.Metrics.Jobs = uint(len(jobSizes))
+	result.Metrics.TotalLines = uint(totalLines)
+
+	if totalLines > p.MaxTotalLines {
+		// The pipeline budget comes first in the issues
+		result.Issues = append([]GitlabPipelineComplexityBudgetIssue{{
+			Type:  complexityIssueTotal,
+			Lines: totalLines,
+			Limit: p.MaxTotalLines,
+		}}, result.Issues...)
+	}
+
+	if len(jobSizes) > complexityLargestJobsCount {
+		jobSizes = jobSizes[:complexityLargestJobsCount]
+	}
+	result.LargestJobs = jobSizes
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"jobs":          result.Metrics.Jobs,
+		"totalLines":    result.Metrics.TotalLines,
+		"oversizedJobs": result.Metrics.OversizedJobs,
+		"compliance":    result.Compliance,
+	}).Info("Pipeline complexity budget control completed")
+
+	return result
+}
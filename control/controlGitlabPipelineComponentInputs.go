@@ -0,0 +1,180 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineComponentInputsValidVersion = "0.1.0"
+
+// Component input problems
+const (
+	componentInputUnknown         = "unknown"
+	componentInputMissingRequired = "missing required"
+)
+
+// GitlabPipelineComponentInputsValidConf holds the configuration for component inputs validation
+type GitlabPipelineComponentInputsValidConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineComponentInputsValidConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	inputsConfig := plumberConfig.GetComponentInputsValidConfig()
+	if inputsConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = inputsConfig.IsEnabled()
+
+	l.WithFields(logrus.Fields{
+		"enabled": p.Enabled,
+	}).Debug("componentInputsValid control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineComponentInputsValidMetrics holds metrics about component inputs
+type GitlabPipelineComponentInputsValidMetrics struct {
+	Components      uint `json:"components"`
+	SpecUnavailable uint `json:"specUnavailable"`
+	UnknownInputs   uint `json:"unknownInputs"`
+	MissingInputs   uint `json:"missingInputs"`
+	CiInvalid       uint `json:"ciInvalid"`
+	CiMissing       uint `json:"ciMissing"`
+}
+
+// GitlabPipelineComponentInputsValidResult holds the result of the component inputs control
+type GitlabPipelineComponentInputsValidResult struct {
+	Issues     []GitlabPipelineComponentInputsValidIssue `json:"issues"`
+	Metrics    GitlabPipelineComponentInputsValidMetrics `json:"metrics"`
+	Compliance float64                                   `json:"compliance"`
+	Version    string                                    `json:"version"`
+	CiValid    bool                                      `json:"ciValid"`
+	CiMissing  bool                                      `json:"ciMissing"`
+	Skipped    bool                                      `json:"skipped"`         // True if control was disabled
+	Error      string                                    `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineComponentInputsValidIssue represents an input passed to a
+// component that its spec doesn't declare, or a required input not passed
+type GitlabPipelineComponentInputsValidIssue struct {
+	Component string `json:"component"`
+	Input     string `json:"input"`
+	Problem   string `json:"problem"` // "unknown" or "missing required"
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the component inputs control
+func (p *GitlabPipelineComponentInputsValidConf) Run(pipelineOriginData *collector.GitlabPipelineOriginData) *GitlabPipelineComponentInputsValidResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineComponentInputsValid",
+		"controlVersion": ControlTypeGitlabPipelineComponentInputsValidVersion,
+	})
+	l.Info("Start component inputs control")
+
+	result := &GitlabPipelineComponentInputsValidResult{
+		Issues:     []GitlabPipelineComponentInputsValidIssue{},
+		Metrics:    GitlabPipelineComponentInputsValidMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineComponentInputsValidVersion,
+		CiValid:    pipelineOriginData.CiValid,
+		CiMissing:  pipelineOriginData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Component inputs control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineOriginData.CiValid || pipelineOriginData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineOriginData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineOriginData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	for _, component := range pipelineOriginData.ComponentInputs {
+		result.Metrics.Components++
+
+		// Without the spec, inputs can't be checked
+		if component.SpecError != "" {
+			l.WithFields(logrus.Fields{
+				"component": component.Component,
+				"error":     component.SpecError,
+			}).Debug("Component spec unavailable, skipping inputs check")
+			result.Metrics.SpecUnavailable++
+			continue
+		}
+
+		passed := make(map[string]bool, len(component.Inputs))
+		for _, input := range component.Inputs {
+			passed[input] = true
+		}
+		declared := make(map[string]bool, len(component.SpecInputs))
+		for _, specInput := range component.SpecInputs {
+			declared[specInput.Name] = true
+		}
+
+		// Inputs passed but not declared in the spec
+		for _, input := range component.Inputs {
+			if declared[input] {
+				continue
+			}
+			result.Issues = append(result.Issues, GitlabPipelineComponentInputsValidIssue{
+				Component: component.Component,
+				Input:     input,
+				Problem:   componentInputUnknown,
+			})
+			result.Metrics.UnknownInputs++
+		}
+
+		// Required inputs (without default) not passed
+		for _, specInput := range component.SpecInputs {
+			if !specInput.Required || passed[specInput.Name] {
+				continue
+			}
+			result.Issues = append(result.Issues, GitlabPipelineComponentInputsValidIssue{
+				Component: component.Component,
+				Input:     specInput.Name,
+				Problem:   componentInputMissingRequired,
+			})
+			result.Metrics.MissingInputs++
+		}
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"components":    result.Metrics.Components,
+		"unknownInputs": result.Metrics.UnknownInputs,
+		"missingInputs": result.Metrics.MissingInputs,
+		"compliance":    result.Compliance,
+	}).Info("Component inputs control completed")
+
+	return result
+}
@@ -0,0 +1,168 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineComponentsMustBeReleasedVersion = "0.1.0"
+
+// Unreleased component issue types
+const (
+	unreleasedComponentNoVersions = "noVersions"
+	unreleasedComponentNoRelease  = "noReleaseDate"
+)
+
+// GitlabPipelineComponentsMustBeReleasedConf holds the configuration for unreleased components detection
+type GitlabPipelineComponentsMustBeReleasedConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineComponentsMustBeReleasedConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	releasedConfig := plumberConfig.GetComponentsMustBeReleasedConfig()
+	if releasedConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = releasedConfig.IsEnabled()
+
+	l.WithFields(logrus.Fields{
+		"enabled": p.Enabled,
+	}).Debug("componentsMustBeReleased control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineComponentsMustBeReleasedMetrics holds metrics about component releases
+type GitlabPipelineComponentsMustBeReleasedMetrics struct {
+	Components   uint `json:"components"`
+	Released     uint `json:"released"`
+	Unreleased   uint `json:"unreleased"`
+	NotInCatalog uint `json:"notInCatalog"` // Components whose project is not a catalog resource
+	CiInvalid    uint `json:"ciInvalid"`
+	CiMissing    uint `json:"ciMissing"`
+}
+
+// GitlabPipelineComponentsMustBeReleasedResult holds the result of the released components control
+type GitlabPipelineComponentsMustBeReleasedResult struct {
+	Issues     []GitlabPipelineComponentsMustBeReleasedIssue `json:"issues"`
+	Metrics    GitlabPipelineComponentsMustBeReleasedMetrics `json:"metrics"`
+	Compliance float64                                       `json:"compliance"`
+	Version    string                                        `json:"version"`
+	CiValid    bool                                          `json:"ciValid"`
+	CiMissing  bool                                          `json:"ciMissing"`
+	Skipped    bool                                          `json:"skipped"`         // True if control was disabled
+	Error      string                                        `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineComponentsMustBeReleasedIssue represents a component whose
+// catalog resource has no released version
+type GitlabPipelineComponentsMustBeReleasedIssue struct {
+	Type            string `json:"type"` // "noVersions" or "noReleaseDate"
+	Component       string `json:"component"`
+	Version         string `json:"version"`
+	CatalogResource string `json:"catalogResource"`
+	Nested          bool   `json:"nested"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the released components control on component includes whose
+// project is a catalog resource
+func (p *GitlabPipelineComponentsMustBeReleasedConf) Run(pipelineOriginData *collector.GitlabPipelineOriginData) *GitlabPipelineComponentsMustBeReleasedResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineComponentsMustBeReleased",
+		"controlVersion": ControlTypeGitlabPipelineComponentsMustBeReleasedVersion,
+	})
+	l.Info("Start released components control")
+
+	result := &GitlabPipelineComponentsMustBeReleasedResult{
+		Issues:     []GitlabPipelineComponentsMustBeReleasedIssue{},
+		Metrics:    GitlabPipelineComponentsMustBeReleasedMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineComponentsMustBeReleasedVersion,
+		CiValid:    pipelineOriginData.CiValid,
+		CiMissing:  pipelineOriginData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Released components control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineOriginData.CiValid || pipelineOriginData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineOriginData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineOriginData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	for _, origin := range pipelineOriginData.Origins {
+		if origin.OriginType != collector.OriginTypeComponent {
+			continue
+		}
+		result.Metrics.Components++
+
+		resourceIndex, exists := pipelineOriginData.GitlabCatalogResourceMap[origin.CatalogResourcePath]
+		if !exists {
+			result.Metrics.NotInCatalog++
+			continue
+		}
+		resource := pipelineOriginData.GitlabCatalogResources[resourceIndex]
+
+		issueType := ""
+		switch {
+		case len(resource.Versions) == 0:
+			issueType = unreleasedComponentNoVersions
+		case resource.LatestReleasedAt == "":
+			issueType = unreleasedComponentNoRelease
+		}
+		if issueType == "" {
+			result.Metrics.Released++
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabPipelineComponentsMustBeReleasedIssue{
+			Type:            issueType,
+			Component:       origin.GitlabIncludeOrigin.Location,
+			Version:         origin.Version,
+			CatalogResource: resource.FullPath,
+			Nested:          origin.Nested,
+		})
+		result.Metrics.Unreleased++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"components": result.Metrics.Components,
+		"unreleased": result.Metrics.Unreleased,
+		"compliance": result.Compliance,
+	}).Info("Released components control completed")
+
+	return result
+}
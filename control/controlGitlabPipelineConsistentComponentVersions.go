@@ -0,0 +1,162 @@
+package control
+
+import (
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineConsistentComponentVersionsVersion = "0.1.0"
+
+// GitlabPipelineConsistentComponentVersionsConf holds the configuration for component version consistency detection
+type GitlabPipelineConsistentComponentVersionsConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineConsistentComponentVersionsConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	versionsConfig := plumberConfig.GetConsistentComponentVersionsConfig()
+	if versionsConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = versionsConfig.IsEnabled()
+
+	l.WithFields(logrus.Fields{
+		"enabled": p.Enabled,
+	}).Debug("consistentComponentVersions control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineConsistentComponentVersionsMetrics holds metrics about component versions
+type GitlabPipelineConsistentComponentVersionsMetrics struct {
+	Components   uint `json:"components"`
+	Consistent   uint `json:"consistent"`
+	Inconsistent uint `json:"inconsistent"`
+	CiInvalid    uint `json:"ciInvalid"`
+	CiMissing    uint `json:"ciMissing"`
+}
+
+// GitlabPipelineConsistentComponentVersionsResult holds the result of the component version consistency control
+type GitlabPipelineConsistentComponentVersionsResult struct {
+	Issues     []GitlabPipelineConsistentComponentVersionsIssue `json:"issues"`
+	Metrics    GitlabPipelineConsistentComponentVersionsMetrics `json:"metrics"`
+	Compliance float64                                          `json:"compliance"`
+	Version    string                                           `json:"version"`
+	CiValid    bool                                             `json:"ciValid"`
+	CiMissing  bool                                             `json:"ciMissing"`
+	Skipped    bool                                             `json:"skipped"`         // True if control was disabled
+	Error      string                                           `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineConsistentComponentVersionsIssue represents a component included at several versions
+type GitlabPipelineConsistentComponentVersionsIssue struct {
+	Component string   `json:"component"` // Component path without version
+	Versions  []string `json:"versions"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the component version consistency control
+func (p *GitlabPipelineConsistentComponentVersionsConf) Run(pipelineOriginData *collector.GitlabPipelineOriginData) *GitlabPipelineConsistentComponentVersionsResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineConsistentComponentVersions",
+		"controlVersion": ControlTypeGitlabPipelineConsistentComponentVersionsVersion,
+	})
+	l.Info("Start component version consistency control")
+
+	result := &GitlabPipelineConsistentComponentVersionsResult{
+		Issues:     []GitlabPipelineConsistentComponentVersionsIssue{},
+		Metrics:    GitlabPipelineConsistentComponentVersionsMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineConsistentComponentVersionsVersion,
+		CiValid:    pipelineOriginData.CiValid,
+		CiMissing:  pipelineOriginData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Component version consistency control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineOriginData.CiValid || pipelineOriginData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineOriginData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineOriginData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	// Group the versions of each component. The location of component
+	// origins is stored without version by the origin collection.
+	componentVersions := map[string]map[string]bool{}
+	for _, origin := range pipelineOriginData.Origins {
+		if origin.OriginType != collector.OriginTypeComponent {
+			continue
+		}
+		component := origin.GitlabIncludeOrigin.Location
+		if componentVersions[component] == nil {
+			componentVersions[component] = map[string]bool{}
+		}
+		componentVersions[component][origin.Version] = true
+	}
+
+	components := make([]string, 0, len(componentVersions))
+	for component := range componentVersions {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	for _, component := range components {
+		result.Metrics.Components++
+		if len(componentVersions[component]) == 1 {
+			result.Metrics.Consistent++
+			continue
+		}
+
+		versions := make([]string, 0, len(componentVersions[component]))
+		for version := range componentVersions[component] {
+			versions = append(versions, version)
+		}
+		sort.Strings(versions)
+
+		result.Issues = append(result.Issues, GitlabPipelineConsistentComponentVersionsIssue{
+			Component: component,
+			Versions:  versions,
+		})
+		result.Metrics.Inconsistent++
+	}
+
+	// Compliance is the share of components included at a single version
+	if result.Metrics.Components > 0 {
+		result.Compliance = float64(result.Metrics.Consistent) / float64(result.Metrics.Components) * 100
+	}
+
+	l.WithFields(logrus.Fields{
+		"components":   result.Metrics.Components,
+		"inconsistent": result.Metrics.Inconsistent,
+		"compliance":   result.Compliance,
+	}).Info("Component version consistency control completed")
+
+	return result
+}
@@ -0,0 +1,217 @@
+package control
+
+import (
+	"fmt"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineDeadJobsVersion = "0.1.0"
+
+// defaultPipelineStages are the stages of a configuration without stages:
+var defaultPipelineStages = []string{"build", "test", "deploy"}
+
+// defaultJobStage is the stage of a job without stage:
+const defaultJobStage = "test"
+
+// Dead job issue types
+const (
+	deadJobIssueUndefinedStage = "undefinedStage" // The stage of the job is not in stages:
+	deadJobIssueNeverRuns      = "neverRuns"      // when: or rules: prevent the job from ever running
+)
+
+// GitlabPipelineDeadJobsConf holds the configuration for dead job detection
+type GitlabPipelineDeadJobsConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineDeadJobsConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	deadJobsConfig := plumberConfig.GetDeadJobsConfig()
+	if deadJobsConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = deadJobsConfig.IsEnabled()
+
+	l.WithFields(logrus.Fields{
+		"enabled": p.Enabled,
+	}).Debug("deadJobs control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineDeadJobsMetrics holds metrics about dead jobs
+type GitlabPipelineDeadJobsMetrics struct {
+	Jobs      uint `json:"jobs"`
+	DeadJobs  uint `json:"deadJobs"`
+	CiInvalid uint `json:"ciInvalid"`
+	CiMissing uint `json:"ciMissing"`
+}
+
+// GitlabPipelineDeadJobsResult holds the result of the dead jobs control
+type GitlabPipelineDeadJobsResult struct {
+	Issues     []GitlabPipelineDeadJobsIssue `json:"issues"`
+	Metrics    GitlabPipelineDeadJobsMetrics `json:"metrics"`
+	Compliance float64                       `json:"compliance"`
+	Version    string                        `json:"version"`
+	CiValid    bool                          `json:"ciValid"`
+	CiMissing  bool                          `json:"ciMissing"`
+	Skipped    bool                          `json:"skipped"`         // True if control was disabled
+	Error      string                        `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineDeadJobsIssue represents a job defined in the configuration
+// that can never run
+type GitlabPipelineDeadJobsIssue struct {
+	Job    string `json:"job"`
+	Stage  string `json:"stage"`
+	Type   string `json:"type"`   // undefinedStage or neverRuns
+	Reason string `json:"reason"` // e.g., all rules have 'when: never'
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the dead jobs control. Only rules that can never match are
+// considered: rules depending on variables, refs or changes may run and are
+// not reported. Compliance is the share of jobs that can run.
+func (p *GitlabPipelineDeadJobsConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineDeadJobsResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineDeadJobs",
+		"controlVersion": ControlTypeGitlabPipelineDeadJobsVersion,
+	})
+	l.Info("Start dead jobs control")
+
+	result := &GitlabPipelineDeadJobsResult{
+		Issues:     []GitlabPipelineDeadJobsIssue{},
+		Metrics:    GitlabPipelineDeadJobsMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineDeadJobsVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Dead jobs control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	// .pre and .post are always available, whatever stages: contains
+	stages := map[string]bool{".pre": true, ".post": true}
+	declaredStages := pipelineImageData.MergedConf.Stages
+	if len(declaredStages) == 0 {
+		declaredStages = defaultPipelineStages
+	}
+	for _, stage := range declaredStages {
+		stages[stage] = true
+	}
+
+	for _, job := range jobs {
+		result.Metrics.Jobs++
+
+		stage := job.Job.Stage
+		if stage == "" {
+			stage = defaultJobStage
+		}
+
+		dead := false
+		if !stages[stage] {
+			result.Issues = append(result.Issues, GitlabPipelineDeadJobsIssue{
+				Job:    job.Name,
+				Stage:  stage,
+				Type:   deadJobIssueUndefinedStage,
+				Reason: fmt.Sprintf("stage '%s' is not defined in stages", stage),
+			})
+			dead = true
+		}
+		if reason := jobNeverRunsReason(job); reason != "" {
+			result.Issues = append(result.Issues, GitlabPipelineDeadJobsIssue{
+				Job:    job.Name,
+				Stage:  stage,
+				Type:   deadJobIssueNeverRuns,
+				Reason: reason,
+			})
+			dead = true
+		}
+
+		if dead {
+			result.Metrics.DeadJobs++
+		}
+	}
+
+	if result.Metrics.Jobs > 0 {
+		result.Compliance = float64(result.Metrics.Jobs-result.Metrics.DeadJobs) / float64(result.Metrics.Jobs) * 100
+	}
+
+	l.WithFields(logrus.Fields{
+		"jobs":       result.Metrics.Jobs,
+		"deadJobs":   result.Metrics.DeadJobs,
+		"compliance": result.Compliance,
+	}).Info("Dead jobs control completed")
+
+	return result
+}
+
+// jobNeverRunsReason returns why the when: or rules: of a job prevent it from
+// ever running, or an empty string if the job may run
+func jobNeverRunsReason(job pipelineJob) string {
+	if when, ok := job.Job.When.(string); ok && when == "never" {
+		return "job has 'when: never'"
+	}
+
+	if job.Job.Rules == nil {
+		return ""
+	}
+	rules, ok := job.Job.Rules.([]interface{})
+	if !ok {
+		return ""
+	}
+	if len(rules) == 0 {
+		return "rules list is empty"
+	}
+	for _, ruleInterface := range rules {
+		rule, ok := ruleInterface.(map[interface{}]interface{})
+		if !ok {
+			return ""
+		}
+		if when, _ := rule["when"].(string); when != "never" {
+			return ""
+		}
+	}
+	return "all rules have 'when: never'"
+}
@@ -0,0 +1,182 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineDebugTraceForbiddenVersion = "0.1.0"
+
+// debugTraceVariables are the variables enabling debug logging, which prints
+// all variables (including masked ones) in job logs
+var debugTraceVariables = []string{
+	"CI_DEBUG_TRACE",
+	"CI_DEBUG_SERVICES",
+}
+
+// GitlabPipelineDebugTraceForbiddenConf holds the configuration for debug trace detection
+type GitlabPipelineDebugTraceForbiddenConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineDebugTraceForbiddenConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	debugConfig := plumberConfig.GetDebugTraceForbiddenConfig()
+	if debugConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = debugConfig.IsEnabled()
+
+	l.WithFields(logrus.Fields{
+		"enabled": p.Enabled,
+	}).Debug("debugTraceForbidden control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineDebugTraceForbiddenMetrics holds metrics about debug trace variables
+type GitlabPipelineDebugTraceForbiddenMetrics struct {
+	TotalJobs uint `json:"totalJobs"`
+	Enabled   uint `json:"enabled"` // Debug trace variables set to a truthy value
+	CiInvalid uint `json:"ciInvalid"`
+	CiMissing uint `json:"ciMissing"`
+}
+
+// GitlabPipelineDebugTraceForbiddenResult holds the result of the debug trace control
+type GitlabPipelineDebugTraceForbiddenResult struct {
+	Issues     []GitlabPipelineDebugTraceForbiddenIssue `json:"issues"`
+	Metrics    GitlabPipelineDebugTraceForbiddenMetrics `json:"metrics"`
+	Compliance float64                                  `json:"compliance"`
+	Version    string                                   `json:"version"`
+	CiValid    bool                                     `json:"ciValid"`
+	CiMissing  bool                                     `json:"ciMissing"`
+	Skipped    bool                                     `json:"skipped"`         // True if control was disabled
+	Error      string                                   `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineDebugTraceForbiddenIssue represents a debug trace variable
+// enabled in a scope. The variable value is never reported.
+type GitlabPipelineDebugTraceForbiddenIssue struct {
+	Scope    string `json:"scope"` // "instance", "group", "project", "global" or "job"
+	Job      string `json:"job,omitempty"`
+	Variable string `json:"variable"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the debug trace control on CI/CD variables of the instance,
+// group and project, and on global and job variables of the configuration
+func (p *GitlabPipelineDebugTraceForbiddenConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineDebugTraceForbiddenResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineDebugTraceForbidden",
+		"controlVersion": ControlTypeGitlabPipelineDebugTraceForbiddenVersion,
+	})
+	l.Info("Start debug trace control")
+
+	result := &GitlabPipelineDebugTraceForbiddenResult{
+		Issues:     []GitlabPipelineDebugTraceForbiddenIssue{},
+		Metrics:    GitlabPipelineDebugTraceForbiddenMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineDebugTraceForbiddenVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Debug trace control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	checkDebugTraceVariables(result, variableScopeInstance, "", pipelineImageData.InstanceVars)
+	checkDebugTraceVariables(result, variableScopeGroup, "", pipelineImageData.GroupVars)
+	checkDebugTraceVariables(result, variableScopeProject, "", pipelineImageData.ProjectVars)
+	checkDebugTraceVariables(result, variableScopeGlobal, "", pipelineImageData.GlobalVars)
+
+	for _, job := range jobs {
+		result.Metrics.TotalJobs++
+
+		jobVars := map[string]string{}
+		for name, valueInterface := range job.Job.Variables {
+			if value, err := gitlab.GetVariableValue(valueInterface); err == nil {
+				jobVars[name] = value
+			}
+		}
+		checkDebugTraceVariables(result, variableScopeJob, job.Name, jobVars)
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":    result.Metrics.Enabled,
+		"compliance": result.Compliance,
+	}).Info("Debug trace control completed")
+
+	return result
+}
+
+// checkDebugTraceVariables adds an issue for each debug trace variable set
+// to a truthy value in a set of variables
+func checkDebugTraceVariables(result *GitlabPipelineDebugTraceForbiddenResult, scope, job string, variables map[string]string) {
+	for _, name := range debugTraceVariables {
+		if value, exists := variables[name]; !exists || !isTruthyVariable(value) {
+			continue
+		}
+		result.Issues = append(result.Issues, GitlabPipelineDebugTraceForbiddenIssue{
+			Scope:    scope,
+			Job:      job,
+			Variable: name,
+		})
+		result.Metrics.Enabled++
+	}
+}
+
+// isTruthyVariable reports whether a variable value enables a boolean setting
+func isTruthyVariable(value string) bool {
+	switch strings.ToLower(strings.Trim(strings.TrimSpace(value), `"'`)) {
+	case "true", "1", "yes", "on":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,292 @@
+package control
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineDependencyPinningVersion = "0.2.0"
+
+// DefaultDependencyPinningPatterns are the install patterns used when none are
+// configured in .plumber.yaml
+var DefaultDependencyPinningPatterns = []configuration.DependencyPinningPattern{
+	{
+		// pip install requests==2.31.0, pip install -r requirements.txt, pip install ./local,
+		// pip install git+https://github.com/org/repo@v1.0 (VCS URLs need a ref)
+		Name:    "pip",
+		Command: `\bpip3?\s+install\b`,
+		Pinned:  `==|^(git|hg|svn|bzr)\+\S+@[^/@#]+(#\S*)?$|^[a-z]+://|^\.|^/|\.txt$|\.whl$`,
+	},
+	{
+		// npm install lodash@4.17.21, npm install @scope/pkg@1.0.0
+		Name:    "npm",
+		Command: `\bnpm\s+(install|i|add)\b`,
+		Pinned:  `^@?[^@]+@.+$|^\.|^/|://|\.tgz$`,
+	},
+	{
+		// yarn add lodash@4.17.21
+		Name:    "yarn",
+		Command: `\byarn\s+(global\s+)?add\b`,
+		Pinned:  `^@?[^@]+@.+$|^\.|^/|://|\.tgz$`,
+	},
+	{
+		// go install golang.org/x/tools/cmd/goimports@v0.20.0 (not @latest)
+		Name:    "go",
+		Command: `\bgo\s+install\b`,
+		Pinned:  `@v?[0-9]|^\.`,
+	},
+}
+
+// dependencyPinningRule is a compiled dependency pinning pattern
+type dependencyPinningRule struct {
+	name    string
+	command *regexp.Regexp
+	pinned  *regexp.Regexp
+}
+
+// GitlabPipelineDependencyPinningConf holds the configuration for dependency pinning detection
+type GitlabPipelineDependencyPinningConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// Patterns is the list of install patterns to check
+	Patterns []configuration.DependencyPinningPattern `json:"patterns"`
+
+	rules []dependencyPinningRule
+}
+
+// validateDependencyPinningConfig validates the dependencyPinning configuration
+func validateDependencyPinningConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	pinningConfig := plumberConfig.GetDependencyPinningConfig()
+	if !pinningConfig.IsEnabled() {
+		return
+	}
+
+	for index, pattern := range pinningConfig.Patterns {
+		field := fmt.Sprintf("dependencyPinning.patterns[%d]", index)
+		if pattern.Command == "" {
+			v.add(field+".command", "field is required", "a regex matching the install command")
+		} else if _, err := regexp.Compile(pattern.Command); err != nil {
+			v.add(field+".command", fmt.Sprintf("invalid regex (%v)", err), "a regex matching the install command")
+		}
+		if pattern.Pinned == "" {
+			v.add(field+".pinned", "field is required", "a regex matching pinned packages")
+		} else if _, err := regexp.Compile(pattern.Pinned); err != nil {
+			v.add(field+".pinned", fmt.Sprintf("invalid regex (%v)", err), "a regex matching pinned packages")
+		}
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineDependencyPinningConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	pinningConfig := plumberConfig.GetDependencyPinningConfig()
+	if pinningConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateDependencyPinningConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = pinningConfig.IsEnabled()
+	p.Patterns = pinningConfig.Patterns
+	if len(p.Patterns) == 0 {
+		p.Patterns = DefaultDependencyPinningPatterns
+	}
+
+	// Compile patterns
+	p.rules = []dependencyPinningRule{}
+	if p.Enabled {
+		for _, pattern := range p.Patterns {
+			p.rules = append(p.rules, dependencyPinningRule{
+				name:    pattern.Name,
+				command: regexp.MustCompile(pattern.Command),
+				pinned:  regexp.MustCompile(pattern.Pinned),
+			})
+		}
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":  p.Enabled,
+		"patterns": len(p.Patterns),
+	}).Debug("dependencyPinning control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineDependencyPinningMetrics holds metrics about unpinned dependency installs
+type GitlabPipelineDependencyPinningMetrics struct {
+	TotalJobs                uint `json:"totalJobs"`
+	JobsWithUnpinnedInstalls uint `json:"jobsWithUnpinnedInstalls"`
+	UnpinnedInstalls         uint `json:"unpinnedInstalls"`
+	CiInvalid                uint `json:"ciInvalid"`
+	CiMissing                uint `json:"ciMissing"`
+}
+
+// GitlabPipelineDependencyPinningResult holds the result of the dependency pinning control
+type GitlabPipelineDependencyPinningResult struct {
+	Issues     []GitlabPipelineDependencyPinningIssue `json:"issues"`
+	Metrics    GitlabPipelineDependencyPinningMetrics `json:"metrics"`
+	Compliance float64                                `json:"compliance"`
+	Version    string                                 `json:"version"`
+	CiValid    bool                                   `json:"ciValid"`
+	CiMissing  bool                                   `json:"ciMissing"`
+	Skipped    bool                                   `json:"skipped"`         // True if control was disabled
+	Error      string                                 `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineDependencyPinningIssue represents a dependency installed without a pinned version
+type GitlabPipelineDependencyPinningIssue struct {
+	Job     string `json:"job"`
+	Line    string `json:"line"`
+	Manager string `json:"manager"`
+	Package string `json:"package"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the dependency pinning control
+func (p *GitlabPipelineDependencyPinningConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineDependencyPinningResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineDependencyPinning",
+		"controlVersion": ControlTypeGitlabPipelineDependencyPinningVersion,
+	})
+	l.Info("Start dependency pinning control")
+
+	result := &GitlabPipelineDependencyPinningResult{
+		Issues:     []GitlabPipelineDependencyPinningIssue{},
+		Metrics:    GitlabPipelineDependencyPinningMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineDependencyPinningVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Dependency pinning control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	// Loop over all script lines of all jobs to find unpinned installs
+	for _, job := range jobs {
+		jobHasIssue := false
+		for _, line := range jobScriptLines(pipelineImageData.MergedConf, job.Job) {
+			for _, rule := range p.rules {
+				for _, pkg := range rule.unpinnedPackages(line) {
+					result.Issues = append(result.Issues, GitlabPipelineDependencyPinningIssue{
+						Job:     job.Name,
+						Line:    line,
+						Manager: rule.name,
+						Package: pkg,
+					})
+					result.Metrics.UnpinnedInstalls++
+					jobHasIssue = true
+				}
+			}
+		}
+		if jobHasIssue {
+			result.Metrics.JobsWithUnpinnedInstalls++
+		}
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	// Set metrics
+	result.Metrics.TotalJobs = uint(len(jobs))
+
+	l.WithFields(logrus.Fields{
+		"totalJobs":        result.Metrics.TotalJobs,
+		"unpinnedInstalls": result.Metrics.UnpinnedInstalls,
+		"compliance":       result.Compliance,
+	}).Info("Dependency pinning control completed")
+
+	return result
+}
+
+// unpinnedPackages returns the packages installed by a script line that are
+// not pinned according to the rule. Options (starting with "-") and
+// variables are ignored.
+func (r dependencyPinningRule) unpinnedPackages(line string) []string {
+	packages := []string{}
+
+	for _, loc := range r.command.FindAllStringIndex(line, -1) {
+		for _, field := range commandArgs(line[loc[1]:]) {
+			arg := strings.Trim(field, `"'`)
+			if arg == "" || strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "$") {
+				continue
+			}
+			if !r.pinned.MatchString(arg) {
+				packages = append(packages, arg)
+			}
+		}
+	}
+
+	return packages
+}
+
+// commandArgs returns the arguments of a command, read until the next shell
+// separator. A token ending with ";" (e.g., "requests;") is the last
+// argument, without the ";".
+func commandArgs(args string) []string {
+	fields := []string{}
+	for _, field := range strings.Fields(args) {
+		if isShellSeparator(field) {
+			break
+		}
+		if strings.HasSuffix(field, ";") {
+			fields = append(fields, strings.TrimSuffix(field, ";"))
+			break
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// isShellSeparator reports whether a script token ends the current command
+func isShellSeparator(token string) bool {
+	switch token {
+	case "&&", "||", ";", "|", ">", ">>", "2>", "2>&1", "&":
+		return true
+	}
+	return strings.HasPrefix(token, ">")
+}
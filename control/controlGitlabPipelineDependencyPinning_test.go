@@ -0,0 +1,102 @@
+package control
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+)
+
+// defaultDependencyPinningConf returns the control enabled with the default patterns
+func defaultDependencyPinningConf(t *testing.T) *GitlabPipelineDependencyPinningConf {
+	t.Helper()
+	enabled := true
+	plumberConfig := &configuration.PlumberConfig{
+		Controls: configuration.ControlsConfig{
+			DependencyPinning: &configuration.DependencyPinningControlConfig{Enabled: &enabled},
+		},
+	}
+	conf := &GitlabPipelineDependencyPinningConf{}
+	if err := conf.GetConf(plumberConfig); err != nil {
+		t.Fatalf("unable to load configuration: %v", err)
+	}
+	return conf
+}
+
+func TestDependencyPinningUnpinnedPackages(t *testing.T) {
+	conf := defaultDependencyPinningConf(t)
+
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{`pip install requests==2.31.0`, []string{}},
+		{`pip install requests`, []string{"requests"}},
+		{`pip install requests; echo ok`, []string{"requests"}},
+		{`pip install requests==2.31.0; pip install flask`, []string{"flask"}},
+		{`pip install requests && pip install flask==3.0.0`, []string{"requests"}},
+		{`pip install -r requirements.txt`, []string{}},
+		{`pip install ./local`, []string{}},
+		{`pip install --index-url https://pypi.example.com/simple requests==2.31.0`, []string{}},
+		{`pip install git+https://github.com/org/repo`, []string{"git+https://github.com/org/repo"}},
+		{`pip install git+ssh://git@github.com/org/repo.git`, []string{"git+ssh://git@github.com/org/repo.git"}},
+		{`pip install git+https://github.com/org/repo@v1.2.0`, []string{}},
+		{`pip install git+https://github.com/org/repo.git@4f2a9c1#egg=repo`, []string{}},
+		{`npm install lodash`, []string{"lodash"}},
+		{`npm install lodash@4.17.21 @scope/pkg@1.0.0`, []string{}},
+		{`npm i @scope/pkg;`, []string{"@scope/pkg"}},
+		{`yarn add lodash > /dev/null`, []string{"lodash"}},
+		{`go install golang.org/x/tools/cmd/goimports@latest`, []string{"golang.org/x/tools/cmd/goimports@latest"}},
+		{`go install golang.org/x/tools/cmd/goimports@v0.20.0`, []string{}},
+		{`pip install $PACKAGE`, []string{}},
+		{`echo pip`, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			packages := []string{}
+			for _, rule := range conf.rules {
+				packages = append(packages, rule.unpinnedPackages(tt.line)...)
+			}
+			if !reflect.DeepEqual(packages, tt.expected) {
+				t.Errorf("unpinned packages = %v, want %v", packages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDependencyPinningRun(t *testing.T) {
+	mergedConf, err := gitlab.ParseGitlabCI([]byte(`
+before_script:
+  - pip install requests; echo ok
+build:
+  script:
+    - npm install lodash@4.17.21
+test:
+  before_script:
+    - pip install pytest==8.0.0
+  script:
+    - go install golang.org/x/lint/golint@latest
+`))
+	if err != nil {
+		t.Fatalf("unable to parse CI configuration: %v", err)
+	}
+
+	result := defaultDependencyPinningConf(t).Run(&collector.GitlabPipelineImageData{
+		MergedConf: mergedConf,
+		CiValid:    true,
+	})
+
+	expected := []GitlabPipelineDependencyPinningIssue{
+		{Job: "build", Line: "pip install requests; echo ok", Manager: "pip", Package: "requests"},
+		{Job: "test", Line: "go install golang.org/x/lint/golint@latest", Manager: "go", Package: "golang.org/x/lint/golint@latest"},
+	}
+	if !reflect.DeepEqual(result.Issues, expected) {
+		t.Errorf("issues = %+v, want %+v", result.Issues, expected)
+	}
+	if result.Compliance != 0 || result.Metrics.TotalJobs != 2 || result.Metrics.JobsWithUnpinnedInstalls != 2 {
+		t.Errorf("compliance = %v, metrics = %+v", result.Compliance, result.Metrics)
+	}
+}
@@ -0,0 +1,198 @@
+package control
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineDeprecatedJwtUsageVersion = "0.1.0"
+
+// deprecatedJwtPattern matches the deprecated CI_JOB_JWT variables, replaced
+// by id_tokens
+var deprecatedJwtPattern = regexp.MustCompile(`\bCI_JOB_JWT(_V1|_V2)?\b`)
+
+// Deprecated JWT usage issue sources
+const (
+	deprecatedJwtSourceScript   = "script"
+	deprecatedJwtSourceVariable = "variable"
+)
+
+// GitlabPipelineDeprecatedJwtUsageConf holds the configuration for deprecated CI_JOB_JWT detection
+type GitlabPipelineDeprecatedJwtUsageConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineDeprecatedJwtUsageConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	jwtConfig := plumberConfig.GetDeprecatedJwtUsageConfig()
+	if jwtConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = jwtConfig.IsEnabled()
+
+	l.WithFields(logrus.Fields{
+		"enabled": p.Enabled,
+	}).Debug("deprecatedJwtUsage control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineDeprecatedJwtUsageMetrics holds metrics about CI_JOB_JWT usage
+type GitlabPipelineDeprecatedJwtUsageMetrics struct {
+	Jobs           uint `json:"jobs"`
+	DeprecatedJobs uint `json:"deprecatedJobs"` // Jobs referencing CI_JOB_JWT, directly or through global variables
+	References     uint `json:"references"`
+	CiInvalid      uint `json:"ciInvalid"`
+	CiMissing      uint `json:"ciMissing"`
+}
+
+// GitlabPipelineDeprecatedJwtUsageResult holds the result of the deprecated JWT control
+type GitlabPipelineDeprecatedJwtUsageResult struct {
+	Issues     []GitlabPipelineDeprecatedJwtUsageIssue `json:"issues"`
+	Metrics    GitlabPipelineDeprecatedJwtUsageMetrics `json:"metrics"`
+	Compliance float64                                 `json:"compliance"`
+	Version    string                                  `json:"version"`
+	CiValid    bool                                    `json:"ciValid"`
+	CiMissing  bool                                    `json:"ciMissing"`
+	Skipped    bool                                    `json:"skipped"`         // True if control was disabled
+	Error      string                                  `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineDeprecatedJwtUsageIssue represents a reference to a deprecated CI_JOB_JWT variable
+type GitlabPipelineDeprecatedJwtUsageIssue struct {
+	Job      string `json:"job,omitempty"` // Empty for global variables
+	Source   string `json:"source"`        // "script" or "variable"
+	Variable string `json:"variable"`      // CI_JOB_JWT, CI_JOB_JWT_V1 or CI_JOB_JWT_V2
+	Line     string `json:"line"`          // Script line, or NAME=value for variables
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the deprecated JWT control. Global variables referencing
+// CI_JOB_JWT are available to all jobs, which then all count as deprecated.
+func (p *GitlabPipelineDeprecatedJwtUsageConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineDeprecatedJwtUsageResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineDeprecatedJwtUsage",
+		"controlVersion": ControlTypeGitlabPipelineDeprecatedJwtUsageVersion,
+	})
+	l.Info("Start deprecated JWT control")
+
+	result := &GitlabPipelineDeprecatedJwtUsageResult{
+		Issues:     []GitlabPipelineDeprecatedJwtUsageIssue{},
+		Metrics:    GitlabPipelineDeprecatedJwtUsageMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineDeprecatedJwtUsageVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Deprecated JWT control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	globalReferences := checkDeprecatedJwtVariables(result, "", pipelineImageData.MergedConf.GlobalVariables)
+
+	for _, job := range jobs {
+		result.Metrics.Jobs++
+
+		references := 0
+		for _, line := range jobScriptLines(pipelineImageData.MergedConf, job.Job) {
+			references += checkDeprecatedJwtLine(result, job.Name, deprecatedJwtSourceScript, line)
+		}
+		references += checkDeprecatedJwtVariables(result, job.Name, job.Job.Variables)
+
+		if references > 0 || globalReferences > 0 {
+			result.Metrics.DeprecatedJobs++
+		}
+	}
+
+	// Compliance is the share of jobs not referencing CI_JOB_JWT, to track
+	// the migration to id_tokens over time
+	if result.Metrics.Jobs > 0 {
+		result.Compliance = float64(result.Metrics.Jobs-result.Metrics.DeprecatedJobs) / float64(result.Metrics.Jobs) * 100
+	}
+
+	l.WithFields(logrus.Fields{
+		"jobs":           result.Metrics.Jobs,
+		"deprecatedJobs": result.Metrics.DeprecatedJobs,
+		"compliance":     result.Compliance,
+	}).Info("Deprecated JWT control completed")
+
+	return result
+}
+
+// checkDeprecatedJwtVariables checks variables as NAME=value lines (global
+// variables if job is empty) and returns the number of references found
+func checkDeprecatedJwtVariables(result *GitlabPipelineDeprecatedJwtUsageResult, job string, variables map[string]interface{}) int {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	references := 0
+	for _, name := range names {
+		value, err := gitlab.GetVariableValue(variables[name])
+		if err != nil {
+			continue
+		}
+		references += checkDeprecatedJwtLine(result, job, deprecatedJwtSourceVariable, name+"="+value)
+	}
+	return references
+}
+
+// checkDeprecatedJwtLine adds an issue for each CI_JOB_JWT variable
+// referenced in a line and returns the number of references found
+func checkDeprecatedJwtLine(result *GitlabPipelineDeprecatedJwtUsageResult, job, source, line string) int {
+	matches := deprecatedJwtPattern.FindAllString(line, -1)
+	for _, variable := range matches {
+		result.Issues = append(result.Issues, GitlabPipelineDeprecatedJwtUsageIssue{
+			Job:      job,
+			Source:   source,
+			Variable: variable,
+			Line:     line,
+		})
+		result.Metrics.References++
+	}
+	return len(matches)
+}
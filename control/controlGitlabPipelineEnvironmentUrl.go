@@ -0,0 +1,214 @@
+package control
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineEnvironmentUrlVersion = "0.1.0"
+
+// GitlabPipelineEnvironmentUrlConf holds the configuration for environment URL allowlist detection
+type GitlabPipelineEnvironmentUrlConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// AllowedDomains is a list of domains environment URLs may point to (supports wildcards)
+	AllowedDomains []string `json:"allowedDomains"`
+}
+
+// validateEnvironmentUrlConfig validates the environmentUrlAllowlist configuration
+func validateEnvironmentUrlConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	envConfig := plumberConfig.GetEnvironmentUrlAllowlistConfig()
+	if !envConfig.IsEnabled() {
+		return
+	}
+
+	if len(envConfig.AllowedDomains) == 0 {
+		v.add("environmentUrlAllowlist.allowedDomains", "field is required when the control is enabled", "a list of allowed domains")
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineEnvironmentUrlConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	envConfig := plumberConfig.GetEnvironmentUrlAllowlistConfig()
+	if envConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateEnvironmentUrlConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = envConfig.IsEnabled()
+	p.AllowedDomains = []string{}
+	for _, domain := range envConfig.AllowedDomains {
+		p.AllowedDomains = append(p.AllowedDomains, strings.ToLower(domain))
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":        p.Enabled,
+		"allowedDomains": p.AllowedDomains,
+	}).Debug("environmentUrlAllowlist control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineEnvironmentUrlMetrics holds metrics about environment URLs
+type GitlabPipelineEnvironmentUrlMetrics struct {
+	Total        uint `json:"total"`
+	Authorized   uint `json:"authorized"`
+	Unauthorized uint `json:"unauthorized"`
+	CiInvalid    uint `json:"ciInvalid"`
+	CiMissing    uint `json:"ciMissing"`
+}
+
+// GitlabPipelineEnvironmentUrlResult holds the result of the environment URL allowlist control
+type GitlabPipelineEnvironmentUrlResult struct {
+	Issues     []GitlabPipelineEnvironmentUrlIssue `json:"issues"`
+	Metrics    GitlabPipelineEnvironmentUrlMetrics `json:"metrics"`
+	Compliance float64                             `json:"compliance"`
+	Version    string                              `json:"version"`
+	CiValid    bool                                `json:"ciValid"`
+	CiMissing  bool                                `json:"ciMissing"`
+	Skipped    bool                                `json:"skipped"`         // True if control was disabled
+	Error      string                              `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineEnvironmentUrlIssue represents an environment URL pointing outside approved domains
+type GitlabPipelineEnvironmentUrlIssue struct {
+	Job         string `json:"job"`
+	Environment string `json:"environment"`
+	URL         string `json:"url"`
+	Host        string `json:"host"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the environment URL allowlist control
+func (p *GitlabPipelineEnvironmentUrlConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineEnvironmentUrlResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineEnvironmentUrl",
+		"controlVersion": ControlTypeGitlabPipelineEnvironmentUrlVersion,
+	})
+	l.Info("Start environment URL allowlist control")
+
+	result := &GitlabPipelineEnvironmentUrlResult{
+		Issues:     []GitlabPipelineEnvironmentUrlIssue{},
+		Metrics:    GitlabPipelineEnvironmentUrlMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineEnvironmentUrlVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Environment URL allowlist control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	// Loop over all jobs to check their environment URL
+	for _, job := range jobs {
+		name, rawURL := parseJobEnvironment(job.Job.Environment)
+		if rawURL == "" {
+			continue
+		}
+
+		jobVars, err := gitlab.ParseJobVariables(job.Job)
+		if err != nil {
+			l.WithError(err).WithField("job", job.Name).Warn("Unable to parse job variables, environment URL is checked unresolved")
+		}
+		resolvedURL := gitlab.ReplaceVariable(rawURL, pipelineImageData.ProjectVars, pipelineImageData.GroupVars, pipelineImageData.InstanceVars, jobVars, pipelineImageData.GlobalVars, nil)
+		host := environmentURLHost(resolvedURL)
+
+		result.Metrics.Total++
+		if host != "" && gitlab.CheckItemMatchToPatterns(host, p.AllowedDomains) {
+			result.Metrics.Authorized++
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabPipelineEnvironmentUrlIssue{
+			Job:         job.Name,
+			Environment: name,
+			URL:         resolvedURL,
+			Host:        host,
+		})
+		result.Metrics.Unauthorized++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"totalEnvironmentUrls": result.Metrics.Total,
+		"unauthorized":         result.Metrics.Unauthorized,
+		"compliance":           result.Compliance,
+	}).Info("Environment URL allowlist control completed")
+
+	return result
+}
+
+// parseJobEnvironment returns the name and URL of a job environment, which can
+// be defined as a string (name only) or as a map
+func parseJobEnvironment(environment interface{}) (string, string) {
+	switch env := environment.(type) {
+	case string:
+		return env, ""
+	case map[interface{}]interface{}:
+		name, _ := env["name"].(string)
+		envURL, _ := env["url"].(string)
+		return name, envURL
+	}
+	return "", ""
+}
+
+// environmentURLHost returns the lowercased host of an environment URL.
+// URLs without a scheme are parsed as https URLs.
+func environmentURLHost(rawURL string) string {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsedURL.Hostname())
+}
@@ -0,0 +1,187 @@
+package control
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineHardcodedJobsVersion = "0.1.0"
+
+// DefaultMaxHardcodedJobs is the maximum number of hardcoded jobs when
+// maxAllowed is not set: every job must come from an include
+const DefaultMaxHardcodedJobs = 0
+
+// GitlabPipelineHardcodedJobsConf holds the configuration for the hardcoded jobs detection
+type GitlabPipelineHardcodedJobsConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// MaxAllowed is the maximum number of jobs defined in the project CI configuration
+	MaxAllowed int `json:"maxAllowed"`
+}
+
+// validateHardcodedJobsConfig validates the hardcodedJobs configuration
+func validateHardcodedJobsConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	hardcodedConfig := plumberConfig.GetHardcodedJobsConfig()
+	if !hardcodedConfig.IsEnabled() {
+		return
+	}
+
+	if hardcodedConfig.MaxAllowed != nil && *hardcodedConfig.MaxAllowed < 0 {
+		v.add("hardcodedJobs.maxAllowed", fmt.Sprintf("invalid number of jobs %d", *hardcodedConfig.MaxAllowed), "a number greater than or equal to 0")
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineHardcodedJobsConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	hardcodedConfig := plumberConfig.GetHardcodedJobsConfig()
+	if hardcodedConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateHardcodedJobsConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = hardcodedConfig.IsEnabled()
+	p.MaxAllowed = DefaultMaxHardcodedJobs
+	if hardcodedConfig.MaxAllowed != nil {
+		p.MaxAllowed = *hardcodedConfig.MaxAllowed
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":    p.Enabled,
+		"maxAllowed": p.MaxAllowed,
+	}).Debug("hardcodedJobs control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineHardcodedJobsMetrics holds metrics about the hardcoded jobs of the pipeline
+type GitlabPipelineHardcodedJobsMetrics struct {
+	Jobs       uint `json:"jobs"`
+	Hardcoded  uint `json:"hardcoded"`
+	MaxAllowed uint `json:"maxAllowed"`
+	CiInvalid  uint `json:"ciInvalid"`
+	CiMissing  uint `json:"ciMissing"`
+}
+
+// GitlabPipelineHardcodedJobsResult holds the result of the hardcoded jobs control
+type GitlabPipelineHardcodedJobsResult struct {
+	Issues     []GitlabPipelineHardcodedJobsIssue `json:"issues"`
+	Metrics    GitlabPipelineHardcodedJobsMetrics `json:"metrics"`
+	Compliance float64                            `json:"compliance"`
+	Version    string                             `json:"version"`
+	CiValid    bool                               `json:"ciValid"`
+	CiMissing  bool                               `json:"ciMissing"`
+	Skipped    bool                               `json:"skipped"`         // True if control was disabled
+	Error      string                             `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineHardcodedJobsIssue represents a job hardcoded in the project
+// CI configuration while the pipeline has more than the maximum
+type GitlabPipelineHardcodedJobsIssue struct {
+	Job        string `json:"job"`
+	Hardcoded  int    `json:"hardcoded"`
+	MaxAllowed int    `json:"maxAllowed"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the hardcoded jobs control. Jobs are hardcoded when they are
+// only defined in the project CI configuration: jobs overriding or extending
+// an included job are not, nor are hidden jobs which never run. When there
+// are more than the maximum, each hardcoded job is an issue.
+func (p *GitlabPipelineHardcodedJobsConf) Run(pipelineOriginData *collector.GitlabPipelineOriginData) *GitlabPipelineHardcodedJobsResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineHardcodedJobs",
+		"controlVersion": ControlTypeGitlabPipelineHardcodedJobsVersion,
+	})
+	l.Info("Start hardcoded jobs control")
+
+	result := &GitlabPipelineHardcodedJobsResult{
+		Issues:     []GitlabPipelineHardcodedJobsIssue{},
+		Metrics:    GitlabPipelineHardcodedJobsMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineHardcodedJobsVersion,
+		CiValid:    pipelineOriginData.CiValid,
+		CiMissing:  pipelineOriginData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Hardcoded jobs control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	result.Metrics.MaxAllowed = uint(p.MaxAllowed)
+
+	// If CI is invalid or missing, return early
+	if !pipelineOriginData.CiValid || pipelineOriginData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineOriginData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineOriginData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	for name := range pipelineOriginData.JobMap {
+		if !strings.HasPrefix(name, ".") {
+			result.Metrics.Jobs++
+		}
+	}
+
+	// The hardcoded origin only lists jobs that don't override an included one
+	hardcodedJobs := []string{}
+	for _, origin := range pipelineOriginData.Origins {
+		if origin.OriginType != collector.OriginTypeHardcoded {
+			continue
+		}
+		for _, job := range origin.Jobs {
+			if strings.HasPrefix(job.Name, ".") {
+				continue
+			}
+			hardcodedJobs = append(hardcodedJobs, job.Name)
+		}
+	}
+	sort.Strings(hardcodedJobs)
+	result.Metrics.Hardcoded = uint(len(hardcodedJobs))
+
+	if len(hardcodedJobs) > p.MaxAllowed {
+		for _, job := range hardcodedJobs {
+			result.Issues = append(result.Issues, GitlabPipelineHardcodedJobsIssue{
+				Job:        job,
+				Hardcoded:  len(hardcodedJobs),
+				MaxAllowed: p.MaxAllowed,
+			})
+		}
+		result.Compliance = 0.0
+	}
+
+	l.WithFields(logrus.Fields{
+		"jobs":       result.Metrics.Jobs,
+		"hardcoded":  result.Metrics.Hardcoded,
+		"maxAllowed": result.Metrics.MaxAllowed,
+		"compliance": result.Compliance,
+	}).Info("Hardcoded jobs control completed")
+
+	return result
+}
@@ -0,0 +1,228 @@
+package control
+
+import (
+	"fmt"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineJobTimeoutPolicyVersion = "0.1.0"
+
+// DefaultMaxTimeoutMinutes is the maximum job timeout when maxTimeoutMinutes is not set
+const DefaultMaxTimeoutMinutes = 180
+
+// Job timeout issue types
+const (
+	jobTimeoutIssueTooLong = "tooLong"
+	jobTimeoutIssueMissing = "missing"
+	jobTimeoutIssueInvalid = "invalid"
+)
+
+// GitlabPipelineJobTimeoutPolicyConf holds the configuration for job timeouts detection
+type GitlabPipelineJobTimeoutPolicyConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// MaxTimeoutMinutes is the maximum timeout of a job, in minutes
+	MaxTimeoutMinutes int `json:"maxTimeoutMinutes"`
+
+	// RequireTimeout flags jobs without timeout (directly or from default)
+	RequireTimeout bool `json:"requireTimeout"`
+}
+
+// validateJobTimeoutPolicyConfig validates the jobTimeoutPolicy configuration
+func validateJobTimeoutPolicyConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	timeoutConfig := plumberConfig.GetJobTimeoutPolicyConfig()
+	if !timeoutConfig.IsEnabled() {
+		return
+	}
+
+	if timeoutConfig.MaxTimeoutMinutes != nil && *timeoutConfig.MaxTimeoutMinutes < 1 {
+		v.add("jobTimeoutPolicy.maxTimeoutMinutes", fmt.Sprintf("invalid number of minutes %d", *timeoutConfig.MaxTimeoutMinutes), "a number greater than or equal to 1")
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineJobTimeoutPolicyConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	timeoutConfig := plumberConfig.GetJobTimeoutPolicyConfig()
+	if timeoutConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateJobTimeoutPolicyConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = timeoutConfig.IsEnabled()
+	p.MaxTimeoutMinutes = DefaultMaxTimeoutMinutes
+	if timeoutConfig.MaxTimeoutMinutes != nil {
+		p.MaxTimeoutMinutes = *timeoutConfig.MaxTimeoutMinutes
+	}
+	p.RequireTimeout = timeoutConfig.RequireTimeout != nil && *timeoutConfig.RequireTimeout
+
+	l.WithFields(logrus.Fields{
+		"enabled":           p.Enabled,
+		"maxTimeoutMinutes": p.MaxTimeoutMinutes,
+		"requireTimeout":    p.RequireTimeout,
+	}).Debug("jobTimeoutPolicy control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineJobTimeoutPolicyMetrics holds metrics about job timeouts
+type GitlabPipelineJobTimeoutPolicyMetrics struct {
+	TotalJobs         uint `json:"totalJobs"`
+	JobsTimeout       uint `json:"jobsTimeout"` // Jobs with a timeout, directly or from default
+	TooLong           uint `json:"tooLong"`
+	Missing           uint `json:"missing"`
+	Invalid           uint `json:"invalid"`
+	MaxTimeoutMinutes uint `json:"maxTimeoutMinutes"`
+	CiInvalid         uint `json:"ciInvalid"`
+	CiMissing         uint `json:"ciMissing"`
+}
+
+// GitlabPipelineJobTimeoutPolicyResult holds the result of the job timeout policy control
+type GitlabPipelineJobTimeoutPolicyResult struct {
+	Issues     []GitlabPipelineJobTimeoutPolicyIssue `json:"issues"`
+	Metrics    GitlabPipelineJobTimeoutPolicyMetrics `json:"metrics"`
+	Compliance float64                               `json:"compliance"`
+	Version    string                                `json:"version"`
+	CiValid    bool                                  `json:"ciValid"`
+	CiMissing  bool                                  `json:"ciMissing"`
+	Skipped    bool                                  `json:"skipped"`         // True if control was disabled
+	Error      string                                `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineJobTimeoutPolicyIssue represents a job with a missing, invalid or too long timeout
+type GitlabPipelineJobTimeoutPolicyIssue struct {
+	Type           string `json:"type"` // "tooLong", "missing" or "invalid"
+	Job            string `json:"job"`
+	Timeout        string `json:"timeout,omitempty"`        // Timeout as written in the configuration
+	TimeoutMinutes int    `json:"timeoutMinutes,omitempty"` // Parsed timeout, for "tooLong" issues
+	FromDefault    bool   `json:"fromDefault"`              // True if the timeout comes from default:timeout
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the job timeout policy control. Jobs without timeout inherit
+// default:timeout.
+func (p *GitlabPipelineJobTimeoutPolicyConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineJobTimeoutPolicyResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineJobTimeoutPolicy",
+		"controlVersion": ControlTypeGitlabPipelineJobTimeoutPolicyVersion,
+	})
+	l.Info("Start job timeout policy control")
+
+	result := &GitlabPipelineJobTimeoutPolicyResult{
+		Issues:     []GitlabPipelineJobTimeoutPolicyIssue{},
+		Metrics:    GitlabPipelineJobTimeoutPolicyMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineJobTimeoutPolicyVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Job timeout policy control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	result.Metrics.MaxTimeoutMinutes = uint(p.MaxTimeoutMinutes)
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	for _, job := range jobs {
+		result.Metrics.TotalJobs++
+
+		timeoutInterface := job.Job.Timeout
+		fromDefault := false
+		if timeoutInterface == nil {
+			timeoutInterface = pipelineImageData.MergedConf.Default.Timeout
+			fromDefault = true
+		}
+
+		issue := GitlabPipelineJobTimeoutPolicyIssue{
+			Job:         job.Name,
+			FromDefault: fromDefault,
+		}
+		if timeoutInterface != nil {
+			issue.Timeout = fmt.Sprintf("%v", timeoutInterface)
+		}
+
+		timeout, hasTimeout, err := gitlab.GetTimeout(timeoutInterface)
+		if !hasTimeout {
+			if p.RequireTimeout {
+				issue.Type = jobTimeoutIssueMissing
+				result.Issues = append(result.Issues, issue)
+				result.Metrics.Missing++
+			}
+			continue
+		}
+		result.Metrics.JobsTimeout++
+
+		if err != nil {
+			l.WithError(err).WithField("job", job.Name).Warn("Unable to parse the job timeout")
+			issue.Type = jobTimeoutIssueInvalid
+			result.Issues = append(result.Issues, issue)
+			result.Metrics.Invalid++
+			continue
+		}
+
+		timeoutMinutes := int(timeout.Minutes())
+		if timeout.Minutes() <= float64(p.MaxTimeoutMinutes) {
+			continue
+		}
+
+		issue.Type = jobTimeoutIssueTooLong
+		issue.TimeoutMinutes = timeoutMinutes
+		result.Issues = append(result.Issues, issue)
+		result.Metrics.TooLong++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"jobsTimeout": result.Metrics.JobsTimeout,
+		"tooLong":     result.Metrics.TooLong,
+		"missing":     result.Metrics.Missing,
+		"compliance":  result.Compliance,
+	}).Info("Job timeout policy control completed")
+
+	return result
+}
@@ -0,0 +1,197 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineLocalIncludeGlobsVersion = "0.1.0"
+
+// GitlabPipelineLocalIncludeGlobsConf holds the configuration for local include globs detection
+type GitlabPipelineLocalIncludeGlobsConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// AllowedPatterns are glob patterns allowed in local includes (exact match)
+	AllowedPatterns []string `json:"allowedPatterns"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineLocalIncludeGlobsConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	globsConfig := plumberConfig.GetLocalIncludeGlobsConfig()
+	if globsConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = globsConfig.IsEnabled()
+	p.AllowedPatterns = globsConfig.AllowedPatterns
+	if p.AllowedPatterns == nil {
+		p.AllowedPatterns = []string{}
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":         p.Enabled,
+		"allowedPatterns": p.AllowedPatterns,
+	}).Debug("localIncludeGlobs control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineLocalIncludeGlobsMetrics holds metrics about local includes
+type GitlabPipelineLocalIncludeGlobsMetrics struct {
+	LocalIncludes uint `json:"localIncludes"`
+	Globs         uint `json:"globs"`
+	AllowedGlobs  uint `json:"allowedGlobs"`
+	CiInvalid     uint `json:"ciInvalid"`
+	CiMissing     uint `json:"ciMissing"`
+}
+
+// GitlabPipelineLocalIncludeGlobsResult holds the result of the local include globs control
+type GitlabPipelineLocalIncludeGlobsResult struct {
+	Issues     []GitlabPipelineLocalIncludeGlobsIssue `json:"issues"`
+	Metrics    GitlabPipelineLocalIncludeGlobsMetrics `json:"metrics"`
+	Compliance float64                                `json:"compliance"`
+	Version    string                                 `json:"version"`
+	CiValid    bool                                   `json:"ciValid"`
+	CiMissing  bool                                   `json:"ciMissing"`
+	Skipped    bool                                   `json:"skipped"`         // True if control was disabled
+	Error      string                                 `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineLocalIncludeGlobsIssue represents a local include using a glob pattern
+type GitlabPipelineLocalIncludeGlobsIssue struct {
+	Pattern string `json:"pattern"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the local include globs control. GitLab may expand globs
+// into one include per matched file in the merged configuration, so local
+// includes of the project CI configuration are checked as well as local
+// origins.
+func (p *GitlabPipelineLocalIncludeGlobsConf) Run(pipelineOriginData *collector.GitlabPipelineOriginData) *GitlabPipelineLocalIncludeGlobsResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineLocalIncludeGlobs",
+		"controlVersion": ControlTypeGitlabPipelineLocalIncludeGlobsVersion,
+	})
+	l.Info("Start local include globs control")
+
+	result := &GitlabPipelineLocalIncludeGlobsResult{
+		Issues:     []GitlabPipelineLocalIncludeGlobsIssue{},
+		Metrics:    GitlabPipelineLocalIncludeGlobsMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineLocalIncludeGlobsVersion,
+		CiValid:    pipelineOriginData.CiValid,
+		CiMissing:  pipelineOriginData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Local include globs control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineOriginData.CiValid || pipelineOriginData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineOriginData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineOriginData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	locations := []string{}
+	for _, origin := range pipelineOriginData.Origins {
+		if origin.OriginType == collector.OriginTypeLocal {
+			locations = append(locations, origin.GitlabIncludeOrigin.Location)
+		}
+	}
+	if pipelineOriginData.Conf != nil {
+		locations = append(locations, localIncludeLocations(pipelineOriginData.Conf.Include)...)
+	}
+
+	seen := map[string]bool{}
+	for _, location := range locations {
+		// The same include can be found in both, with or without a leading slash
+		key := strings.TrimPrefix(location, "/")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result.Metrics.LocalIncludes++
+
+		if !strings.Contains(location, "*") {
+			continue
+		}
+		result.Metrics.Globs++
+
+		if p.isAllowed(location) {
+			result.Metrics.AllowedGlobs++
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabPipelineLocalIncludeGlobsIssue{
+			Pattern: location,
+		})
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"localIncludes": result.Metrics.LocalIncludes,
+		"globs":         result.Metrics.Globs,
+		"compliance":    result.Compliance,
+	}).Info("Local include globs control completed")
+
+	return result
+}
+
+// isAllowed reports whether a glob pattern is in the allowed patterns
+func (p *GitlabPipelineLocalIncludeGlobsConf) isAllowed(pattern string) bool {
+	for _, allowed := range p.AllowedPatterns {
+		if strings.TrimPrefix(allowed, "/") == strings.TrimPrefix(pattern, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// localIncludeLocations returns the locations of local includes of a CI
+// configuration. Include strings that are not URLs are local files.
+func localIncludeLocations(includes []interface{}) []string {
+	locations := []string{}
+	for _, include := range includes {
+		switch entry := include.(type) {
+		case string:
+			if !strings.Contains(entry, "://") {
+				locations = append(locations, entry)
+			}
+		case map[interface{}]interface{}:
+			if local, ok := entry["local"].(string); ok {
+				locations = append(locations, local)
+			}
+		}
+	}
+	return locations
+}
@@ -0,0 +1,177 @@
+package control
+
+import (
+	"fmt"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineMaxIncludesVersion = "0.1.0"
+
+// DefaultMaxIncludes is the maximum number of includes when maxCount is not set
+const DefaultMaxIncludes = 20
+
+// GitlabPipelineMaxIncludesConf holds the configuration for the maximum includes detection
+type GitlabPipelineMaxIncludesConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// MaxCount is the maximum number of includes of the pipeline
+	MaxCount int `json:"maxCount"`
+}
+
+// validateMaxIncludesConfig validates the maxIncludes configuration
+func validateMaxIncludesConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	includesConfig := plumberConfig.GetMaxIncludesConfig()
+	if !includesConfig.IsEnabled() {
+		return
+	}
+
+	if includesConfig.MaxCount != nil && *includesConfig.MaxCount < 0 {
+		v.add("maxIncludes.maxCount", fmt.Sprintf("invalid number of includes %d", *includesConfig.MaxCount), "a number greater than or equal to 0")
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineMaxIncludesConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	includesConfig := plumberConfig.GetMaxIncludesConfig()
+	if includesConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateMaxIncludesConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = includesConfig.IsEnabled()
+	p.MaxCount = DefaultMaxIncludes
+	if includesConfig.MaxCount != nil {
+		p.MaxCount = *includesConfig.MaxCount
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":  p.Enabled,
+		"maxCount": p.MaxCount,
+	}).Debug("maxIncludes control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineMaxIncludesMetrics holds metrics about the includes of the pipeline
+type GitlabPipelineMaxIncludesMetrics struct {
+	Includes  uint `json:"includes"`
+	MaxCount  uint `json:"maxCount"`
+	CiInvalid uint `json:"ciInvalid"`
+	CiMissing uint `json:"ciMissing"`
+}
+
+// GitlabPipelineMaxIncludesResult holds the result of the maximum includes control
+type GitlabPipelineMaxIncludesResult struct {
+	Issues     []GitlabPipelineMaxIncludesIssue `json:"issues"`
+	Metrics    GitlabPipelineMaxIncludesMetrics `json:"metrics"`
+	Compliance float64                          `json:"compliance"`
+	Version    string                           `json:"version"`
+	CiValid    bool                             `json:"ciValid"`
+	CiMissing  bool                             `json:"ciMissing"`
+	Skipped    bool                             `json:"skipped"`         // True if control was disabled
+	Error      string                           `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineMaxIncludesIssue represents a pipeline with too many includes
+type GitlabPipelineMaxIncludesIssue struct {
+	Count    int                        `json:"count"`
+	MaxCount int                        `json:"maxCount"`
+	Includes []GitlabPipelineIncludeRef `json:"includes"`
+}
+
+// GitlabPipelineIncludeRef is an include of the pipeline
+type GitlabPipelineIncludeRef struct {
+	Type     string `json:"type"`
+	Location string `json:"location"`
+	Version  string `json:"version,omitempty"`
+	Nested   bool   `json:"nested"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the maximum includes control. Every include is counted,
+// nested ones too; jobs defined in the project configuration are not.
+func (p *GitlabPipelineMaxIncludesConf) Run(pipelineOriginData *collector.GitlabPipelineOriginData) *GitlabPipelineMaxIncludesResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineMaxIncludes",
+		"controlVersion": ControlTypeGitlabPipelineMaxIncludesVersion,
+	})
+	l.Info("Start max includes control")
+
+	result := &GitlabPipelineMaxIncludesResult{
+		Issues:     []GitlabPipelineMaxIncludesIssue{},
+		Metrics:    GitlabPipelineMaxIncludesMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineMaxIncludesVersion,
+		CiValid:    pipelineOriginData.CiValid,
+		CiMissing:  pipelineOriginData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Max includes control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	result.Metrics.MaxCount = uint(p.MaxCount)
+
+	// If CI is invalid or missing, return early
+	if !pipelineOriginData.CiValid || pipelineOriginData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineOriginData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineOriginData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	includes := []GitlabPipelineIncludeRef{}
+	for _, origin := range pipelineOriginData.Origins {
+		if origin.OriginType == collector.OriginTypeHardcoded {
+			continue
+		}
+		includes = append(includes, GitlabPipelineIncludeRef{
+			Type:     origin.OriginType,
+			Location: origin.GitlabIncludeOrigin.Location,
+			Version:  origin.Version,
+			Nested:   origin.Nested,
+		})
+	}
+	result.Metrics.Includes = uint(len(includes))
+
+	if len(includes) > p.MaxCount {
+		result.Issues = append(result.Issues, GitlabPipelineMaxIncludesIssue{
+			Count:    len(includes),
+			MaxCount: p.MaxCount,
+			Includes: includes,
+		})
+		result.Compliance = 0.0
+	}
+
+	l.WithFields(logrus.Fields{
+		"includes":   result.Metrics.Includes,
+		"maxCount":   result.Metrics.MaxCount,
+		"compliance": result.Compliance,
+	}).Info("Max includes control completed")
+
+	return result
+}
@@ -0,0 +1,281 @@
+package control
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineNoInsecureTransportVersion = "0.1.0"
+
+// DefaultInsecureTransportPatterns are the patterns disabling TLS verification
+// used when forbiddenPatterns is not set. Variables are matched as NAME=value.
+var DefaultInsecureTransportPatterns = []string{
+	`\bcurl\b[^|;&]*\s(-[a-zA-Z]*k[a-zA-Z]*|--insecure)(\s|$)`,
+	`\bwget\b[^|;&]*\s--no-check-certificate\b`,
+	`(?i)\bhttp\.sslVerify[= ]+["']?false\b`,
+	`\bGIT_SSL_NO_VERIFY=["']?(1|true)\b`,
+	`\bPYTHONHTTPSVERIFY=["']?0\b`,
+	`\bNODE_TLS_REJECT_UNAUTHORIZED=["']?0\b`,
+	`\bstrict-ssl[= ]+["']?false\b`,
+	`--trusted-host\b`,
+	`--insecure-skip-tls-verify\b`,
+	`--tls-verify[= ]false\b`,
+}
+
+// Insecure transport issue sources
+const (
+	insecureTransportSourceScript   = "script"
+	insecureTransportSourceVariable = "variable"
+)
+
+// GitlabPipelineNoInsecureTransportConf holds the configuration for insecure transport detection
+type GitlabPipelineNoInsecureTransportConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// ForbiddenPatterns are regexes matching a disabled TLS verification
+	ForbiddenPatterns []string `json:"forbiddenPatterns"`
+
+	// AllowedJobs are job names allowed to disable TLS verification (supports wildcards)
+	AllowedJobs []string `json:"allowedJobs"`
+
+	// AllowedPatterns are regexes of allowed lines, e.g., calls to an internal CA host
+	AllowedPatterns []string `json:"allowedPatterns"`
+
+	forbidden []*regexp.Regexp
+	allowed   []*regexp.Regexp
+}
+
+// validateNoInsecureTransportConfig validates the noInsecureTransport configuration
+func validateNoInsecureTransportConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	transportConfig := plumberConfig.GetNoInsecureTransportConfig()
+	if !transportConfig.IsEnabled() {
+		return
+	}
+
+	for index, pattern := range transportConfig.ForbiddenPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			v.add(fmt.Sprintf("noInsecureTransport.forbiddenPatterns[%d]", index), fmt.Sprintf("invalid regex (%v)", err), "a regex matching a disabled TLS verification")
+		}
+	}
+	for index, pattern := range transportConfig.AllowedPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			v.add(fmt.Sprintf("noInsecureTransport.allowedPatterns[%d]", index), fmt.Sprintf("invalid regex (%v)", err), "a regex matching allowed lines")
+		}
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineNoInsecureTransportConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	transportConfig := plumberConfig.GetNoInsecureTransportConfig()
+	if transportConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateNoInsecureTransportConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = transportConfig.IsEnabled()
+	p.ForbiddenPatterns = transportConfig.ForbiddenPatterns
+	if len(p.ForbiddenPatterns) == 0 {
+		p.ForbiddenPatterns = DefaultInsecureTransportPatterns
+	}
+	p.AllowedJobs = transportConfig.AllowedJobs
+	if p.AllowedJobs == nil {
+		p.AllowedJobs = []string{}
+	}
+	p.AllowedPatterns = transportConfig.AllowedPatterns
+	if p.AllowedPatterns == nil {
+		p.AllowedPatterns = []string{}
+	}
+
+	// Compile patterns
+	p.forbidden = []*regexp.Regexp{}
+	p.allowed = []*regexp.Regexp{}
+	if p.Enabled {
+		for _, pattern := range p.ForbiddenPatterns {
+			p.forbidden = append(p.forbidden, regexp.MustCompile(pattern))
+		}
+		for _, pattern := range p.AllowedPatterns {
+			p.allowed = append(p.allowed, regexp.MustCompile(pattern))
+		}
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":           p.Enabled,
+		"forbiddenPatterns": len(p.ForbiddenPatterns),
+		"allowedJobs":       p.AllowedJobs,
+		"allowedPatterns":   len(p.AllowedPatterns),
+	}).Debug("noInsecureTransport control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineNoInsecureTransportMetrics holds metrics about disabled TLS verification
+type GitlabPipelineNoInsecureTransportMetrics struct {
+	TotalJobs        uint `json:"totalJobs"`
+	InsecureJobs     uint `json:"insecureJobs"`
+	AllowedJobs      uint `json:"allowedJobs"`
+	InsecureCommands uint `json:"insecureCommands"`
+	CiInvalid        uint `json:"ciInvalid"`
+	CiMissing        uint `json:"ciMissing"`
+}
+
+// GitlabPipelineNoInsecureTransportResult holds the result of the insecure transport control
+type GitlabPipelineNoInsecureTransportResult struct {
+	Issues     []GitlabPipelineNoInsecureTransportIssue `json:"issues"`
+	Metrics    GitlabPipelineNoInsecureTransportMetrics `json:"metrics"`
+	Compliance float64                                  `json:"compliance"`
+	Version    string                                   `json:"version"`
+	CiValid    bool                                     `json:"ciValid"`
+	CiMissing  bool                                     `json:"ciMissing"`
+	Skipped    bool                                     `json:"skipped"`         // True if control was disabled
+	Error      string                                   `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineNoInsecureTransportIssue represents a disabled TLS verification in a job
+type GitlabPipelineNoInsecureTransportIssue struct {
+	Job    string `json:"job,omitempty"` // Empty for global variables
+	Source string `json:"source"`        // "script" or "variable"
+	Token  string `json:"token"`         // Matched text
+	Line   string `json:"line"`          // Script line, or NAME=value for variables
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the insecure transport control
+func (p *GitlabPipelineNoInsecureTransportConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineNoInsecureTransportResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineNoInsecureTransport",
+		"controlVersion": ControlTypeGitlabPipelineNoInsecureTransportVersion,
+	})
+	l.Info("Start insecure transport control")
+
+	result := &GitlabPipelineNoInsecureTransportResult{
+		Issues:     []GitlabPipelineNoInsecureTransportIssue{},
+		Metrics:    GitlabPipelineNoInsecureTransportMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineNoInsecureTransportVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Insecure transport control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	// Global variables apply to all jobs
+	p.checkVariables(result, "", pipelineImageData.MergedConf.GlobalVariables)
+
+	for _, job := range jobs {
+		result.Metrics.TotalJobs++
+		if gitlab.CheckItemMatchToPatterns(job.Name, p.AllowedJobs) {
+			result.Metrics.AllowedJobs++
+			continue
+		}
+
+		issuesBefore := len(result.Issues)
+		for _, line := range jobScriptLines(pipelineImageData.MergedConf, job.Job) {
+			p.checkLine(result, job.Name, insecureTransportSourceScript, line)
+		}
+		p.checkVariables(result, job.Name, job.Job.Variables)
+		if len(result.Issues) > issuesBefore {
+			result.Metrics.InsecureJobs++
+		}
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"totalJobs":        result.Metrics.TotalJobs,
+		"insecureCommands": result.Metrics.InsecureCommands,
+		"compliance":       result.Compliance,
+	}).Info("Insecure transport control completed")
+
+	return result
+}
+
+// checkVariables checks variables as NAME=value lines (global variables if job is empty)
+func (p *GitlabPipelineNoInsecureTransportConf) checkVariables(result *GitlabPipelineNoInsecureTransportResult, job string, variables map[string]interface{}) {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, err := gitlab.GetVariableValue(variables[name])
+		if err != nil {
+			continue
+		}
+		p.checkLine(result, job, insecureTransportSourceVariable, name+"="+value)
+	}
+}
+
+// checkLine adds an issue for each forbidden pattern matching a line, unless
+// the line is allowed
+func (p *GitlabPipelineNoInsecureTransportConf) checkLine(result *GitlabPipelineNoInsecureTransportResult, job, source, line string) {
+	for _, allowed := range p.allowed {
+		if allowed.MatchString(line) {
+			return
+		}
+	}
+
+	for _, forbidden := range p.forbidden {
+		token := strings.TrimSpace(forbidden.FindString(line))
+		if token == "" {
+			continue
+		}
+		result.Issues = append(result.Issues, GitlabPipelineNoInsecureTransportIssue{
+			Job:    job,
+			Source: source,
+			Token:  token,
+			Line:   line,
+		})
+		result.Metrics.InsecureCommands++
+	}
+}
@@ -0,0 +1,168 @@
+package control
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineNoPublicCatalogComponentsVersion = "0.1.0"
+
+// gitlabComHost is the host of the GitLab.com instance and its public catalog
+const gitlabComHost = "gitlab.com"
+
+// GitlabPipelineNoPublicCatalogComponentsConf holds the configuration for public catalog components detection
+type GitlabPipelineNoPublicCatalogComponentsConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineNoPublicCatalogComponentsConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	catalogConfig := plumberConfig.GetNoPublicCatalogComponentsConfig()
+	if catalogConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = catalogConfig.IsEnabled()
+
+	l.WithFields(logrus.Fields{
+		"enabled": p.Enabled,
+	}).Debug("noPublicCatalogComponents control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineNoPublicCatalogComponentsMetrics holds metrics about component origins
+type GitlabPipelineNoPublicCatalogComponentsMetrics struct {
+	SelfHosted       bool `json:"selfHosted"` // False on GitLab.com, where the control has nothing to check
+	Components       uint `json:"components"`
+	PublicComponents uint `json:"publicComponents"`
+	CiInvalid        uint `json:"ciInvalid"`
+	CiMissing        uint `json:"ciMissing"`
+}
+
+// GitlabPipelineNoPublicCatalogComponentsResult holds the result of the public catalog components control
+type GitlabPipelineNoPublicCatalogComponentsResult struct {
+	Issues     []GitlabPipelineNoPublicCatalogComponentsIssue `json:"issues"`
+	Metrics    GitlabPipelineNoPublicCatalogComponentsMetrics `json:"metrics"`
+	Compliance float64                                        `json:"compliance"`
+	Version    string                                         `json:"version"`
+	CiValid    bool                                           `json:"ciValid"`
+	CiMissing  bool                                           `json:"ciMissing"`
+	Skipped    bool                                           `json:"skipped"`         // True if control was disabled
+	Error      string                                         `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineNoPublicCatalogComponentsIssue represents a component fetched
+// from GitLab.com or another instance than the self-hosted one
+type GitlabPipelineNoPublicCatalogComponentsIssue struct {
+	Component string `json:"component"`
+	Instance  string `json:"instance"`
+	Version   string `json:"version,omitempty"`
+	Nested    bool   `json:"nested"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the public catalog components control. On a self-hosted
+// instance, components must come from the instance itself; on GitLab.com
+// the public catalog is the instance catalog and the control passes.
+func (p *GitlabPipelineNoPublicCatalogComponentsConf) Run(pipelineOriginData *collector.GitlabPipelineOriginData, gitlabURL string) *GitlabPipelineNoPublicCatalogComponentsResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineNoPublicCatalogComponents",
+		"controlVersion": ControlTypeGitlabPipelineNoPublicCatalogComponentsVersion,
+	})
+	l.Info("Start public catalog components control")
+
+	result := &GitlabPipelineNoPublicCatalogComponentsResult{
+		Issues:     []GitlabPipelineNoPublicCatalogComponentsIssue{},
+		Metrics:    GitlabPipelineNoPublicCatalogComponentsMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineNoPublicCatalogComponentsVersion,
+		CiValid:    pipelineOriginData.CiValid,
+		CiMissing:  pipelineOriginData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Public catalog components control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineOriginData.CiValid || pipelineOriginData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineOriginData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineOriginData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	result.Metrics.SelfHosted = isSelfHostedInstance(gitlabURL)
+	if !result.Metrics.SelfHosted {
+		l.Info("Analyzed instance is GitLab.com, public catalog components are allowed")
+		return result
+	}
+
+	for _, origin := range pipelineOriginData.Origins {
+		if origin.OriginType != collector.OriginTypeComponent {
+			continue
+		}
+		result.Metrics.Components++
+
+		instance := foreignIncludeInstance(origin.GitlabIncludeOrigin.Location, gitlabURL)
+		if instance == "" {
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabPipelineNoPublicCatalogComponentsIssue{
+			Component: strings.TrimPrefix(origin.GitlabIncludeOrigin.Location, "/"),
+			Instance:  instance,
+			Version:   origin.Version,
+			Nested:    origin.Nested,
+		})
+		result.Metrics.PublicComponents++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"components":       result.Metrics.Components,
+		"publicComponents": result.Metrics.PublicComponents,
+		"compliance":       result.Compliance,
+	}).Info("Public catalog components control completed")
+
+	return result
+}
+
+// isSelfHostedInstance reports whether a GitLab URL is another instance than
+// GitLab.com
+func isSelfHostedInstance(gitlabURL string) bool {
+	parsedURL, err := url.Parse(gitlabURL)
+	if err != nil {
+		return true
+	}
+	return !strings.EqualFold(parsedURL.Hostname(), gitlabComHost)
+}
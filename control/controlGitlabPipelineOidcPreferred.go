@@ -0,0 +1,227 @@
+package control
+
+import (
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineOidcPreferredVersion = "0.1.0"
+
+// DefaultOidcDeployJobPatterns identifies deploy jobs when jobPatterns is not set
+var DefaultOidcDeployJobPatterns = []string{
+	"*deploy*",
+}
+
+// DefaultOidcCredentialVariables are the usual long-lived cloud credential variables
+var DefaultOidcCredentialVariables = []string{
+	"AWS_ACCESS_KEY_ID",
+	"AWS_SECRET_*",
+	"GOOGLE_APPLICATION_CREDENTIALS",
+	"GCP_SERVICE_ACCOUNT_KEY",
+	"GCLOUD_SERVICE_KEY",
+	"AZURE_CLIENT_SECRET",
+	"ARM_CLIENT_SECRET",
+}
+
+// GitlabPipelineOidcPreferredConf holds the configuration for OIDC preferred detection
+type GitlabPipelineOidcPreferredConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// JobPatterns is a list of job name patterns identifying deploy jobs (supports wildcards)
+	JobPatterns []string `json:"jobPatterns"`
+
+	// CredentialVariables is a list of credential variable name patterns (supports wildcards)
+	CredentialVariables []string `json:"credentialVariables"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineOidcPreferredConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	oidcConfig := plumberConfig.GetOidcPreferredConfig()
+	if oidcConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = oidcConfig.IsEnabled()
+	p.JobPatterns = oidcConfig.JobPatterns
+	if len(p.JobPatterns) == 0 {
+		p.JobPatterns = DefaultOidcDeployJobPatterns
+	}
+	p.CredentialVariables = oidcConfig.CredentialVariables
+	if len(p.CredentialVariables) == 0 {
+		p.CredentialVariables = DefaultOidcCredentialVariables
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":             p.Enabled,
+		"jobPatterns":         p.JobPatterns,
+		"credentialVariables": p.CredentialVariables,
+	}).Debug("oidcPreferred control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineOidcPreferredMetrics holds metrics about deploy jobs
+type GitlabPipelineOidcPreferredMetrics struct {
+	DeployJobs      uint `json:"deployJobs"`
+	UsingIdTokens   uint `json:"usingIdTokens"`
+	UsingStaticKeys uint `json:"usingStaticKeys"`
+	CiInvalid       uint `json:"ciInvalid"`
+	CiMissing       uint `json:"ciMissing"`
+}
+
+// GitlabPipelineOidcPreferredResult holds the result of the OIDC preferred control
+type GitlabPipelineOidcPreferredResult struct {
+	Issues     []GitlabPipelineOidcPreferredIssue `json:"issues"`
+	Metrics    GitlabPipelineOidcPreferredMetrics `json:"metrics"`
+	Compliance float64                            `json:"compliance"`
+	Version    string                             `json:"version"`
+	CiValid    bool                               `json:"ciValid"`
+	CiMissing  bool                               `json:"ciMissing"`
+	Skipped    bool                               `json:"skipped"`         // True if control was disabled
+	Error      string                             `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineOidcPreferredIssue represents a deploy job using static cloud credentials without id_tokens
+type GitlabPipelineOidcPreferredIssue struct {
+	Job       string   `json:"job"`
+	Variables []string `json:"variables"` // Credential variables available to or used by the job
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the OIDC preferred control
+func (p *GitlabPipelineOidcPreferredConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineOidcPreferredResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineOidcPreferred",
+		"controlVersion": ControlTypeGitlabPipelineOidcPreferredVersion,
+	})
+	l.Info("Start OIDC preferred control")
+
+	result := &GitlabPipelineOidcPreferredResult{
+		Issues:     []GitlabPipelineOidcPreferredIssue{},
+		Metrics:    GitlabPipelineOidcPreferredMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineOidcPreferredVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("OIDC preferred control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	// Credential variables defined in CI/CD settings or at the root of the
+	// configuration are exposed to every job, tools (e.g., aws CLI) read them
+	// from the environment without any explicit reference
+	inheritedCredentials := map[string]bool{}
+	for _, vars := range []map[string]string{pipelineImageData.InstanceVars, pipelineImageData.GroupVars, pipelineImageData.ProjectVars, pipelineImageData.GlobalVars} {
+		for name := range vars {
+			if gitlab.CheckItemMatchToPatterns(name, p.CredentialVariables) {
+				inheritedCredentials[name] = true
+			}
+		}
+	}
+
+	for _, job := range jobs {
+		if !gitlab.CheckItemMatchToPatterns(job.Name, p.JobPatterns) {
+			continue
+		}
+		result.Metrics.DeployJobs++
+
+		if job.Job.IdTokens != nil {
+			result.Metrics.UsingIdTokens++
+			continue
+		}
+
+		credentials := map[string]bool{}
+		for name := range inheritedCredentials {
+			credentials[name] = true
+		}
+
+		// Credential variables defined by the job or referenced in its variables and scripts
+		references := jobScriptLines(pipelineImageData.MergedConf, job.Job)
+		for name, value := range job.Job.Variables {
+			if gitlab.CheckItemMatchToPatterns(name, p.CredentialVariables) {
+				credentials[name] = true
+			}
+			if valueString, ok := value.(string); ok {
+				references = append(references, valueString)
+			}
+		}
+		for _, line := range references {
+			for _, name := range gitlab.ExtractVariableNames(line) {
+				if gitlab.CheckItemMatchToPatterns(name, p.CredentialVariables) {
+					credentials[name] = true
+				}
+			}
+		}
+
+		if len(credentials) == 0 {
+			continue
+		}
+
+		variables := make([]string, 0, len(credentials))
+		for name := range credentials {
+			variables = append(variables, name)
+		}
+		sort.Strings(variables)
+
+		result.Metrics.UsingStaticKeys++
+		result.Issues = append(result.Issues, GitlabPipelineOidcPreferredIssue{
+			Job:       job.Name,
+			Variables: variables,
+		})
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"deployJobs":      result.Metrics.DeployJobs,
+		"usingStaticKeys": result.Metrics.UsingStaticKeys,
+		"compliance":      result.Compliance,
+	}).Info("OIDC preferred control completed")
+
+	return result
+}
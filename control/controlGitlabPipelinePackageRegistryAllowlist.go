@@ -0,0 +1,252 @@
+package control
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelinePackageRegistryAllowlistVersion = "0.1.0"
+
+// DefaultPackageRegistryVariables are the variables configuring package
+// registries, inspected when variables is not set
+var DefaultPackageRegistryVariables = []string{
+	"PIP_INDEX_URL",
+	"PIP_EXTRA_INDEX_URL",
+	"UV_INDEX_URL",
+	"UV_EXTRA_INDEX_URL",
+	"NPM_CONFIG_REGISTRY",
+	"YARN_REGISTRY",
+	"GOPROXY",
+}
+
+// packageRegistryValueSeparators splits a variable value holding several
+// URLs (e.g., GOPROXY=https://proxy.example.com,direct)
+var packageRegistryValueSeparators = regexp.MustCompile(`[\s,|]+`)
+
+// GitlabPipelinePackageRegistryAllowlistConf holds the configuration for package registry allowlisting
+type GitlabPipelinePackageRegistryAllowlistConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// Variables is a list of variable names configuring package registries (supports wildcards)
+	Variables []string `json:"variables"`
+
+	// AllowedHosts is a list of hosts package registries may be served from (supports wildcards)
+	AllowedHosts []string `json:"allowedHosts"`
+}
+
+// validatePackageRegistryAllowlistConfig validates the packageRegistryAllowlist configuration
+func validatePackageRegistryAllowlistConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	registryConfig := plumberConfig.GetPackageRegistryAllowlistConfig()
+	if !registryConfig.IsEnabled() {
+		return
+	}
+
+	if len(registryConfig.AllowedHosts) == 0 {
+		v.add("packageRegistryAllowlist.allowedHosts", "field is required when the control is enabled", "a list of allowed hosts, e.g. nexus.example.com")
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelinePackageRegistryAllowlistConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	registryConfig := plumberConfig.GetPackageRegistryAllowlistConfig()
+	if registryConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validatePackageRegistryAllowlistConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = registryConfig.IsEnabled()
+	p.Variables = registryConfig.Variables
+	if len(p.Variables) == 0 {
+		p.Variables = DefaultPackageRegistryVariables
+	}
+	p.AllowedHosts = registryConfig.AllowedHosts
+
+	l.WithFields(logrus.Fields{
+		"enabled":      p.Enabled,
+		"variables":    p.Variables,
+		"allowedHosts": p.AllowedHosts,
+	}).Debug("packageRegistryAllowlist control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelinePackageRegistryAllowlistMetrics holds metrics about package registry variables
+type GitlabPipelinePackageRegistryAllowlistMetrics struct {
+	Variables  uint `json:"variables"`  // Registry variables found in all scopes
+	Unresolved uint `json:"unresolved"` // URLs referencing other variables, not checked
+	NotAllowed uint `json:"notAllowed"` // URLs whose host is not allowed
+	CiInvalid  uint `json:"ciInvalid"`
+	CiMissing  uint `json:"ciMissing"`
+}
+
+// GitlabPipelinePackageRegistryAllowlistResult holds the result of the package registry allowlist control
+type GitlabPipelinePackageRegistryAllowlistResult struct {
+	Issues     []GitlabPipelinePackageRegistryAllowlistIssue `json:"issues"`
+	Metrics    GitlabPipelinePackageRegistryAllowlistMetrics `json:"metrics"`
+	Compliance float64                                       `json:"compliance"`
+	Version    string                                        `json:"version"`
+	CiValid    bool                                          `json:"ciValid"`
+	CiMissing  bool                                          `json:"ciMissing"`
+	Skipped    bool                                          `json:"skipped"`         // True if control was disabled
+	Error      string                                        `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelinePackageRegistryAllowlistIssue represents a package registry
+// variable pointing to a host outside the allowlist. Only the host is
+// reported, the value may contain credentials.
+type GitlabPipelinePackageRegistryAllowlistIssue struct {
+	Scope    string `json:"scope"` // "instance", "group", "project", "global" or "job"
+	Job      string `json:"job,omitempty"`
+	Variable string `json:"variable"`
+	Host     string `json:"host"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the package registry allowlist control on CI/CD variables of
+// the instance, group and project, and on global and job variables of the
+// configuration. Variable values are never logged.
+func (p *GitlabPipelinePackageRegistryAllowlistConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelinePackageRegistryAllowlistResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelinePackageRegistryAllowlist",
+		"controlVersion": ControlTypeGitlabPipelinePackageRegistryAllowlistVersion,
+	})
+	l.Info("Start package registry allowlist control")
+
+	result := &GitlabPipelinePackageRegistryAllowlistResult{
+		Issues:     []GitlabPipelinePackageRegistryAllowlistIssue{},
+		Metrics:    GitlabPipelinePackageRegistryAllowlistMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelinePackageRegistryAllowlistVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Package registry allowlist control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	p.checkRegistryVariables(result, variableScopeInstance, "", pipelineImageData.InstanceVars)
+	p.checkRegistryVariables(result, variableScopeGroup, "", pipelineImageData.GroupVars)
+	p.checkRegistryVariables(result, variableScopeProject, "", pipelineImageData.ProjectVars)
+	p.checkRegistryVariables(result, variableScopeGlobal, "", pipelineImageData.GlobalVars)
+
+	for _, job := range jobs {
+		jobVars := map[string]string{}
+		for name, valueInterface := range job.Job.Variables {
+			if value, err := gitlab.GetVariableValue(valueInterface); err == nil {
+				jobVars[name] = value
+			}
+		}
+		p.checkRegistryVariables(result, variableScopeJob, job.Name, jobVars)
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"variables":  result.Metrics.Variables,
+		"notAllowed": result.Metrics.NotAllowed,
+		"compliance": result.Compliance,
+	}).Info("Package registry allowlist control completed")
+
+	return result
+}
+
+// checkRegistryVariables adds an issue for each URL of a registry variable
+// whose host is not allowed, in a set of variables
+func (p *GitlabPipelinePackageRegistryAllowlistConf) checkRegistryVariables(result *GitlabPipelinePackageRegistryAllowlistResult, scope, job string, variables map[string]string) {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		if gitlab.CheckItemMatchToPatterns(name, p.Variables) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		result.Metrics.Variables++
+
+		for _, registryURL := range packageRegistryValueSeparators.Split(strings.TrimSpace(variables[name]), -1) {
+			host, resolved := packageRegistryHost(registryURL)
+			if !resolved {
+				result.Metrics.Unresolved++
+				continue
+			}
+			if host == "" || gitlab.CheckItemMatchToPatterns(host, p.AllowedHosts) {
+				continue
+			}
+			result.Issues = append(result.Issues, GitlabPipelinePackageRegistryAllowlistIssue{
+				Scope:    scope,
+				Job:      job,
+				Variable: name,
+				Host:     host,
+			})
+			result.Metrics.NotAllowed++
+		}
+	}
+}
+
+// packageRegistryHost returns the lowercased host of a registry URL, or an
+// empty host for keywords (e.g., GOPROXY direct or off). URLs referencing
+// other variables are not resolved.
+func packageRegistryHost(registryURL string) (string, bool) {
+	registryURL = strings.Trim(registryURL, `"'`)
+	if registryURL == "" || !strings.Contains(registryURL, "://") {
+		return "", true
+	}
+	if strings.Contains(registryURL, "$") {
+		return "", false
+	}
+	parsedURL, err := url.Parse(registryURL)
+	if err != nil {
+		return "", true
+	}
+	return strings.ToLower(parsedURL.Hostname()), true
+}
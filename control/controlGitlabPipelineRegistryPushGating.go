@@ -0,0 +1,278 @@
+package control
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineRegistryPushGatingVersion = "0.1.0"
+
+// DefaultRegistryPushPatterns are the patterns of commands pushing images to
+// a registry, used when pushPatterns is not set
+var DefaultRegistryPushPatterns = []string{
+	`\b(docker|podman|buildah)\s+push\b`,
+	`\bdocker\s+(buildx\s+)?build\b.*\s--push\b`,
+	`\bcrane\s+(push|copy|cp|append|mutate)\b`,
+	`\bskopeo\s+copy\b`,
+	`/kaniko/executor\b`,
+}
+
+// unprotectedPipeline is a pipeline running on a ref that is not protected
+type unprotectedPipeline struct {
+	description string
+	ref         string
+	source      string
+}
+
+// Refs and sources of the simulated pipelines: a push pipeline on an
+// unprotected branch, a merge request pipeline and a push pipeline on an
+// unprotected tag. The names are made up so that they match no rule
+// targeting a specific branch or tag.
+var unprotectedPipelines = []unprotectedPipeline{
+	{description: "unprotected branch", ref: "plumber-unprotected-branch", source: "push"},
+	{description: "merge request", ref: "plumber-unprotected-branch", source: "merge_request_event"},
+	{description: "unprotected tag", ref: simulatedTagPrefix + "plumber-unprotected-tag", source: "push"},
+}
+
+// GitlabPipelineRegistryPushGatingConf holds the configuration for ungated registry pushes detection
+type GitlabPipelineRegistryPushGatingConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// PushPatterns are regexes matching script lines pushing images to a registry
+	PushPatterns []string `json:"pushPatterns"`
+
+	push []*regexp.Regexp
+}
+
+// validateRegistryPushGatingConfig validates the registryPushGating configuration
+func validateRegistryPushGatingConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	pushConfig := plumberConfig.GetRegistryPushGatingConfig()
+	if !pushConfig.IsEnabled() {
+		return
+	}
+
+	for index, pattern := range pushConfig.PushPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			v.add(fmt.Sprintf("registryPushGating.pushPatterns[%d]", index), fmt.Sprintf("invalid regex (%v)", err), "a regex matching a registry push command")
+		}
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineRegistryPushGatingConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	pushConfig := plumberConfig.GetRegistryPushGatingConfig()
+	if pushConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateRegistryPushGatingConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = pushConfig.IsEnabled()
+	p.PushPatterns = pushConfig.PushPatterns
+	if len(p.PushPatterns) == 0 {
+		p.PushPatterns = DefaultRegistryPushPatterns
+	}
+
+	// Compile patterns
+	p.push = []*regexp.Regexp{}
+	if p.Enabled {
+		for _, pattern := range p.PushPatterns {
+			p.push = append(p.push, regexp.MustCompile(pattern))
+		}
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":      p.Enabled,
+		"pushPatterns": len(p.PushPatterns),
+	}).Debug("registryPushGating control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineRegistryPushGatingMetrics holds metrics about registry pushes
+type GitlabPipelineRegistryPushGatingMetrics struct {
+	TotalJobs   uint `json:"totalJobs"`
+	PushJobs    uint `json:"pushJobs"`
+	Gated       uint `json:"gated"`       // Push jobs running only on protected refs
+	Ungated     uint `json:"ungated"`     // Push jobs that can run on unprotected refs
+	Unevaluated uint `json:"unevaluated"` // Push jobs whose rules could not be evaluated
+	CiInvalid   uint `json:"ciInvalid"`
+	CiMissing   uint `json:"ciMissing"`
+}
+
+// GitlabPipelineRegistryPushGatingResult holds the result of the registry push gating control
+type GitlabPipelineRegistryPushGatingResult struct {
+	Issues     []GitlabPipelineRegistryPushGatingIssue `json:"issues"`
+	Metrics    GitlabPipelineRegistryPushGatingMetrics `json:"metrics"`
+	Compliance float64                                 `json:"compliance"`
+	Version    string                                  `json:"version"`
+	CiValid    bool                                    `json:"ciValid"`
+	CiMissing  bool                                    `json:"ciMissing"`
+	Skipped    bool                                    `json:"skipped"`         // True if control was disabled
+	Error      string                                  `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineRegistryPushGatingIssue represents a push job that can run on an unprotected ref
+type GitlabPipelineRegistryPushGatingIssue struct {
+	Job     string `json:"job"`
+	Command string `json:"command"` // Part of the script line detected as a registry push
+	RunsOn  string `json:"runsOn"`  // "unprotected branch", "merge request" or "unprotected tag"
+	Reason  string `json:"reason"`  // Why the job runs, from rules evaluation
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the registry push gating control. Jobs whose script matches a
+// push pattern are evaluated against pipelines on unprotected refs; a job
+// running in any of them is flagged. The default branch is assumed protected.
+func (p *GitlabPipelineRegistryPushGatingConf) Run(pipelineImageData *collector.GitlabPipelineImageData, project *gitlab.ProjectInfo) *GitlabPipelineRegistryPushGatingResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineRegistryPushGating",
+		"controlVersion": ControlTypeGitlabPipelineRegistryPushGatingVersion,
+	})
+	l.Info("Start registry push gating control")
+
+	result := &GitlabPipelineRegistryPushGatingResult{
+		Issues:     []GitlabPipelineRegistryPushGatingIssue{},
+		Metrics:    GitlabPipelineRegistryPushGatingMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineRegistryPushGatingVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Registry push gating control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	// Pipeline variables of each unprotected pipeline, after workflow rules.
+	// A nil entry means the workflow prevents the pipeline from running.
+	pipelineVars := make([]map[string]string, len(unprotectedPipelines))
+	for index, pipeline := range unprotectedPipelines {
+		vars := simulatedPipelineVariables(pipeline.ref, pipeline.source, project, pipelineImageData)
+		vars["CI_COMMIT_REF_PROTECTED"] = "false"
+		workflowEvaluation, err := evaluateWorkflowRules(pipelineImageData.MergedConf, vars)
+		if err != nil {
+			// Assume the pipeline runs, jobs rules are still evaluated
+			l.WithError(err).WithField("pipeline", pipeline.description).Warn("Unable to evaluate workflow rules")
+		} else if !workflowEvaluation.Run {
+			continue
+		}
+		pipelineVars[index] = vars
+	}
+
+	for _, job := range jobs {
+		result.Metrics.TotalJobs++
+
+		command := p.findPushCommand(jobScriptLines(pipelineImageData.MergedConf, job.Job))
+		if command == "" {
+			continue
+		}
+		result.Metrics.PushJobs++
+
+		issue, evaluated := p.findUnprotectedPipeline(job, pipelineVars)
+		if !evaluated {
+			l.WithField("job", job.Name).Warn("Unable to evaluate the rules of a push job")
+			result.Metrics.Unevaluated++
+			continue
+		}
+		if issue == nil {
+			result.Metrics.Gated++
+			continue
+		}
+
+		issue.Command = command
+		result.Issues = append(result.Issues, *issue)
+		result.Metrics.Ungated++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"pushJobs":   result.Metrics.PushJobs,
+		"ungated":    result.Metrics.Ungated,
+		"compliance": result.Compliance,
+	}).Info("Registry push gating control completed")
+
+	return result
+}
+
+// findPushCommand returns the part of the first script line matching a push
+// pattern, or an empty string if the job doesn't push images
+func (p *GitlabPipelineRegistryPushGatingConf) findPushCommand(lines []string) string {
+	for _, line := range lines {
+		for _, pattern := range p.push {
+			if match := pattern.FindString(line); match != "" {
+				return match
+			}
+		}
+	}
+	return ""
+}
+
+// findUnprotectedPipeline returns an issue for the first unprotected pipeline
+// the job runs in, or nil if it runs in none of them. The boolean is false if
+// the job rules could not be evaluated.
+func (p *GitlabPipelineRegistryPushGatingConf) findUnprotectedPipeline(job pipelineJob, pipelineVars []map[string]string) (*GitlabPipelineRegistryPushGatingIssue, bool) {
+	for index, vars := range pipelineVars {
+		if vars == nil {
+			continue
+		}
+		evaluation, err := evaluateJobRules(job, vars)
+		if err != nil {
+			return nil, false
+		}
+		if evaluation.Run {
+			return &GitlabPipelineRegistryPushGatingIssue{
+				Job:    job.Name,
+				RunsOn: unprotectedPipelines[index].description,
+				Reason: evaluation.Reason,
+			}, true
+		}
+	}
+	return nil, true
+}
@@ -0,0 +1,194 @@
+package control
+
+import (
+	"fmt"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineRetryPolicyVersion = "0.1.0"
+
+// DefaultMaxRetries is the maximum number of retries when maxRetries is not set
+const DefaultMaxRetries = 1
+
+// gitlabMaxRetries is the highest number of retries accepted by GitLab
+const gitlabMaxRetries = 2
+
+// GitlabPipelineRetryPolicyConf holds the configuration for job retries detection
+type GitlabPipelineRetryPolicyConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// MaxRetries is the maximum number of retries a job may configure
+	MaxRetries int `json:"maxRetries"`
+}
+
+// validateRetryPolicyConfig validates the retryPolicy configuration
+func validateRetryPolicyConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	retryConfig := plumberConfig.GetRetryPolicyConfig()
+	if !retryConfig.IsEnabled() {
+		return
+	}
+
+	if retryConfig.MaxRetries != nil && (*retryConfig.MaxRetries < 0 || *retryConfig.MaxRetries > gitlabMaxRetries) {
+		v.add("retryPolicy.maxRetries", fmt.Sprintf("invalid number of retries %d", *retryConfig.MaxRetries), "a number between 0 and 2")
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineRetryPolicyConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	retryConfig := plumberConfig.GetRetryPolicyConfig()
+	if retryConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateRetryPolicyConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = retryConfig.IsEnabled()
+	p.MaxRetries = DefaultMaxRetries
+	if retryConfig.MaxRetries != nil {
+		p.MaxRetries = *retryConfig.MaxRetries
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":    p.Enabled,
+		"maxRetries": p.MaxRetries,
+	}).Debug("retryPolicy control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineRetryPolicyMetrics holds metrics about job retries
+type GitlabPipelineRetryPolicyMetrics struct {
+	TotalJobs    uint `json:"totalJobs"`
+	JobsRetry    uint `json:"jobsRetry"` // Jobs configuring retries, directly or from default
+	AboveMaximum uint `json:"aboveMaximum"`
+	MaxRetries   uint `json:"maxRetries"`
+	CiInvalid    uint `json:"ciInvalid"`
+	CiMissing    uint `json:"ciMissing"`
+}
+
+// GitlabPipelineRetryPolicyResult holds the result of the retry policy control
+type GitlabPipelineRetryPolicyResult struct {
+	Issues     []GitlabPipelineRetryPolicyIssue `json:"issues"`
+	Metrics    GitlabPipelineRetryPolicyMetrics `json:"metrics"`
+	Compliance float64                          `json:"compliance"`
+	Version    string                           `json:"version"`
+	CiValid    bool                             `json:"ciValid"`
+	CiMissing  bool                             `json:"ciMissing"`
+	Skipped    bool                             `json:"skipped"`         // True if control was disabled
+	Error      string                           `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineRetryPolicyIssue represents a job configuring too many retries
+type GitlabPipelineRetryPolicyIssue struct {
+	Job         string `json:"job"`
+	Retries     int    `json:"retries"`
+	FromDefault bool   `json:"fromDefault"` // True if the retries come from default:retry
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the retry policy control. Jobs without retry inherit
+// default:retry.
+func (p *GitlabPipelineRetryPolicyConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineRetryPolicyResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineRetryPolicy",
+		"controlVersion": ControlTypeGitlabPipelineRetryPolicyVersion,
+	})
+	l.Info("Start retry policy control")
+
+	result := &GitlabPipelineRetryPolicyResult{
+		Issues:     []GitlabPipelineRetryPolicyIssue{},
+		Metrics:    GitlabPipelineRetryPolicyMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineRetryPolicyVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Retry policy control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	result.Metrics.MaxRetries = uint(p.MaxRetries)
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	defaultRetries, defaultHasRetry := gitlab.GetRetryMax(pipelineImageData.MergedConf.Default.Retry)
+
+	for _, job := range jobs {
+		result.Metrics.TotalJobs++
+
+		retries, hasRetry := gitlab.GetRetryMax(job.Job.Retry)
+		fromDefault := false
+		if job.Job.Retry == nil {
+			retries, hasRetry = defaultRetries, defaultHasRetry
+			fromDefault = true
+		}
+		if !hasRetry || retries == 0 {
+			continue
+		}
+		result.Metrics.JobsRetry++
+
+		if retries <= p.MaxRetries {
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabPipelineRetryPolicyIssue{
+			Job:         job.Name,
+			Retries:     retries,
+			FromDefault: fromDefault,
+		})
+		result.Metrics.AboveMaximum++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"jobsRetry":    result.Metrics.JobsRetry,
+		"aboveMaximum": result.Metrics.AboveMaximum,
+		"compliance":   result.Compliance,
+	}).Info("Retry policy control completed")
+
+	return result
+}
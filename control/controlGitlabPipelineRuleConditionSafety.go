@@ -0,0 +1,219 @@
+package control
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineRuleConditionSafetyVersion = "0.1.0"
+
+// DefaultRuleConditionPrivilegedJobPatterns identifies privileged jobs when
+// jobPatterns is not set
+var DefaultRuleConditionPrivilegedJobPatterns = []string{
+	"*deploy*",
+	"*release*",
+	"*publish*",
+}
+
+// DefaultRuleConditionUnsafeVariables are the variables anyone able to push
+// a commit controls, used when unsafeVariables is not set
+var DefaultRuleConditionUnsafeVariables = []string{
+	"CI_COMMIT_MESSAGE",
+	"CI_COMMIT_TITLE",
+	"CI_COMMIT_DESCRIPTION",
+}
+
+// ruleConditionVariablePattern matches variables referenced in a rules:if
+// expression, as $NAME or ${NAME}
+var ruleConditionVariablePattern = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)\}?`)
+
+// GitlabPipelineRuleConditionSafetyConf holds the configuration for unsafe rule condition detection
+type GitlabPipelineRuleConditionSafetyConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// JobPatterns is a list of job name patterns identifying privileged jobs (supports wildcards)
+	JobPatterns []string `json:"jobPatterns"`
+
+	// UnsafeVariables is a list of variable name patterns that must not gate privileged jobs (supports wildcards)
+	UnsafeVariables []string `json:"unsafeVariables"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineRuleConditionSafetyConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	safetyConfig := plumberConfig.GetRuleConditionSafetyConfig()
+	if safetyConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = safetyConfig.IsEnabled()
+	p.JobPatterns = safetyConfig.JobPatterns
+	if len(p.JobPatterns) == 0 {
+		p.JobPatterns = DefaultRuleConditionPrivilegedJobPatterns
+	}
+	p.UnsafeVariables = safetyConfig.UnsafeVariables
+	if len(p.UnsafeVariables) == 0 {
+		p.UnsafeVariables = DefaultRuleConditionUnsafeVariables
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":         p.Enabled,
+		"jobPatterns":     p.JobPatterns,
+		"unsafeVariables": p.UnsafeVariables,
+	}).Debug("ruleConditionSafety control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineRuleConditionSafetyMetrics holds metrics about rule conditions of privileged jobs
+type GitlabPipelineRuleConditionSafetyMetrics struct {
+	PrivilegedJobs   uint `json:"privilegedJobs"`
+	UnsafeConditions uint `json:"unsafeConditions"`
+	CiInvalid        uint `json:"ciInvalid"`
+	CiMissing        uint `json:"ciMissing"`
+}
+
+// GitlabPipelineRuleConditionSafetyResult holds the result of the rule condition safety control
+type GitlabPipelineRuleConditionSafetyResult struct {
+	Issues     []GitlabPipelineRuleConditionSafetyIssue `json:"issues"`
+	Metrics    GitlabPipelineRuleConditionSafetyMetrics `json:"metrics"`
+	Compliance float64                                  `json:"compliance"`
+	Version    string                                   `json:"version"`
+	CiValid    bool                                     `json:"ciValid"`
+	CiMissing  bool                                     `json:"ciMissing"`
+	Skipped    bool                                     `json:"skipped"`         // True if control was disabled
+	Error      string                                   `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineRuleConditionSafetyIssue represents a rule of a privileged job
+// whose condition references a variable controlled by the commit author
+type GitlabPipelineRuleConditionSafetyIssue struct {
+	Job       string   `json:"job"`
+	Condition string   `json:"condition"`
+	Variables []string `json:"variables"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the rule condition safety control on the rules:if of
+// privileged jobs. Rules with 'when: never' only prevent the job from running
+// and are not reported.
+func (p *GitlabPipelineRuleConditionSafetyConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineRuleConditionSafetyResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineRuleConditionSafety",
+		"controlVersion": ControlTypeGitlabPipelineRuleConditionSafetyVersion,
+	})
+	l.Info("Start rule condition safety control")
+
+	result := &GitlabPipelineRuleConditionSafetyResult{
+		Issues:     []GitlabPipelineRuleConditionSafetyIssue{},
+		Metrics:    GitlabPipelineRuleConditionSafetyMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineRuleConditionSafetyVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Rule condition safety control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	for _, job := range jobs {
+		if !gitlab.CheckItemMatchToPatterns(job.Name, p.JobPatterns) {
+			continue
+		}
+		result.Metrics.PrivilegedJobs++
+
+		rules, err := gitlab.GetRules(job.Job.Rules)
+		if err != nil {
+			l.WithError(err).WithField("job", job.Name).Warn("Unable to parse job rules, skipping job")
+			continue
+		}
+
+		for _, rule := range rules {
+			if rule.If == "" || rule.When == "never" {
+				continue
+			}
+			variables := p.unsafeConditionVariables(rule.If)
+			if len(variables) == 0 {
+				continue
+			}
+			result.Issues = append(result.Issues, GitlabPipelineRuleConditionSafetyIssue{
+				Job:       job.Name,
+				Condition: rule.If,
+				Variables: variables,
+			})
+			result.Metrics.UnsafeConditions++
+		}
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"privilegedJobs":   result.Metrics.PrivilegedJobs,
+		"unsafeConditions": result.Metrics.UnsafeConditions,
+		"compliance":       result.Compliance,
+	}).Info("Rule condition safety control completed")
+
+	return result
+}
+
+// unsafeConditionVariables returns the unsafe variables referenced in a
+// rules:if expression, sorted and without duplicates
+func (p *GitlabPipelineRuleConditionSafetyConf) unsafeConditionVariables(condition string) []string {
+	found := map[string]bool{}
+	for _, match := range ruleConditionVariablePattern.FindAllStringSubmatch(condition, -1) {
+		if gitlab.CheckItemMatchToPatterns(match[1], p.UnsafeVariables) {
+			found[match[1]] = true
+		}
+	}
+
+	variables := make([]string, 0, len(found))
+	for variable := range found {
+		variables = append(variables, variable)
+	}
+	sort.Strings(variables)
+	return variables
+}
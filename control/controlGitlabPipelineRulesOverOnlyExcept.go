@@ -0,0 +1,157 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineRulesOverOnlyExceptVersion = "0.1.0"
+
+// GitlabPipelineRulesOverOnlyExceptConf holds the configuration for deprecated only/except detection
+type GitlabPipelineRulesOverOnlyExceptConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineRulesOverOnlyExceptConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	rulesConfig := plumberConfig.GetRulesOverOnlyExceptConfig()
+	if rulesConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = rulesConfig.IsEnabled()
+
+	l.WithFields(logrus.Fields{
+		"enabled": p.Enabled,
+	}).Debug("rulesOverOnlyExcept control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineRulesOverOnlyExceptMetrics holds metrics about only/except usage
+type GitlabPipelineRulesOverOnlyExceptMetrics struct {
+	Jobs           uint `json:"jobs"`
+	UsingRules     uint `json:"usingRules"`
+	UsingOnly      uint `json:"usingOnly"`
+	UsingExcept    uint `json:"usingExcept"`
+	DeprecatedJobs uint `json:"deprecatedJobs"` // Jobs using only and/or except
+	CiInvalid      uint `json:"ciInvalid"`
+	CiMissing      uint `json:"ciMissing"`
+}
+
+// GitlabPipelineRulesOverOnlyExceptResult holds the result of the only/except control
+type GitlabPipelineRulesOverOnlyExceptResult struct {
+	Issues     []GitlabPipelineRulesOverOnlyExceptIssue `json:"issues"`
+	Metrics    GitlabPipelineRulesOverOnlyExceptMetrics `json:"metrics"`
+	Compliance float64                                  `json:"compliance"`
+	Version    string                                   `json:"version"`
+	CiValid    bool                                     `json:"ciValid"`
+	CiMissing  bool                                     `json:"ciMissing"`
+	Skipped    bool                                     `json:"skipped"`         // True if control was disabled
+	Error      string                                   `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineRulesOverOnlyExceptIssue represents a job using the deprecated only/except keywords
+type GitlabPipelineRulesOverOnlyExceptIssue struct {
+	Job      string   `json:"job"`
+	Keywords []string `json:"keywords"` // "only" and/or "except"
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the only/except control
+func (p *GitlabPipelineRulesOverOnlyExceptConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineRulesOverOnlyExceptResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineRulesOverOnlyExcept",
+		"controlVersion": ControlTypeGitlabPipelineRulesOverOnlyExceptVersion,
+	})
+	l.Info("Start only/except control")
+
+	result := &GitlabPipelineRulesOverOnlyExceptResult{
+		Issues:     []GitlabPipelineRulesOverOnlyExceptIssue{},
+		Metrics:    GitlabPipelineRulesOverOnlyExceptMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineRulesOverOnlyExceptVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Only/except control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	for _, job := range jobs {
+		result.Metrics.Jobs++
+		if job.Job.Rules != nil {
+			result.Metrics.UsingRules++
+		}
+
+		keywords := []string{}
+		if job.Job.Only != nil {
+			keywords = append(keywords, "only")
+			result.Metrics.UsingOnly++
+		}
+		if job.Job.Except != nil {
+			keywords = append(keywords, "except")
+			result.Metrics.UsingExcept++
+		}
+		if len(keywords) == 0 {
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabPipelineRulesOverOnlyExceptIssue{
+			Job:      job.Name,
+			Keywords: keywords,
+		})
+		result.Metrics.DeprecatedJobs++
+	}
+
+	// Compliance is the share of jobs not using only/except, to track the
+	// migration to rules over time
+	if result.Metrics.Jobs > 0 {
+		result.Compliance = float64(result.Metrics.Jobs-result.Metrics.DeprecatedJobs) / float64(result.Metrics.Jobs) * 100
+	}
+
+	l.WithFields(logrus.Fields{
+		"jobs":           result.Metrics.Jobs,
+		"deprecatedJobs": result.Metrics.DeprecatedJobs,
+		"compliance":     result.Compliance,
+	}).Info("Only/except control completed")
+
+	return result
+}
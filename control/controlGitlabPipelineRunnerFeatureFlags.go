@@ -0,0 +1,183 @@
+package control
+
+import (
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineRunnerFeatureFlagsVersion = "0.1.0"
+
+// DefaultForbiddenRunnerFeatureFlags are the runner feature flags changing
+// the isolation of builds, used when forbiddenFlags is not set
+var DefaultForbiddenRunnerFeatureFlags = []string{
+	"FF_NETWORK_PER_BUILD",
+	"FF_DISABLE_UMASK_FOR_DOCKER_EXECUTOR",
+	"FF_DISABLE_UMASK_FOR_KUBERNETES_EXECUTOR",
+	"FF_USE_LEGACY_KUBERNETES_EXECUTION_STRATEGY",
+}
+
+// GitlabPipelineRunnerFeatureFlagsConf holds the configuration for runner feature flag detection
+type GitlabPipelineRunnerFeatureFlagsConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// ForbiddenFlags are the feature flag variable names that must not be set (supports wildcards)
+	ForbiddenFlags []string `json:"forbiddenFlags"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineRunnerFeatureFlagsConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	flagsConfig := plumberConfig.GetRunnerFeatureFlagsConfig()
+	if flagsConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = flagsConfig.IsEnabled()
+	p.ForbiddenFlags = DefaultForbiddenRunnerFeatureFlags
+	if len(flagsConfig.ForbiddenFlags) > 0 {
+		p.ForbiddenFlags = flagsConfig.ForbiddenFlags
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":        p.Enabled,
+		"forbiddenFlags": p.ForbiddenFlags,
+	}).Debug("runnerFeatureFlags control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineRunnerFeatureFlagsMetrics holds metrics about runner feature flags
+type GitlabPipelineRunnerFeatureFlagsMetrics struct {
+	TotalJobs uint `json:"totalJobs"`
+	Forbidden uint `json:"forbidden"` // Forbidden feature flags set
+	CiInvalid uint `json:"ciInvalid"`
+	CiMissing uint `json:"ciMissing"`
+}
+
+// GitlabPipelineRunnerFeatureFlagsResult holds the result of the runner feature flags control
+type GitlabPipelineRunnerFeatureFlagsResult struct {
+	Issues     []GitlabPipelineRunnerFeatureFlagsIssue `json:"issues"`
+	Metrics    GitlabPipelineRunnerFeatureFlagsMetrics `json:"metrics"`
+	Compliance float64                                 `json:"compliance"`
+	Version    string                                  `json:"version"`
+	CiValid    bool                                    `json:"ciValid"`
+	CiMissing  bool                                    `json:"ciMissing"`
+	Skipped    bool                                    `json:"skipped"`         // True if control was disabled
+	Error      string                                  `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineRunnerFeatureFlagsIssue represents a forbidden runner feature
+// flag set in a scope. The variable value is never reported.
+type GitlabPipelineRunnerFeatureFlagsIssue struct {
+	Scope string `json:"scope"` // "instance", "group", "project", "global" or "job"
+	Job   string `json:"job,omitempty"`
+	Flag  string `json:"flag"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the runner feature flags control on CI/CD variables of the
+// instance, group and project, and on global and job variables of the
+// configuration. A forbidden flag is reported whatever its value.
+func (p *GitlabPipelineRunnerFeatureFlagsConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineRunnerFeatureFlagsResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineRunnerFeatureFlags",
+		"controlVersion": ControlTypeGitlabPipelineRunnerFeatureFlagsVersion,
+	})
+	l.Info("Start runner feature flags control")
+
+	result := &GitlabPipelineRunnerFeatureFlagsResult{
+		Issues:     []GitlabPipelineRunnerFeatureFlagsIssue{},
+		Metrics:    GitlabPipelineRunnerFeatureFlagsMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineRunnerFeatureFlagsVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Runner feature flags control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	p.checkFeatureFlags(result, variableScopeInstance, "", gitlab.GetMapKeys(pipelineImageData.InstanceVars))
+	p.checkFeatureFlags(result, variableScopeGroup, "", gitlab.GetMapKeys(pipelineImageData.GroupVars))
+	p.checkFeatureFlags(result, variableScopeProject, "", gitlab.GetMapKeys(pipelineImageData.ProjectVars))
+	p.checkFeatureFlags(result, variableScopeGlobal, "", gitlab.GetMapKeys(pipelineImageData.GlobalVars))
+
+	for _, job := range jobs {
+		result.Metrics.TotalJobs++
+
+		names := make([]string, 0, len(job.Job.Variables))
+		for name := range job.Job.Variables {
+			names = append(names, name)
+		}
+		p.checkFeatureFlags(result, variableScopeJob, job.Name, names)
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"forbidden":  result.Metrics.Forbidden,
+		"compliance": result.Compliance,
+	}).Info("Runner feature flags control completed")
+
+	return result
+}
+
+// checkFeatureFlags adds an issue for each forbidden feature flag among the
+// names of a set of variables
+func (p *GitlabPipelineRunnerFeatureFlagsConf) checkFeatureFlags(result *GitlabPipelineRunnerFeatureFlagsResult, scope, job string, names []string) {
+	sort.Strings(names)
+	for _, name := range names {
+		if !gitlab.CheckItemMatchToPatterns(name, p.ForbiddenFlags) {
+			continue
+		}
+		result.Issues = append(result.Issues, GitlabPipelineRunnerFeatureFlagsIssue{
+			Scope: scope,
+			Job:   job,
+			Flag:  name,
+		})
+		result.Metrics.Forbidden++
+	}
+}
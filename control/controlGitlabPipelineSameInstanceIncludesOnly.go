@@ -0,0 +1,187 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineSameInstanceIncludesOnlyVersion = "0.1.0"
+
+// GitlabPipelineSameInstanceIncludesOnlyConf holds the configuration for cross-instance includes detection
+type GitlabPipelineSameInstanceIncludesOnlyConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// AllowedInstances is a list of other GitLab instances includes may be fetched from (supports wildcards)
+	AllowedInstances []string `json:"allowedInstances"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineSameInstanceIncludesOnlyConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	instancesConfig := plumberConfig.GetSameInstanceIncludesOnlyConfig()
+	if instancesConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration, instances are compared without scheme
+	p.Enabled = instancesConfig.IsEnabled()
+	p.AllowedInstances = make([]string, 0, len(instancesConfig.AllowedInstances))
+	for _, instance := range instancesConfig.AllowedInstances {
+		p.AllowedInstances = append(p.AllowedInstances, trimInstanceScheme(instance))
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":          p.Enabled,
+		"allowedInstances": p.AllowedInstances,
+	}).Debug("sameInstanceIncludesOnly control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineSameInstanceIncludesOnlyMetrics holds metrics about component and remote includes
+type GitlabPipelineSameInstanceIncludesOnlyMetrics struct {
+	Total        uint `json:"total"`
+	SameInstance uint `json:"sameInstance"`
+	Allowed      uint `json:"allowed"` // Includes from an allowlisted instance
+	Foreign      uint `json:"foreign"`
+	CiInvalid    uint `json:"ciInvalid"`
+	CiMissing    uint `json:"ciMissing"`
+}
+
+// GitlabPipelineSameInstanceIncludesOnlyResult holds the result of the same instance includes control
+type GitlabPipelineSameInstanceIncludesOnlyResult struct {
+	Issues     []GitlabPipelineSameInstanceIncludesOnlyIssue `json:"issues"`
+	Metrics    GitlabPipelineSameInstanceIncludesOnlyMetrics `json:"metrics"`
+	Compliance float64                                       `json:"compliance"`
+	Version    string                                        `json:"version"`
+	CiValid    bool                                          `json:"ciValid"`
+	CiMissing  bool                                          `json:"ciMissing"`
+	Skipped    bool                                          `json:"skipped"`         // True if control was disabled
+	Error      string                                        `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineSameInstanceIncludesOnlyIssue represents a component or remote
+// include fetched from another GitLab instance
+type GitlabPipelineSameInstanceIncludesOnlyIssue struct {
+	Location string `json:"location"`
+	Type     string `json:"type"`     // component or remote
+	Instance string `json:"instance"` // Instance the include is fetched from
+	Nested   bool   `json:"nested"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the same instance includes control on component and remote
+// includes. Includes prefixed with the host of gitlabURL or with a
+// $CI_SERVER_* variable are fetched from the analyzed instance.
+func (p *GitlabPipelineSameInstanceIncludesOnlyConf) Run(pipelineOriginData *collector.GitlabPipelineOriginData, gitlabURL string) *GitlabPipelineSameInstanceIncludesOnlyResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineSameInstanceIncludesOnly",
+		"controlVersion": ControlTypeGitlabPipelineSameInstanceIncludesOnlyVersion,
+	})
+	l.Info("Start same instance includes control")
+
+	result := &GitlabPipelineSameInstanceIncludesOnlyResult{
+		Issues:     []GitlabPipelineSameInstanceIncludesOnlyIssue{},
+		Metrics:    GitlabPipelineSameInstanceIncludesOnlyMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineSameInstanceIncludesOnlyVersion,
+		CiValid:    pipelineOriginData.CiValid,
+		CiMissing:  pipelineOriginData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Same instance includes control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineOriginData.CiValid || pipelineOriginData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineOriginData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineOriginData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	for _, origin := range pipelineOriginData.Origins {
+		if origin.OriginType != collector.OriginTypeComponent && origin.OriginType != collector.OriginTypeRemote {
+			continue
+		}
+		result.Metrics.Total++
+
+		instance := foreignIncludeInstance(origin.GitlabIncludeOrigin.Location, gitlabURL)
+		if instance == "" {
+			result.Metrics.SameInstance++
+			continue
+		}
+		if gitlab.CheckItemMatchToPatterns(instance, p.AllowedInstances) {
+			result.Metrics.Allowed++
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabPipelineSameInstanceIncludesOnlyIssue{
+			Location: origin.GitlabIncludeOrigin.Location,
+			Type:     origin.OriginType,
+			Instance: instance,
+			Nested:   origin.Nested,
+		})
+		result.Metrics.Foreign++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"includes":   result.Metrics.Total,
+		"foreign":    result.Metrics.Foreign,
+		"compliance": result.Compliance,
+	}).Info("Same instance includes control completed")
+
+	return result
+}
+
+// foreignIncludeInstance returns the instance a component or remote include
+// is fetched from, or an empty string if it is the instance of gitlabURL.
+// Component locations are stored by the collector without their version and
+// with a leading slash when the instance is not recognized.
+func foreignIncludeInstance(location string, gitlabURL string) string {
+	location = strings.TrimPrefix(trimInstanceScheme(location), "/")
+
+	instance, cleanPath, _ := collector.ParseGitlabComponentPath(location, gitlabURL)
+	if instance != "" {
+		return ""
+	}
+
+	host, _, _ := strings.Cut(cleanPath, "/")
+	return strings.ToLower(host)
+}
+
+// trimInstanceScheme removes the scheme and trailing slashes of an instance
+// or URL (e.g., https://gitlab.com/ gives gitlab.com)
+func trimInstanceScheme(instance string) string {
+	instance = strings.TrimPrefix(instance, "https://")
+	instance = strings.TrimPrefix(instance, "http://")
+	return strings.TrimRight(instance, "/")
+}
@@ -0,0 +1,194 @@
+package control
+
+import (
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineSecretsManagerRequiredVersion = "0.1.0"
+
+// DefaultSensitiveNamePatterns are the variable names considered sensitive when not configured
+var DefaultSensitiveNamePatterns = []string{
+	"*PASSWORD*",
+	"*PASSWD*",
+	"*SECRET*",
+	"*TOKEN*",
+	"*API_KEY*",
+	"*PRIVATE_KEY*",
+	"*CREDENTIALS*",
+}
+
+// GitlabPipelineSecretsManagerRequiredConf holds the configuration for secrets manager usage detection
+type GitlabPipelineSecretsManagerRequiredConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// SensitiveNamePatterns is a list of variable name patterns considered sensitive (supports wildcards)
+	SensitiveNamePatterns []string `json:"sensitiveNamePatterns"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineSecretsManagerRequiredConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	secretsConfig := plumberConfig.GetSecretsManagerRequiredConfig()
+	if secretsConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = secretsConfig.IsEnabled()
+	p.SensitiveNamePatterns = secretsConfig.SensitiveNamePatterns
+	if len(p.SensitiveNamePatterns) == 0 {
+		p.SensitiveNamePatterns = DefaultSensitiveNamePatterns
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":               p.Enabled,
+		"sensitiveNamePatterns": p.SensitiveNamePatterns,
+	}).Debug("secretsManagerRequired control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineSecretsManagerRequiredMetrics holds metrics about sensitive variables
+type GitlabPipelineSecretsManagerRequiredMetrics struct {
+	SensitiveVariables uint `json:"sensitiveVariables"`
+	FromSecretsManager uint `json:"fromSecretsManager"`
+	Inline             uint `json:"inline"`
+	CiInvalid          uint `json:"ciInvalid"`
+	CiMissing          uint `json:"ciMissing"`
+}
+
+// GitlabPipelineSecretsManagerRequiredResult holds the result of the secrets manager required control
+type GitlabPipelineSecretsManagerRequiredResult struct {
+	Issues     []GitlabPipelineSecretsManagerRequiredIssue `json:"issues"`
+	Metrics    GitlabPipelineSecretsManagerRequiredMetrics `json:"metrics"`
+	Compliance float64                                     `json:"compliance"`
+	Version    string                                      `json:"version"`
+	CiValid    bool                                        `json:"ciValid"`
+	CiMissing  bool                                        `json:"ciMissing"`
+	Skipped    bool                                        `json:"skipped"`         // True if control was disabled
+	Error      string                                      `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineSecretsManagerRequiredIssue represents a sensitive variable declared inline in a job.
+// The value of the variable is never reported.
+type GitlabPipelineSecretsManagerRequiredIssue struct {
+	Job      string `json:"job"`
+	Variable string `json:"variable"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the secrets manager required control
+func (p *GitlabPipelineSecretsManagerRequiredConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineSecretsManagerRequiredResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineSecretsManagerRequired",
+		"controlVersion": ControlTypeGitlabPipelineSecretsManagerRequiredVersion,
+	})
+	l.Info("Start secrets manager required control")
+
+	result := &GitlabPipelineSecretsManagerRequiredResult{
+		Issues:     []GitlabPipelineSecretsManagerRequiredIssue{},
+		Metrics:    GitlabPipelineSecretsManagerRequiredMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineSecretsManagerRequiredVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Secrets manager required control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	for _, job := range jobs {
+		// Sensitive values fetched from a secrets manager
+		for _, name := range jobSecretNames(job.Job.Secrets) {
+			if gitlab.CheckItemMatchToPatterns(name, p.SensitiveNamePatterns) {
+				result.Metrics.SensitiveVariables++
+				result.Metrics.FromSecretsManager++
+			}
+		}
+
+		// Sensitive values declared inline in the job variables
+		names := make([]string, 0, len(job.Job.Variables))
+		for name := range job.Job.Variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if !gitlab.CheckItemMatchToPatterns(name, p.SensitiveNamePatterns) {
+				continue
+			}
+			result.Metrics.SensitiveVariables++
+			result.Metrics.Inline++
+			result.Issues = append(result.Issues, GitlabPipelineSecretsManagerRequiredIssue{
+				Job:      job.Name,
+				Variable: name,
+			})
+		}
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"sensitiveVariables": result.Metrics.SensitiveVariables,
+		"inline":             result.Metrics.Inline,
+		"compliance":         result.Compliance,
+	}).Info("Secrets manager required control completed")
+
+	return result
+}
+
+// jobSecretNames returns the names of the secrets declared under 'secrets:'
+func jobSecretNames(secrets interface{}) []string {
+	names := []string{}
+	if secretsMap, ok := secrets.(map[interface{}]interface{}); ok {
+		for name := range secretsMap {
+			if nameString, ok := name.(string); ok {
+				names = append(names, nameString)
+			}
+		}
+	}
+	return names
+}
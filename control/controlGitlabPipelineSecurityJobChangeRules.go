@@ -0,0 +1,182 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineSecurityJobChangeRulesVersion = "0.1.0"
+
+// DefaultSecurityJobPatterns matches the jobs of GitLab security scanning templates
+var DefaultSecurityJobPatterns = []string{
+	"*sast*",
+	"secret_detection*",
+	"dependency_scanning*",
+	"container_scanning*",
+	"dast*",
+	"*-dependency_scanning",
+	"iac-sast*",
+	"license_scanning*",
+}
+
+// GitlabPipelineSecurityJobChangeRulesConf holds the configuration for security job change rules detection
+type GitlabPipelineSecurityJobChangeRulesConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// JobPatterns is a list of job name patterns identifying security jobs (supports wildcards)
+	JobPatterns []string `json:"jobPatterns"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineSecurityJobChangeRulesConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	changeRulesConfig := plumberConfig.GetSecurityJobChangeRulesConfig()
+	if changeRulesConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = changeRulesConfig.IsEnabled()
+	p.JobPatterns = changeRulesConfig.JobPatterns
+	if len(p.JobPatterns) == 0 {
+		p.JobPatterns = DefaultSecurityJobPatterns
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":     p.Enabled,
+		"jobPatterns": p.JobPatterns,
+	}).Debug("securityJobChangeRules control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineSecurityJobChangeRulesMetrics holds metrics about security jobs
+type GitlabPipelineSecurityJobChangeRulesMetrics struct {
+	SecurityJobs     uint `json:"securityJobs"`
+	WithChangesRules uint `json:"withChangesRules"`
+	CiInvalid        uint `json:"ciInvalid"`
+	CiMissing        uint `json:"ciMissing"`
+}
+
+// GitlabPipelineSecurityJobChangeRulesResult holds the result of the security job change rules control
+type GitlabPipelineSecurityJobChangeRulesResult struct {
+	Issues     []GitlabPipelineSecurityJobChangeRulesIssue `json:"issues"`
+	Metrics    GitlabPipelineSecurityJobChangeRulesMetrics `json:"metrics"`
+	Compliance float64                                     `json:"compliance"`
+	Version    string                                      `json:"version"`
+	CiValid    bool                                        `json:"ciValid"`
+	CiMissing  bool                                        `json:"ciMissing"`
+	Skipped    bool                                        `json:"skipped"`         // True if control was disabled
+	Error      string                                      `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineSecurityJobChangeRulesIssue represents a security job restricted by 'rules:changes'
+type GitlabPipelineSecurityJobChangeRulesIssue struct {
+	Job     string   `json:"job"`
+	Changes []string `json:"changes"` // Paths of all 'changes' clauses of the job rules
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the security job change rules control
+func (p *GitlabPipelineSecurityJobChangeRulesConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineSecurityJobChangeRulesResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineSecurityJobChangeRules",
+		"controlVersion": ControlTypeGitlabPipelineSecurityJobChangeRulesVersion,
+	})
+	l.Info("Start security job change rules control")
+
+	result := &GitlabPipelineSecurityJobChangeRulesResult{
+		Issues:     []GitlabPipelineSecurityJobChangeRulesIssue{},
+		Metrics:    GitlabPipelineSecurityJobChangeRulesMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineSecurityJobChangeRulesVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Security job change rules control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	// Security scans should run whatever the changed files are: any 'changes'
+	// clause lets a change outside of the listed paths skip the scan
+	for _, job := range jobs {
+		if !gitlab.CheckItemMatchToPatterns(job.Name, p.JobPatterns) {
+			continue
+		}
+		result.Metrics.SecurityJobs++
+
+		rules, err := gitlab.GetRules(job.Job.Rules)
+		if err != nil {
+			l.WithError(err).WithField("job", job.Name).Warn("Unable to parse job rules, skipping job")
+			continue
+		}
+
+		hasChanges := false
+		changes := []string{}
+		for _, rule := range rules {
+			if rule.HasChanges {
+				hasChanges = true
+				changes = append(changes, rule.ChangesFrom...)
+			}
+		}
+		if !hasChanges {
+			continue
+		}
+
+		result.Metrics.WithChangesRules++
+		result.Issues = append(result.Issues, GitlabPipelineSecurityJobChangeRulesIssue{
+			Job:     job.Name,
+			Changes: changes,
+		})
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"securityJobs":     result.Metrics.SecurityJobs,
+		"withChangesRules": result.Metrics.WithChangesRules,
+		"compliance":       result.Compliance,
+	}).Info("Security job change rules control completed")
+
+	return result
+}
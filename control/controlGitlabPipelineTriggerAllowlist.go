@@ -0,0 +1,233 @@
+package control
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineTriggerAllowlistVersion = "0.1.0"
+
+// Trigger target types
+const (
+	triggerTargetProject = "project"
+	triggerTargetInclude = "include"
+	triggerTargetRemote  = "remote"
+)
+
+// GitlabPipelineTriggerAllowlistConf holds the configuration for trigger allowlist detection
+type GitlabPipelineTriggerAllowlistConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// AllowedProjects is a list of projects (or remote URLs) trigger jobs may target (supports wildcards)
+	AllowedProjects []string `json:"allowedProjects"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineTriggerAllowlistConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	triggerConfig := plumberConfig.GetTriggerAllowlistConfig()
+	if triggerConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = triggerConfig.IsEnabled()
+	p.AllowedProjects = triggerConfig.AllowedProjects
+	if p.AllowedProjects == nil {
+		p.AllowedProjects = []string{}
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":         p.Enabled,
+		"allowedProjects": p.AllowedProjects,
+	}).Debug("triggerAllowlist control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineTriggerAllowlistMetrics holds metrics about trigger jobs
+type GitlabPipelineTriggerAllowlistMetrics struct {
+	Total        uint `json:"total"`
+	Authorized   uint `json:"authorized"`
+	Unauthorized uint `json:"unauthorized"`
+	CiInvalid    uint `json:"ciInvalid"`
+	CiMissing    uint `json:"ciMissing"`
+}
+
+// GitlabPipelineTriggerAllowlistResult holds the result of the trigger allowlist control
+type GitlabPipelineTriggerAllowlistResult struct {
+	Issues     []GitlabPipelineTriggerAllowlistIssue `json:"issues"`
+	Metrics    GitlabPipelineTriggerAllowlistMetrics `json:"metrics"`
+	Compliance float64                               `json:"compliance"`
+	Version    string                                `json:"version"`
+	CiValid    bool                                  `json:"ciValid"`
+	CiMissing  bool                                  `json:"ciMissing"`
+	Skipped    bool                                  `json:"skipped"`         // True if control was disabled
+	Error      string                                `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineTriggerAllowlistIssue represents a trigger job targeting a project not in the allowlist
+type GitlabPipelineTriggerAllowlistIssue struct {
+	Job        string `json:"job"`
+	TargetType string `json:"targetType"` // "project", "include" (project of a child pipeline include) or "remote"
+	Target     string `json:"target"`
+}
+
+// triggerTarget is a project or remote configuration a trigger job hands control to
+type triggerTarget struct {
+	targetType string
+	target     string
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the trigger allowlist control
+func (p *GitlabPipelineTriggerAllowlistConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineTriggerAllowlistResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineTriggerAllowlist",
+		"controlVersion": ControlTypeGitlabPipelineTriggerAllowlistVersion,
+	})
+	l.Info("Start trigger allowlist control")
+
+	result := &GitlabPipelineTriggerAllowlistResult{
+		Issues:     []GitlabPipelineTriggerAllowlistIssue{},
+		Metrics:    GitlabPipelineTriggerAllowlistMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineTriggerAllowlistVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Trigger allowlist control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	// Loop over all trigger jobs to check their targets
+	for _, job := range jobs {
+		if job.Job.Trigger == nil {
+			continue
+		}
+
+		jobVars, _ := gitlab.ParseJobVariables(job.Job)
+		for _, target := range parseTriggerTargets(job.Job.Trigger) {
+			resolved := gitlab.ReplaceVariable(target.target, pipelineImageData.ProjectVars, pipelineImageData.GroupVars, pipelineImageData.InstanceVars, jobVars, pipelineImageData.GlobalVars, nil)
+
+			result.Metrics.Total++
+			if gitlab.CheckItemMatchToPatterns(resolved, p.AllowedProjects) {
+				result.Metrics.Authorized++
+				continue
+			}
+
+			result.Issues = append(result.Issues, GitlabPipelineTriggerAllowlistIssue{
+				Job:        job.Name,
+				TargetType: target.targetType,
+				Target:     resolved,
+			})
+			result.Metrics.Unauthorized++
+		}
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"totalTriggerTargets": result.Metrics.Total,
+		"unauthorized":        result.Metrics.Unauthorized,
+		"compliance":          result.Compliance,
+	}).Info("Trigger allowlist control completed")
+
+	return result
+}
+
+// parseTriggerTargets returns the external targets of a trigger job. Both the
+// 'trigger: project' (multi-project pipeline) and 'trigger: include' (child
+// pipeline) forms are supported. Child pipelines from local files, templates
+// or artifacts stay in the current project and are not returned.
+func parseTriggerTargets(trigger interface{}) []triggerTarget {
+	targets := []triggerTarget{}
+
+	switch t := trigger.(type) {
+	case string:
+		targets = append(targets, triggerTarget{targetType: triggerTargetProject, target: t})
+	case map[interface{}]interface{}:
+		if project, ok := t["project"].(string); ok && project != "" {
+			targets = append(targets, triggerTarget{targetType: triggerTargetProject, target: project})
+		}
+		if include, ok := t["include"]; ok {
+			targets = append(targets, parseTriggerIncludeTargets(include)...)
+		}
+	}
+
+	return targets
+}
+
+// parseTriggerIncludeTargets returns the external targets of a trigger include
+func parseTriggerIncludeTargets(include interface{}) []triggerTarget {
+	targets := []triggerTarget{}
+
+	switch inc := include.(type) {
+	case string:
+		// A string is a local file, unless it is a remote URL
+		if isRemoteInclude(inc) {
+			targets = append(targets, triggerTarget{targetType: triggerTargetRemote, target: inc})
+		}
+	case []interface{}:
+		for _, item := range inc {
+			targets = append(targets, parseTriggerIncludeTargets(item)...)
+		}
+	case map[interface{}]interface{}:
+		if project, ok := inc["project"]; ok {
+			targets = append(targets, triggerTarget{targetType: triggerTargetInclude, target: fmt.Sprintf("%v", project)})
+		}
+		if remote, ok := inc["remote"]; ok {
+			targets = append(targets, triggerTarget{targetType: triggerTargetRemote, target: fmt.Sprintf("%v", remote)})
+		}
+	}
+
+	return targets
+}
+
+// isRemoteInclude reports whether an include string is a remote URL
+func isRemoteInclude(include string) bool {
+	return strings.HasPrefix(include, "http://") || strings.HasPrefix(include, "https://")
+}
@@ -0,0 +1,164 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineTrustedIncludeProjectsVersion = "0.1.0"
+
+// GitlabPipelineTrustedIncludeProjectsConf holds the configuration for untrusted project includes detection
+type GitlabPipelineTrustedIncludeProjectsConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// AllowedProjects is a list of projects files may be included from (supports wildcards)
+	AllowedProjects []string `json:"allowedProjects"`
+}
+
+// validateTrustedIncludeProjectsConfig validates the trustedIncludeProjects configuration
+func validateTrustedIncludeProjectsConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	projectsConfig := plumberConfig.GetTrustedIncludeProjectsConfig()
+	if !projectsConfig.IsEnabled() {
+		return
+	}
+
+	if len(projectsConfig.AllowedProjects) == 0 {
+		v.add("trustedIncludeProjects.allowedProjects", "field is required when the control is enabled", "a list of allowed projects")
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineTrustedIncludeProjectsConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	projectsConfig := plumberConfig.GetTrustedIncludeProjectsConfig()
+	if projectsConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateTrustedIncludeProjectsConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = projectsConfig.IsEnabled()
+	p.AllowedProjects = projectsConfig.AllowedProjects
+
+	l.WithFields(logrus.Fields{
+		"enabled":         p.Enabled,
+		"allowedProjects": p.AllowedProjects,
+	}).Debug("trustedIncludeProjects control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineTrustedIncludeProjectsMetrics holds metrics about project includes
+type GitlabPipelineTrustedIncludeProjectsMetrics struct {
+	Total        uint `json:"total"`
+	Authorized   uint `json:"authorized"`
+	Unauthorized uint `json:"unauthorized"`
+	CiInvalid    uint `json:"ciInvalid"`
+	CiMissing    uint `json:"ciMissing"`
+}
+
+// GitlabPipelineTrustedIncludeProjectsResult holds the result of the trusted include projects control
+type GitlabPipelineTrustedIncludeProjectsResult struct {
+	Issues     []GitlabPipelineTrustedIncludeProjectsIssue `json:"issues"`
+	Metrics    GitlabPipelineTrustedIncludeProjectsMetrics `json:"metrics"`
+	Compliance float64                                     `json:"compliance"`
+	Version    string                                      `json:"version"`
+	CiValid    bool                                        `json:"ciValid"`
+	CiMissing  bool                                        `json:"ciMissing"`
+	Skipped    bool                                        `json:"skipped"`         // True if control was disabled
+	Error      string                                      `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineTrustedIncludeProjectsIssue represents a file included from a project outside the allowlist
+type GitlabPipelineTrustedIncludeProjectsIssue struct {
+	Location string `json:"location"` // Path of the included file
+	Project  string `json:"project"`  // Source project of the file
+	Version  string `json:"version,omitempty"`
+	Nested   bool   `json:"nested"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the trusted include projects control on project file includes
+func (p *GitlabPipelineTrustedIncludeProjectsConf) Run(pipelineOriginData *collector.GitlabPipelineOriginData) *GitlabPipelineTrustedIncludeProjectsResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineTrustedIncludeProjects",
+		"controlVersion": ControlTypeGitlabPipelineTrustedIncludeProjectsVersion,
+	})
+	l.Info("Start trusted include projects control")
+
+	result := &GitlabPipelineTrustedIncludeProjectsResult{
+		Issues:     []GitlabPipelineTrustedIncludeProjectsIssue{},
+		Metrics:    GitlabPipelineTrustedIncludeProjectsMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineTrustedIncludeProjectsVersion,
+		CiValid:    pipelineOriginData.CiValid,
+		CiMissing:  pipelineOriginData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Trusted include projects control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineOriginData.CiValid || pipelineOriginData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineOriginData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineOriginData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	for _, origin := range pipelineOriginData.Origins {
+		if origin.OriginType != collector.OriginTypeProject {
+			continue
+		}
+		result.Metrics.Total++
+
+		if gitlab.CheckItemMatchToPatterns(origin.GitlabIncludeOrigin.Project, p.AllowedProjects) {
+			result.Metrics.Authorized++
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabPipelineTrustedIncludeProjectsIssue{
+			Location: origin.GitlabIncludeOrigin.Location,
+			Project:  origin.GitlabIncludeOrigin.Project,
+			Version:  origin.Version,
+			Nested:   origin.Nested,
+		})
+		result.Metrics.Unauthorized++
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"projectIncludes": result.Metrics.Total,
+		"unauthorized":    result.Metrics.Unauthorized,
+		"compliance":      result.Compliance,
+	}).Info("Trusted include projects control completed")
+
+	return result
+}
@@ -0,0 +1,170 @@
+package control
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineVariableCountBudgetVersion = "0.1.0"
+
+// DefaultMaxProjectVariables is the maximum number of project variables when
+// maxProjectVariables is not set
+const DefaultMaxProjectVariables = 50
+
+// GitlabPipelineVariableCountBudgetConf holds the configuration for variable bloat detection
+type GitlabPipelineVariableCountBudgetConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// MaxProjectVariables is the maximum number of CI/CD variables of the project
+	MaxProjectVariables int `json:"maxProjectVariables"`
+}
+
+// validateVariableCountBudgetConfig validates the variableCountBudget configuration
+func validateVariableCountBudgetConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	budgetConfig := plumberConfig.GetVariableCountBudgetConfig()
+	if !budgetConfig.IsEnabled() {
+		return
+	}
+
+	if budgetConfig.MaxProjectVariables != nil && *budgetConfig.MaxProjectVariables < 0 {
+		v.add("variableCountBudget.maxProjectVariables", fmt.Sprintf("invalid number of variables %d", *budgetConfig.MaxProjectVariables), "a number greater than or equal to 0")
+	}
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineVariableCountBudgetConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	budgetConfig := plumberConfig.GetVariableCountBudgetConfig()
+	if budgetConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+	if err := validateControlConfig(plumberConfig, validateVariableCountBudgetConfig); err != nil {
+		return err
+	}
+
+	// Apply configuration
+	p.Enabled = budgetConfig.IsEnabled()
+	p.MaxProjectVariables = DefaultMaxProjectVariables
+	if budgetConfig.MaxProjectVariables != nil {
+		p.MaxProjectVariables = *budgetConfig.MaxProjectVariables
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":             p.Enabled,
+		"maxProjectVariables": p.MaxProjectVariables,
+	}).Debug("variableCountBudget control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineVariableCountBudgetMetrics holds metrics about CI/CD variables
+type GitlabPipelineVariableCountBudgetMetrics struct {
+	ProjectVariables    uint `json:"projectVariables"`
+	GroupVariables      uint `json:"groupVariables"`
+	InstanceVariables   uint `json:"instanceVariables"`
+	MaxProjectVariables uint `json:"maxProjectVariables"`
+	CiInvalid           uint `json:"ciInvalid"`
+	CiMissing           uint `json:"ciMissing"`
+}
+
+// GitlabPipelineVariableCountBudgetResult holds the result of the variable count budget control
+type GitlabPipelineVariableCountBudgetResult struct {
+	Issues     []GitlabPipelineVariableCountBudgetIssue `json:"issues"`
+	Metrics    GitlabPipelineVariableCountBudgetMetrics `json:"metrics"`
+	Compliance float64                                  `json:"compliance"`
+	Version    string                                   `json:"version"`
+	CiValid    bool                                     `json:"ciValid"`
+	CiMissing  bool                                     `json:"ciMissing"`
+	Skipped    bool                                     `json:"skipped"`         // True if control was disabled
+	Error      string                                   `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineVariableCountBudgetIssue represents a project with too many
+// CI/CD variables. Variable values are never reported.
+type GitlabPipelineVariableCountBudgetIssue struct {
+	Count     int      `json:"count"`
+	MaxCount  int      `json:"maxCount"`
+	Variables []string `json:"variables"` // Variable names
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the variable count budget control on the project CI/CD
+// variables. Variables defined for several environments are counted once.
+func (p *GitlabPipelineVariableCountBudgetConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineVariableCountBudgetResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineVariableCountBudget",
+		"controlVersion": ControlTypeGitlabPipelineVariableCountBudgetVersion,
+	})
+	l.Info("Start variable count budget control")
+
+	result := &GitlabPipelineVariableCountBudgetResult{
+		Issues:     []GitlabPipelineVariableCountBudgetIssue{},
+		Metrics:    GitlabPipelineVariableCountBudgetMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineVariableCountBudgetVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Variable count budget control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	result.Metrics.MaxProjectVariables = uint(p.MaxProjectVariables)
+
+	// If CI is invalid or missing, return early: variables are not collected
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	result.Metrics.ProjectVariables = uint(len(pipelineImageData.ProjectVars))
+	result.Metrics.GroupVariables = uint(len(pipelineImageData.GroupVars))
+	result.Metrics.InstanceVariables = uint(len(pipelineImageData.InstanceVars))
+
+	if len(pipelineImageData.ProjectVars) > p.MaxProjectVariables {
+		names := make([]string, 0, len(pipelineImageData.ProjectVars))
+		for name := range pipelineImageData.ProjectVars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		result.Issues = append(result.Issues, GitlabPipelineVariableCountBudgetIssue{
+			Count:     len(names),
+			MaxCount:  p.MaxProjectVariables,
+			Variables: names,
+		})
+		result.Compliance = 0.0
+	}
+
+	l.WithFields(logrus.Fields{
+		"projectVariables":    result.Metrics.ProjectVariables,
+		"maxProjectVariables": result.Metrics.MaxProjectVariables,
+		"compliance":          result.Compliance,
+	}).Info("Variable count budget control completed")
+
+	return result
+}
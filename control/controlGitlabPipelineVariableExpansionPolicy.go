@@ -0,0 +1,203 @@
+package control
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabPipelineVariableExpansionPolicyVersion = "0.1.0"
+
+// DefaultNoExpandVariablePatterns are secret-like variable names whose value
+// must not be expanded, used when noExpandPatterns is not set
+var DefaultNoExpandVariablePatterns = []string{
+	"*TOKEN*",
+	"*PASSWORD*",
+	"*SECRET*",
+	"*PRIVATE_KEY*",
+}
+
+// GitlabPipelineVariableExpansionPolicyConf holds the configuration for variable expansion detection
+type GitlabPipelineVariableExpansionPolicyConf struct {
+	// Enabled controls whether this check runs
+	Enabled bool `json:"enabled"`
+
+	// NoExpandPatterns are variable names that must set 'expand: false' (supports wildcards)
+	NoExpandPatterns []string `json:"noExpandPatterns"`
+
+	// ExpandPatterns are variable names that must not set 'expand: false' (supports wildcards)
+	ExpandPatterns []string `json:"expandPatterns"`
+}
+
+// GetConf loads configuration from PlumberConfig
+// The control is disabled if not configured
+func (p *GitlabPipelineVariableExpansionPolicyConf) GetConf(plumberConfig *configuration.PlumberConfig) error {
+	expansionConfig := plumberConfig.GetVariableExpansionPolicyConfig()
+	if expansionConfig == nil {
+		p.Enabled = false
+		return nil
+	}
+
+	// Apply configuration
+	p.Enabled = expansionConfig.IsEnabled()
+	p.NoExpandPatterns = expansionConfig.NoExpandPatterns
+	if len(p.NoExpandPatterns) == 0 {
+		p.NoExpandPatterns = DefaultNoExpandVariablePatterns
+	}
+	p.ExpandPatterns = expansionConfig.ExpandPatterns
+	if p.ExpandPatterns == nil {
+		p.ExpandPatterns = []string{}
+	}
+
+	l.WithFields(logrus.Fields{
+		"enabled":          p.Enabled,
+		"noExpandPatterns": p.NoExpandPatterns,
+		"expandPatterns":   p.ExpandPatterns,
+	}).Debug("variableExpansionPolicy control configuration loaded from .plumber.yaml file")
+
+	return nil
+}
+
+// GitlabPipelineVariableExpansionPolicyMetrics holds metrics about variable expansion
+type GitlabPipelineVariableExpansionPolicyMetrics struct {
+	Variables        uint `json:"variables"` // Variables with a reference, where expansion matters
+	UnexpectedExpand uint `json:"unexpectedExpand"`
+	UnexpectedRaw    uint `json:"unexpectedRaw"`
+	CiInvalid        uint `json:"ciInvalid"`
+	CiMissing        uint `json:"ciMissing"`
+}
+
+// GitlabPipelineVariableExpansionPolicyResult holds the result of the variable expansion control
+type GitlabPipelineVariableExpansionPolicyResult struct {
+	Issues     []GitlabPipelineVariableExpansionPolicyIssue `json:"issues"`
+	Metrics    GitlabPipelineVariableExpansionPolicyMetrics `json:"metrics"`
+	Compliance float64                                      `json:"compliance"`
+	Version    string                                       `json:"version"`
+	CiValid    bool                                         `json:"ciValid"`
+	CiMissing  bool                                         `json:"ciMissing"`
+	Skipped    bool                                         `json:"skipped"`         // True if control was disabled
+	Error      string                                       `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineVariableExpansionPolicyIssue represents a variable whose expansion setting doesn't match the policy
+type GitlabPipelineVariableExpansionPolicyIssue struct {
+	Job            string `json:"job,omitempty"` // Empty for global variables
+	Variable       string `json:"variable"`
+	Expand         bool   `json:"expand"`
+	ExpectedExpand bool   `json:"expectedExpand"`
+}
+
+///////////////////////
+// Control functions //
+///////////////////////
+
+// Run executes the variable expansion control. Only variables whose value
+// contains a reference ('$') are checked, expansion has no effect on others.
+func (p *GitlabPipelineVariableExpansionPolicyConf) Run(pipelineImageData *collector.GitlabPipelineImageData) *GitlabPipelineVariableExpansionPolicyResult {
+	l := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineVariableExpansionPolicy",
+		"controlVersion": ControlTypeGitlabPipelineVariableExpansionPolicyVersion,
+	})
+	l.Info("Start variable expansion control")
+
+	result := &GitlabPipelineVariableExpansionPolicyResult{
+		Issues:     []GitlabPipelineVariableExpansionPolicyIssue{},
+		Metrics:    GitlabPipelineVariableExpansionPolicyMetrics{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabPipelineVariableExpansionPolicyVersion,
+		CiValid:    pipelineImageData.CiValid,
+		CiMissing:  pipelineImageData.CiMissing,
+		Skipped:    false,
+	}
+
+	// Check if control is enabled
+	if !p.Enabled {
+		l.Info("Variable expansion control is disabled, skipping")
+		result.Skipped = true
+		return result
+	}
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		l.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	p.checkVariables(result, "", pipelineImageData.MergedConf.GlobalVariables)
+	for _, job := range jobs {
+		p.checkVariables(result, job.Name, job.Job.Variables)
+	}
+
+	// Calculate compliance based on issues
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+		l.WithField("issuesCount", len(result.Issues)).Debug("Found issues, setting compliance to 0")
+	}
+
+	l.WithFields(logrus.Fields{
+		"variables":        result.Metrics.Variables,
+		"unexpectedExpand": result.Metrics.UnexpectedExpand,
+		"unexpectedRaw":    result.Metrics.UnexpectedRaw,
+		"compliance":       result.Compliance,
+	}).Info("Variable expansion control completed")
+
+	return result
+}
+
+// checkVariables checks the expansion setting of a set of variables
+// (global variables if job is empty)
+func (p *GitlabPipelineVariableExpansionPolicyConf) checkVariables(result *GitlabPipelineVariableExpansionPolicyResult, job string, variables map[string]interface{}) {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, err := gitlab.GetVariableValue(variables[name])
+		if err != nil || !strings.Contains(value, "$") {
+			continue
+		}
+		result.Metrics.Variables++
+
+		expand := gitlab.GetVariableExpand(variables[name])
+		issue := GitlabPipelineVariableExpansionPolicyIssue{
+			Job:      job,
+			Variable: name,
+			Expand:   expand,
+		}
+
+		switch {
+		case expand && gitlab.CheckItemMatchToPatterns(name, p.NoExpandPatterns):
+			issue.ExpectedExpand = false
+			result.Issues = append(result.Issues, issue)
+			result.Metrics.UnexpectedExpand++
+		case !expand && gitlab.CheckItemMatchToPatterns(name, p.ExpandPatterns):
+			issue.ExpectedExpand = true
+			result.Issues = append(result.Issues, issue)
+			result.Metrics.UnexpectedRaw++
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProjectDefaultBranchNameVersion = "0.1.0"
+
+// DefaultAllowedDefaultBranchNames are the allowed default branch names when allowedNames is not set
+var DefaultAllowedDefaultBranchNames = []string{"main"}
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabDefaultBranchNameControl handles default branch name compliance checking
+type GitlabDefaultBranchNameControl struct {
+	config *configuration.DefaultBranchNameControlConfig
+}
+
+// NewGitlabDefaultBranchNameControl creates a new default branch name control instance
+func NewGitlabDefaultBranchNameControl(config *configuration.DefaultBranchNameControlConfig) *GitlabDefaultBranchNameControl {
+	return &GitlabDefaultBranchNameControl{
+		config: config,
+	}
+}
+
+// GitlabDefaultBranchNameResult holds the result of the default branch name control
+type GitlabDefaultBranchNameResult struct {
+	Issues        []GitlabDefaultBranchNameIssue `json:"issues"`
+	DefaultBranch string                         `json:"defaultBranch"`
+	AllowedNames  []string                       `json:"allowedNames"`
+	Compliance    float64                        `json:"compliance"`
+	Version       string                         `json:"version"`
+	Skipped       bool                           `json:"skipped"`         // True if control was disabled
+	Error         string                         `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabDefaultBranchNameIssue represents a project whose default branch name is not allowed
+type GitlabDefaultBranchNameIssue struct {
+	DefaultBranch string   `json:"defaultBranch"`
+	AllowedNames  []string `json:"allowedNames"`
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the default branch name compliance check
+func (c *GitlabDefaultBranchNameControl) Run(project *gitlab.ProjectInfo) *GitlabDefaultBranchNameResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabDefaultBranchName",
+		"controlVersion": ControlTypeGitlabProjectDefaultBranchNameVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabDefaultBranchNameResult{
+		Issues:        []GitlabDefaultBranchNameIssue{},
+		DefaultBranch: project.DefaultBranch,
+		AllowedNames:  []string{},
+		Compliance:    100.0,
+		Version:       ControlTypeGitlabProjectDefaultBranchNameVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Default branch name control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start default branch name control")
+
+	result.AllowedNames = DefaultAllowedDefaultBranchNames
+	if len(c.config.AllowedNames) > 0 {
+		result.AllowedNames = c.config.AllowedNames
+	}
+
+	// Empty repositories have no default branch
+	if project.DefaultBranch == "" {
+		logger.Warn("Project has no default branch, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "project has no default branch"
+		return result
+	}
+
+	allowed := false
+	for _, name := range result.AllowedNames {
+		if project.DefaultBranch == name {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		result.Issues = append(result.Issues, GitlabDefaultBranchNameIssue{
+			DefaultBranch: project.DefaultBranch,
+			AllowedNames:  result.AllowedNames,
+		})
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"defaultBranch": project.DefaultBranch,
+		"allowedNames":  result.AllowedNames,
+		"compliance":    result.Compliance,
+	}).Info("Default branch name control completed")
+
+	return result
+}
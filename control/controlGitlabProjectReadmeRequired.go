@@ -0,0 +1,123 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProjectReadmeRequiredVersion = "0.1.0"
+
+// DefaultReadmePaths are the README paths looked for when paths is not set
+var DefaultReadmePaths = []string{"README.md", "README.rst", "README"}
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabReadmeRequiredControl handles README presence compliance checking
+type GitlabReadmeRequiredControl struct {
+	config *configuration.ReadmeRequiredControlConfig
+}
+
+// NewGitlabReadmeRequiredControl creates a new README required control instance
+func NewGitlabReadmeRequiredControl(config *configuration.ReadmeRequiredControlConfig) *GitlabReadmeRequiredControl {
+	return &GitlabReadmeRequiredControl{
+		config: config,
+	}
+}
+
+// Paths returns the README paths to look for
+func (c *GitlabReadmeRequiredControl) Paths() []string {
+	if c.config == nil || len(c.config.Paths) == 0 {
+		return DefaultReadmePaths
+	}
+	return c.config.Paths
+}
+
+// GitlabReadmeRequiredResult holds the result of the README required control
+type GitlabReadmeRequiredResult struct {
+	Issues       []GitlabReadmeRequiredIssue `json:"issues"`
+	CheckedPaths []string                    `json:"checkedPaths"`
+	FoundPath    string                      `json:"foundPath,omitempty"`
+	Compliance   float64                     `json:"compliance"`
+	Version      string                      `json:"version"`
+	Skipped      bool                        `json:"skipped"`         // True if control was disabled
+	Error        string                      `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabReadmeRequiredIssue represents a project without README
+type GitlabReadmeRequiredIssue struct {
+	Ref          string   `json:"ref"`
+	CheckedPaths []string `json:"checkedPaths"`
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the README required compliance check
+func (c *GitlabReadmeRequiredControl) Run(
+	filesData *collector.GitlabRepositoryFilesData,
+	project *gitlab.ProjectInfo,
+) *GitlabReadmeRequiredResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabReadmeRequired",
+		"controlVersion": ControlTypeGitlabProjectReadmeRequiredVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabReadmeRequiredResult{
+		Issues:       []GitlabReadmeRequiredIssue{},
+		CheckedPaths: c.Paths(),
+		Compliance:   100.0,
+		Version:      ControlTypeGitlabProjectReadmeRequiredVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("README required control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start README required control")
+
+	// Repository files could not be checked
+	if filesData == nil {
+		logger.Warn("Repository files are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "repository files are not available"
+		return result
+	}
+
+	for _, path := range result.CheckedPaths {
+		if filesData.Files[path] {
+			result.FoundPath = path
+			break
+		}
+	}
+
+	if result.FoundPath == "" {
+		result.Issues = append(result.Issues, GitlabReadmeRequiredIssue{
+			Ref:          filesData.Ref,
+			CheckedPaths: result.CheckedPaths,
+		})
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"foundPath":  result.FoundPath,
+		"compliance": result.Compliance,
+	}).Info("README required control completed")
+
+	return result
+}
@@ -0,0 +1,197 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProjectRepoStructureVersion = "0.1.0"
+
+// Repository structure issue types
+const (
+	repoStructureIssueMissingFolder = "missingFolder"
+	repoStructureIssueMissingFile   = "missingFile"
+)
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabRepoStructureControl handles repository structure compliance checking
+type GitlabRepoStructureControl struct {
+	config *configuration.RepoStructureControlConfig
+}
+
+// NewGitlabRepoStructureControl creates a new repository structure control instance
+func NewGitlabRepoStructureControl(config *configuration.RepoStructureControlConfig) *GitlabRepoStructureControl {
+	return &GitlabRepoStructureControl{
+		config: config,
+	}
+}
+
+// validateRepoStructureConfig validates the repoStructure configuration
+func validateRepoStructureConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	structureConfig := plumberConfig.GetRepoStructureConfig()
+	if !structureConfig.IsEnabled() {
+		return
+	}
+
+	if len(structureConfig.RequiredFolders) == 0 && len(structureConfig.RequiredFiles) == 0 {
+		v.add("repoStructure.requiredFolders", "no required folders nor files when the control is enabled", "a list of folders, e.g. .gitlab/issue_templates/")
+	}
+	for _, folder := range structureConfig.RequiredFolders {
+		if repoStructurePath(folder) == "" {
+			v.add("repoStructure.requiredFolders", "empty path", "a folder relative to the repository root, e.g. .gitlab/")
+		}
+	}
+	for _, file := range structureConfig.RequiredFiles {
+		if repoStructurePath(file) == "" {
+			v.add("repoStructure.requiredFiles", "empty path", "a file relative to the repository root, e.g. .gitlab/CODEOWNERS")
+		}
+	}
+}
+
+// repoStructurePath returns a configured path relative to the repository
+// root, without leading or trailing slash (e.g., .gitlab/ gives .gitlab)
+func repoStructurePath(path string) string {
+	return strings.Trim(strings.TrimSpace(path), "/")
+}
+
+// Paths returns the required files to look for
+func (c *GitlabRepoStructureControl) Paths() []string {
+	paths := []string{}
+	if c.config == nil {
+		return paths
+	}
+	for _, path := range c.config.RequiredFiles {
+		paths = append(paths, repoStructurePath(path))
+	}
+	return paths
+}
+
+// Folders returns the required folders to look for
+func (c *GitlabRepoStructureControl) Folders() []string {
+	folders := []string{}
+	if c.config == nil {
+		return folders
+	}
+	for _, folder := range c.config.RequiredFolders {
+		folders = append(folders, repoStructurePath(folder))
+	}
+	return folders
+}
+
+// GitlabRepoStructureMetrics holds metrics for the repository structure control
+type GitlabRepoStructureMetrics struct {
+	RequiredFolders int `json:"requiredFolders"`
+	RequiredFiles   int `json:"requiredFiles"`
+	MissingFolders  int `json:"missingFolders"`
+	MissingFiles    int `json:"missingFiles"`
+}
+
+// GitlabRepoStructureResult holds the result of the repository structure control
+type GitlabRepoStructureResult struct {
+	Issues     []GitlabRepoStructureIssue `json:"issues"`
+	Metrics    GitlabRepoStructureMetrics `json:"metrics"`
+	Compliance float64                    `json:"compliance"`
+	Version    string                     `json:"version"`
+	Skipped    bool                       `json:"skipped"`         // True if control was disabled
+	Error      string                     `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabRepoStructureIssue represents a required folder or file missing from
+// the default branch
+type GitlabRepoStructureIssue struct {
+	Type string `json:"type"` // missingFolder or missingFile
+	Path string `json:"path"` // As configured
+	Ref  string `json:"ref"`
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the repository structure compliance check. An empty folder
+// doesn't exist in a Git repository and is reported as missing.
+func (c *GitlabRepoStructureControl) Run(
+	filesData *collector.GitlabRepositoryFilesData,
+	project *gitlab.ProjectInfo,
+) *GitlabRepoStructureResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabRepoStructure",
+		"controlVersion": ControlTypeGitlabProjectRepoStructureVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabRepoStructureResult{
+		Issues:     []GitlabRepoStructureIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProjectRepoStructureVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Repository structure control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start repository structure control")
+
+	// Repository files could not be checked
+	if filesData == nil {
+		logger.Warn("Repository files are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "repository files are not available"
+		return result
+	}
+
+	for _, folder := range c.config.RequiredFolders {
+		result.Metrics.RequiredFolders++
+		if !filesData.Folders[repoStructurePath(folder)] {
+			result.Issues = append(result.Issues, GitlabRepoStructureIssue{
+				Type: repoStructureIssueMissingFolder,
+				Path: folder,
+				Ref:  filesData.Ref,
+			})
+			result.Metrics.MissingFolders++
+		}
+	}
+
+	for _, file := range c.config.RequiredFiles {
+		result.Metrics.RequiredFiles++
+		if !filesData.Files[repoStructurePath(file)] {
+			result.Issues = append(result.Issues, GitlabRepoStructureIssue{
+				Type: repoStructureIssueMissingFile,
+				Path: file,
+				Ref:  filesData.Ref,
+			})
+			result.Metrics.MissingFiles++
+		}
+	}
+
+	// Compliance is the share of required paths present
+	required := result.Metrics.RequiredFolders + result.Metrics.RequiredFiles
+	if required > 0 {
+		result.Compliance = float64(required-len(result.Issues)) / float64(required) * 100
+	}
+
+	logger.WithFields(logrus.Fields{
+		"missingFolders": result.Metrics.MissingFolders,
+		"missingFiles":   result.Metrics.MissingFiles,
+		"compliance":     result.Compliance,
+	}).Info("Repository structure control completed")
+
+	return result
+}
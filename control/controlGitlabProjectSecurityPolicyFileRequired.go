@@ -0,0 +1,121 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProjectSecurityPolicyFileRequiredVersion = "0.1.0"
+
+// SecurityPolicyFilePaths are the paths where GitLab looks for the security
+// policy of a project
+var SecurityPolicyFilePaths = []string{"SECURITY.md", ".gitlab/SECURITY.md", "docs/SECURITY.md"}
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabSecurityPolicyFileRequiredControl handles security policy file presence compliance checking
+type GitlabSecurityPolicyFileRequiredControl struct {
+	config *configuration.SecurityPolicyFileRequiredControlConfig
+}
+
+// NewGitlabSecurityPolicyFileRequiredControl creates a new security policy file required control instance
+func NewGitlabSecurityPolicyFileRequiredControl(config *configuration.SecurityPolicyFileRequiredControlConfig) *GitlabSecurityPolicyFileRequiredControl {
+	return &GitlabSecurityPolicyFileRequiredControl{
+		config: config,
+	}
+}
+
+// Paths returns the security policy file paths to look for
+func (c *GitlabSecurityPolicyFileRequiredControl) Paths() []string {
+	return SecurityPolicyFilePaths
+}
+
+// GitlabSecurityPolicyFileRequiredResult holds the result of the security policy file required control
+type GitlabSecurityPolicyFileRequiredResult struct {
+	Issues       []GitlabSecurityPolicyFileRequiredIssue `json:"issues"`
+	CheckedPaths []string                                `json:"checkedPaths"`
+	FoundPath    string                                  `json:"foundPath,omitempty"`
+	Compliance   float64                                 `json:"compliance"`
+	Version      string                                  `json:"version"`
+	Skipped      bool                                    `json:"skipped"`         // True if control was disabled
+	Error        string                                  `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabSecurityPolicyFileRequiredIssue represents a project without a security policy file
+type GitlabSecurityPolicyFileRequiredIssue struct {
+	Ref          string   `json:"ref"`
+	CheckedPaths []string `json:"checkedPaths"`
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the security policy file required compliance check
+func (c *GitlabSecurityPolicyFileRequiredControl) Run(
+	filesData *collector.GitlabRepositoryFilesData,
+	project *gitlab.ProjectInfo,
+) *GitlabSecurityPolicyFileRequiredResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabSecurityPolicyFileRequired",
+		"controlVersion": ControlTypeGitlabProjectSecurityPolicyFileRequiredVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabSecurityPolicyFileRequiredResult{
+		Issues:       []GitlabSecurityPolicyFileRequiredIssue{},
+		CheckedPaths: c.Paths(),
+		Compliance:   100.0,
+		Version:      ControlTypeGitlabProjectSecurityPolicyFileRequiredVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Security policy file required control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start security policy file required control")
+
+	// Repository files could not be checked
+	if filesData == nil {
+		logger.Warn("Repository files are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "repository files are not available"
+		return result
+	}
+
+	for _, path := range result.CheckedPaths {
+		if filesData.Files[path] {
+			result.FoundPath = path
+			break
+		}
+	}
+
+	if result.FoundPath == "" {
+		result.Issues = append(result.Issues, GitlabSecurityPolicyFileRequiredIssue{
+			Ref:          filesData.Ref,
+			CheckedPaths: result.CheckedPaths,
+		})
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"foundPath":  result.FoundPath,
+		"compliance": result.Compliance,
+	}).Info("Security policy file required control completed")
+
+	return result
+}
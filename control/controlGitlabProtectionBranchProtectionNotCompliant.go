@@ -360,7 +360,7 @@ func (c *GitlabBranchProtectionControl) checkBranches(
 				// 0 (No one) and smaller than the minimum currently set in branch,
 				// we apply it as min access level as it's equal or more permissive
 				// to the current min
-				if branch.MinPushAccessLevel == 0 || ((pushAccessLevel.AccessLevel != gitlab.AccessLevelNo) && (pushAccessLevel.AccessLevel < branch.MinMergeAccessLevel)) {
+				if branch.MinPushAccessLevel == 0 || ((pushAccessLevel.AccessLevel != gitlab.AccessLevelNo) && (pushAccessLevel.AccessLevel < branch.MinPushAccessLevel)) {
 					branch.MinPushAccessLevel = pushAccessLevel.AccessLevel
 				}
 			}
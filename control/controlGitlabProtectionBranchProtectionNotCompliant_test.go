@@ -0,0 +1,73 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+)
+
+// testBranchProtection returns a protection rule with a single push and merge access level
+func testBranchProtection(pattern string, push, merge int) gitlab.BranchProtection {
+	return gitlab.BranchProtection{
+		ProtectionPattern: pattern,
+		PushAccessLevels:  []gitlab.BranchProtectionAccessLevel{{AccessLevel: push}},
+		MergeAccessLevels: []gitlab.BranchProtectionAccessLevel{{AccessLevel: merge}},
+	}
+}
+
+func TestCheckBranchesMinAccessLevels(t *testing.T) {
+	// The branch matches both rules: the second push level is compared with
+	// the minimum push level of the first rule, while the merge minimum differs
+	tests := []struct {
+		name          string
+		first, second gitlab.BranchProtection
+		expectedPush  int
+		expectedMerge int
+	}{
+		{
+			name:          "push below the minimum",
+			first:         testBranchProtection("main", gitlab.AccessLevelMaintainer, gitlab.AccessLevelDeveloper),
+			second:        testBranchProtection("ma*", gitlab.AccessLevelDeveloper, gitlab.AccessLevelMaintainer),
+			expectedPush:  gitlab.AccessLevelDeveloper,
+			expectedMerge: gitlab.AccessLevelDeveloper,
+		},
+		{
+			name:          "push equal to the minimum",
+			first:         testBranchProtection("main", gitlab.AccessLevelMaintainer, gitlab.AccessLevelDeveloper),
+			second:        testBranchProtection("ma*", gitlab.AccessLevelMaintainer, gitlab.AccessLevelDeveloper),
+			expectedPush:  gitlab.AccessLevelMaintainer,
+			expectedMerge: gitlab.AccessLevelDeveloper,
+		},
+		{
+			name:          "push above the minimum",
+			first:         testBranchProtection("main", gitlab.AccessLevelDeveloper, gitlab.AccessLevelAdmin),
+			second:        testBranchProtection("ma*", gitlab.AccessLevelMaintainer, gitlab.AccessLevelAdmin),
+			expectedPush:  gitlab.AccessLevelDeveloper,
+			expectedMerge: gitlab.AccessLevelAdmin,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enabled := true
+			branchControl := NewGitlabBranchProtectionControl(&configuration.BranchProtectionControlConfig{
+				Enabled:      &enabled,
+				NamePatterns: []string{"main"},
+			})
+
+			branches := branchControl.checkBranches([]string{"main"}, []gitlab.BranchProtection{tt.first, tt.second}, "main")
+
+			branch, ok := branches["main"]
+			if !ok || !branch.Protected {
+				t.Fatalf("branch main should be protected, got %+v", branches)
+			}
+			if branch.MinPushAccessLevel != tt.expectedPush {
+				t.Errorf("min push access level = %d, want %d", branch.MinPushAccessLevel, tt.expectedPush)
+			}
+			if branch.MinMergeAccessLevel != tt.expectedMerge {
+				t.Errorf("min merge access level = %d, want %d", branch.MinMergeAccessLevel, tt.expectedMerge)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionDefaultBranchNoDeletionVersion = "0.1.0"
+
+// Reasons the default branch can be deleted
+const (
+	branchDeletionNotProtected    = "notProtected"
+	branchDeletionUnprotectAccess = "unprotectAccess"
+)
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabDefaultBranchNoDeletionControl handles default branch deletion compliance checking
+type GitlabDefaultBranchNoDeletionControl struct {
+	config *configuration.DefaultBranchNoDeletionControlConfig
+}
+
+// NewGitlabDefaultBranchNoDeletionControl creates a new default branch deletion control instance
+func NewGitlabDefaultBranchNoDeletionControl(config *configuration.DefaultBranchNoDeletionControlConfig) *GitlabDefaultBranchNoDeletionControl {
+	return &GitlabDefaultBranchNoDeletionControl{
+		config: config,
+	}
+}
+
+// GitlabDefaultBranchNoDeletionMetrics holds metrics for the default branch deletion control
+type GitlabDefaultBranchNoDeletionMetrics struct {
+	Protections uint `json:"protections"` // Protections matching the default branch
+}
+
+// GitlabDefaultBranchNoDeletionResult holds the result of the default branch deletion control
+type GitlabDefaultBranchNoDeletionResult struct {
+	Issues     []GitlabDefaultBranchNoDeletionIssue `json:"issues"`
+	Metrics    GitlabDefaultBranchNoDeletionMetrics `json:"metrics"`
+	Compliance float64                              `json:"compliance"`
+	Version    string                               `json:"version"`
+	Skipped    bool                                 `json:"skipped"`         // True if control was disabled
+	Error      string                               `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabDefaultBranchNoDeletionIssue represents a default branch that can be
+// deleted, because it is not protected or because roles below Maintainer can
+// unprotect it
+type GitlabDefaultBranchNoDeletionIssue struct {
+	Branch          string   `json:"branch"`
+	Pattern         string   `json:"pattern,omitempty"`         // Protection allowing the deletion
+	Reason          string   `json:"reason"`                    // notProtected or unprotectAccess
+	UnprotectAccess []string `json:"unprotectAccess,omitempty"` // Roles below Maintainer allowed to unprotect the branch
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the default branch deletion compliance check. Protected
+// branches can't be deleted with git push: only users allowed to unprotect
+// them can delete them, Maintainers unless set otherwise (EE).
+func (c *GitlabDefaultBranchNoDeletionControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabDefaultBranchNoDeletionResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabDefaultBranchNoDeletion",
+		"controlVersion": ControlTypeGitlabProtectionDefaultBranchNoDeletionVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabDefaultBranchNoDeletionResult{
+		Issues:     []GitlabDefaultBranchNoDeletionIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionDefaultBranchNoDeletionVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Default branch no deletion control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start default branch no deletion control")
+
+	if protectionData == nil {
+		logger.Warn("Branch protections are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "branch protections are not available"
+		return result
+	}
+
+	for _, protection := range protectionData.BranchProtections {
+		if !gitlab.CheckItemMatchToPatterns(project.DefaultBranch, []string{protection.ProtectionPattern}) {
+			continue
+		}
+		result.Metrics.Protections++
+
+		unprotectAccess := []string{}
+		for _, level := range protection.UnprotectAccessLevels {
+			if level.UserID == 0 && level.GroupID == 0 && level.AccessLevel > gitlab.AccessLevelNo && level.AccessLevel < gitlab.AccessLevelMaintainer {
+				unprotectAccess = append(unprotectAccess, level.AccessLevelDescription)
+			}
+		}
+		if len(unprotectAccess) > 0 {
+			result.Issues = append(result.Issues, GitlabDefaultBranchNoDeletionIssue{
+				Branch:          project.DefaultBranch,
+				Pattern:         protection.ProtectionPattern,
+				Reason:          branchDeletionUnprotectAccess,
+				UnprotectAccess: unprotectAccess,
+			})
+		}
+	}
+
+	if result.Metrics.Protections == 0 {
+		result.Issues = append(result.Issues, GitlabDefaultBranchNoDeletionIssue{
+			Branch: project.DefaultBranch,
+			Reason: branchDeletionNotProtected,
+		})
+	}
+
+	if len(result.Issues) > 0 {
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"protections": result.Metrics.Protections,
+		"issues":      len(result.Issues),
+		"compliance":  result.Compliance,
+	}).Info("Default branch no deletion control completed")
+
+	return result
+}
@@ -0,0 +1,202 @@
+package control
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionDeployTokensVersion = "0.1.0"
+
+// DefaultDeployTokenMaxAgeDays is the maximum validity of a deploy token when maxAgeDays is not set
+const DefaultDeployTokenMaxAgeDays = 365
+
+// DefaultForbiddenDeployTokenScopes are the forbidden scopes when forbiddenScopes is not set
+var DefaultForbiddenDeployTokenScopes = []string{
+	"write_registry",
+	"write_package_registry",
+}
+
+// Deploy token issue types
+const (
+	deployTokenIssueNoExpiration   = "noExpiration"
+	deployTokenIssueExpiresTooLate = "expiresTooLate"
+	deployTokenIssueForbiddenScope = "forbiddenScope"
+)
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabDeployTokensControl handles deploy tokens compliance checking
+type GitlabDeployTokensControl struct {
+	config *configuration.DeployTokensControlConfig
+}
+
+// NewGitlabDeployTokensControl creates a new deploy tokens control instance
+func NewGitlabDeployTokensControl(config *configuration.DeployTokensControlConfig) *GitlabDeployTokensControl {
+	return &GitlabDeployTokensControl{
+		config: config,
+	}
+}
+
+// validateDeployTokensConfig validates the deployTokens configuration
+func validateDeployTokensConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	tokensConfig := plumberConfig.GetDeployTokensConfig()
+	if !tokensConfig.IsEnabled() {
+		return
+	}
+
+	if tokensConfig.MaxAgeDays != nil && *tokensConfig.MaxAgeDays < 1 {
+		v.add("deployTokens.maxAgeDays", fmt.Sprintf("invalid number of days %d", *tokensConfig.MaxAgeDays), "a number greater than or equal to 1")
+	}
+}
+
+// GitlabDeployTokensMetrics holds metrics for the deploy tokens control
+type GitlabDeployTokensMetrics struct {
+	Tokens          int `json:"tokens"`
+	Active          int `json:"active"` // Neither revoked nor expired
+	NoExpiration    int `json:"noExpiration"`
+	ExpiresTooLate  int `json:"expiresTooLate"`
+	ForbiddenScopes int `json:"forbiddenScopes"`
+	MaxAgeDays      int `json:"maxAgeDays"`
+}
+
+// GitlabDeployTokensResult holds the result of the deploy tokens control
+type GitlabDeployTokensResult struct {
+	Issues     []GitlabDeployTokensIssue `json:"issues"`
+	Metrics    GitlabDeployTokensMetrics `json:"metrics"`
+	Compliance float64                   `json:"compliance"`
+	Version    string                    `json:"version"`
+	Skipped    bool                      `json:"skipped"`         // True if control was disabled
+	Error      string                    `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabDeployTokensIssue represents a risky deploy token. The token secret is never reported.
+type GitlabDeployTokensIssue struct {
+	Type      string     `json:"type"` // "noExpiration", "expiresTooLate" or "forbiddenScope"
+	TokenID   int        `json:"tokenId"`
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	Scope     string     `json:"scope,omitempty"` // Forbidden scope, for "forbiddenScope" issues
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	ValidDays int        `json:"validDays,omitempty"` // Days until expiration, for "expiresTooLate" issues
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the deploy tokens compliance check
+func (c *GitlabDeployTokensControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabDeployTokensResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabDeployTokens",
+		"controlVersion": ControlTypeGitlabProtectionDeployTokensVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabDeployTokensResult{
+		Issues:     []GitlabDeployTokensIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionDeployTokensVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Deploy tokens control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start deploy tokens control")
+
+	maxAgeDays := DefaultDeployTokenMaxAgeDays
+	if c.config.MaxAgeDays != nil {
+		maxAgeDays = *c.config.MaxAgeDays
+	}
+	result.Metrics.MaxAgeDays = maxAgeDays
+
+	forbiddenScopes := DefaultForbiddenDeployTokenScopes
+	if len(c.config.ForbiddenScopes) > 0 {
+		forbiddenScopes = c.config.ForbiddenScopes
+	}
+
+	// Deploy tokens could not be fetched (e.g., missing permissions)
+	if protectionData == nil || protectionData.DeployTokens == nil {
+		logger.Warn("Deploy tokens are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "deploy tokens are not available"
+		return result
+	}
+
+	// The API doesn't give the creation date of deploy tokens, so the age
+	// limit is enforced on the remaining validity of the token
+	latestExpiration := time.Now().AddDate(0, 0, maxAgeDays)
+
+	for _, token := range protectionData.DeployTokens {
+		result.Metrics.Tokens++
+		if token.Revoked || token.Expired {
+			continue
+		}
+		result.Metrics.Active++
+
+		issue := GitlabDeployTokensIssue{
+			TokenID:   token.ID,
+			Name:      token.Name,
+			Scopes:    token.Scopes,
+			ExpiresAt: token.ExpiresAt,
+		}
+
+		if token.ExpiresAt == nil {
+			noExpirationIssue := issue
+			noExpirationIssue.Type = deployTokenIssueNoExpiration
+			result.Issues = append(result.Issues, noExpirationIssue)
+			result.Metrics.NoExpiration++
+		} else if token.ExpiresAt.After(latestExpiration) {
+			tooLateIssue := issue
+			tooLateIssue.Type = deployTokenIssueExpiresTooLate
+			tooLateIssue.ValidDays = int(time.Until(*token.ExpiresAt).Hours() / 24)
+			result.Issues = append(result.Issues, tooLateIssue)
+			result.Metrics.ExpiresTooLate++
+		}
+
+		for _, scope := range token.Scopes {
+			if !gitlab.CheckItemMatchToPatterns(scope, forbiddenScopes) {
+				continue
+			}
+			scopeIssue := issue
+			scopeIssue.Type = deployTokenIssueForbiddenScope
+			scopeIssue.Scope = scope
+			result.Issues = append(result.Issues, scopeIssue)
+			result.Metrics.ForbiddenScopes++
+		}
+	}
+
+	if len(result.Issues) > 0 {
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"tokens":          result.Metrics.Tokens,
+		"noExpiration":    result.Metrics.NoExpiration,
+		"expiresTooLate":  result.Metrics.ExpiresTooLate,
+		"forbiddenScopes": result.Metrics.ForbiddenScopes,
+		"compliance":      result.Compliance,
+	}).Info("Deploy tokens control completed")
+
+	return result
+}
@@ -0,0 +1,203 @@
+package control
+
+import (
+	"fmt"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+	glab "gitlab.com/gitlab-org/api/client-go"
+)
+
+const ControlTypeGitlabProtectionMRApprovalVersion = "0.1.0"
+
+// DefaultMRMinApprovers is the number of approvals required to merge into
+// the default branch when minApprovers is not set
+const DefaultMRMinApprovers = 1
+
+// Merge request approval requirements, each failing one is a distinct issue
+const (
+	mrApprovalMinApprovers         = "minApprovers"
+	mrApprovalPreventAuthor        = "preventAuthorApproval"
+	mrApprovalResetApprovalsOnPush = "resetApprovalsOnPush"
+)
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabMRApprovalControl handles merge request approval rules compliance checking
+type GitlabMRApprovalControl struct {
+	config *configuration.MergeRequestApprovalControlConfig
+}
+
+// NewGitlabMRApprovalControl creates a new merge request approval control instance
+func NewGitlabMRApprovalControl(config *configuration.MergeRequestApprovalControlConfig) *GitlabMRApprovalControl {
+	return &GitlabMRApprovalControl{
+		config: config,
+	}
+}
+
+// validateMRApprovalConfig validates the mergeRequestApproval configuration
+func validateMRApprovalConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	approvalConfig := plumberConfig.GetMergeRequestApprovalConfig()
+	if !approvalConfig.IsEnabled() {
+		return
+	}
+
+	if approvalConfig.MinApprovers != nil && *approvalConfig.MinApprovers < 1 {
+		v.add("mergeRequestApproval.minApprovers", fmt.Sprintf("invalid number of approvers %d", *approvalConfig.MinApprovers), "a number greater than or equal to 1")
+	}
+}
+
+// GitlabMRApprovalMetrics holds metrics for the merge request approval control
+type GitlabMRApprovalMetrics struct {
+	Requirements              uint     `json:"requirements"`
+	Failed                    uint     `json:"failed"`
+	ApprovalsRequired         int      `json:"approvalsRequired"` // Approvals required by the approval rules of the default branch
+	ApprovalRules             []string `json:"approvalRules"`     // Rules applying to the default branch
+	AuthorApproval            bool     `json:"authorApproval"`
+	BehaviorWhenCommitIsAdded int      `json:"behaviorWhenCommitIsAdded"` // One of the BehaviorWhenCommitIsAdded* ids of the protection collector
+}
+
+// GitlabMRApprovalResult holds the result of the merge request approval control
+type GitlabMRApprovalResult struct {
+	Issues     []GitlabMRApprovalIssue `json:"issues"`
+	Metrics    GitlabMRApprovalMetrics `json:"metrics"`
+	Compliance float64                 `json:"compliance"`
+	Version    string                  `json:"version"`
+	Skipped    bool                    `json:"skipped"`         // True if control was disabled or approval data is not available
+	Error      string                  `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabMRApprovalIssue represents a merge request approval requirement the
+// project doesn't meet
+type GitlabMRApprovalIssue struct {
+	Requirement               string `json:"requirement"`                         // minApprovers, preventAuthorApproval or resetApprovalsOnPush
+	ApprovalsRequired         int    `json:"approvalsRequired,omitempty"`         // Set for minApprovers
+	MinApprovers              int    `json:"minApprovers,omitempty"`              // Set for minApprovers
+	BehaviorWhenCommitIsAdded string `json:"behaviorWhenCommitIsAdded,omitempty"` // Set for resetApprovalsOnPush
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the merge request approval compliance check on the approval
+// rules of the default branch and the approval settings of the project.
+// Approval data is not available on GitLab Free (403/404), the control is
+// then skipped rather than passed.
+func (c *GitlabMRApprovalControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabMRApprovalResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabMRApproval",
+		"controlVersion": ControlTypeGitlabProtectionMRApprovalVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabMRApprovalResult{
+		Issues:     []GitlabMRApprovalIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionMRApprovalVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Merge request approval control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start merge request approval control")
+
+	if protectionData == nil || protectionData.MRApprovalRules == nil || protectionData.MRApprovalSettings == nil {
+		logger.Warn("Merge request approval rules are not available (may require GitLab Premium), skipping")
+		result.Skipped = true
+		result.Error = "merge request approval rules are not available (may require GitLab Premium)"
+		return result
+	}
+
+	minApprovers := DefaultMRMinApprovers
+	if c.config.MinApprovers != nil {
+		minApprovers = *c.config.MinApprovers
+	}
+	approvalsRequired, approvalRules := defaultBranchApprovals(protectionData.MRApprovalRules, project.DefaultBranch)
+	result.Metrics.ApprovalsRequired = approvalsRequired
+	result.Metrics.ApprovalRules = approvalRules
+	result.Metrics.AuthorApproval = protectionData.MRApprovalSettings.MergeRequestsAuthorApproval
+	result.Metrics.BehaviorWhenCommitIsAdded = behaviorWhenCommitIsAdded(protectionData.MRApprovalSettings)
+
+	result.Metrics.Requirements++
+	if approvalsRequired < minApprovers {
+		result.Issues = append(result.Issues, GitlabMRApprovalIssue{
+			Requirement:       mrApprovalMinApprovers,
+			ApprovalsRequired: approvalsRequired,
+			MinApprovers:      minApprovers,
+		})
+	}
+
+	// Both settings are required unless explicitly set to false
+	if c.config.PreventAuthorApproval == nil || *c.config.PreventAuthorApproval {
+		result.Metrics.Requirements++
+		if result.Metrics.AuthorApproval {
+			result.Issues = append(result.Issues, GitlabMRApprovalIssue{Requirement: mrApprovalPreventAuthor})
+		}
+	}
+	if c.config.ResetApprovalsOnPush == nil || *c.config.ResetApprovalsOnPush {
+		result.Metrics.Requirements++
+		if result.Metrics.BehaviorWhenCommitIsAdded != collector.BehaviorWhenCommitIsAddedRemoveApprovalsId {
+			result.Issues = append(result.Issues, GitlabMRApprovalIssue{
+				Requirement:               mrApprovalResetApprovalsOnPush,
+				BehaviorWhenCommitIsAdded: behaviorWhenCommitIsAddedText(result.Metrics.BehaviorWhenCommitIsAdded),
+			})
+		}
+	}
+
+	// Compliance is the share of requirements met
+	result.Metrics.Failed = uint(len(result.Issues))
+	result.Compliance = float64(result.Metrics.Requirements-result.Metrics.Failed) / float64(result.Metrics.Requirements) * 100
+
+	logger.WithFields(logrus.Fields{
+		"approvalsRequired": approvalsRequired,
+		"minApprovers":      minApprovers,
+		"failed":            result.Metrics.Failed,
+		"compliance":        result.Compliance,
+	}).Info("Merge request approval control completed")
+
+	return result
+}
+
+// behaviorWhenCommitIsAdded returns what happens to the approvals of a merge
+// request when a commit is added, as a BehaviorWhenCommitIsAdded* id
+func behaviorWhenCommitIsAdded(settings *glab.ProjectApprovals) int {
+	if settings.ResetApprovalsOnPush {
+		return collector.BehaviorWhenCommitIsAddedRemoveApprovalsId
+	}
+	if settings.SelectiveCodeOwnerRemovals {
+		return collector.BehaviorWhenCommitIsAddedRemoveCodeOwnerApprovalsId
+	}
+	return collector.BehaviorWhenCommitIsAddedKeepApprovalsId
+}
+
+// behaviorWhenCommitIsAddedText returns the GitLab setting label of a
+// BehaviorWhenCommitIsAdded* id
+func behaviorWhenCommitIsAddedText(behavior int) string {
+	switch behavior {
+	case collector.BehaviorWhenCommitIsAddedRemoveApprovalsId:
+		return collector.BehaviorWhenCommitIsAddedRemoveApprovalsText
+	case collector.BehaviorWhenCommitIsAddedRemoveCodeOwnerApprovalsId:
+		return collector.BehaviorWhenCommitIsAddedRemoveCodeOwnerText
+	default:
+		return collector.BehaviorWhenCommitIsAddedKeepApprovalsText
+	}
+}
@@ -0,0 +1,213 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionManualJobAccessVersion = "0.1.0"
+
+// jobWhenManual is the value of when making a job manual
+const jobWhenManual = "manual"
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabManualJobAccessControl handles manual job access compliance checking
+type GitlabManualJobAccessControl struct {
+	config *configuration.ManualJobAccessControlConfig
+}
+
+// NewGitlabManualJobAccessControl creates a new manual job access control instance
+func NewGitlabManualJobAccessControl(config *configuration.ManualJobAccessControlConfig) *GitlabManualJobAccessControl {
+	return &GitlabManualJobAccessControl{
+		config: config,
+	}
+}
+
+// GitlabManualJobAccessMetrics holds metrics for the manual job access control
+type GitlabManualJobAccessMetrics struct {
+	ManualJobs            uint `json:"manualJobs"`
+	ProtectedEnvironments uint `json:"protectedEnvironments"`
+	Unrestricted          uint `json:"unrestricted"`
+	CiInvalid             uint `json:"ciInvalid"`
+	CiMissing             uint `json:"ciMissing"`
+}
+
+// GitlabManualJobAccessResult holds the result of the manual job access control
+type GitlabManualJobAccessResult struct {
+	Issues     []GitlabManualJobAccessIssue `json:"issues"`
+	Metrics    GitlabManualJobAccessMetrics `json:"metrics"`
+	Compliance float64                      `json:"compliance"`
+	Version    string                       `json:"version"`
+	Skipped    bool                         `json:"skipped"`         // True if control was disabled
+	Error      string                       `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabManualJobAccessIssue represents a manual job deploying to a protected
+// environment that Developers are allowed to deploy to
+type GitlabManualJobAccessIssue struct {
+	Job                string   `json:"job"`
+	Environment        string   `json:"environment"`
+	DeployAccessLevels []string `json:"deployAccessLevels"` // Descriptions of the roles, users and groups allowed to deploy
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the manual job access compliance check. A protected
+// environment is restricted if deployments require an approval, or if only
+// Maintainers, specific users or specific groups can deploy to it.
+func (c *GitlabManualJobAccessControl) Run(
+	pipelineImageData *collector.GitlabPipelineImageData,
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabManualJobAccessResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabManualJobAccess",
+		"controlVersion": ControlTypeGitlabProtectionManualJobAccessVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabManualJobAccessResult{
+		Issues:     []GitlabManualJobAccessIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionManualJobAccessVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Manual job access control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start manual job access control")
+
+	// If CI is invalid or missing, return early
+	if !pipelineImageData.CiValid || pipelineImageData.CiMissing {
+		result.Compliance = 0.0
+		if !pipelineImageData.CiValid {
+			result.Metrics.CiInvalid = 1
+		}
+		if pipelineImageData.CiMissing {
+			result.Metrics.CiMissing = 1
+		}
+		return result
+	}
+
+	// Protected environments could not be fetched (e.g., GitLab CE or
+	// missing permissions), the control can't be evaluated
+	if protectionData == nil || protectionData.ProtectedEnvironments == nil {
+		logger.Warn("Protected environments are not available, skipping the control")
+		result.Skipped = true
+		return result
+	}
+
+	protectedEnvironments := map[string]gitlab.ProtectedEnvironmentInfo{}
+	for _, environment := range protectionData.ProtectedEnvironments {
+		protectedEnvironments[environment.Name] = environment
+	}
+	result.Metrics.ProtectedEnvironments = uint(len(protectedEnvironments))
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		logger.WithError(err).Error("Unable to parse jobs from merged CI configuration")
+		result.Compliance = 0.0
+		result.Error = err.Error()
+		return result
+	}
+
+	for _, job := range jobs {
+		if !isManualJob(job.Job) {
+			continue
+		}
+		result.Metrics.ManualJobs++
+
+		name, _ := parseJobEnvironment(job.Job.Environment)
+		if name == "" {
+			continue
+		}
+		jobVars, err := gitlab.ParseJobVariables(job.Job)
+		if err != nil {
+			logger.WithError(err).WithField("job", job.Name).Warn("Unable to parse job variables, environment name is checked unresolved")
+		}
+		name = gitlab.ReplaceVariable(name, pipelineImageData.ProjectVars, pipelineImageData.GroupVars, pipelineImageData.InstanceVars, jobVars, pipelineImageData.GlobalVars, nil)
+
+		environment, protected := protectedEnvironments[name]
+		if !protected || environmentDeployRestricted(environment) {
+			continue
+		}
+
+		deployAccessLevels := []string{}
+		for _, level := range environment.DeployAccessLevels {
+			deployAccessLevels = append(deployAccessLevels, level.AccessLevelDescription)
+		}
+		result.Issues = append(result.Issues, GitlabManualJobAccessIssue{
+			Job:                job.Name,
+			Environment:        name,
+			DeployAccessLevels: deployAccessLevels,
+		})
+		result.Metrics.Unrestricted++
+	}
+
+	if len(result.Issues) > 0 {
+		result.Compliance = 0.0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"manualJobs":   result.Metrics.ManualJobs,
+		"unrestricted": result.Metrics.Unrestricted,
+		"compliance":   result.Compliance,
+	}).Info("Manual job access control completed")
+
+	return result
+}
+
+// isManualJob reports whether a job is manual, with when: manual set on the
+// job or on one of its rules
+func isManualJob(job *gitlab.GitlabJob) bool {
+	if when, ok := job.When.(string); ok && when == jobWhenManual {
+		return true
+	}
+
+	rules, err := gitlab.GetRules(job.Rules)
+	if err != nil {
+		return false
+	}
+	for _, rule := range rules {
+		if rule.When == jobWhenManual {
+			return true
+		}
+	}
+	return false
+}
+
+// environmentDeployRestricted reports whether deploying to a protected
+// environment is restricted above the Developer role
+func environmentDeployRestricted(environment gitlab.ProtectedEnvironmentInfo) bool {
+	if environment.RequiredApprovals > 0 {
+		return true
+	}
+	if len(environment.DeployAccessLevels) == 0 {
+		return false
+	}
+	for _, level := range environment.DeployAccessLevels {
+		// Specific users and groups are restrictions, roles must be Maintainer or above
+		if level.UserID == 0 && level.GroupID == 0 && level.AccessLevel < gitlab.AccessLevelMaintainer {
+			return false
+		}
+	}
+	return true
+}
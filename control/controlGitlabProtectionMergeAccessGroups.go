@@ -0,0 +1,174 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionMergeAccessGroupsVersion = "0.1.0"
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabMergeAccessGroupsControl handles merge access groups compliance checking
+type GitlabMergeAccessGroupsControl struct {
+	config *configuration.MergeAccessGroupsControlConfig
+}
+
+// NewGitlabMergeAccessGroupsControl creates a new merge access groups control instance
+func NewGitlabMergeAccessGroupsControl(config *configuration.MergeAccessGroupsControlConfig) *GitlabMergeAccessGroupsControl {
+	return &GitlabMergeAccessGroupsControl{
+		config: config,
+	}
+}
+
+// validateMergeAccessGroupsConfig validates the mergeAccessGroups configuration
+func validateMergeAccessGroupsConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	groupsConfig := plumberConfig.GetMergeAccessGroupsConfig()
+	if !groupsConfig.IsEnabled() {
+		return
+	}
+
+	if len(groupsConfig.RequiredMergeGroups) == 0 {
+		v.add("mergeAccessGroups.requiredMergeGroups", "field is required when the control is enabled", "a list of group full paths")
+	}
+}
+
+// GitlabMergeAccessGroupsMetrics holds metrics for the merge access groups control
+type GitlabMergeAccessGroupsMetrics struct {
+	ProtectedBranches int `json:"protectedBranches"`
+	Restricted        int `json:"restricted"`
+	NotRestricted     int `json:"notRestricted"`
+}
+
+// GitlabMergeAccessGroupsResult holds the result of the merge access groups control
+type GitlabMergeAccessGroupsResult struct {
+	Issues     []GitlabMergeAccessGroupsIssue `json:"issues"`
+	Metrics    GitlabMergeAccessGroupsMetrics `json:"metrics"`
+	Compliance float64                        `json:"compliance"`
+	Version    string                         `json:"version"`
+	Skipped    bool                           `json:"skipped"`         // True if control was disabled
+	Error      string                         `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabMergeAccessGroupsIssue represents a protected branch whose merges are
+// not restricted to the required groups
+type GitlabMergeAccessGroupsIssue struct {
+	Branch      string   `json:"branch"`      // Protection pattern
+	MergeAccess []string `json:"mergeAccess"` // Roles, users and groups currently allowed to merge
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the merge access groups compliance check. A protected branch
+// is compliant when merges are only allowed to required groups: roles and
+// individual users allowed to merge bypass the separation of duties.
+func (c *GitlabMergeAccessGroupsControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabMergeAccessGroupsResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabMergeAccessGroups",
+		"controlVersion": ControlTypeGitlabProtectionMergeAccessGroupsVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabMergeAccessGroupsResult{
+		Issues:     []GitlabMergeAccessGroupsIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionMergeAccessGroupsVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Merge access groups control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start merge access groups control")
+
+	if protectionData == nil {
+		logger.Warn("Branch protections are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "branch protections are not available"
+		return result
+	}
+
+	for _, branchProtection := range protectionData.BranchProtections {
+		result.Metrics.ProtectedBranches++
+
+		if c.isMergeRestrictedToGroups(branchProtection.MergeAccessLevels) {
+			result.Metrics.Restricted++
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabMergeAccessGroupsIssue{
+			Branch:      branchProtection.ProtectionPattern,
+			MergeAccess: describeMergeAccess(branchProtection.MergeAccessLevels),
+		})
+		result.Metrics.NotRestricted++
+	}
+
+	if len(result.Issues) > 0 {
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"protectedBranches": result.Metrics.ProtectedBranches,
+		"notRestricted":     result.Metrics.NotRestricted,
+		"compliance":        result.Compliance,
+	}).Info("Merge access groups control completed")
+
+	return result
+}
+
+// isMergeRestrictedToGroups reports whether at least one required group is
+// allowed to merge, and nobody else ("No one" entries are ignored)
+func (c *GitlabMergeAccessGroupsControl) isMergeRestrictedToGroups(mergeAccessLevels []gitlab.BranchProtectionAccessLevel) bool {
+	requiredGroupFound := false
+	for _, accessLevel := range mergeAccessLevels {
+		switch {
+		case accessLevel.GroupID != 0:
+			if accessLevel.GroupFullPath == "" || !gitlab.CheckItemMatchToPatterns(accessLevel.GroupFullPath, c.config.RequiredMergeGroups) {
+				return false
+			}
+			requiredGroupFound = true
+		case accessLevel.UserID != 0:
+			return false
+		case accessLevel.AccessLevel != gitlab.AccessLevelNo:
+			return false
+		}
+	}
+	return requiredGroupFound
+}
+
+// describeMergeAccess returns a readable description of who is allowed to merge
+func describeMergeAccess(mergeAccessLevels []gitlab.BranchProtectionAccessLevel) []string {
+	descriptions := []string{}
+	for _, accessLevel := range mergeAccessLevels {
+		switch {
+		case accessLevel.GroupID != 0 && accessLevel.GroupFullPath != "":
+			descriptions = append(descriptions, "group "+accessLevel.GroupFullPath)
+		case accessLevel.GroupID != 0:
+			descriptions = append(descriptions, "group "+accessLevel.AccessLevelDescription)
+		case accessLevel.UserID != 0:
+			descriptions = append(descriptions, "user "+accessLevel.AccessLevelDescription)
+		default:
+			descriptions = append(descriptions, accessLevel.AccessLevelDescription)
+		}
+	}
+	return descriptions
+}
@@ -0,0 +1,205 @@
+package control
+
+import (
+	"fmt"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+	glab "gitlab.com/gitlab-org/api/client-go"
+)
+
+const ControlTypeGitlabProtectionMergeTrainApprovalsVersion = "0.1.0"
+
+// DefaultMergeTrainMinApprovals is the minimum number of approvals required
+// when merge trains are enabled and minApprovals is not set
+const DefaultMergeTrainMinApprovals = 1
+
+// Approval rule types counted as approval requirements. Code owner and
+// security report rules only apply to some merge requests.
+const (
+	approvalRuleTypeRegular     = "regular"
+	approvalRuleTypeAnyApprover = "any_approver"
+)
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabMergeTrainApprovalsControl handles merge train approvals compliance checking
+type GitlabMergeTrainApprovalsControl struct {
+	config *configuration.MergeTrainApprovalsControlConfig
+}
+
+// NewGitlabMergeTrainApprovalsControl creates a new merge train approvals control instance
+func NewGitlabMergeTrainApprovalsControl(config *configuration.MergeTrainApprovalsControlConfig) *GitlabMergeTrainApprovalsControl {
+	return &GitlabMergeTrainApprovalsControl{
+		config: config,
+	}
+}
+
+// validateMergeTrainApprovalsConfig validates the mergeTrainApprovals configuration
+func validateMergeTrainApprovalsConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	approvalsConfig := plumberConfig.GetMergeTrainApprovalsConfig()
+	if !approvalsConfig.IsEnabled() {
+		return
+	}
+
+	if approvalsConfig.MinApprovals != nil && *approvalsConfig.MinApprovals < 1 {
+		v.add("mergeTrainApprovals.minApprovals", fmt.Sprintf("invalid number of approvals %d", *approvalsConfig.MinApprovals), "a number greater than or equal to 1")
+	}
+}
+
+// GitlabMergeTrainApprovalsMetrics holds metrics for the merge train approvals control
+type GitlabMergeTrainApprovalsMetrics struct {
+	MergeTrainsEnabled bool `json:"mergeTrainsEnabled"`
+	ApprovalsRequired  int  `json:"approvalsRequired"`
+	MinApprovals       int  `json:"minApprovals"`
+}
+
+// GitlabMergeTrainApprovalsResult holds the result of the merge train approvals control
+type GitlabMergeTrainApprovalsResult struct {
+	Issues     []GitlabMergeTrainApprovalsIssue `json:"issues"`
+	Metrics    GitlabMergeTrainApprovalsMetrics `json:"metrics"`
+	Compliance float64                          `json:"compliance"`
+	Version    string                           `json:"version"`
+	Skipped    bool                             `json:"skipped"`         // True if control was disabled
+	Error      string                           `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabMergeTrainApprovalsIssue represents a project whose merge trains are
+// enabled with fewer required approvals than the policy
+type GitlabMergeTrainApprovalsIssue struct {
+	ApprovalsRequired    int      `json:"approvalsRequired"`
+	MinApprovals         int      `json:"minApprovals"`
+	ApprovalRules        []string `json:"approvalRules"`        // Rules applying to the default branch
+	SkipTrainAllowed     bool     `json:"skipTrainAllowed"`     // Merge trains can be skipped
+	AuthorApproval       bool     `json:"authorApproval"`       // Authors can approve their own merge requests
+	ResetApprovalsOnPush bool     `json:"resetApprovalsOnPush"` // Approvals are removed when a commit is added
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the merge train approvals compliance check. Only approval rules
+// applying to the default branch are counted, as merge trains usually target it.
+func (c *GitlabMergeTrainApprovalsControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabMergeTrainApprovalsResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabMergeTrainApprovals",
+		"controlVersion": ControlTypeGitlabProtectionMergeTrainApprovalsVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabMergeTrainApprovalsResult{
+		Issues:     []GitlabMergeTrainApprovalsIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionMergeTrainApprovalsVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Merge train approvals control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start merge train approvals control")
+
+	minApprovals := DefaultMergeTrainMinApprovals
+	if c.config.MinApprovals != nil {
+		minApprovals = *c.config.MinApprovals
+	}
+	result.Metrics.MinApprovals = minApprovals
+
+	if protectionData == nil || protectionData.MRSettings == nil {
+		logger.Warn("Merge request settings are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "merge request settings are not available"
+		return result
+	}
+
+	// Without merge trains, there is nothing to bypass
+	if !protectionData.MRSettings.MergeTrainsEnabled {
+		logger.Info("Merge trains are not enabled, the control passes")
+		return result
+	}
+	result.Metrics.MergeTrainsEnabled = true
+
+	// Approval rules could not be fetched (e.g., missing permissions)
+	if protectionData.MRApprovalRules == nil || protectionData.MRApprovalSettings == nil {
+		logger.Warn("Merge request approvals are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "merge request approvals are not available"
+		return result
+	}
+
+	approvalsRequired, approvalRules := defaultBranchApprovals(protectionData.MRApprovalRules, project.DefaultBranch)
+	result.Metrics.ApprovalsRequired = approvalsRequired
+
+	if approvalsRequired < minApprovals {
+		result.Issues = append(result.Issues, GitlabMergeTrainApprovalsIssue{
+			ApprovalsRequired:    approvalsRequired,
+			MinApprovals:         minApprovals,
+			ApprovalRules:        approvalRules,
+			SkipTrainAllowed:     protectionData.MRSettings.MergeTrainsSkipTrainAllowed,
+			AuthorApproval:       protectionData.MRApprovalSettings.MergeRequestsAuthorApproval,
+			ResetApprovalsOnPush: protectionData.MRApprovalSettings.ResetApprovalsOnPush,
+		})
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"approvalsRequired": approvalsRequired,
+		"minApprovals":      minApprovals,
+		"compliance":        result.Compliance,
+	}).Info("Merge train approvals control completed")
+
+	return result
+}
+
+// defaultBranchApprovals returns the number of approvals required to merge
+// into the default branch, and the names of the rules applying to it. All
+// rules must be satisfied, so the highest requirement is returned.
+func defaultBranchApprovals(rules []*glab.ProjectApprovalRule, defaultBranch string) (int, []string) {
+	approvalsRequired := 0
+	ruleNames := []string{}
+	for _, rule := range rules {
+		if rule == nil || (rule.RuleType != approvalRuleTypeRegular && rule.RuleType != approvalRuleTypeAnyApprover) {
+			continue
+		}
+		if !approvalRuleAppliesToBranch(rule, defaultBranch) {
+			continue
+		}
+		ruleNames = append(ruleNames, rule.Name)
+		if int(rule.ApprovalsRequired) > approvalsRequired {
+			approvalsRequired = int(rule.ApprovalsRequired)
+		}
+	}
+	return approvalsRequired, ruleNames
+}
+
+// approvalRuleAppliesToBranch reports whether an approval rule applies to a
+// branch. Rules without protected branches apply to all branches.
+func approvalRuleAppliesToBranch(rule *glab.ProjectApprovalRule, branch string) bool {
+	if rule.AppliesToAllProtectedBranches || len(rule.ProtectedBranches) == 0 {
+		return true
+	}
+	for _, protectedBranch := range rule.ProtectedBranches {
+		if protectedBranch != nil && gitlab.CheckItemMatchToPatterns(branch, []string{protectedBranch.Name}) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package control
+
+import (
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionMinimumMaintainersVersion = "0.1.0"
+
+// DefaultMinimumMaintainers is the minimum number of maintainers when minCount is not set
+const DefaultMinimumMaintainers = 2
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabMinimumMaintainersControl handles minimum maintainers compliance checking
+type GitlabMinimumMaintainersControl struct {
+	config *configuration.MinimumMaintainersControlConfig
+}
+
+// NewGitlabMinimumMaintainersControl creates a new minimum maintainers control instance
+func NewGitlabMinimumMaintainersControl(config *configuration.MinimumMaintainersControlConfig) *GitlabMinimumMaintainersControl {
+	return &GitlabMinimumMaintainersControl{
+		config: config,
+	}
+}
+
+// GitlabMinimumMaintainersMetrics holds metrics for the minimum maintainers control
+type GitlabMinimumMaintainersMetrics struct {
+	Members     int `json:"members"`
+	Maintainers int `json:"maintainers"`
+	MinCount    int `json:"minCount"`
+}
+
+// GitlabMinimumMaintainersResult holds the result of the minimum maintainers control
+type GitlabMinimumMaintainersResult struct {
+	Issues      []GitlabMinimumMaintainersIssue `json:"issues"`
+	Metrics     GitlabMinimumMaintainersMetrics `json:"metrics"`
+	Maintainers []string                        `json:"maintainers"` // Usernames of members at or above Maintainer
+	Compliance  float64                         `json:"compliance"`
+	Version     string                          `json:"version"`
+	Skipped     bool                            `json:"skipped"`         // True if control was disabled
+	Error       string                          `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabMinimumMaintainersIssue represents a project with too few maintainers
+type GitlabMinimumMaintainersIssue struct {
+	Maintainers int `json:"maintainers"`
+	MinCount    int `json:"minCount"`
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the minimum maintainers compliance check
+func (c *GitlabMinimumMaintainersControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabMinimumMaintainersResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabMinimumMaintainers",
+		"controlVersion": ControlTypeGitlabProtectionMinimumMaintainersVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabMinimumMaintainersResult{
+		Issues:      []GitlabMinimumMaintainersIssue{},
+		Maintainers: []string{},
+		Compliance:  100.0,
+		Version:     ControlTypeGitlabProtectionMinimumMaintainersVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Minimum maintainers control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start minimum maintainers control")
+
+	minCount := DefaultMinimumMaintainers
+	if c.config.MinCount != nil {
+		minCount = *c.config.MinCount
+	}
+	result.Metrics.MinCount = minCount
+
+	// Members could not be fetched (e.g., missing permissions)
+	if protectionData == nil || protectionData.ProjectMembers == nil {
+		logger.Warn("Project members are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "project members are not available"
+		return result
+	}
+
+	// Count members at or above the Maintainer access level
+	for _, member := range protectionData.ProjectMembers {
+		if member.AccessLevel >= gitlab.AccessLevelMaintainer {
+			result.Maintainers = append(result.Maintainers, member.Name)
+		}
+	}
+	sort.Strings(result.Maintainers)
+
+	result.Metrics.Members = len(protectionData.ProjectMembers)
+	result.Metrics.Maintainers = len(result.Maintainers)
+
+	if result.Metrics.Maintainers < minCount {
+		result.Issues = append(result.Issues, GitlabMinimumMaintainersIssue{
+			Maintainers: result.Metrics.Maintainers,
+			MinCount:    minCount,
+		})
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"maintainers": result.Metrics.Maintainers,
+		"minCount":    minCount,
+		"compliance":  result.Compliance,
+	}).Info("Minimum maintainers control completed")
+
+	return result
+}
@@ -0,0 +1,158 @@
+package control
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionNoDirectElevatedMembersVersion = "0.1.0"
+
+// DefaultMaxDirectAccessLevel is the maximum access level of a direct member
+// when maxDirectAccessLevel is not set
+const DefaultMaxDirectAccessLevel = gitlab.AccessLevelReporter
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabNoDirectElevatedMembersControl handles direct project members compliance checking
+type GitlabNoDirectElevatedMembersControl struct {
+	config *configuration.NoDirectElevatedMembersControlConfig
+}
+
+// NewGitlabNoDirectElevatedMembersControl creates a new direct members control instance
+func NewGitlabNoDirectElevatedMembersControl(config *configuration.NoDirectElevatedMembersControlConfig) *GitlabNoDirectElevatedMembersControl {
+	return &GitlabNoDirectElevatedMembersControl{
+		config: config,
+	}
+}
+
+// validateNoDirectElevatedMembersConfig validates the noDirectElevatedMembers configuration
+func validateNoDirectElevatedMembersConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	membersConfig := plumberConfig.GetNoDirectElevatedMembersConfig()
+	if !membersConfig.IsEnabled() {
+		return
+	}
+
+	if membersConfig.MaxDirectAccessLevel != nil {
+		switch *membersConfig.MaxDirectAccessLevel {
+		case gitlab.AccessLevelNo, gitlab.AccessLevelGuest, gitlab.AccessLevelReporter, gitlab.AccessLevelDeveloper, gitlab.AccessLevelMaintainer:
+		default:
+			v.add("noDirectElevatedMembers.maxDirectAccessLevel", fmt.Sprintf("invalid access level %d", *membersConfig.MaxDirectAccessLevel), "0 (no direct member), 10 (Guest), 20 (Reporter), 30 (Developer) or 40 (Maintainer)")
+		}
+	}
+}
+
+// GitlabNoDirectElevatedMembersMetrics holds metrics for the direct members control
+type GitlabNoDirectElevatedMembersMetrics struct {
+	Members              int `json:"members"` // Direct and inherited members
+	DirectMembers        int `json:"directMembers"`
+	ElevatedMembers      int `json:"elevatedMembers"` // Direct members above maxDirectAccessLevel
+	MaxDirectAccessLevel int `json:"maxDirectAccessLevel"`
+}
+
+// GitlabNoDirectElevatedMembersResult holds the result of the direct members control
+type GitlabNoDirectElevatedMembersResult struct {
+	Issues     []GitlabNoDirectElevatedMembersIssue `json:"issues"`
+	Metrics    GitlabNoDirectElevatedMembersMetrics `json:"metrics"`
+	Compliance float64                              `json:"compliance"`
+	Version    string                               `json:"version"`
+	Skipped    bool                                 `json:"skipped"`         // True if control was disabled
+	Error      string                               `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabNoDirectElevatedMembersIssue represents a member added directly to the
+// project with an access level above the maximum. Emails are never reported.
+type GitlabNoDirectElevatedMembersIssue struct {
+	Username             string `json:"username"`
+	AccessLevel          int    `json:"accessLevel"`
+	MaxDirectAccessLevel int    `json:"maxDirectAccessLevel"`
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the direct members compliance check. Access granted through
+// group membership, including groups the project is shared with, is not flagged.
+func (c *GitlabNoDirectElevatedMembersControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabNoDirectElevatedMembersResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabNoDirectElevatedMembers",
+		"controlVersion": ControlTypeGitlabProtectionNoDirectElevatedMembersVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabNoDirectElevatedMembersResult{
+		Issues:     []GitlabNoDirectElevatedMembersIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionNoDirectElevatedMembersVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("No direct elevated members control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start no direct elevated members control")
+
+	maxDirectAccessLevel := DefaultMaxDirectAccessLevel
+	if c.config.MaxDirectAccessLevel != nil {
+		maxDirectAccessLevel = *c.config.MaxDirectAccessLevel
+	}
+	result.Metrics.MaxDirectAccessLevel = maxDirectAccessLevel
+
+	// Direct members could not be fetched (e.g., missing permissions)
+	if protectionData == nil || protectionData.DirectMembers == nil {
+		logger.Warn("Direct project members are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "direct project members are not available"
+		return result
+	}
+
+	for _, member := range protectionData.DirectMembers {
+		if member.AccessLevel <= maxDirectAccessLevel {
+			continue
+		}
+		result.Issues = append(result.Issues, GitlabNoDirectElevatedMembersIssue{
+			Username:             member.Name,
+			AccessLevel:          member.AccessLevel,
+			MaxDirectAccessLevel: maxDirectAccessLevel,
+		})
+	}
+	sort.Slice(result.Issues, func(i, j int) bool {
+		return result.Issues[i].Username < result.Issues[j].Username
+	})
+
+	result.Metrics.Members = len(protectionData.ProjectMembers)
+	result.Metrics.DirectMembers = len(protectionData.DirectMembers)
+	result.Metrics.ElevatedMembers = len(result.Issues)
+
+	if len(result.Issues) > 0 {
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"directMembers":   result.Metrics.DirectMembers,
+		"elevatedMembers": result.Metrics.ElevatedMembers,
+		"compliance":      result.Compliance,
+	}).Info("No direct elevated members control completed")
+
+	return result
+}
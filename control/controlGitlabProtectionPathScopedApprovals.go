@@ -0,0 +1,319 @@
+package control
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionPathScopedApprovalsVersion = "0.1.0"
+
+// DefaultPathScopedMinApprovals is the number of approvals required for a
+// sensitive path when minApprovals is not set
+const DefaultPathScopedMinApprovals = 1
+
+// codeOwnersPaths are the locations of the CODEOWNERS file, in the order
+// GitLab looks them up: the first one found is used
+var codeOwnersPaths = []string{
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// codeOwnersSectionPattern matches a CODEOWNERS section header, e.g.
+// [Infrastructure][2] @infra-team or ^[Docs] for an optional section
+var codeOwnersSectionPattern = regexp.MustCompile(`^(\^)?\[([^\]]+)\](?:\[(\d+)\])?(.*)$`)
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabPathScopedApprovalsControl handles path scoped approvals compliance checking
+type GitlabPathScopedApprovalsControl struct {
+	config *configuration.PathScopedApprovalsControlConfig
+}
+
+// NewGitlabPathScopedApprovalsControl creates a new path scoped approvals control instance
+func NewGitlabPathScopedApprovalsControl(config *configuration.PathScopedApprovalsControlConfig) *GitlabPathScopedApprovalsControl {
+	return &GitlabPathScopedApprovalsControl{
+		config: config,
+	}
+}
+
+// validatePathScopedApprovalsConfig validates the pathScopedApprovals configuration
+func validatePathScopedApprovalsConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	approvalsConfig := plumberConfig.GetPathScopedApprovalsConfig()
+	if !approvalsConfig.IsEnabled() {
+		return
+	}
+
+	if len(approvalsConfig.Requirements) == 0 {
+		v.add("pathScopedApprovals.requirements", "field is required when the control is enabled", "a list of {pathPattern, minApprovals}, e.g. pathPattern: \"*.tf\"")
+	}
+	for index, requirement := range approvalsConfig.Requirements {
+		field := fmt.Sprintf("pathScopedApprovals.requirements[%d]", index)
+		if strings.TrimSpace(requirement.PathPattern) == "" {
+			v.add(field+".pathPattern", "field is required", "a path as written in CODEOWNERS, e.g. *.tf")
+		}
+		if requirement.MinApprovals != nil && *requirement.MinApprovals < 1 {
+			v.add(field+".minApprovals", fmt.Sprintf("invalid number of approvals %d", *requirement.MinApprovals), "a number greater than or equal to 1")
+		}
+	}
+}
+
+// Paths returns the possible locations of the CODEOWNERS file
+func (c *GitlabPathScopedApprovalsControl) Paths() []string {
+	return codeOwnersPaths
+}
+
+// GitlabPathScopedApprovalsMetrics holds metrics for the path scoped approvals control
+type GitlabPathScopedApprovalsMetrics struct {
+	Requirements              uint `json:"requirements"`
+	Covered                   uint `json:"covered"`
+	Uncovered                 uint `json:"uncovered"`
+	ApprovalsRequired         int  `json:"approvalsRequired"` // Approvals required by the approval rules of the default branch, for any path
+	CodeOwnerApprovalRequired bool `json:"codeOwnerApprovalRequired"`
+}
+
+// GitlabPathScopedApprovalsResult holds the result of the path scoped approvals control
+type GitlabPathScopedApprovalsResult struct {
+	Issues     []GitlabPathScopedApprovalsIssue `json:"issues"`
+	Metrics    GitlabPathScopedApprovalsMetrics `json:"metrics"`
+	CodeOwners string                           `json:"codeOwners,omitempty"` // Path of the CODEOWNERS file used
+	Compliance float64                          `json:"compliance"`
+	Version    string                           `json:"version"`
+	Skipped    bool                             `json:"skipped"`         // True if control was disabled
+	Error      string                           `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPathScopedApprovalsIssue represents a sensitive path whose changes
+// can be merged with fewer approvals than required
+type GitlabPathScopedApprovalsIssue struct {
+	Path         string `json:"path"`
+	MinApprovals int    `json:"minApprovals"`
+	Approvals    int    `json:"approvals"` // Approvals required for the path by approval rules or code owners
+}
+
+// codeOwnersEntry is a path of a CODEOWNERS file with the approvals its
+// section requires
+type codeOwnersEntry struct {
+	Pattern   string
+	HasOwners bool
+	Section   string
+	Approvals int
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the path scoped approvals compliance check on the default
+// branch. Approval rules apply to every path; code owner approvals only
+// count when they are required on the default branch. The highest
+// requirement of both applies to each sensitive path.
+func (c *GitlabPathScopedApprovalsControl) Run(
+	filesData *collector.GitlabRepositoryFilesData,
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabPathScopedApprovalsResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabPathScopedApprovals",
+		"controlVersion": ControlTypeGitlabProtectionPathScopedApprovalsVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabPathScopedApprovalsResult{
+		Issues:     []GitlabPathScopedApprovalsIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionPathScopedApprovalsVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Path scoped approvals control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start path scoped approvals control")
+
+	// Approval rules are a premium feature, without them the control can't
+	// be evaluated and is skipped rather than failed
+	if protectionData == nil || protectionData.MRApprovalRules == nil {
+		logger.Warn("Merge request approval rules are not available (may require GitLab Premium), skipping")
+		result.Skipped = true
+		result.Error = "merge request approval rules are not available"
+		return result
+	}
+
+	approvalsRequired, _ := defaultBranchApprovals(protectionData.MRApprovalRules, project.DefaultBranch)
+	result.Metrics.ApprovalsRequired = approvalsRequired
+	result.Metrics.CodeOwnerApprovalRequired = codeOwnerApprovalRequired(protectionData.BranchProtections, project.DefaultBranch)
+
+	var entries []codeOwnersEntry
+	if filesData != nil {
+		for _, codeOwnersPath := range codeOwnersPaths {
+			if filesData.Files[codeOwnersPath] {
+				result.CodeOwners = codeOwnersPath
+				entries = parseCodeOwners(filesData.Contents[codeOwnersPath])
+				break
+			}
+		}
+	}
+
+	for _, requirement := range c.config.Requirements {
+		minApprovals := DefaultPathScopedMinApprovals
+		if requirement.MinApprovals != nil {
+			minApprovals = *requirement.MinApprovals
+		}
+		result.Metrics.Requirements++
+
+		approvals := approvalsRequired
+		if result.Metrics.CodeOwnerApprovalRequired {
+			if codeOwnerApprovals := codeOwnersApprovals(entries, requirement.PathPattern); codeOwnerApprovals > approvals {
+				approvals = codeOwnerApprovals
+			}
+		}
+
+		if approvals >= minApprovals {
+			result.Metrics.Covered++
+			continue
+		}
+
+		result.Issues = append(result.Issues, GitlabPathScopedApprovalsIssue{
+			Path:         requirement.PathPattern,
+			MinApprovals: minApprovals,
+			Approvals:    approvals,
+		})
+		result.Metrics.Uncovered++
+	}
+
+	// Compliance is the share of sensitive paths covered
+	if result.Metrics.Requirements > 0 {
+		result.Compliance = float64(result.Metrics.Covered) / float64(result.Metrics.Requirements) * 100
+	}
+
+	logger.WithFields(logrus.Fields{
+		"requirements": result.Metrics.Requirements,
+		"uncovered":    result.Metrics.Uncovered,
+		"compliance":   result.Compliance,
+	}).Info("Path scoped approvals control completed")
+
+	return result
+}
+
+// codeOwnerApprovalRequired reports whether a protection of the branch
+// requires code owner approval
+func codeOwnerApprovalRequired(protections []gitlab.BranchProtection, branch string) bool {
+	for _, protection := range protections {
+		if protection.CodeOwnerApprovalRequired && gitlab.CheckItemMatchToPatterns(branch, []string{protection.ProtectionPattern}) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCodeOwners parses the entries of a CODEOWNERS file. Entries of an
+// optional section (^[Section]) require no approval; other sections require
+// one approval unless set (e.g., [Section][2]). Entries without owners take
+// the default owners of their section.
+func parseCodeOwners(content string) []codeOwnersEntry {
+	entries := []codeOwnersEntry{}
+	section := ""
+	approvals := 1
+	sectionHasOwners := false
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := codeOwnersSectionPattern.FindStringSubmatch(line); match != nil {
+			section = match[2]
+			approvals = 1
+			if match[3] != "" {
+				approvals, _ = strconv.Atoi(match[3])
+			}
+			if match[1] == "^" {
+				approvals = 0
+			}
+			sectionHasOwners = len(strings.Fields(match[4])) > 0
+			continue
+		}
+
+		fields := strings.Fields(line)
+		entries = append(entries, codeOwnersEntry{
+			Pattern:   fields[0],
+			HasOwners: len(fields) > 1 || sectionHasOwners,
+			Section:   section,
+			Approvals: approvals,
+		})
+	}
+	return entries
+}
+
+// codeOwnersApprovals returns the code owner approvals required for a path.
+// In each section the last matching entry applies; all sections must be
+// satisfied, so the highest requirement is returned.
+func codeOwnersApprovals(entries []codeOwnersEntry, filePath string) int {
+	lastMatch := map[string]codeOwnersEntry{}
+	for _, entry := range entries {
+		if codeOwnersPatternMatches(entry.Pattern, filePath) {
+			lastMatch[entry.Section] = entry
+		}
+	}
+
+	approvals := 0
+	for _, entry := range lastMatch {
+		if entry.HasOwners && entry.Approvals > approvals {
+			approvals = entry.Approvals
+		}
+	}
+	return approvals
+}
+
+// codeOwnersPatternMatches reports whether a CODEOWNERS pattern covers a
+// path. Patterns starting with / are relative to the repository root,
+// patterns ending with / match folders, and patterns without / match the
+// file name at any depth. The path may itself be a pattern (e.g., *.tf is
+// covered by *.tf or *).
+func codeOwnersPatternMatches(pattern string, filePath string) bool {
+	anchored := strings.HasPrefix(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	filePath = strings.TrimPrefix(filePath, "/")
+	if pattern == "" {
+		return false
+	}
+
+	// Folder: the path is in the folder, at the root if anchored
+	if strings.HasSuffix(pattern, "/") {
+		if strings.HasPrefix(filePath, pattern) {
+			return true
+		}
+		return !anchored && strings.Contains(filePath, "/"+pattern)
+	}
+
+	// File name at any depth
+	if !anchored && !strings.Contains(pattern, "/") {
+		return gitlab.CheckItemMatchToPatterns(path.Base(filePath), []string{pattern}) ||
+			gitlab.CheckItemMatchToPatterns(filePath, []string{pattern})
+	}
+
+	// Path from the root, or a folder written without its trailing slash
+	return gitlab.CheckItemMatchToPatterns(filePath, []string{pattern}) || strings.HasPrefix(filePath, pattern+"/")
+}
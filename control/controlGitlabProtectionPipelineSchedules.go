@@ -0,0 +1,208 @@
+package control
+
+import (
+	"fmt"
+	"strings"
+
+	wildcard "github.com/IGLOU-EU/go-wildcard/v2"
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionPipelineSchedulesVersion = "0.1.0"
+
+// DefaultMaxScheduleOwnerAccessLevel is the maximum access level of a schedule
+// owner when maxOwnerAccessLevel is not set
+const DefaultMaxScheduleOwnerAccessLevel = gitlab.AccessLevelMaintainer
+
+// Pipeline schedule issue types
+const (
+	scheduleIssueOverprivilegedOwner = "overprivilegedOwner"
+	scheduleIssueUnprotectedRef      = "unprotectedRef"
+)
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabPipelineSchedulesControl handles pipeline schedules compliance checking
+type GitlabPipelineSchedulesControl struct {
+	config *configuration.PipelineSchedulesControlConfig
+}
+
+// NewGitlabPipelineSchedulesControl creates a new pipeline schedules control instance
+func NewGitlabPipelineSchedulesControl(config *configuration.PipelineSchedulesControlConfig) *GitlabPipelineSchedulesControl {
+	return &GitlabPipelineSchedulesControl{
+		config: config,
+	}
+}
+
+// validatePipelineSchedulesConfig validates the pipelineSchedules configuration
+func validatePipelineSchedulesConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	schedulesConfig := plumberConfig.GetPipelineSchedulesConfig()
+	if !schedulesConfig.IsEnabled() {
+		return
+	}
+
+	if schedulesConfig.MaxOwnerAccessLevel != nil {
+		switch *schedulesConfig.MaxOwnerAccessLevel {
+		case gitlab.AccessLevelDeveloper, gitlab.AccessLevelMaintainer, gitlab.AccessLevelOwner:
+		default:
+			v.add("pipelineSchedules.maxOwnerAccessLevel", fmt.Sprintf("invalid access level %d", *schedulesConfig.MaxOwnerAccessLevel), "30 (Developer), 40 (Maintainer) or 50 (Owner)")
+		}
+	}
+}
+
+// GitlabPipelineSchedulesMetrics holds metrics for the pipeline schedules control
+type GitlabPipelineSchedulesMetrics struct {
+	Schedules            int `json:"schedules"`
+	Active               int `json:"active"`
+	OverprivilegedOwners int `json:"overprivilegedOwners"`
+	UnprotectedRefs      int `json:"unprotectedRefs"`
+	MaxOwnerAccessLevel  int `json:"maxOwnerAccessLevel"`
+	UnknownOwners        int `json:"unknownOwners"`       // Owners whose access level is unknown (not a member, or members not available)
+	SkippedTagSchedules  int `json:"skippedTagSchedules"` // Schedules on tags, only branch protections are checked
+}
+
+// GitlabPipelineSchedulesResult holds the result of the pipeline schedules control
+type GitlabPipelineSchedulesResult struct {
+	Issues     []GitlabPipelineSchedulesIssue `json:"issues"`
+	Metrics    GitlabPipelineSchedulesMetrics `json:"metrics"`
+	Compliance float64                        `json:"compliance"`
+	Version    string                         `json:"version"`
+	Skipped    bool                           `json:"skipped"`         // True if control was disabled
+	Error      string                         `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPipelineSchedulesIssue represents a risky pipeline schedule
+type GitlabPipelineSchedulesIssue struct {
+	Type             string `json:"type"` // "overprivilegedOwner" or "unprotectedRef"
+	ScheduleID       int    `json:"scheduleId"`
+	Description      string `json:"description"`
+	Cron             string `json:"cron"`
+	Ref              string `json:"ref"`
+	Owner            string `json:"owner"`
+	OwnerAccessLevel int    `json:"ownerAccessLevel,omitempty"`
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the pipeline schedules compliance check
+func (c *GitlabPipelineSchedulesControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabPipelineSchedulesResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabPipelineSchedules",
+		"controlVersion": ControlTypeGitlabProtectionPipelineSchedulesVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabPipelineSchedulesResult{
+		Issues:     []GitlabPipelineSchedulesIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionPipelineSchedulesVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Pipeline schedules control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start pipeline schedules control")
+
+	maxOwnerAccessLevel := DefaultMaxScheduleOwnerAccessLevel
+	if c.config.MaxOwnerAccessLevel != nil {
+		maxOwnerAccessLevel = *c.config.MaxOwnerAccessLevel
+	}
+	result.Metrics.MaxOwnerAccessLevel = maxOwnerAccessLevel
+
+	// Schedules could not be fetched (e.g., missing permissions)
+	if protectionData == nil || protectionData.PipelineSchedules == nil {
+		logger.Warn("Pipeline schedules are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "pipeline schedules are not available"
+		return result
+	}
+
+	// Access level of each project member
+	memberAccessLevels := map[int]int{}
+	for _, member := range protectionData.ProjectMembers {
+		memberAccessLevels[member.ID] = member.AccessLevel
+	}
+
+	for _, schedule := range protectionData.PipelineSchedules {
+		result.Metrics.Schedules++
+		if !schedule.Active {
+			continue
+		}
+		result.Metrics.Active++
+
+		issue := GitlabPipelineSchedulesIssue{
+			ScheduleID:  schedule.ID,
+			Description: schedule.Description,
+			Cron:        schedule.Cron,
+			Ref:         schedule.Ref,
+			Owner:       schedule.OwnerUsername,
+		}
+
+		// The pipeline runs with the permissions of the schedule owner
+		if accessLevel, isMember := memberAccessLevels[schedule.OwnerID]; !isMember {
+			result.Metrics.UnknownOwners++
+		} else if accessLevel > maxOwnerAccessLevel {
+			ownerIssue := issue
+			ownerIssue.Type = scheduleIssueOverprivilegedOwner
+			ownerIssue.OwnerAccessLevel = accessLevel
+			result.Issues = append(result.Issues, ownerIssue)
+			result.Metrics.OverprivilegedOwners++
+		}
+
+		// Only branch protections are collected, schedules on tags are not checked
+		if strings.HasPrefix(schedule.Ref, "refs/tags/") {
+			result.Metrics.SkippedTagSchedules++
+			continue
+		}
+		if !isBranchProtected(strings.TrimPrefix(schedule.Ref, "refs/heads/"), protectionData.BranchProtections) {
+			refIssue := issue
+			refIssue.Type = scheduleIssueUnprotectedRef
+			result.Issues = append(result.Issues, refIssue)
+			result.Metrics.UnprotectedRefs++
+		}
+	}
+
+	if len(result.Issues) > 0 {
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"schedules":            result.Metrics.Schedules,
+		"overprivilegedOwners": result.Metrics.OverprivilegedOwners,
+		"unprotectedRefs":      result.Metrics.UnprotectedRefs,
+		"compliance":           result.Compliance,
+	}).Info("Pipeline schedules control completed")
+
+	return result
+}
+
+// isBranchProtected reports whether a branch matches one of the protection patterns
+func isBranchProtected(branch string, branchProtections []gitlab.BranchProtection) bool {
+	for _, branchProtection := range branchProtections {
+		if wildcard.Match(branchProtection.ProtectionPattern, branch) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package control
+
+import (
+	"fmt"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionProtectedEnvironmentsVersion = "0.1.0"
+
+// DefaultMinDeployAccessLevel is the minimum access level allowed to deploy
+// to a required environment when minDeployAccessLevel is not set
+const DefaultMinDeployAccessLevel = gitlab.AccessLevelMaintainer
+
+// Protected environments issue types
+const (
+	protectedEnvironmentIssueUnprotected = "unprotected" // The environment is not protected
+	protectedEnvironmentIssuePermissive  = "permissive"  // A role below the minimum access level can deploy
+)
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabProtectedEnvironmentsControl handles protected environments compliance checking
+type GitlabProtectedEnvironmentsControl struct {
+	config *configuration.ProtectedEnvironmentsControlConfig
+}
+
+// NewGitlabProtectedEnvironmentsControl creates a new protected environments control instance
+func NewGitlabProtectedEnvironmentsControl(config *configuration.ProtectedEnvironmentsControlConfig) *GitlabProtectedEnvironmentsControl {
+	return &GitlabProtectedEnvironmentsControl{
+		config: config,
+	}
+}
+
+// validateProtectedEnvironmentsConfig validates the protectedEnvironments configuration
+func validateProtectedEnvironmentsConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	environmentsConfig := plumberConfig.GetProtectedEnvironmentsConfig()
+	if !environmentsConfig.IsEnabled() {
+		return
+	}
+
+	if len(environmentsConfig.RequiredEnvironments) == 0 {
+		v.add("protectedEnvironments.requiredEnvironments", "empty list when the control is enabled", "a list of environment names, e.g. production")
+	}
+	if environmentsConfig.MinDeployAccessLevel != nil {
+		switch *environmentsConfig.MinDeployAccessLevel {
+		case gitlab.AccessLevelDeveloper, gitlab.AccessLevelMaintainer, gitlab.AccessLevelAdmin:
+		default:
+			v.add("protectedEnvironments.minDeployAccessLevel", fmt.Sprintf("invalid access level %d", *environmentsConfig.MinDeployAccessLevel), "30 (Developer), 40 (Maintainer) or 60 (Admin)")
+		}
+	}
+}
+
+// GitlabProtectedEnvironmentsMetrics holds metrics for the protected environments control
+type GitlabProtectedEnvironmentsMetrics struct {
+	RequiredEnvironments  int `json:"requiredEnvironments"`
+	CompliantEnvironments int `json:"compliantEnvironments"`
+	Unprotected           int `json:"unprotected"`
+	Permissive            int `json:"permissive"`
+	MinDeployAccessLevel  int `json:"minDeployAccessLevel"`
+}
+
+// GitlabProtectedEnvironmentsResult holds the result of the protected environments control
+type GitlabProtectedEnvironmentsResult struct {
+	Issues     []GitlabProtectedEnvironmentsIssue `json:"issues"`
+	Metrics    GitlabProtectedEnvironmentsMetrics `json:"metrics"`
+	Compliance float64                            `json:"compliance"`
+	Version    string                             `json:"version"`
+	Skipped    bool                               `json:"skipped"`         // True if control was disabled
+	Error      string                             `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabProtectedEnvironmentsIssue represents a required environment that is
+// not protected, or that a role below the minimum access level can deploy to
+type GitlabProtectedEnvironmentsIssue struct {
+	Type                     string `json:"type"` // unprotected or permissive
+	Environment              string `json:"environment"`
+	DeployAccessLevel        int    `json:"deployAccessLevel,omitempty"`     // Lowest role allowed to deploy, for permissive environments
+	DeployAccessLevelText    string `json:"deployAccessLevelText,omitempty"` // e.g., Developer
+	MinDeployAccessLevel     int    `json:"minDeployAccessLevel"`
+	MinDeployAccessLevelText string `json:"minDeployAccessLevelText"`
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the protected environments compliance check. Deploy access
+// given to specific users or groups is not compared to the minimum access
+// level, only roles are.
+func (c *GitlabProtectedEnvironmentsControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabProtectedEnvironmentsResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabProtectedEnvironments",
+		"controlVersion": ControlTypeGitlabProtectionProtectedEnvironmentsVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabProtectedEnvironmentsResult{
+		Issues:     []GitlabProtectedEnvironmentsIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionProtectedEnvironmentsVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Protected environments control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start protected environments control")
+
+	// Protected environments could not be fetched (e.g., GitLab CE or
+	// missing permissions), the control can't be evaluated
+	if protectionData == nil || protectionData.ProtectedEnvironments == nil {
+		logger.Warn("Protected environments are not available, skipping the control")
+		result.Skipped = true
+		return result
+	}
+
+	minDeployAccessLevel := DefaultMinDeployAccessLevel
+	if c.config.MinDeployAccessLevel != nil {
+		minDeployAccessLevel = *c.config.MinDeployAccessLevel
+	}
+	result.Metrics.MinDeployAccessLevel = minDeployAccessLevel
+
+	protectedEnvironments := map[string]gitlab.ProtectedEnvironmentInfo{}
+	for _, environment := range protectionData.ProtectedEnvironments {
+		protectedEnvironments[environment.Name] = environment
+	}
+
+	for _, name := range c.config.RequiredEnvironments {
+		result.Metrics.RequiredEnvironments++
+
+		issue := GitlabProtectedEnvironmentsIssue{
+			Environment:              name,
+			MinDeployAccessLevel:     minDeployAccessLevel,
+			MinDeployAccessLevelText: gitlab.AccessLevelText(minDeployAccessLevel),
+		}
+
+		environment, protected := protectedEnvironments[name]
+		if !protected {
+			issue.Type = protectedEnvironmentIssueUnprotected
+			result.Issues = append(result.Issues, issue)
+			result.Metrics.Unprotected++
+			continue
+		}
+
+		lowestLevel, permissive := lowestRoleDeployAccessLevel(environment, minDeployAccessLevel)
+		if !permissive {
+			result.Metrics.CompliantEnvironments++
+			continue
+		}
+
+		issue.Type = protectedEnvironmentIssuePermissive
+		issue.DeployAccessLevel = lowestLevel
+		issue.DeployAccessLevelText = gitlab.AccessLevelText(lowestLevel)
+		result.Issues = append(result.Issues, issue)
+		result.Metrics.Permissive++
+	}
+
+	// Compliance is the share of required environments protected as required
+	if result.Metrics.RequiredEnvironments > 0 {
+		result.Compliance = float64(result.Metrics.CompliantEnvironments) / float64(result.Metrics.RequiredEnvironments) * 100
+	}
+
+	logger.WithFields(logrus.Fields{
+		"requiredEnvironments":  result.Metrics.RequiredEnvironments,
+		"compliantEnvironments": result.Metrics.CompliantEnvironments,
+		"compliance":            result.Compliance,
+	}).Info("Protected environments control completed")
+
+	return result
+}
+
+// lowestRoleDeployAccessLevel returns the lowest role allowed to deploy to a
+// protected environment, and whether it is below the minimum access level
+func lowestRoleDeployAccessLevel(environment gitlab.ProtectedEnvironmentInfo, minDeployAccessLevel int) (int, bool) {
+	lowestLevel := 0
+	for _, level := range environment.DeployAccessLevels {
+		if level.UserID != 0 || level.GroupID != 0 {
+			continue
+		}
+		if lowestLevel == 0 || level.AccessLevel < lowestLevel {
+			lowestLevel = level.AccessLevel
+		}
+	}
+	return lowestLevel, lowestLevel != 0 && lowestLevel < minDeployAccessLevel
+}
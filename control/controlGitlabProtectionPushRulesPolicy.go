@@ -0,0 +1,178 @@
+package control
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionPushRulesPolicyVersion = "0.1.0"
+
+// Push rules checked by the control
+const (
+	pushRuleAuthorEmailRegex   = "authorEmailRegex"
+	pushRuleCommitMessageRegex = "commitMessageRegex"
+)
+
+// Push rules policy issue types
+const (
+	pushRuleIssueMissing   = "missing"   // The push rule is not set
+	pushRuleIssueDifferent = "different" // The push rule is set to another regex, which may be more lax
+)
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabPushRulesPolicyControl handles push rules compliance checking
+type GitlabPushRulesPolicyControl struct {
+	config *configuration.PushRulesPolicyControlConfig
+}
+
+// NewGitlabPushRulesPolicyControl creates a new push rules policy control instance
+func NewGitlabPushRulesPolicyControl(config *configuration.PushRulesPolicyControlConfig) *GitlabPushRulesPolicyControl {
+	return &GitlabPushRulesPolicyControl{
+		config: config,
+	}
+}
+
+// validatePushRulesPolicyConfig validates the pushRulesPolicy configuration
+func validatePushRulesPolicyConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	pushRulesConfig := plumberConfig.GetPushRulesPolicyConfig()
+	if !pushRulesConfig.IsEnabled() {
+		return
+	}
+
+	if pushRulesConfig.RequiredAuthorEmailRegex == "" && pushRulesConfig.RequiredCommitMessageRegex == "" {
+		v.add("pushRulesPolicy", "no required push rule when the control is enabled", "requiredAuthorEmailRegex or requiredCommitMessageRegex")
+	}
+	if _, err := regexp.Compile(pushRulesConfig.RequiredAuthorEmailRegex); err != nil {
+		v.add("pushRulesPolicy.requiredAuthorEmailRegex", fmt.Sprintf("invalid regex (%v)", err), "a regex matching allowed author emails")
+	}
+	if _, err := regexp.Compile(pushRulesConfig.RequiredCommitMessageRegex); err != nil {
+		v.add("pushRulesPolicy.requiredCommitMessageRegex", fmt.Sprintf("invalid regex (%v)", err), "a regex matching allowed commit messages")
+	}
+}
+
+// GitlabPushRulesPolicyMetrics holds metrics for the push rules policy control
+type GitlabPushRulesPolicyMetrics struct {
+	RequiredRules int `json:"requiredRules"`
+	EnforcedRules int `json:"enforcedRules"`
+}
+
+// GitlabPushRulesPolicyResult holds the result of the push rules policy control
+type GitlabPushRulesPolicyResult struct {
+	Issues     []GitlabPushRulesPolicyIssue `json:"issues"`
+	Metrics    GitlabPushRulesPolicyMetrics `json:"metrics"`
+	Compliance float64                      `json:"compliance"`
+	Version    string                       `json:"version"`
+	Skipped    bool                         `json:"skipped"`         // True if control was disabled
+	Error      string                       `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabPushRulesPolicyIssue represents a push rule not enforcing the required regex
+type GitlabPushRulesPolicyIssue struct {
+	Type     string `json:"type"`     // missing or different
+	Rule     string `json:"rule"`     // authorEmailRegex or commitMessageRegex
+	Current  string `json:"current"`  // Regex set in the push rules, empty if missing
+	Required string `json:"required"` // Regex required by the configuration
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the push rules policy compliance check. A push rule enforces
+// the policy only if it is set to the required regex: another regex can't be
+// proven to be as strict.
+func (c *GitlabPushRulesPolicyControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabPushRulesPolicyResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabPushRulesPolicy",
+		"controlVersion": ControlTypeGitlabProtectionPushRulesPolicyVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabPushRulesPolicyResult{
+		Issues:     []GitlabPushRulesPolicyIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionPushRulesPolicyVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Push rules policy control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start push rules policy control")
+
+	// Push rules could not be fetched (e.g., GitLab CE or missing
+	// permissions), the control can't be evaluated
+	if protectionData == nil || protectionData.PushRules == nil {
+		logger.Warn("Push rules are not available, skipping the control")
+		result.Skipped = true
+		return result
+	}
+
+	requiredRules := []struct {
+		rule     string
+		current  string
+		required string
+	}{
+		{pushRuleAuthorEmailRegex, protectionData.PushRules.AuthorEmailRegex, c.config.RequiredAuthorEmailRegex},
+		{pushRuleCommitMessageRegex, protectionData.PushRules.CommitMessageRegex, c.config.RequiredCommitMessageRegex},
+	}
+
+	for _, requiredRule := range requiredRules {
+		if requiredRule.required == "" {
+			continue
+		}
+		result.Metrics.RequiredRules++
+
+		current := strings.TrimSpace(requiredRule.current)
+		if current == strings.TrimSpace(requiredRule.required) {
+			result.Metrics.EnforcedRules++
+			continue
+		}
+
+		issueType := pushRuleIssueDifferent
+		if current == "" {
+			issueType = pushRuleIssueMissing
+		}
+		result.Issues = append(result.Issues, GitlabPushRulesPolicyIssue{
+			Type:     issueType,
+			Rule:     requiredRule.rule,
+			Current:  current,
+			Required: requiredRule.required,
+		})
+	}
+
+	// Compliance is the share of required push rules enforced
+	if result.Metrics.RequiredRules > 0 {
+		result.Compliance = float64(result.Metrics.EnforcedRules) / float64(result.Metrics.RequiredRules) * 100
+	}
+
+	logger.WithFields(logrus.Fields{
+		"requiredRules": result.Metrics.RequiredRules,
+		"enforcedRules": result.Metrics.EnforcedRules,
+		"compliance":    result.Compliance,
+	}).Info("Push rules policy control completed")
+
+	return result
+}
@@ -0,0 +1,188 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionSeparationOfDutiesVersion = "0.1.0"
+
+// Separation of duties requirements, each failing one is a distinct issue
+const (
+	separationOfDutiesAuthorApproval     = "authorApproval"
+	separationOfDutiesCommittersApproval = "committersApproval"
+	separationOfDutiesMinApprovals       = "minApprovals"
+	separationOfDutiesDirectPush         = "directPush"
+)
+
+// separationOfDutiesApprovalChecks are the checks relying on merge request
+// approval data, which is only available on GitLab Premium
+var separationOfDutiesApprovalChecks = []string{
+	separationOfDutiesAuthorApproval,
+	separationOfDutiesCommittersApproval,
+	separationOfDutiesMinApprovals,
+}
+
+// separationOfDutiesSkippedNote explains which checks couldn't run when the
+// merge request approval data is not available
+var separationOfDutiesSkippedNote = "merge request approval data is not available (requires GitLab Premium), these checks could not run: " +
+	strings.Join(separationOfDutiesApprovalChecks, ", ")
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabSeparationOfDutiesControl handles separation of duties compliance checking
+type GitlabSeparationOfDutiesControl struct {
+	config *configuration.SeparationOfDutiesControlConfig
+}
+
+// NewGitlabSeparationOfDutiesControl creates a new separation of duties control instance
+func NewGitlabSeparationOfDutiesControl(config *configuration.SeparationOfDutiesControlConfig) *GitlabSeparationOfDutiesControl {
+	return &GitlabSeparationOfDutiesControl{
+		config: config,
+	}
+}
+
+// GitlabSeparationOfDutiesMetrics holds metrics for the separation of duties control
+type GitlabSeparationOfDutiesMetrics struct {
+	Requirements      uint `json:"requirements"`
+	Failed            uint `json:"failed"`
+	ApprovalsRequired int  `json:"approvalsRequired"` // Approvals required by the approval rules of the default branch
+}
+
+// GitlabSeparationOfDutiesResult holds the result of the separation of duties control
+type GitlabSeparationOfDutiesResult struct {
+	Issues     []GitlabSeparationOfDutiesIssue `json:"issues"`
+	Metrics    GitlabSeparationOfDutiesMetrics `json:"metrics"`
+	Compliance float64                         `json:"compliance"`
+	Version    string                          `json:"version"`
+	Skipped    bool                            `json:"skipped"`         // True if control was disabled
+	Error      string                          `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabSeparationOfDutiesIssue represents a separation of duties
+// requirement the project doesn't meet
+type GitlabSeparationOfDutiesIssue struct {
+	Requirement       string   `json:"requirement"`                 // authorApproval, committersApproval, minApprovals or directPush
+	ApprovalsRequired int      `json:"approvalsRequired,omitempty"` // Set for minApprovals
+	PushAccess        []string `json:"pushAccess,omitempty"`        // Set for directPush: who can push to the default branch
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the separation of duties compliance check: the author and
+// committers of a merge request can't approve it, at least one approval is
+// required to merge into the default branch, and no one can push to the
+// default branch without a merge request. Without approval data (e.g.,
+// GitLab Free), the control is skipped with a note rather than passed.
+func (c *GitlabSeparationOfDutiesControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabSeparationOfDutiesResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabSeparationOfDuties",
+		"controlVersion": ControlTypeGitlabProtectionSeparationOfDutiesVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabSeparationOfDutiesResult{
+		Issues:     []GitlabSeparationOfDutiesIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionSeparationOfDutiesVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Separation of duties control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start separation of duties control")
+
+	if protectionData == nil || protectionData.MRApprovalRules == nil || protectionData.MRApprovalSettings == nil {
+		logger.Warn("Separation of duties control skipped: " + separationOfDutiesSkippedNote)
+		result.Skipped = true
+		result.Error = separationOfDutiesSkippedNote
+		return result
+	}
+
+	// Authors and committers must not approve their own changes
+	result.Metrics.Requirements++
+	if protectionData.MRApprovalSettings.MergeRequestsAuthorApproval {
+		result.Issues = append(result.Issues, GitlabSeparationOfDutiesIssue{Requirement: separationOfDutiesAuthorApproval})
+	}
+	result.Metrics.Requirements++
+	if !protectionData.MRApprovalSettings.MergeRequestsDisableCommittersApproval {
+		result.Issues = append(result.Issues, GitlabSeparationOfDutiesIssue{Requirement: separationOfDutiesCommittersApproval})
+	}
+
+	// Someone else must approve before merging
+	approvalsRequired, _ := defaultBranchApprovals(protectionData.MRApprovalRules, project.DefaultBranch)
+	result.Metrics.ApprovalsRequired = approvalsRequired
+	result.Metrics.Requirements++
+	if approvalsRequired < 1 {
+		result.Issues = append(result.Issues, GitlabSeparationOfDutiesIssue{
+			Requirement:       separationOfDutiesMinApprovals,
+			ApprovalsRequired: approvalsRequired,
+		})
+	}
+
+	// Pushing to the default branch bypasses merge requests and their approvals
+	result.Metrics.Requirements++
+	if pushAccess := defaultBranchPushAccess(protectionData.BranchProtections, project.DefaultBranch); len(pushAccess) > 0 {
+		result.Issues = append(result.Issues, GitlabSeparationOfDutiesIssue{
+			Requirement: separationOfDutiesDirectPush,
+			PushAccess:  pushAccess,
+		})
+	}
+
+	// Compliance is the share of requirements met
+	result.Metrics.Failed = uint(len(result.Issues))
+	result.Compliance = float64(result.Metrics.Requirements-result.Metrics.Failed) / float64(result.Metrics.Requirements) * 100
+
+	logger.WithFields(logrus.Fields{
+		"requirements": result.Metrics.Requirements,
+		"failed":       result.Metrics.Failed,
+		"compliance":   result.Compliance,
+	}).Info("Separation of duties control completed")
+
+	return result
+}
+
+// defaultBranchPushAccess returns who can push to the default branch
+// without a merge request. An unprotected branch can be pushed to by
+// developers; otherwise the access of all matching protections adds up.
+func defaultBranchPushAccess(protections []gitlab.BranchProtection, defaultBranch string) []string {
+	pushAccess := []string{}
+	protected := false
+	for _, protection := range protections {
+		if !gitlab.CheckItemMatchToPatterns(defaultBranch, []string{protection.ProtectionPattern}) {
+			continue
+		}
+		protected = true
+		for _, level := range protection.PushAccessLevels {
+			if level.AccessLevel > 0 || level.UserID > 0 || level.GroupID > 0 {
+				pushAccess = append(pushAccess, level.AccessLevelDescription)
+			}
+		}
+	}
+	if !protected {
+		return []string{"Developers + Maintainers (branch not protected)"}
+	}
+	return pushAccess
+}
@@ -0,0 +1,162 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+const ControlTypeGitlabProtectionWebhookAllowlistVersion = "0.1.0"
+
+// Webhook issue types
+const (
+	webhookIssueUnauthorizedHost   = "unauthorizedHost"
+	webhookIssueSSLVerificationOff = "sslVerificationDisabled"
+)
+
+//////////////////////////
+// Control configuration //
+//////////////////////////
+
+// GitlabWebhookAllowlistControl handles webhook allowlist compliance checking
+type GitlabWebhookAllowlistControl struct {
+	config *configuration.WebhookAllowlistControlConfig
+}
+
+// NewGitlabWebhookAllowlistControl creates a new webhook allowlist control instance
+func NewGitlabWebhookAllowlistControl(config *configuration.WebhookAllowlistControlConfig) *GitlabWebhookAllowlistControl {
+	return &GitlabWebhookAllowlistControl{
+		config: config,
+	}
+}
+
+// validateWebhookAllowlistConfig validates the webhookAllowlist configuration
+func validateWebhookAllowlistConfig(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	webhookConfig := plumberConfig.GetWebhookAllowlistConfig()
+	if !webhookConfig.IsEnabled() {
+		return
+	}
+
+	if len(webhookConfig.AllowedHosts) == 0 {
+		v.add("webhookAllowlist.allowedHosts", "field is required when the control is enabled", "a list of allowed hosts")
+	}
+}
+
+// GitlabWebhookAllowlistMetrics holds metrics for the webhook allowlist control
+type GitlabWebhookAllowlistMetrics struct {
+	Webhooks                int `json:"webhooks"`
+	UnauthorizedHosts       int `json:"unauthorizedHosts"`
+	SSLVerificationDisabled int `json:"sslVerificationDisabled"`
+}
+
+// GitlabWebhookAllowlistResult holds the result of the webhook allowlist control
+type GitlabWebhookAllowlistResult struct {
+	Issues     []GitlabWebhookAllowlistIssue `json:"issues"`
+	Metrics    GitlabWebhookAllowlistMetrics `json:"metrics"`
+	Compliance float64                       `json:"compliance"`
+	Version    string                        `json:"version"`
+	Skipped    bool                          `json:"skipped"`         // True if control was disabled
+	Error      string                        `json:"error,omitempty"` // Error message if data collection failed
+}
+
+////////////////////
+// Control issues //
+////////////////////
+
+// GitlabWebhookAllowlistIssue represents a webhook sending events to an
+// unauthorized host, or without SSL verification. Only the host of the
+// webhook URL is reported, the full URL may contain secrets.
+type GitlabWebhookAllowlistIssue struct {
+	Type      string `json:"type"` // "unauthorizedHost" or "sslVerificationDisabled"
+	WebhookID int    `json:"webhookId"`
+	Name      string `json:"name,omitempty"`
+	Host      string `json:"host"`
+}
+
+///////////////////
+// Control run  //
+///////////////////
+
+// Run executes the webhook allowlist compliance check
+func (c *GitlabWebhookAllowlistControl) Run(
+	protectionData *collector.GitlabProtectionAnalysisData,
+	project *gitlab.ProjectInfo,
+) *GitlabWebhookAllowlistResult {
+
+	// Set logging
+	logger := l.WithFields(logrus.Fields{
+		"control":        "GitlabWebhookAllowlist",
+		"controlVersion": ControlTypeGitlabProtectionWebhookAllowlistVersion,
+		"project":        project.Path,
+		"projectId":      project.ID,
+	})
+
+	result := &GitlabWebhookAllowlistResult{
+		Issues:     []GitlabWebhookAllowlistIssue{},
+		Compliance: 100.0,
+		Version:    ControlTypeGitlabProtectionWebhookAllowlistVersion,
+	}
+
+	// Check if control is enabled
+	if c.config == nil || !c.config.IsEnabled() {
+		logger.Info("Webhook allowlist control is disabled or not configured")
+		result.Skipped = true
+		return result
+	}
+
+	logger.Info("Start webhook allowlist control")
+
+	// Webhooks could not be fetched (e.g., missing permissions)
+	if protectionData == nil || protectionData.Webhooks == nil {
+		logger.Warn("Project webhooks are not available, cannot evaluate the control")
+		result.Compliance = 0
+		result.Error = "project webhooks are not available"
+		return result
+	}
+
+	allowedHosts := []string{}
+	for _, host := range c.config.AllowedHosts {
+		allowedHosts = append(allowedHosts, strings.ToLower(host))
+	}
+
+	for _, webhook := range protectionData.Webhooks {
+		result.Metrics.Webhooks++
+
+		if !gitlab.CheckItemMatchToPatterns(webhook.Host, allowedHosts) {
+			result.Issues = append(result.Issues, GitlabWebhookAllowlistIssue{
+				Type:      webhookIssueUnauthorizedHost,
+				WebhookID: webhook.ID,
+				Name:      webhook.Name,
+				Host:      webhook.Host,
+			})
+			result.Metrics.UnauthorizedHosts++
+		}
+
+		// Plain HTTP webhooks can't verify SSL either
+		if webhook.Scheme != "https" || !webhook.EnableSSLVerification {
+			result.Issues = append(result.Issues, GitlabWebhookAllowlistIssue{
+				Type:      webhookIssueSSLVerificationOff,
+				WebhookID: webhook.ID,
+				Name:      webhook.Name,
+				Host:      webhook.Host,
+			})
+			result.Metrics.SSLVerificationDisabled++
+		}
+	}
+
+	if len(result.Issues) > 0 {
+		result.Compliance = 0
+	}
+
+	logger.WithFields(logrus.Fields{
+		"webhooks":                result.Metrics.Webhooks,
+		"unauthorizedHosts":       result.Metrics.UnauthorizedHosts,
+		"sslVerificationDisabled": result.Metrics.SSLVerificationDisabled,
+		"compliance":              result.Compliance,
+	}).Info("Webhook allowlist control completed")
+
+	return result
+}
@@ -0,0 +1,60 @@
+package control
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ControlError is a control that failed unexpectedly during the analysis
+type ControlError struct {
+	Control string `json:"control"`
+	Error   string `json:"error"`
+}
+
+// runControl runs a control and recovers from a panic in it, so that a bug
+// in one control doesn't lose the results of the others. The control then
+// gets a result with a 0 compliance and the error, and is listed in the
+// control errors of the analysis. The issues of the result are sorted, so
+// that the output is the same between runs.
+func runControl[T any](result *AnalysisResult, name string, run func() *T) (controlResult *T) {
+	defer func() {
+		if r := recover(); r != nil {
+			message := fmt.Sprintf("control failed unexpectedly: %v", r)
+			l.WithFields(logrus.Fields{
+				"control": name,
+				"panic":   r,
+			}).Error("Control failed unexpectedly, continuing with the other controls")
+			l.WithField("control", name).Debugf("Stack trace of the control failure:\n%s", debug.Stack())
+
+			result.ControlErrors = append(result.ControlErrors, ControlError{Control: name, Error: message})
+			controlResult = failedControlResult[T](message)
+		}
+	}()
+	controlResult = run()
+	sortResultIssues(controlResult)
+	return controlResult
+}
+
+// failedControlResult builds the result of a failed control. Control results
+// share their Issues, Compliance and Error fields, they are set when present.
+func failedControlResult[T any](message string) *T {
+	controlResult := new(T)
+	value := reflect.ValueOf(controlResult).Elem()
+	if value.Kind() != reflect.Struct {
+		return controlResult
+	}
+
+	if field := value.FieldByName("Issues"); field.IsValid() && field.Kind() == reflect.Slice && field.CanSet() {
+		field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+	}
+	if field := value.FieldByName("Compliance"); field.IsValid() && field.Kind() == reflect.Float64 && field.CanSet() {
+		field.SetFloat(0)
+	}
+	if field := value.FieldByName("Error"); field.IsValid() && field.Kind() == reflect.String && field.CanSet() {
+		field.SetString(message)
+	}
+	return controlResult
+}
@@ -0,0 +1,80 @@
+package control
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getplumber/plumber/collector"
+)
+
+func TestRunControlRecoversFromPanic(t *testing.T) {
+	result := &AnalysisResult{}
+
+	result.ImageForbiddenTagsResult = runControl(result, "containerImageMustNotUseForbiddenTags", func() *GitlabImageForbiddenTagsResult {
+		panic("unexpected image")
+	})
+	result.ImageAuthorizedSourcesResult = runControl(result, "containerImageMustComeFromAuthorizedSources", func() *GitlabImageAuthorizedSourcesResult {
+		var conf *GitlabImageAuthorizedSourcesConf
+		return conf.Run(&collector.GitlabPipelineImageData{CiValid: true}) // nil pointer dereference
+	})
+	result.HardcodedJobsResult = runControl(result, "hardcodedJobs", func() *GitlabPipelineHardcodedJobsResult {
+		return &GitlabPipelineHardcodedJobsResult{
+			Issues:     []GitlabPipelineHardcodedJobsIssue{},
+			Compliance: 100,
+			Version:    ControlTypeGitlabPipelineHardcodedJobsVersion,
+		}
+	})
+
+	// The panicking controls get a failed result
+	failed := []struct {
+		control    string
+		issues     int
+		issuesNil  bool
+		compliance float64
+		err        string
+	}{
+		{
+			control:    "containerImageMustNotUseForbiddenTags",
+			issuesNil:  result.ImageForbiddenTagsResult.Issues == nil,
+			issues:     len(result.ImageForbiddenTagsResult.Issues),
+			compliance: result.ImageForbiddenTagsResult.Compliance,
+			err:        result.ImageForbiddenTagsResult.Error,
+		},
+		{
+			control:    "containerImageMustComeFromAuthorizedSources",
+			issuesNil:  result.ImageAuthorizedSourcesResult.Issues == nil,
+			issues:     len(result.ImageAuthorizedSourcesResult.Issues),
+			compliance: result.ImageAuthorizedSourcesResult.Compliance,
+			err:        result.ImageAuthorizedSourcesResult.Error,
+		},
+	}
+	for _, f := range failed {
+		if f.issuesNil || f.issues != 0 {
+			t.Errorf("%s issues: nil = %v, count = %d, want an empty list", f.control, f.issuesNil, f.issues)
+		}
+		if f.compliance != 0 {
+			t.Errorf("%s compliance = %v, want 0", f.control, f.compliance)
+		}
+		if !strings.HasPrefix(f.err, "control failed unexpectedly: ") {
+			t.Errorf("%s error = %q, want the panic", f.control, f.err)
+		}
+	}
+	if !strings.Contains(result.ImageForbiddenTagsResult.Error, "unexpected image") {
+		t.Errorf("error = %q, want the panic value", result.ImageForbiddenTagsResult.Error)
+	}
+
+	// Both failures are listed, in the order the controls ran
+	if len(result.ControlErrors) != 2 ||
+		result.ControlErrors[0].Control != "containerImageMustNotUseForbiddenTags" ||
+		result.ControlErrors[1].Control != "containerImageMustComeFromAuthorizedSources" {
+		t.Errorf("control errors = %+v, want both panicking controls", result.ControlErrors)
+	}
+
+	// The other control still reports its result
+	if result.HardcodedJobsResult.Compliance != 100 || result.HardcodedJobsResult.Error != "" {
+		t.Errorf("hardcodedJobs result = %+v, want it untouched", result.HardcodedJobsResult)
+	}
+	if issues := result.ControlIssues(); len(issues) != 0 {
+		t.Errorf("issues = %+v, want none", issues)
+	}
+}
@@ -0,0 +1,196 @@
+package control
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/utils"
+)
+
+// controlResultFields are the AnalysisResult fields of the controls whose
+// result isn't named after the control (e.g., branchMustBeProtected gives
+// BranchProtectionResult). Other controls use their name with an uppercase
+// first letter and a Result suffix.
+var controlResultFields = map[string]string{
+	"containerImageMustNotUseForbiddenTags":       "ImageForbiddenTagsResult",
+	"containerImageMustComeFromAuthorizedSources": "ImageAuthorizedSourcesResult",
+	"branchMustBeProtected":                       "BranchProtectionResult",
+}
+
+// resultFieldControls are the controls by AnalysisResult field
+var resultFieldControls = func() map[string]string {
+	controls := map[string]string{}
+	for _, control := range configuration.ControlNames() {
+		controls[controlResultField(control)] = control
+	}
+	return controls
+}()
+
+// ControlResult is the result of a control, read from its AnalysisResult field
+type ControlResult struct {
+	// Control is the name of the control in .plumber.yaml (e.g., branchMustBeProtected)
+	Control string
+
+	// Key is the key of the result in the JSON output (e.g., branchProtectionResult)
+	Key string
+
+	Compliance float64
+	Skipped    bool
+	Error      string
+
+	// Issues is the slice of issues of the control, with their own type
+	Issues     interface{}
+	IssueCount int
+}
+
+// ControlThresholdFailure is a control whose compliance is below its
+// threshold: the one set in its configuration, or the global one
+type ControlThresholdFailure struct {
+	Control    string  `json:"control"`
+	Compliance float64 `json:"compliance"`
+	Threshold  float64 `json:"threshold"`
+}
+
+// controlResultField returns the AnalysisResult field of a control
+func controlResultField(control string) string {
+	if fieldName, ok := controlResultFields[control]; ok {
+		return fieldName
+	}
+	return strings.ToUpper(control[:1]) + control[1:] + "Result"
+}
+
+// ControlResultKey returns the key of the result of a control in the JSON
+// output (e.g., branchMustBeProtected gives branchProtectionResult)
+func ControlResultKey(control string) string {
+	fieldName := controlResultField(control)
+	return strings.ToLower(fieldName[:1]) + fieldName[1:]
+}
+
+// controlResultValues returns the result structs of the controls of the
+// analysis by control name, in the order of the AnalysisResult fields.
+// Controls without result are left out.
+func (r *AnalysisResult) controlResultValues() ([]string, []reflect.Value) {
+	controls := []string{}
+	values := []reflect.Value{}
+	value := reflect.ValueOf(r).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		control, ok := resultFieldControls[value.Type().Field(i).Name]
+		if !ok || value.Field(i).IsNil() {
+			continue
+		}
+		controls = append(controls, control)
+		values = append(values, value.Field(i).Elem())
+	}
+	return controls, values
+}
+
+// ControlResults returns the results of the controls of the analysis, in the
+// order of the AnalysisResult fields. Controls without result are left out.
+// It is the single list of controls the compliance, the thresholds and the
+// reports are computed from.
+func (r *AnalysisResult) ControlResults() []ControlResult {
+	controls, values := r.controlResultValues()
+	results := make([]ControlResult, 0, len(controls))
+	for i, control := range controls {
+		issues := values[i].FieldByName("Issues")
+		results = append(results, ControlResult{
+			Control:    control,
+			Key:        ControlResultKey(control),
+			Compliance: values[i].FieldByName("Compliance").Float(),
+			Skipped:    values[i].FieldByName("Skipped").Bool(),
+			Error:      values[i].FieldByName("Error").String(),
+			Issues:     issues.Interface(),
+			IssueCount: issues.Len(),
+		})
+	}
+	return results
+}
+
+// OverallCompliance returns the average compliance of the controls that ran,
+// and their number. It is 0 if no control ran (e.g., data collection
+// failed): nothing could be verified.
+func (r *AnalysisResult) OverallCompliance() (float64, int) {
+	complianceSum := 0.0
+	controlCount := 0
+	for _, controlResult := range r.ControlResults() {
+		if controlResult.Skipped {
+			continue
+		}
+		complianceSum += controlResult.Compliance
+		controlCount++
+	}
+	if controlCount == 0 {
+		return 0, 0
+	}
+	return complianceSum / float64(controlCount), controlCount
+}
+
+// RoundCompliance rounds the compliance of every control result to the
+// precision used for the overall compliance, so that the compliance shown in
+// the outputs is the one compared to the thresholds
+func (r *AnalysisResult) RoundCompliance(precision int) {
+	_, values := r.controlResultValues()
+	for _, value := range values {
+		compliance := value.FieldByName("Compliance")
+		compliance.SetFloat(utils.RoundToPrecision(compliance.Float(), precision))
+	}
+}
+
+// ControlThresholdFailures returns the controls that ran with a compliance
+// below their own threshold, or below the global threshold when they have
+// none, sorted by control name. Compliance is rounded to the precision used
+// for the overall threshold.
+func ControlThresholdFailures(result *AnalysisResult, plumberConfig *configuration.PlumberConfig, globalThreshold float64, precision int) []ControlThresholdFailure {
+	thresholds := plumberConfig.ControlThresholds()
+	failures := []ControlThresholdFailure{}
+	for _, controlResult := range result.ControlResults() {
+		if controlResult.Skipped {
+			continue
+		}
+		threshold, ok := thresholds[controlResult.Control]
+		if !ok {
+			threshold = globalThreshold
+		}
+		compliance := utils.RoundToPrecision(controlResult.Compliance, precision)
+		if compliance < threshold {
+			failures = append(failures, ControlThresholdFailure{
+				Control:    controlResult.Control,
+				Compliance: compliance,
+				Threshold:  threshold,
+			})
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Control < failures[j].Control
+	})
+	return failures
+}
+
+// ThresholdFailure returns the threshold failure of a control, if it failed
+func (r *AnalysisResult) ThresholdFailure(control string) (ControlThresholdFailure, bool) {
+	for _, failure := range r.ThresholdFailures {
+		if failure.Control == control {
+			return failure, true
+		}
+	}
+	return ControlThresholdFailure{}, false
+}
+
+// validateControlThresholds validates the threshold of all controls
+func validateControlThresholds(plumberConfig *configuration.PlumberConfig, v *configValidator) {
+	thresholds := plumberConfig.ControlThresholds()
+	controls := make([]string, 0, len(thresholds))
+	for control := range thresholds {
+		controls = append(controls, control)
+	}
+	sort.Strings(controls)
+
+	for _, control := range controls {
+		if threshold := thresholds[control]; threshold < 0 || threshold > 100 {
+			v.add(control+".threshold", fmt.Sprintf("invalid threshold %g", threshold), "a compliance percentage between 0 and 100")
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package control
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getplumber/plumber/configuration"
+)
+
+// allControlResults returns an analysis result where every control has an
+// empty result
+func allControlResults() *AnalysisResult {
+	result := &AnalysisResult{}
+	value := reflect.ValueOf(result).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if strings.HasSuffix(value.Type().Field(i).Name, "Result") && field.Kind() == reflect.Ptr {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+	}
+	return result
+}
+
+func TestControlResultsCoverAllControls(t *testing.T) {
+	controlResults := allControlResults().ControlResults()
+
+	names := configuration.ControlNames()
+	if len(controlResults) != len(names) {
+		t.Fatalf("ControlResults() returned %d controls, want %d", len(controlResults), len(names))
+	}
+
+	found := map[string]bool{}
+	for _, controlResult := range controlResults {
+		found[controlResult.Control] = true
+		if controlResult.Key != ControlResultKey(controlResult.Control) {
+			t.Errorf("control %s: key = %s, want %s", controlResult.Control, controlResult.Key, ControlResultKey(controlResult.Control))
+		}
+	}
+	for _, name := range names {
+		if !found[name] {
+			t.Errorf("control %s has no result", name)
+		}
+	}
+}
+
+func TestOverallCompliance(t *testing.T) {
+	tests := []struct {
+		name      string
+		result    *AnalysisResult
+		want      float64
+		wantCount int
+	}{
+		{
+			name:   "no control ran",
+			result: &AnalysisResult{},
+		},
+		{
+			name: "skipped controls are left out",
+			result: &AnalysisResult{
+				ImageForbiddenTagsResult:     &GitlabImageForbiddenTagsResult{Compliance: 50},
+				BranchProtectionResult:       &GitlabBranchProtectionResult{Compliance: 100},
+				ImageAuthorizedSourcesResult: &GitlabImageAuthorizedSourcesResult{Compliance: 0, Skipped: true},
+			},
+			want:      75,
+			wantCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compliance, count := tt.result.OverallCompliance()
+			if compliance != tt.want || count != tt.wantCount {
+				t.Errorf("OverallCompliance() = %v, %d, want %v, %d", compliance, count, tt.want, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestRoundCompliance(t *testing.T) {
+	result := allControlResults()
+	result.ImageForbiddenTagsResult.Compliance = 99.95
+	result.HardcodedJobsResult.Compliance = 66.666
+
+	result.RoundCompliance(1)
+
+	if result.ImageForbiddenTagsResult.Compliance != 100 {
+		t.Errorf("ImageForbiddenTagsResult compliance = %v, want 100", result.ImageForbiddenTagsResult.Compliance)
+	}
+	if result.HardcodedJobsResult.Compliance != 66.7 {
+		t.Errorf("HardcodedJobsResult compliance = %v, want 66.7", result.HardcodedJobsResult.Compliance)
+	}
+}
+
+func TestControlThresholdFailures(t *testing.T) {
+	plumberConfig := loadTestPlumberConfig(t, `  branchMustBeProtected:
+    enabled: true
+    threshold: 50
+`)
+	result := &AnalysisResult{
+		ImageForbiddenTagsResult:     &GitlabImageForbiddenTagsResult{Compliance: 70},
+		ImageAuthorizedSourcesResult: &GitlabImageAuthorizedSourcesResult{Compliance: 100},
+		BranchProtectionResult:       &GitlabBranchProtectionResult{Compliance: 60},
+		DependencyPinningResult:      &GitlabPipelineDependencyPinningResult{Skipped: true},
+		HardcodedJobsResult:          &GitlabPipelineHardcodedJobsResult{Compliance: 100},
+	}
+
+	// The average is above the global threshold, but the control without
+	// its own threshold is below it. The branch protection is below the
+	// global threshold too, but above its own one, and the skipped control
+	// is left out.
+	if compliance, _ := result.OverallCompliance(); compliance < 80 {
+		t.Fatalf("OverallCompliance() = %v, want at least 80", compliance)
+	}
+
+	got := ControlThresholdFailures(result, plumberConfig, 80, 1)
+	want := []ControlThresholdFailure{
+		{Control: "containerImageMustNotUseForbiddenTags", Compliance: 70, Threshold: 80},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ControlThresholdFailures() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,37 @@
+package control
+
+import (
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+)
+
+// GitLab instance editions
+const (
+	InstanceEditionEnterprise = "EE"
+	InstanceEditionCommunity  = "CE"
+	InstanceEditionUnknown    = "unknown"
+)
+
+// SkippedReasonNotAvailableOnCE is the reason given for controls (or settings)
+// skipped because they rely on GitLab EE features
+const SkippedReasonNotAvailableOnCE = "not available on GitLab CE"
+
+// detectInstanceEdition returns the edition of the GitLab instance. If it can't
+// be fetched (e.g., metadata endpoint restricted), the edition is unknown and
+// EE features are assumed to be available so that failures are still reported.
+func detectInstanceEdition(conf *configuration.Configuration) string {
+	enterprise, err := gitlab.IsGitlabInstanceEnterprise(conf.GitlabToken, conf.GitlabURL, conf)
+	if err != nil {
+		l.WithError(err).Warn("Unable to detect the GitLab instance edition, assuming EE features are available")
+		return InstanceEditionUnknown
+	}
+	if enterprise {
+		return InstanceEditionEnterprise
+	}
+	return InstanceEditionCommunity
+}
+
+// enterpriseFeaturesAvailable reports whether controls relying on EE features can run
+func enterpriseFeaturesAvailable(edition string) bool {
+	return edition != InstanceEditionCommunity
+}
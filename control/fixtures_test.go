@@ -0,0 +1,122 @@
+package control
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab"
+)
+
+func TestLoadFixturesRoundTrip(t *testing.T) {
+	const (
+		secret = "s3cr3t-value"
+		email  = "alice@example.com"
+	)
+	dir := t.TempDir()
+
+	origin := &collector.GitlabPipelineOriginData{
+		ConfString:      "build:\n  image: app:latest\n  script: make\n",
+		CiValid:         true,
+		JobHardcodedMap: map[string]bool{"build": true},
+	}
+	image := &collector.GitlabPipelineImageData{
+		CiValid:      true,
+		InstanceVars: map[string]string{"INSTANCE_TOKEN": secret},
+		GroupVars:    map[string]string{"GROUP_TOKEN": secret},
+		ProjectVars:  map[string]string{"DEPLOY_TOKEN": secret},
+		Images: []collector.GitlabPipelineImageInfo{
+			{Link: "app:latest", Name: "app", Tag: "latest", Job: "build"},
+		},
+	}
+	protection := &collector.GitlabProtectionAnalysisData{
+		ProjectMembers: []gitlab.GitlabMemberInfo{
+			{ID: 1, Name: "alice", Email: email, AccessLevel: gitlab.AccessLevelMaintainer},
+			{ID: 2, Name: "bob", Email: "bob@example.com", AccessLevel: gitlab.AccessLevelMaintainer},
+		},
+		DirectMembers: []gitlab.GitlabMemberInfo{
+			{ID: 1, Name: "alice", Email: email, AccessLevel: gitlab.AccessLevelMaintainer},
+		},
+	}
+
+	if err := collector.DumpPipelineOriginFixture(dir, origin); err != nil {
+		t.Fatalf("DumpPipelineOriginFixture() error = %v", err)
+	}
+	if err := collector.DumpPipelineImageFixture(dir, image); err != nil {
+		t.Fatalf("DumpPipelineImageFixture() error = %v", err)
+	}
+	if err := collector.DumpProtectionFixture(dir, protection); err != nil {
+		t.Fatalf("DumpProtectionFixture() error = %v", err)
+	}
+
+	// Dumping redacts copies, the collected data is left untouched
+	if image.ProjectVars["DEPLOY_TOKEN"] != secret || protection.ProjectMembers[0].Email != email {
+		t.Error("dumping fixtures changed the collected data")
+	}
+
+	// Secrets never reach the files
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(files) != 3 {
+		t.Fatalf("fixture files = %v (error %v), want 3 files", files, err)
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(content), secret) || strings.Contains(string(content), "@example.com") {
+			t.Errorf("%s holds a secret value or an email", filepath.Base(file))
+		}
+	}
+
+	fixtures, err := collector.LoadFixtures(dir)
+	if err != nil {
+		t.Fatalf("LoadFixtures() error = %v", err)
+	}
+	if fixtures.PipelineOrigin == nil || fixtures.PipelineImage == nil || fixtures.Protection == nil {
+		t.Fatalf("LoadFixtures() = %+v, want all the fixtures", fixtures)
+	}
+
+	// Parsed CI configurations are rebuilt from their YAML content
+	if fixtures.PipelineOrigin.Conf == nil || fixtures.PipelineOrigin.JobHardcodedContent["build"] == nil {
+		t.Errorf("the CI configuration of the origin fixture was not rebuilt")
+	}
+
+	// Redacted values stay redacted once loaded
+	for _, vars := range []map[string]string{fixtures.PipelineImage.InstanceVars, fixtures.PipelineImage.GroupVars, fixtures.PipelineImage.ProjectVars} {
+		for key, value := range vars {
+			if value != "***REDACTED***" {
+				t.Errorf("variable %s = %q, want it redacted", key, value)
+			}
+		}
+	}
+	for _, members := range [][]gitlab.GitlabMemberInfo{fixtures.Protection.ProjectMembers, fixtures.Protection.DirectMembers} {
+		for _, member := range members {
+			if member.Email != "***REDACTED***" {
+				t.Errorf("email of %s = %q, want it redacted", member.Name, member.Email)
+			}
+		}
+	}
+
+	// Controls run on the loaded data as on the collected one
+	forbiddenTags, err := forbiddenTagsConf([]string{"latest"}, TagMatchModeWildcard)
+	if err != nil {
+		t.Fatalf("forbiddenTagsConf() error = %v", err)
+	}
+	tagsResult := forbiddenTags.Run(fixtures.PipelineImage)
+	wantIssues := []GitlabPipelineImageIssueTag{{Link: "app:latest", Tag: "latest", Job: "build"}}
+	if !reflect.DeepEqual(tagsResult.Issues, wantIssues) || tagsResult.Compliance != 0 {
+		t.Errorf("forbidden tags issues = %+v, compliance = %v, want %+v, 0", tagsResult.Issues, tagsResult.Compliance, wantIssues)
+	}
+
+	enabled := true
+	maintainers := NewGitlabMinimumMaintainersControl(&configuration.MinimumMaintainersControlConfig{Enabled: &enabled})
+	maintainersResult := maintainers.Run(fixtures.Protection, &gitlab.ProjectInfo{Path: "group/project"})
+	if !reflect.DeepEqual(maintainersResult.Maintainers, []string{"alice", "bob"}) || maintainersResult.Compliance != 100 {
+		t.Errorf("maintainers = %v, compliance = %v, want [alice bob], 100", maintainersResult.Maintainers, maintainersResult.Compliance)
+	}
+}
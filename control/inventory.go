@@ -0,0 +1,109 @@
+package control
+
+import (
+	"sort"
+
+	"github.com/getplumber/plumber/collector"
+)
+
+// JobInventoryEntry holds the facts collected for a job of the pipeline,
+// written in JSON output with --include-inventory. The default image is
+// listed as a job named <default>, without origin.
+type JobInventoryEntry struct {
+	Name       string             `json:"name"`
+	Origin     JobInventoryOrigin `json:"origin"`
+	Image      *JobInventoryImage `json:"image,omitempty"` // nil if the job has no image
+	Hardcoded  bool               `json:"hardcoded"`
+	Overridden bool               `json:"overridden"`
+}
+
+// JobInventoryOrigin is where a job comes from
+type JobInventoryOrigin struct {
+	Type      string `json:"type"`                // component, project, local, remote, template or hardcoded
+	Location  string `json:"location,omitempty"`  // Include location, empty for hardcoded jobs
+	Component string `json:"component,omitempty"` // Component path without version, for component includes
+	Version   string `json:"version,omitempty"`
+}
+
+// JobInventoryImage is the image a job runs in, after variable resolution
+type JobInventoryImage struct {
+	Link     string `json:"link"`
+	Registry string `json:"registry"`
+	Name     string `json:"name"`
+	Tag      string `json:"tag"`
+	Digest   string `json:"digest,omitempty"`
+}
+
+// buildJobInventory lists every job of the merged configuration with its
+// origin and image. A job extending a job of an include belongs to several
+// origins: the first one found is kept, hardcoded jobs coming last.
+func buildJobInventory(pipelineOriginData *collector.GitlabPipelineOriginData, pipelineImageData *collector.GitlabPipelineImageData) []JobInventoryEntry {
+	origins := map[string]*collector.GitlabPipelineOriginDataFull{}
+	for i := range pipelineOriginData.Origins {
+		origin := &pipelineOriginData.Origins[i]
+		for _, job := range origin.Jobs {
+			if _, ok := origins[job.Name]; !ok {
+				origins[job.Name] = origin
+			}
+		}
+	}
+
+	images := map[string]collector.GitlabPipelineImageInfo{}
+	if pipelineImageData != nil {
+		for _, image := range pipelineImageData.Images {
+			images[image.Job] = image
+		}
+	}
+
+	inventory := make([]JobInventoryEntry, 0, len(pipelineOriginData.JobMap))
+	for name, job := range pipelineOriginData.JobMap {
+		entry := JobInventoryEntry{
+			Name:       name,
+			Hardcoded:  job.IsHardocded,
+			Overridden: job.IsOverridden,
+		}
+
+		if origin, ok := origins[name]; ok {
+			entry.Origin = JobInventoryOrigin{
+				Type:     origin.OriginType,
+				Location: origin.GitlabIncludeOrigin.Location,
+				Version:  origin.Version,
+			}
+			if origin.OriginType == collector.OriginTypeComponent {
+				entry.Origin.Component = origin.GitlabComponent.ComponentIncludePath
+			}
+		}
+
+		if image, ok := images[name]; ok {
+			entry.Image = newJobInventoryImage(image)
+		}
+
+		inventory = append(inventory, entry)
+	}
+
+	// The default image is listed on its own, without origin
+	if image, ok := images[collector.DefaultImageJob]; ok {
+		inventory = append(inventory, JobInventoryEntry{
+			Name:  collector.DefaultImageJob,
+			Image: newJobInventoryImage(image),
+		})
+	}
+
+	sort.Slice(inventory, func(i, j int) bool {
+		return inventory[i].Name < inventory[j].Name
+	})
+
+	return inventory
+}
+
+// newJobInventoryImage returns the inventory entry of an image
+func newJobInventoryImage(image collector.GitlabPipelineImageInfo) *JobInventoryImage {
+	inventoryImage := &JobInventoryImage{
+		Link:     image.Link,
+		Registry: image.Registry,
+		Name:     image.Name,
+		Tag:      image.Tag,
+		Digest:   image.Digest,
+	}
+	return inventoryImage
+}
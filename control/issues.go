@@ -0,0 +1,867 @@
+package control
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ControlIssue is an issue of any control, flattened for reporting
+type ControlIssue struct {
+	// Control is the name of the control in .plumber.yaml (e.g., branchMustBeProtected)
+	Control string `json:"control"`
+
+	// Severity of the issue: high, medium or low, from the control
+	Severity string `json:"severity"`
+
+	// Job is the CI job concerned by the issue, if any
+	Job string `json:"job,omitempty"`
+
+	// Resource is the element concerned by the issue, if any (image, variable, token...)
+	Resource string `json:"resource,omitempty"`
+
+	// Message is a human readable description of the issue
+	Message string `json:"message"`
+
+	// Branch is the branch concerned by the issue, if any
+	Branch string `json:"branch,omitempty"`
+}
+
+// Issue severities
+const (
+	SeverityHigh   = "high"
+	SeverityMedium = "medium"
+	SeverityLow    = "low"
+)
+
+// controlSeverities is the severity of the issues of each control. Controls
+// not listed have a medium severity.
+var controlSeverities = map[string]string{
+	"containerImageMustComeFromAuthorizedSources": SeverityHigh,
+	"branchMustBeProtected":                       SeverityHigh,
+	"triggerAllowlist":                            SeverityHigh,
+	"oidcPreferred":                               SeverityHigh,
+	"secretsManagerRequired":                      SeverityHigh,
+	"pipelineSchedules":                           SeverityHigh,
+	"webhookAllowlist":                            SeverityHigh,
+	"deployTokens":                                SeverityHigh,
+	"mergeAccessGroups":                           SeverityHigh,
+	"noInsecureTransport":                         SeverityHigh,
+	"artifactsMustBePrivate":                      SeverityHigh,
+	"registryPushGating":                          SeverityHigh,
+	"debugTraceForbidden":                         SeverityHigh,
+	"noDirectElevatedMembers":                     SeverityHigh,
+	"mergeTrainApprovals":                         SeverityHigh,
+	"manualJobAccess":                             SeverityHigh,
+	"pushRulesPolicy":                             SeverityHigh,
+	"trustedIncludeProjects":                      SeverityHigh,
+	"protectedEnvironments":                       SeverityHigh,
+	"ruleConditionSafety":                         SeverityHigh,
+	"sameInstanceIncludesOnly":                    SeverityHigh,
+	"imageNameAllowlist":                          SeverityHigh,
+	"pathScopedApprovals":                         SeverityHigh,
+	"packageRegistryAllowlist":                    SeverityHigh,
+	"jobImageOverrideTrust":                       SeverityHigh,
+	"separationOfDuties":                          SeverityHigh,
+	"defaultBranchNoDeletion":                     SeverityHigh,
+	"mergeRequestApproval":                        SeverityHigh,
+	"pipelineComplexityBudget":                    SeverityLow,
+	"localIncludeGlobs":                           SeverityLow,
+	"deadJobs":                                    SeverityLow,
+	"repoStructure":                               SeverityLow,
+	"defaultBranchName":                           SeverityLow,
+	"rulesOverOnlyExcept":                         SeverityLow,
+	"readmeRequired":                              SeverityLow,
+	"securityPolicyFileRequired":                  SeverityLow,
+	"retryPolicy":                                 SeverityLow,
+	"jobTimeoutPolicy":                            SeverityLow,
+	"maxIncludes":                                 SeverityLow,
+	"variableCountBudget":                         SeverityLow,
+}
+
+// ControlSeverity returns the severity of the issues of a control
+func ControlSeverity(control string) string {
+	if severity, ok := controlSeverities[control]; ok {
+		return severity
+	}
+	return SeverityMedium
+}
+
+// ControlIssues returns the issues of all controls that ran, in a flat list.
+// Controls keep their order, and the issues of each control are sorted by job
+// then resource so that results are reproducible between runs.
+func (r *AnalysisResult) ControlIssues() []ControlIssue {
+	issues := r.controlIssues()
+	for i := range issues {
+		issues[i].Severity = ControlSeverity(issues[i].Control)
+	}
+	sortControlIssues(issues)
+	return issues
+}
+
+// sortControlIssues sorts issues by job then resource, without changing the
+// order of the controls
+func sortControlIssues(issues []ControlIssue) {
+	controlOrder := map[string]int{}
+	for _, issue := range issues {
+		if _, exists := controlOrder[issue.Control]; !exists {
+			controlOrder[issue.Control] = len(controlOrder)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Control != issues[j].Control {
+			return controlOrder[issues[i].Control] < controlOrder[issues[j].Control]
+		}
+		if issues[i].Job != issues[j].Job {
+			return issues[i].Job < issues[j].Job
+		}
+		return issues[i].Resource < issues[j].Resource
+	})
+}
+
+// sortResultIssues sorts the issues of a control result by job, then by
+// their content. Issues are often built from maps, whose order changes
+// between runs. Control results share their Issues field, results without
+// it are left as is.
+func sortResultIssues(controlResult interface{}) {
+	value := reflect.ValueOf(controlResult)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return
+	}
+	issues := value.Elem().FieldByName("Issues")
+	if !issues.IsValid() || issues.Kind() != reflect.Slice || issues.Len() < 2 {
+		return
+	}
+
+	sorter := &issueSorter{
+		jobs:     make([]string, issues.Len()),
+		contents: make([]string, issues.Len()),
+		swap:     reflect.Swapper(issues.Interface()),
+	}
+	for i := 0; i < issues.Len(); i++ {
+		issue := reflect.Indirect(issues.Index(i))
+		if issue.Kind() == reflect.Struct {
+			if job := issue.FieldByName("Job"); job.IsValid() && job.Kind() == reflect.String {
+				sorter.jobs[i] = job.String()
+			}
+		}
+		content, _ := json.Marshal(issues.Index(i).Interface())
+		sorter.contents[i] = string(content)
+	}
+	sort.Stable(sorter)
+}
+
+// issueSorter sorts the issues of a control result with their sort keys
+type issueSorter struct {
+	jobs     []string
+	contents []string
+	swap     func(i, j int)
+}
+
+func (s *issueSorter) Len() int {
+	return len(s.jobs)
+}
+
+func (s *issueSorter) Less(i, j int) bool {
+	if s.jobs[i] != s.jobs[j] {
+		return s.jobs[i] < s.jobs[j]
+	}
+	return s.contents[i] < s.contents[j]
+}
+
+func (s *issueSorter) Swap(i, j int) {
+	s.jobs[i], s.jobs[j] = s.jobs[j], s.jobs[i]
+	s.contents[i], s.contents[j] = s.contents[j], s.contents[i]
+	s.swap(i, j)
+}
+
+// controlIssues flattens the issues of all controls that ran
+func (r *AnalysisResult) controlIssues() []ControlIssue {
+	issues := []ControlIssue{}
+
+	if r.ImageForbiddenTagsResult != nil && !r.ImageForbiddenTagsResult.Skipped {
+		for _, issue := range r.ImageForbiddenTagsResult.Issues {
+			message := fmt.Sprintf("Job '%s' uses forbidden tag '%s' (image: %s)", issue.Job, issue.Tag, issue.Link)
+			if issue.Unresolved {
+				message = fmt.Sprintf("Job '%s' uses tag variable '%s' that could not be resolved, the tag cannot be checked (image: %s)", issue.Job, issue.UnresolvedTag, issue.Link)
+			} else if issue.FromVariable {
+				message = fmt.Sprintf("Job '%s' uses forbidden tag '%s' from variable '%s' (image: %s)", issue.Job, issue.Tag, issue.UnresolvedTag, issue.Link)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "containerImageMustNotUseForbiddenTags",
+				Job:      issue.Job,
+				Resource: issue.Link,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.ImageAuthorizedSourcesResult != nil && !r.ImageAuthorizedSourcesResult.Skipped {
+		for _, issue := range r.ImageAuthorizedSourcesResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "containerImageMustComeFromAuthorizedSources",
+				Job:      issue.Job,
+				Resource: issue.Link,
+				Message:  fmt.Sprintf("Job '%s' uses unauthorized image: %s", issue.Job, issue.Link),
+			})
+		}
+	}
+
+	if r.BranchProtectionResult != nil && !r.BranchProtectionResult.Skipped {
+		for _, issue := range r.BranchProtectionResult.Issues {
+			message := fmt.Sprintf("Branch '%s' has non-compliant protection settings", issue.BranchName)
+			if issue.Type == "unprotected" {
+				message = fmt.Sprintf("Branch '%s' is not protected", issue.BranchName)
+			}
+			issues = append(issues, ControlIssue{
+				Control: "branchMustBeProtected",
+				Message: message,
+				Branch:  issue.BranchName,
+			})
+		}
+	}
+
+	if r.DependencyPinningResult != nil && !r.DependencyPinningResult.Skipped {
+		for _, issue := range r.DependencyPinningResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "dependencyPinning",
+				Job:      issue.Job,
+				Resource: issue.Package,
+				Message:  fmt.Sprintf("Job '%s' installs unpinned %s package '%s'", issue.Job, issue.Manager, issue.Package),
+			})
+		}
+	}
+
+	if r.EnvironmentUrlAllowlistResult != nil && !r.EnvironmentUrlAllowlistResult.Skipped {
+		for _, issue := range r.EnvironmentUrlAllowlistResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "environmentUrlAllowlist",
+				Job:      issue.Job,
+				Resource: issue.URL,
+				Message:  fmt.Sprintf("Job '%s' environment '%s' points to unapproved URL: %s", issue.Job, issue.Environment, issue.URL),
+			})
+		}
+	}
+
+	if r.CacheKeyIsolationResult != nil && !r.CacheKeyIsolationResult.Skipped {
+		for _, issue := range r.CacheKeyIsolationResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "cacheKeyIsolation",
+				Job:      issue.Job,
+				Resource: issue.Key,
+				Message:  fmt.Sprintf("Job '%s' uses a cache key shared across branches: %s", issue.Job, issue.Key),
+			})
+		}
+	}
+
+	if r.MinimumMaintainersResult != nil && !r.MinimumMaintainersResult.Skipped {
+		for _, issue := range r.MinimumMaintainersResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "minimumMaintainers",
+				Message: fmt.Sprintf("Project has %d maintainer(s), at least %d required", issue.Maintainers, issue.MinCount),
+			})
+		}
+	}
+
+	if r.TriggerAllowlistResult != nil && !r.TriggerAllowlistResult.Skipped {
+		for _, issue := range r.TriggerAllowlistResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "triggerAllowlist",
+				Job:      issue.Job,
+				Resource: issue.Target,
+				Message:  fmt.Sprintf("Job '%s' triggers a pipeline from an unauthorized %s: %s", issue.Job, issue.TargetType, issue.Target),
+			})
+		}
+	}
+
+	if r.SecurityJobChangeRulesResult != nil && !r.SecurityJobChangeRulesResult.Skipped {
+		for _, issue := range r.SecurityJobChangeRulesResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "securityJobChangeRules",
+				Job:     issue.Job,
+				Message: fmt.Sprintf("Security job '%s' only runs on changes to: %s", issue.Job, strings.Join(issue.Changes, ", ")),
+			})
+		}
+	}
+
+	if r.OidcPreferredResult != nil && !r.OidcPreferredResult.Skipped {
+		for _, issue := range r.OidcPreferredResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "oidcPreferred",
+				Job:     issue.Job,
+				Message: fmt.Sprintf("Deploy job '%s' uses static credentials (%s) without id_tokens", issue.Job, strings.Join(issue.Variables, ", ")),
+			})
+		}
+	}
+
+	if r.SecretsManagerRequiredResult != nil && !r.SecretsManagerRequiredResult.Skipped {
+		for _, issue := range r.SecretsManagerRequiredResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "secretsManagerRequired",
+				Job:      issue.Job,
+				Resource: issue.Variable,
+				Message:  fmt.Sprintf("Job '%s' declares sensitive variable '%s' inline instead of under secrets:", issue.Job, issue.Variable),
+			})
+		}
+	}
+
+	if r.ComponentInputsValidResult != nil && !r.ComponentInputsValidResult.Skipped {
+		for _, issue := range r.ComponentInputsValidResult.Issues {
+			message := fmt.Sprintf("Component '%s' is passed unknown input '%s'", issue.Component, issue.Input)
+			if issue.Problem == componentInputMissingRequired {
+				message = fmt.Sprintf("Component '%s' is missing required input '%s'", issue.Component, issue.Input)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "componentInputsValid",
+				Resource: issue.Component,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.PipelineComplexityBudgetResult != nil && !r.PipelineComplexityBudgetResult.Skipped {
+		for _, issue := range r.PipelineComplexityBudgetResult.Issues {
+			message := fmt.Sprintf("Pipeline has %d lines, exceeding the budget of %d", issue.Lines, issue.Limit)
+			if issue.Type == complexityIssueJob {
+				message = fmt.Sprintf("Job '%s' has %d lines, exceeding the budget of %d", issue.Job, issue.Lines, issue.Limit)
+			}
+			issues = append(issues, ControlIssue{
+				Control: "pipelineComplexityBudget",
+				Job:     issue.Job,
+				Message: message,
+			})
+		}
+	}
+
+	if r.DefaultBranchNameResult != nil && !r.DefaultBranchNameResult.Skipped {
+		for _, issue := range r.DefaultBranchNameResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "defaultBranchName",
+				Branch:  issue.DefaultBranch,
+				Message: fmt.Sprintf("Default branch '%s' is not one of the allowed names: %s", issue.DefaultBranch, strings.Join(issue.AllowedNames, ", ")),
+			})
+		}
+	}
+
+	if r.PipelineSchedulesResult != nil && !r.PipelineSchedulesResult.Skipped {
+		for _, issue := range r.PipelineSchedulesResult.Issues {
+			message := fmt.Sprintf("Schedule '%s' (%s) targets unprotected ref '%s'", issue.Description, issue.Cron, issue.Ref)
+			if issue.Type == scheduleIssueOverprivilegedOwner {
+				message = fmt.Sprintf("Schedule '%s' (%s on %s) runs as '%s' with access level %d", issue.Description, issue.Cron, issue.Ref, issue.Owner, issue.OwnerAccessLevel)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "pipelineSchedules",
+				Resource: issue.Description,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.WebhookAllowlistResult != nil && !r.WebhookAllowlistResult.Skipped {
+		for _, issue := range r.WebhookAllowlistResult.Issues {
+			message := fmt.Sprintf("Webhook #%d sends events to unauthorized host %s", issue.WebhookID, issue.Host)
+			if issue.Type == webhookIssueSSLVerificationOff {
+				message = fmt.Sprintf("Webhook #%d to %s doesn't verify SSL", issue.WebhookID, issue.Host)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "webhookAllowlist",
+				Resource: issue.Host,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.DeployTokensResult != nil && !r.DeployTokensResult.Skipped {
+		for _, issue := range r.DeployTokensResult.Issues {
+			var message string
+			switch issue.Type {
+			case deployTokenIssueNoExpiration:
+				message = fmt.Sprintf("Deploy token '%s' (%s) never expires", issue.Name, strings.Join(issue.Scopes, ", "))
+			case deployTokenIssueExpiresTooLate:
+				message = fmt.Sprintf("Deploy token '%s' (%s) is valid for %d more days", issue.Name, strings.Join(issue.Scopes, ", "), issue.ValidDays)
+			default:
+				message = fmt.Sprintf("Deploy token '%s' has forbidden scope '%s'", issue.Name, issue.Scope)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "deployTokens",
+				Resource: issue.Name,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.ConsistentComponentVersionsResult != nil && !r.ConsistentComponentVersionsResult.Skipped {
+		for _, issue := range r.ConsistentComponentVersionsResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "consistentComponentVersions",
+				Resource: issue.Component,
+				Message:  fmt.Sprintf("Component '%s' is included at several versions: %s", issue.Component, strings.Join(issue.Versions, ", ")),
+			})
+		}
+	}
+
+	if r.RootUserDiscouragedResult != nil && !r.RootUserDiscouragedResult.Skipped {
+		for _, issue := range r.RootUserDiscouragedResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "rootUserDiscouraged",
+				Job:      issue.Job,
+				Resource: issue.Link,
+				Message:  fmt.Sprintf("Job '%s' likely runs as root (image: %s)", issue.Job, issue.Link),
+			})
+		}
+	}
+
+	if r.RulesOverOnlyExceptResult != nil && !r.RulesOverOnlyExceptResult.Skipped {
+		for _, issue := range r.RulesOverOnlyExceptResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "rulesOverOnlyExcept",
+				Job:     issue.Job,
+				Message: fmt.Sprintf("Job '%s' uses deprecated %s instead of rules", issue.Job, strings.Join(issue.Keywords, "/")),
+			})
+		}
+	}
+
+	if r.ReadmeRequiredResult != nil && !r.ReadmeRequiredResult.Skipped {
+		for _, issue := range r.ReadmeRequiredResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "readmeRequired",
+				Branch:  issue.Ref,
+				Message: fmt.Sprintf("No README found on branch '%s' (checked: %s)", issue.Ref, strings.Join(issue.CheckedPaths, ", ")),
+			})
+		}
+	}
+
+	if r.MergeAccessGroupsResult != nil && !r.MergeAccessGroupsResult.Skipped {
+		for _, issue := range r.MergeAccessGroupsResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "mergeAccessGroups",
+				Branch:  issue.Branch,
+				Message: fmt.Sprintf("Merges to protected branch '%s' are not restricted to approved groups (allowed to merge: %s)", issue.Branch, strings.Join(issue.MergeAccess, ", ")),
+			})
+		}
+	}
+
+	if r.VariableExpansionPolicyResult != nil && !r.VariableExpansionPolicyResult.Skipped {
+		for _, issue := range r.VariableExpansionPolicyResult.Issues {
+			scope := "Global variable"
+			if issue.Job != "" {
+				scope = fmt.Sprintf("Variable of job '%s'", issue.Job)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "variableExpansionPolicy",
+				Job:      issue.Job,
+				Resource: issue.Variable,
+				Message:  fmt.Sprintf("%s '%s' has expand: %t, expected expand: %t", scope, issue.Variable, issue.Expand, issue.ExpectedExpand),
+			})
+		}
+	}
+
+	if r.NoInsecureTransportResult != nil && !r.NoInsecureTransportResult.Skipped {
+		for _, issue := range r.NoInsecureTransportResult.Issues {
+			message := fmt.Sprintf("Global variables disable TLS verification (%s)", issue.Token)
+			if issue.Job != "" {
+				message = fmt.Sprintf("Job '%s' disables TLS verification in its %s (%s)", issue.Job, issue.Source, issue.Token)
+			}
+			issues = append(issues, ControlIssue{
+				Control: "noInsecureTransport",
+				Job:     issue.Job,
+				Message: message,
+			})
+		}
+	}
+
+	if r.ArtifactsMustBePrivateResult != nil && !r.ArtifactsMustBePrivateResult.Skipped {
+		for _, issue := range r.ArtifactsMustBePrivateResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "artifactsMustBePrivate",
+				Job:     issue.Job,
+				Message: fmt.Sprintf("Job '%s' has public artifacts (set artifacts:public: false or artifacts:access)", issue.Job),
+			})
+		}
+	}
+
+	if r.RetryPolicyResult != nil && !r.RetryPolicyResult.Skipped {
+		for _, issue := range r.RetryPolicyResult.Issues {
+			message := fmt.Sprintf("Job '%s' retries %d times, above the maximum of %d", issue.Job, issue.Retries, r.RetryPolicyResult.Metrics.MaxRetries)
+			if issue.FromDefault {
+				message = fmt.Sprintf("Job '%s' retries %d times from default:retry, above the maximum of %d", issue.Job, issue.Retries, r.RetryPolicyResult.Metrics.MaxRetries)
+			}
+			issues = append(issues, ControlIssue{
+				Control: "retryPolicy",
+				Job:     issue.Job,
+				Message: message,
+			})
+		}
+	}
+
+	if r.RegistryPushGatingResult != nil && !r.RegistryPushGatingResult.Skipped {
+		for _, issue := range r.RegistryPushGatingResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "registryPushGating",
+				Job:     issue.Job,
+				Message: fmt.Sprintf("Job '%s' pushes images (%s) and can run on %s: %s", issue.Job, issue.Command, issue.RunsOn, issue.Reason),
+			})
+		}
+	}
+
+	if r.JobTimeoutPolicyResult != nil && !r.JobTimeoutPolicyResult.Skipped {
+		for _, issue := range r.JobTimeoutPolicyResult.Issues {
+			var message string
+			switch issue.Type {
+			case jobTimeoutIssueTooLong:
+				message = fmt.Sprintf("Job '%s' has a timeout of %d minutes ('%s'), above the maximum of %d", issue.Job, issue.TimeoutMinutes, issue.Timeout, r.JobTimeoutPolicyResult.Metrics.MaxTimeoutMinutes)
+			case jobTimeoutIssueInvalid:
+				message = fmt.Sprintf("Job '%s' has an invalid timeout '%s'", issue.Job, issue.Timeout)
+			default:
+				message = fmt.Sprintf("Job '%s' has no timeout", issue.Job)
+			}
+			issues = append(issues, ControlIssue{
+				Control: "jobTimeoutPolicy",
+				Job:     issue.Job,
+				Message: message,
+			})
+		}
+	}
+
+	if r.DebugTraceForbiddenResult != nil && !r.DebugTraceForbiddenResult.Skipped {
+		for _, issue := range r.DebugTraceForbiddenResult.Issues {
+			message := fmt.Sprintf("%s is enabled in %s variables, it prints all variables in job logs", issue.Variable, issue.Scope)
+			if issue.Job != "" {
+				message = fmt.Sprintf("Job '%s' enables %s, it prints all variables in job logs", issue.Job, issue.Variable)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "debugTraceForbidden",
+				Job:      issue.Job,
+				Resource: issue.Variable,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.MaxIncludesResult != nil && !r.MaxIncludesResult.Skipped {
+		for _, issue := range r.MaxIncludesResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "maxIncludes",
+				Message: fmt.Sprintf("Pipeline has %d includes, above the maximum of %d", issue.Count, issue.MaxCount),
+			})
+		}
+	}
+
+	if r.NoDirectElevatedMembersResult != nil && !r.NoDirectElevatedMembersResult.Skipped {
+		for _, issue := range r.NoDirectElevatedMembersResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "noDirectElevatedMembers",
+				Resource: issue.Username,
+				Message:  fmt.Sprintf("Member '%s' is added directly to the project with access level %d (maximum for direct members: %d)", issue.Username, issue.AccessLevel, issue.MaxDirectAccessLevel),
+			})
+		}
+	}
+
+	if r.MergeTrainApprovalsResult != nil && !r.MergeTrainApprovalsResult.Skipped {
+		for _, issue := range r.MergeTrainApprovalsResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "mergeTrainApprovals",
+				Message: fmt.Sprintf("Merge trains are enabled with %d required approval(s), at least %d required", issue.ApprovalsRequired, issue.MinApprovals),
+			})
+		}
+	}
+
+	if r.ComponentsMustBeReleasedResult != nil && !r.ComponentsMustBeReleasedResult.Skipped {
+		for _, issue := range r.ComponentsMustBeReleasedResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "componentsMustBeReleased",
+				Resource: issue.Component,
+				Message:  fmt.Sprintf("Component '%s' comes from catalog resource '%s', which has no released version", issue.Component, issue.CatalogResource),
+			})
+		}
+	}
+
+	if r.VariableCountBudgetResult != nil && !r.VariableCountBudgetResult.Skipped {
+		for _, issue := range r.VariableCountBudgetResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "variableCountBudget",
+				Message: fmt.Sprintf("Project has %d CI/CD variables, above the maximum of %d", issue.Count, issue.MaxCount),
+			})
+		}
+	}
+
+	if r.ManualJobAccessResult != nil && !r.ManualJobAccessResult.Skipped {
+		for _, issue := range r.ManualJobAccessResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "manualJobAccess",
+				Job:      issue.Job,
+				Resource: issue.Environment,
+				Message:  fmt.Sprintf("Manual job '%s' deploys to protected environment '%s' without access restriction", issue.Job, issue.Environment),
+			})
+		}
+	}
+
+	if r.PushRulesPolicyResult != nil && !r.PushRulesPolicyResult.Skipped {
+		for _, issue := range r.PushRulesPolicyResult.Issues {
+			message := fmt.Sprintf("Push rule '%s' is not set, '%s' required", issue.Rule, issue.Required)
+			if issue.Type == pushRuleIssueDifferent {
+				message = fmt.Sprintf("Push rule '%s' is '%s', '%s' required", issue.Rule, issue.Current, issue.Required)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "pushRulesPolicy",
+				Resource: issue.Rule,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.SecurityPolicyFileRequiredResult != nil && !r.SecurityPolicyFileRequiredResult.Skipped {
+		for _, issue := range r.SecurityPolicyFileRequiredResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "securityPolicyFileRequired",
+				Branch:  issue.Ref,
+				Message: fmt.Sprintf("No security policy file found on branch '%s' (checked: %s)", issue.Ref, strings.Join(issue.CheckedPaths, ", ")),
+			})
+		}
+	}
+
+	if r.RunnerFeatureFlagsResult != nil && !r.RunnerFeatureFlagsResult.Skipped {
+		for _, issue := range r.RunnerFeatureFlagsResult.Issues {
+			message := fmt.Sprintf("Runner feature flag %s is set in %s variables", issue.Flag, issue.Scope)
+			if issue.Job != "" {
+				message = fmt.Sprintf("Job '%s' sets runner feature flag %s", issue.Job, issue.Flag)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "runnerFeatureFlags",
+				Job:      issue.Job,
+				Resource: issue.Flag,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.TrustedIncludeProjectsResult != nil && !r.TrustedIncludeProjectsResult.Skipped {
+		for _, issue := range r.TrustedIncludeProjectsResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "trustedIncludeProjects",
+				Resource: issue.Project,
+				Message:  fmt.Sprintf("File '%s' is included from project '%s', which is not allowed", issue.Location, issue.Project),
+			})
+		}
+	}
+
+	if r.ProtectedEnvironmentsResult != nil && !r.ProtectedEnvironmentsResult.Skipped {
+		for _, issue := range r.ProtectedEnvironmentsResult.Issues {
+			message := fmt.Sprintf("Environment '%s' is not protected", issue.Environment)
+			if issue.Type == protectedEnvironmentIssuePermissive {
+				message = fmt.Sprintf("Environment '%s' can be deployed to by %s, %s required", issue.Environment, issue.DeployAccessLevelText, issue.MinDeployAccessLevelText)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "protectedEnvironments",
+				Resource: issue.Environment,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.DeprecatedJwtUsageResult != nil && !r.DeprecatedJwtUsageResult.Skipped {
+		for _, issue := range r.DeprecatedJwtUsageResult.Issues {
+			message := fmt.Sprintf("Global variables reference the deprecated %s, use id_tokens instead", issue.Variable)
+			if issue.Job != "" {
+				message = fmt.Sprintf("Job '%s' references the deprecated %s in its %s, use id_tokens instead", issue.Job, issue.Variable, issue.Source)
+			}
+			issues = append(issues, ControlIssue{
+				Control: "deprecatedJwtUsage",
+				Job:     issue.Job,
+				Message: message,
+			})
+		}
+	}
+
+	if r.LocalIncludeGlobsResult != nil && !r.LocalIncludeGlobsResult.Skipped {
+		for _, issue := range r.LocalIncludeGlobsResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "localIncludeGlobs",
+				Resource: issue.Pattern,
+				Message:  fmt.Sprintf("Local include uses the glob pattern '%s' instead of explicit paths", issue.Pattern),
+			})
+		}
+	}
+
+	if r.DeadJobsResult != nil && !r.DeadJobsResult.Skipped {
+		for _, issue := range r.DeadJobsResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "deadJobs",
+				Job:     issue.Job,
+				Message: fmt.Sprintf("Job '%s' can never run: %s", issue.Job, issue.Reason),
+			})
+		}
+	}
+
+	if r.RepoStructureResult != nil && !r.RepoStructureResult.Skipped {
+		for _, issue := range r.RepoStructureResult.Issues {
+			kind := "file"
+			if issue.Type == repoStructureIssueMissingFolder {
+				kind = "folder"
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "repoStructure",
+				Branch:   issue.Ref,
+				Resource: issue.Path,
+				Message:  fmt.Sprintf("Required %s '%s' is missing on branch '%s'", kind, issue.Path, issue.Ref),
+			})
+		}
+	}
+
+	if r.TagMustNotBeBranchNameResult != nil && !r.TagMustNotBeBranchNameResult.Skipped {
+		for _, issue := range r.TagMustNotBeBranchNameResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "tagMustNotBeBranchName",
+				Job:      issue.Job,
+				Resource: issue.Link,
+				Message:  fmt.Sprintf("Job '%s' uses image tag '%s', the name of branch '%s' (image: %s)", issue.Job, issue.Tag, issue.Branch, issue.Link),
+			})
+		}
+	}
+
+	if r.RuleConditionSafetyResult != nil && !r.RuleConditionSafetyResult.Skipped {
+		for _, issue := range r.RuleConditionSafetyResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "ruleConditionSafety",
+				Job:     issue.Job,
+				Message: fmt.Sprintf("Job '%s' is gated on %s, controlled by the commit author (if: %s)", issue.Job, strings.Join(issue.Variables, ", "), issue.Condition),
+			})
+		}
+	}
+
+	if r.SameInstanceIncludesOnlyResult != nil && !r.SameInstanceIncludesOnlyResult.Skipped {
+		for _, issue := range r.SameInstanceIncludesOnlyResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "sameInstanceIncludesOnly",
+				Resource: issue.Location,
+				Message:  fmt.Sprintf("Include '%s' (%s) is fetched from another GitLab instance: %s", issue.Location, issue.Type, issue.Instance),
+			})
+		}
+	}
+
+	if r.ImageNameAllowlistResult != nil && !r.ImageNameAllowlistResult.Skipped {
+		for _, issue := range r.ImageNameAllowlistResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "imageNameAllowlist",
+				Job:      issue.Job,
+				Resource: issue.Link,
+				Message:  fmt.Sprintf("Job '%s' uses image '%s', which is not in the allowlist (image: %s)", issue.Job, issue.Image, issue.Link),
+			})
+		}
+	}
+
+	if r.PathScopedApprovalsResult != nil && !r.PathScopedApprovalsResult.Skipped {
+		for _, issue := range r.PathScopedApprovalsResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "pathScopedApprovals",
+				Resource: issue.Path,
+				Message:  fmt.Sprintf("Changes to '%s' require %d approval(s), below the minimum of %d", issue.Path, issue.Approvals, issue.MinApprovals),
+			})
+		}
+	}
+
+	if r.NoPublicCatalogComponentsResult != nil && !r.NoPublicCatalogComponentsResult.Skipped {
+		for _, issue := range r.NoPublicCatalogComponentsResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "noPublicCatalogComponents",
+				Resource: issue.Component,
+				Message:  fmt.Sprintf("Component '%s' is fetched from %s instead of the self-hosted instance", issue.Component, issue.Instance),
+			})
+		}
+	}
+
+	if r.PackageRegistryAllowlistResult != nil && !r.PackageRegistryAllowlistResult.Skipped {
+		for _, issue := range r.PackageRegistryAllowlistResult.Issues {
+			message := fmt.Sprintf("%s in %s variables points to %s, which is not an allowed registry host", issue.Variable, issue.Scope, issue.Host)
+			if issue.Job != "" {
+				message = fmt.Sprintf("Job '%s' sets %s to %s, which is not an allowed registry host", issue.Job, issue.Variable, issue.Host)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "packageRegistryAllowlist",
+				Job:      issue.Job,
+				Resource: issue.Variable,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.JobImageOverrideTrustResult != nil && !r.JobImageOverrideTrustResult.Skipped {
+		for _, issue := range r.JobImageOverrideTrustResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control:  "jobImageOverrideTrust",
+				Job:      issue.Job,
+				Resource: issue.Link,
+				Message:  fmt.Sprintf("Job '%s' overrides the trusted default image %s with untrusted image %s", issue.Job, issue.DefaultImage, issue.Link),
+			})
+		}
+	}
+
+	if r.SeparationOfDutiesResult != nil && !r.SeparationOfDutiesResult.Skipped {
+		for _, issue := range r.SeparationOfDutiesResult.Issues {
+			message := ""
+			switch issue.Requirement {
+			case separationOfDutiesAuthorApproval:
+				message = "Authors can approve their own merge requests"
+			case separationOfDutiesCommittersApproval:
+				message = "Committers can approve merge requests they contributed to"
+			case separationOfDutiesMinApprovals:
+				message = fmt.Sprintf("Merging into the default branch requires %d approval(s), at least 1 is required", issue.ApprovalsRequired)
+			case separationOfDutiesDirectPush:
+				message = fmt.Sprintf("The default branch can be pushed to without a merge request by: %s", strings.Join(issue.PushAccess, ", "))
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "separationOfDuties",
+				Resource: issue.Requirement,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.DefaultBranchNoDeletionResult != nil && !r.DefaultBranchNoDeletionResult.Skipped {
+		for _, issue := range r.DefaultBranchNoDeletionResult.Issues {
+			message := fmt.Sprintf("Default branch '%s' is not protected and can be deleted", issue.Branch)
+			if issue.Reason == branchDeletionUnprotectAccess {
+				message = fmt.Sprintf("Default branch '%s' can be unprotected and deleted by %s (protection '%s')", issue.Branch, strings.Join(issue.UnprotectAccess, ", "), issue.Pattern)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "defaultBranchNoDeletion",
+				Resource: issue.Branch,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.MergeRequestApprovalResult != nil && !r.MergeRequestApprovalResult.Skipped {
+		for _, issue := range r.MergeRequestApprovalResult.Issues {
+			var message string
+			switch issue.Requirement {
+			case mrApprovalMinApprovers:
+				message = fmt.Sprintf("Merging into the default branch requires %d approval(s), at least %d required", issue.ApprovalsRequired, issue.MinApprovers)
+			case mrApprovalPreventAuthor:
+				message = "Authors can approve their own merge requests"
+			case mrApprovalResetApprovalsOnPush:
+				message = fmt.Sprintf("Approvals are not reset when a commit is added (%s)", issue.BehaviorWhenCommitIsAdded)
+			}
+			issues = append(issues, ControlIssue{
+				Control:  "mergeRequestApproval",
+				Resource: issue.Requirement,
+				Message:  message,
+			})
+		}
+	}
+
+	if r.HardcodedJobsResult != nil && !r.HardcodedJobsResult.Skipped {
+		for _, issue := range r.HardcodedJobsResult.Issues {
+			issues = append(issues, ControlIssue{
+				Control: "hardcodedJobs",
+				Job:     issue.Job,
+				Message: fmt.Sprintf("Job '%s' is hardcoded in the project CI configuration (%d hardcoded jobs, maximum %d)", issue.Job, issue.Hardcoded, issue.MaxAllowed),
+			})
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,83 @@
+package control
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/getplumber/plumber/collector"
+)
+
+func TestSortResultIssues(t *testing.T) {
+	result := &GitlabImageForbiddenTagsResult{
+		Issues: []GitlabPipelineImageIssueTag{
+			{Job: "test", Link: "node:latest", Tag: "latest"},
+			{Job: "build", Link: "golang:latest", Tag: "latest"},
+			{Job: "test", Link: "alpine:dev", Tag: "dev"},
+		},
+	}
+
+	sortResultIssues(result)
+
+	expected := []GitlabPipelineImageIssueTag{
+		{Job: "build", Link: "golang:latest", Tag: "latest"},
+		{Job: "test", Link: "alpine:dev", Tag: "dev"},
+		{Job: "test", Link: "node:latest", Tag: "latest"},
+	}
+	if !reflect.DeepEqual(result.Issues, expected) {
+		t.Errorf("issues = %+v, want %+v", result.Issues, expected)
+	}
+
+	// Results without issues are left as is
+	sortResultIssues(&ControlError{Control: "test"})
+	sortResultIssues((*GitlabImageForbiddenTagsResult)(nil))
+}
+
+// TestAnalysisResultDeterministicJSON checks that the same pipeline gives
+// the same JSON, whatever the order images were collected in (built from
+// the jobs map of the CI configuration)
+func TestAnalysisResultDeterministicJSON(t *testing.T) {
+	images := []collector.GitlabPipelineImageInfo{
+		{Job: "build", Link: "golang:latest", Name: "golang", Tag: "latest"},
+		{Job: "lint", Link: "docker.io/golangci/golangci-lint:dev", Name: "golangci/golangci-lint", Tag: "dev", Registry: "docker.io"},
+		{Job: "test", Link: "node:latest", Name: "node", Tag: "latest"},
+		{Job: "test", Link: "quay.io/org/tool:latest", Name: "org/tool", Tag: "latest", Registry: "quay.io"},
+		{Job: "deploy", Link: "registry.example.com/deploy:1.0", Name: "deploy", Tag: "1.0", Registry: "registry.example.com"},
+		{Job: "release", Link: "ghcr.io/org/release:dev", Name: "org/release", Tag: "dev", Registry: "ghcr.io"},
+	}
+	forbiddenTagsConf := &GitlabImageForbiddenTagsConf{Enabled: true, ForbiddenTags: []string{"latest", "dev"}, MatchMode: "wildcard"}
+	authorizedSourcesConf := &GitlabImageAuthorizedSourcesConf{Enabled: true, TrustedUrls: []string{"registry.example.com/*"}}
+
+	random := rand.New(rand.NewSource(1))
+	var first []byte
+	for run := 0; run < 10; run++ {
+		shuffled := append([]collector.GitlabPipelineImageInfo{}, images...)
+		random.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		imageData := &collector.GitlabPipelineImageData{CiValid: true, Images: shuffled}
+
+		result := &AnalysisResult{ProjectPath: "group/project", CiValid: true}
+		result.ImageForbiddenTagsResult = runControl(result, "containerImageMustNotUseForbiddenTags", func() *GitlabImageForbiddenTagsResult {
+			return forbiddenTagsConf.Run(imageData)
+		})
+		result.ImageAuthorizedSourcesResult = runControl(result, "containerImageMustComeFromAuthorizedSources", func() *GitlabImageAuthorizedSourcesResult {
+			return authorizedSourcesConf.Run(imageData)
+		})
+
+		output, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			t.Fatalf("unable to encode result: %v", err)
+		}
+		if run == 0 {
+			first = output
+			if len(result.ImageForbiddenTagsResult.Issues) == 0 || len(result.ImageAuthorizedSourcesResult.Issues) == 0 {
+				t.Fatalf("expected issues in both controls, got %s", output)
+			}
+			continue
+		}
+		if !bytes.Equal(output, first) {
+			t.Fatalf("run %d output differs from the first run:\n%s\nfirst:\n%s", run, output, first)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package control
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultSimulatedPipelineSource is the pipeline source used when only a ref is simulated
+const DefaultSimulatedPipelineSource = "push"
+
+// simulatedTagPrefix marks a simulated ref as a tag (e.g., refs/tags/v1.0.0)
+const simulatedTagPrefix = "refs/tags/"
+
+// RulesSimulationResult holds which jobs would run for a simulated ref and pipeline source
+type RulesSimulationResult struct {
+	Ref            string             `json:"ref"`
+	Source         string             `json:"source"`
+	WorkflowRuns   bool               `json:"workflowRuns"`
+	WorkflowReason string             `json:"workflowReason,omitempty"`
+	IncludedJobs   []string           `json:"includedJobs"`
+	ExcludedJobs   []RulesExcludedJob `json:"excludedJobs"`
+
+	// WorkflowError is set when the workflow rules can't be evaluated, the
+	// pipeline is then considered to run
+	WorkflowError string `json:"workflowError,omitempty"`
+
+	// UnevaluableJobs are the jobs whose rules can't be evaluated (e.g., an
+	// unsupported expression), they are kept as if they would run
+	UnevaluableJobs []RulesUnevaluableJob `json:"unevaluableJobs,omitempty"`
+}
+
+// RulesExcludedJob is a job that would not run for the simulated ref and pipeline source
+type RulesExcludedJob struct {
+	Job    string `json:"job"`
+	Reason string `json:"reason"`
+}
+
+// RulesUnevaluableJob is a job whose rules can't be evaluated
+type RulesUnevaluableJob struct {
+	Job   string `json:"job"`
+	Error string `json:"error"`
+}
+
+// simulatedPipelineVariables builds the variables available to rules for a
+// simulated ref and pipeline source. CI/CD and global variables are included
+// so that rules depending on them are evaluated as GitLab would.
+func simulatedPipelineVariables(ref, source string, project *gitlab.ProjectInfo, pipelineImageData *collector.GitlabPipelineImageData) map[string]string {
+	vars := map[string]string{}
+
+	for _, scope := range []map[string]string{
+		pipelineImageData.GlobalVars,
+		pipelineImageData.InstanceVars,
+		pipelineImageData.GroupVars,
+		pipelineImageData.ProjectVars,
+	} {
+		for key, value := range scope {
+			vars[key] = value
+		}
+	}
+
+	vars["CI_PIPELINE_SOURCE"] = source
+	vars["CI_DEFAULT_BRANCH"] = project.DefaultBranch
+	vars["CI_PROJECT_PATH"] = project.Path
+
+	if strings.HasPrefix(ref, simulatedTagPrefix) {
+		tag := strings.TrimPrefix(ref, simulatedTagPrefix)
+		vars["CI_COMMIT_REF_NAME"] = tag
+		vars["CI_COMMIT_TAG"] = tag
+	} else if source == "merge_request_event" {
+		vars["CI_COMMIT_REF_NAME"] = ref
+		vars["CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"] = ref
+		vars["CI_MERGE_REQUEST_TARGET_BRANCH_NAME"] = project.DefaultBranch
+		vars["CI_MERGE_REQUEST_ID"] = "1"
+		vars["CI_MERGE_REQUEST_IID"] = "1"
+	} else {
+		vars["CI_COMMIT_REF_NAME"] = ref
+		vars["CI_COMMIT_BRANCH"] = ref
+	}
+
+	return vars
+}
+
+// SimulatePipelineRules evaluates workflow and job rules of the merged CI
+// configuration for a simulated ref and pipeline source, and returns the jobs
+// that would run and the ones excluded by rules. Rules that can't be
+// evaluated don't fail the simulation: the workflow, or the job, is recorded
+// as unevaluable and considered to run.
+func SimulatePipelineRules(ref, source string, project *gitlab.ProjectInfo, pipelineImageData *collector.GitlabPipelineImageData) (*RulesSimulationResult, error) {
+	l := l.WithFields(logrus.Fields{
+		"action": "SimulatePipelineRules",
+		"ref":    ref,
+		"source": source,
+	})
+	l.Info("Simulating pipeline rules")
+
+	result := &RulesSimulationResult{
+		Ref:          ref,
+		Source:       source,
+		WorkflowRuns: true,
+		IncludedJobs: []string{},
+		ExcludedJobs: []RulesExcludedJob{},
+	}
+
+	vars := simulatedPipelineVariables(ref, source, project, pipelineImageData)
+
+	jobs, err := parsePipelineJobs(pipelineImageData.MergedConf)
+	if err != nil {
+		return result, fmt.Errorf("unable to parse jobs: %w", err)
+	}
+
+	// Evaluate workflow:rules first, they can prevent the whole pipeline from running
+	workflowEvaluation, err := evaluateWorkflowRules(pipelineImageData.MergedConf, vars)
+	if err != nil {
+		l.WithError(err).Warn("Unable to evaluate workflow rules, considering that the pipeline runs")
+		result.WorkflowError = err.Error()
+	} else {
+		result.WorkflowRuns = workflowEvaluation.Run
+		result.WorkflowReason = workflowEvaluation.Reason
+	}
+
+	if !result.WorkflowRuns {
+		for _, job := range jobs {
+			result.ExcludedJobs = append(result.ExcludedJobs, RulesExcludedJob{
+				Job:    job.Name,
+				Reason: fmt.Sprintf("workflow rules: %s", result.WorkflowReason),
+			})
+		}
+		l.WithField("reason", result.WorkflowReason).Info("Pipeline would not run for the simulated ref")
+		return result, nil
+	}
+
+	// Evaluate each job's rules (or only/except) with the job variables
+	for _, job := range jobs {
+		evaluation, err := evaluateJobRules(job, vars)
+		if err != nil {
+			l.WithError(err).WithField("job", job.Name).Warn("Unable to evaluate job rules, considering that the job runs")
+			result.UnevaluableJobs = append(result.UnevaluableJobs, RulesUnevaluableJob{
+				Job:   job.Name,
+				Error: err.Error(),
+			})
+			continue
+		}
+
+		if evaluation.Run {
+			result.IncludedJobs = append(result.IncludedJobs, job.Name)
+		} else {
+			result.ExcludedJobs = append(result.ExcludedJobs, RulesExcludedJob{
+				Job:    job.Name,
+				Reason: evaluation.Reason,
+			})
+		}
+	}
+
+	l.WithFields(logrus.Fields{
+		"includedJobs":    len(result.IncludedJobs),
+		"excludedJobs":    len(result.ExcludedJobs),
+		"unevaluableJobs": len(result.UnevaluableJobs),
+	}).Info("Pipeline rules simulation completed")
+
+	return result, nil
+}
+
+// evaluateWorkflowRules evaluates the workflow:rules of a CI configuration.
+// The pipeline runs if there are no workflow rules. The variables defined by
+// the matching rule are added to vars.
+func evaluateWorkflowRules(conf *gitlab.GitlabCIConf, vars map[string]string) (gitlab.RulesEvaluation, error) {
+	workflow, ok := conf.Workflow.(map[interface{}]interface{})
+	if !ok {
+		return gitlab.RulesEvaluation{Run: true}, nil
+	}
+	rules, ok := workflow["rules"]
+	if !ok || rules == nil {
+		return gitlab.RulesEvaluation{Run: true}, nil
+	}
+
+	evaluation, err := gitlab.EvaluateRules(rules, vars)
+	if err != nil {
+		return evaluation, fmt.Errorf("unable to evaluate workflow rules: %w", err)
+	}
+	for key, value := range evaluation.Variables {
+		vars[key] = value
+	}
+	return evaluation, nil
+}
+
+// evaluateJobRules evaluates the rules (or only/except) of a job with the
+// pipeline variables and the job variables
+func evaluateJobRules(job pipelineJob, vars map[string]string) (gitlab.RulesEvaluation, error) {
+	jobVars := map[string]string{}
+	for key, value := range vars {
+		jobVars[key] = value
+	}
+	if variables, err := gitlab.ParseJobVariables(job.Job); err == nil {
+		for key, value := range variables {
+			jobVars[key] = value
+		}
+	}
+
+	var evaluation gitlab.RulesEvaluation
+	var err error
+	if job.Job.Rules != nil {
+		evaluation, err = gitlab.EvaluateRules(job.Job.Rules, jobVars)
+	} else {
+		evaluation, err = gitlab.EvaluateOnlyExcept(job.Job.Only, job.Job.Except, jobVars)
+	}
+	if err != nil {
+		return evaluation, fmt.Errorf("unable to evaluate rules of job '%s': %w", job.Name, err)
+	}
+	return evaluation, nil
+}
+
+// filterImagesForSimulation keeps only the images of jobs that would run
+func filterImagesForSimulation(pipelineImageData *collector.GitlabPipelineImageData, simulation *RulesSimulationResult) {
+	excluded := map[string]bool{}
+	for _, job := range simulation.ExcludedJobs {
+		excluded[job.Job] = true
+	}
+
+	images := []collector.GitlabPipelineImageInfo{}
+	for _, image := range pipelineImageData.Images {
+		if !excluded[image.Job] {
+			images = append(images, image)
+		}
+	}
+	pipelineImageData.Images = images
+}
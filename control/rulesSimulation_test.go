@@ -0,0 +1,93 @@
+package control
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getplumber/plumber/collector"
+	"github.com/getplumber/plumber/gitlab"
+)
+
+func TestSimulatePipelineRulesUnevaluableJob(t *testing.T) {
+	conf, err := gitlab.ParseGitlabCI([]byte(`
+build:
+  image: golang:1.25
+  script: go build
+  rules:
+    - if: $CI_COMMIT_BRANCH == "main"
+release:
+  image: alpine:3.20
+  script: echo release
+  rules:
+    - if: $CI_COMMIT_TAG
+broken:
+  image: node:22
+  script: npm test
+  rules:
+    - if: $CI_COMMIT_BRANCH == "unterminated
+`))
+	if err != nil {
+		t.Fatalf("unable to parse CI configuration: %v", err)
+	}
+	imageData := &collector.GitlabPipelineImageData{
+		MergedConf: conf,
+		Images: []collector.GitlabPipelineImageInfo{
+			{Job: "broken", Link: "node:22"},
+			{Job: "build", Link: "golang:1.25"},
+			{Job: "release", Link: "alpine:3.20"},
+		},
+	}
+	project := &gitlab.ProjectInfo{DefaultBranch: "main", Path: "group/project"}
+
+	simulation, err := SimulatePipelineRules("main", DefaultSimulatedPipelineSource, project, imageData)
+	if err != nil {
+		t.Fatalf("an unevaluable job must not fail the simulation: %v", err)
+	}
+
+	if !reflect.DeepEqual(simulation.IncludedJobs, []string{"build"}) {
+		t.Errorf("included jobs = %v, want [build]", simulation.IncludedJobs)
+	}
+	if len(simulation.ExcludedJobs) != 1 || simulation.ExcludedJobs[0].Job != "release" {
+		t.Errorf("excluded jobs = %+v, want release", simulation.ExcludedJobs)
+	}
+	if len(simulation.UnevaluableJobs) != 1 || simulation.UnevaluableJobs[0].Job != "broken" || simulation.UnevaluableJobs[0].Error == "" {
+		t.Errorf("unevaluable jobs = %+v, want broken with its error", simulation.UnevaluableJobs)
+	}
+
+	// The unevaluable job keeps its image, only excluded jobs are filtered
+	filterImagesForSimulation(imageData, simulation)
+	jobs := []string{}
+	for _, image := range imageData.Images {
+		jobs = append(jobs, image.Job)
+	}
+	if !reflect.DeepEqual(jobs, []string{"broken", "build"}) {
+		t.Errorf("jobs with images = %v, want [broken build]", jobs)
+	}
+}
+
+func TestSimulatePipelineRulesUnevaluableWorkflow(t *testing.T) {
+	conf, err := gitlab.ParseGitlabCI([]byte(`
+workflow:
+  rules:
+    - if: ($CI_COMMIT_BRANCH == "main"
+build:
+  image: golang:1.25
+  script: go build
+`))
+	if err != nil {
+		t.Fatalf("unable to parse CI configuration: %v", err)
+	}
+	imageData := &collector.GitlabPipelineImageData{MergedConf: conf}
+	project := &gitlab.ProjectInfo{DefaultBranch: "main", Path: "group/project"}
+
+	simulation, err := SimulatePipelineRules("main", DefaultSimulatedPipelineSource, project, imageData)
+	if err != nil {
+		t.Fatalf("unevaluable workflow rules must not fail the simulation: %v", err)
+	}
+	if !simulation.WorkflowRuns || simulation.WorkflowError == "" {
+		t.Errorf("workflow runs = %v, error = %q, want a running pipeline with the error", simulation.WorkflowRuns, simulation.WorkflowError)
+	}
+	if !reflect.DeepEqual(simulation.IncludedJobs, []string{"build"}) {
+		t.Errorf("included jobs = %v, want [build]", simulation.IncludedJobs)
+	}
+}
@@ -42,6 +42,7 @@ func RunAnalysis(conf *configuration.Configuration) (*AnalysisResult, error) {
 
 	// Update result with project info
 	result.ProjectID = project.IdOnPlatform
+	result.Archived = project.Archived
 
 	l.WithFields(logrus.Fields{
 		"projectID":     project.IdOnPlatform,
@@ -51,6 +52,30 @@ func RunAnalysis(conf *configuration.Configuration) (*AnalysisResult, error) {
 		"archived":      project.Archived,
 	}).Info("Project information fetched")
 
+	// Archived projects have no running pipelines, skip them unless requested
+	if project.Archived && !conf.IncludeArchived {
+		l.Info("Project is archived, skipping analysis")
+		result.SkippedArchived = true
+		return result, nil
+	}
+
+	// Most data collections rely on GraphQL, fail early with a clear message if it's disabled
+	if err := gitlab.CheckGraphQLAvailability(conf.GitlabToken, conf.GitlabURL, conf); err != nil {
+		l.WithError(err).Error("GraphQL API check failed")
+		result.CiValid = false
+		result.CiMissing = true
+		result.ImageForbiddenTagsResult = &GitlabImageForbiddenTagsResult{
+			Version:    ControlTypeGitlabImageForbiddenTagsVersion,
+			Compliance: 0,
+			Error:      err.Error(),
+		}
+		return result, err
+	}
+
+	// Controls relying on EE features are skipped on CE instances
+	result.InstanceEdition = detectInstanceEdition(conf)
+	l.WithField("instanceEdition", result.InstanceEdition).Debug("GitLab instance edition detected")
+
 	// Convert to ProjectInfo for collectors
 	projectInfo := project.ToProjectInfo()
 
@@ -85,6 +110,13 @@ func RunAnalysis(conf *configuration.Configuration) (*AnalysisResult, error) {
 
 	result.CiValid = pipelineOriginData.CiValid
 	result.CiMissing = pipelineOriginData.CiMissing
+	result.Diagnostics = pipelineOriginData.Diagnostics
+
+	if conf.FixtureDumpDir != "" {
+		if err := collector.DumpPipelineOriginFixture(conf.FixtureDumpDir, pipelineOriginData); err != nil {
+			l.WithError(err).Error("Unable to dump pipeline origin fixture")
+		}
+	}
 
 	// Store origin metrics
 	if pipelineOriginMetrics != nil {
@@ -99,12 +131,18 @@ func RunAnalysis(conf *configuration.Configuration) (*AnalysisResult, error) {
 			OriginTemplate:      pipelineOriginMetrics.OriginTemplate,
 			OriginGitLabCatalog: pipelineOriginMetrics.OriginGitLabCatalog,
 			OriginOutdated:      pipelineOriginMetrics.OriginOutdated,
+			OriginOfficial:      pipelineOriginMetrics.OriginOfficial,
 		}
 	}
 
 	// If limited analysis (CI invalid or missing), return early
 	if pipelineOriginData.LimitedAnalysis {
 		l.Info("Limited analysis due to CI configuration issues")
+		result.LimitedAnalysis = true
+		if conf.StrictCI {
+			result.StrictCiFailure = limitedAnalysisReason(pipelineOriginData)
+			l.WithField("reason", result.StrictCiFailure).Warn("Strict CI mode, the analysis fails")
+		}
 		return result, nil
 	}
 
@@ -123,6 +161,12 @@ func RunAnalysis(conf *configuration.Configuration) (*AnalysisResult, error) {
 		return result, err
 	}
 
+	if conf.FixtureDumpDir != "" {
+		if err := collector.DumpPipelineImageFixture(conf.FixtureDumpDir, pipelineImageData); err != nil {
+			l.WithError(err).Error("Unable to dump pipeline image fixture")
+		}
+	}
+
 	// Store image metrics
 	if pipelineImageMetrics != nil {
 		result.PipelineImageMetrics = &PipelineImageMetricsSummary{
@@ -130,6 +174,33 @@ func RunAnalysis(conf *configuration.Configuration) (*AnalysisResult, error) {
 		}
 	}
 
+	// List every job with its origin and image, before any rules simulation
+	// filters the images
+	if conf.IncludeInventory {
+		result.Inventory = buildJobInventory(pipelineOriginData, pipelineImageData)
+	}
+
+	// Evaluate workflow and job rules for the simulated ref/source, if requested,
+	// so that image controls only consider jobs that would actually run
+	if conf.SimulateRef != "" || conf.SimulateSource != "" {
+		simulateRef := conf.SimulateRef
+		if simulateRef == "" {
+			simulateRef = projectInfo.AnalyzeBranch
+		}
+		simulateSource := conf.SimulateSource
+		if simulateSource == "" {
+			simulateSource = DefaultSimulatedPipelineSource
+		}
+
+		simulation, err := SimulatePipelineRules(simulateRef, simulateSource, projectInfo, pipelineImageData)
+		if err != nil {
+			l.WithError(err).Error("Pipeline rules simulation failed")
+			return result, fmt.Errorf("rules simulation failed: %w", err)
+		}
+		result.RulesSimulation = simulation
+		filterImagesForSimulation(pipelineImageData, simulation)
+	}
+
 	///////////////////
 	// Run Controls
 	///////////////////
@@ -144,8 +215,9 @@ func RunAnalysis(conf *configuration.Configuration) (*AnalysisResult, error) {
 		return result, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	forbiddenTagsResult := forbiddenTagsConf.Run(pipelineImageData)
-	result.ImageForbiddenTagsResult = forbiddenTagsResult
+	result.ImageForbiddenTagsResult = runControl(result, "containerImageMustNotUseForbiddenTags", func() *GitlabImageForbiddenTagsResult {
+		return forbiddenTagsConf.Run(pipelineImageData)
+	})
 
 	// 4. Run Image Authorized Sources control
 	l.Info("Running Image Authorized Sources control")
@@ -156,36 +228,1103 @@ func RunAnalysis(conf *configuration.Configuration) (*AnalysisResult, error) {
 		return result, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	authorizedSourcesResult := authorizedSourcesConf.Run(pipelineImageData)
-	result.ImageAuthorizedSourcesResult = authorizedSourcesResult
+	result.ImageAuthorizedSourcesResult = runControl(result, "containerImageMustComeFromAuthorizedSources", func() *GitlabImageAuthorizedSourcesResult {
+		return authorizedSourcesConf.Run(pipelineImageData)
+	})
 
-	// 5. Run Branch Must Be Protected control (if enabled)
+	// Run Protection data collection once, only if a control needing it is enabled
 	branchProtectionConfig := conf.PlumberConfig.GetBranchMustBeProtectedConfig()
+	minimumMaintainersConfig := conf.PlumberConfig.GetMinimumMaintainersConfig()
+	pipelineSchedulesConfig := conf.PlumberConfig.GetPipelineSchedulesConfig()
+	webhookAllowlistConfig := conf.PlumberConfig.GetWebhookAllowlistConfig()
+	deployTokensConfig := conf.PlumberConfig.GetDeployTokensConfig()
+	mergeAccessGroupsConfig := conf.PlumberConfig.GetMergeAccessGroupsConfig()
+	noDirectElevatedMembersConfig := conf.PlumberConfig.GetNoDirectElevatedMembersConfig()
+	mergeTrainApprovalsConfig := conf.PlumberConfig.GetMergeTrainApprovalsConfig()
+	manualJobAccessConfig := conf.PlumberConfig.GetManualJobAccessConfig()
+	pushRulesPolicyConfig := conf.PlumberConfig.GetPushRulesPolicyConfig()
+	protectedEnvironmentsConfig := conf.PlumberConfig.GetProtectedEnvironmentsConfig()
+	tagMustNotBeBranchNameConfig := conf.PlumberConfig.GetTagMustNotBeBranchNameConfig()
+	pathScopedApprovalsConfig := conf.PlumberConfig.GetPathScopedApprovalsConfig()
+	separationOfDutiesConfig := conf.PlumberConfig.GetSeparationOfDutiesConfig()
+	defaultBranchNoDeletionConfig := conf.PlumberConfig.GetDefaultBranchNoDeletionConfig()
+	mergeRequestApprovalConfig := conf.PlumberConfig.GetMergeRequestApprovalConfig()
+
+	var protectionData *collector.GitlabProtectionAnalysisData
+	var protectionErr error
+	if branchProtectionConfig.IsEnabled() || minimumMaintainersConfig.IsEnabled() || pipelineSchedulesConfig.IsEnabled() ||
+		webhookAllowlistConfig.IsEnabled() || deployTokensConfig.IsEnabled() || mergeAccessGroupsConfig.IsEnabled() ||
+		noDirectElevatedMembersConfig.IsEnabled() || mergeTrainApprovalsConfig.IsEnabled() || manualJobAccessConfig.IsEnabled() ||
+		pushRulesPolicyConfig.IsEnabled() || protectedEnvironmentsConfig.IsEnabled() || tagMustNotBeBranchNameConfig.IsEnabled() ||
+		pathScopedApprovalsConfig.IsEnabled() || separationOfDutiesConfig.IsEnabled() ||
+		defaultBranchNoDeletionConfig.IsEnabled() || mergeRequestApprovalConfig.IsEnabled() {
+		l.Info("Running Protection data collection")
+		protectionDC := &collector.GitlabProtectionDataCollection{}
+		protectionData, _, protectionErr = protectionDC.Run(projectInfo, conf.GitlabToken, conf)
+		if protectionErr != nil {
+			l.WithError(protectionErr).Error("Protection data collection failed")
+		} else if conf.FixtureDumpDir != "" {
+			if err := collector.DumpProtectionFixture(conf.FixtureDumpDir, protectionData); err != nil {
+				l.WithError(err).Error("Unable to dump protection fixture")
+			}
+		}
+	}
+
+	// 5. Run Branch Must Be Protected control (if enabled)
 	if branchProtectionConfig != nil && branchProtectionConfig.IsEnabled() {
 		l.Info("Running Branch Must Be Protected control")
 
-		// Run Protection data collection first
-		protectionDC := &collector.GitlabProtectionDataCollection{}
-		protectionData, _, err := protectionDC.Run(projectInfo, conf.GitlabToken, conf)
-		if err != nil {
-			l.WithError(err).Error("Protection data collection failed")
+		if protectionErr != nil {
 			// Data collection failed - set compliance to 0 but continue
 			result.BranchProtectionResult = &GitlabBranchProtectionResult{
 				Enabled:    true,
 				Compliance: 0,
 				Version:    ControlTypeGitlabProtectionBranchProtectionNotCompliantVersion,
-				Error:      err.Error(),
+				Error:      protectionErr.Error(),
 			}
 		} else {
+			// Code owner approval is an EE feature, it can't be required on CE
+			if !enterpriseFeaturesAvailable(result.InstanceEdition) && branchProtectionConfig.CodeOwnerApprovalRequired != nil && *branchProtectionConfig.CodeOwnerApprovalRequired {
+				l.Warn("branchMustBeProtected.codeOwnerApprovalRequired ignored: " + SkippedReasonNotAvailableOnCE)
+				result.Diagnostics = append(result.Diagnostics, "branchMustBeProtected.codeOwnerApprovalRequired ignored: "+SkippedReasonNotAvailableOnCE)
+				ceConfig := *branchProtectionConfig
+				ceConfig.CodeOwnerApprovalRequired = nil
+				branchProtectionConfig = &ceConfig
+			}
+
 			// Run the branch protection control
 			branchProtectionControl := NewGitlabBranchProtectionControl(branchProtectionConfig)
-			branchProtectionResult := branchProtectionControl.Run(protectionData, projectInfo)
-			result.BranchProtectionResult = branchProtectionResult
+			result.BranchProtectionResult = runControl(result, "branchMustBeProtected", func() *GitlabBranchProtectionResult {
+				return branchProtectionControl.Run(protectionData, projectInfo)
+			})
 		}
 	} else {
 		l.Debug("Branch Must Be Protected control is disabled or not configured")
 	}
 
+	// 6. Run Dependency Pinning control (if enabled)
+	dependencyPinningConf := &GitlabPipelineDependencyPinningConf{}
+	if err := dependencyPinningConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load DependencyPinning config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if dependencyPinningConf.Enabled {
+		l.Info("Running Dependency Pinning control")
+		result.DependencyPinningResult = runControl(result, "dependencyPinning", func() *GitlabPipelineDependencyPinningResult {
+			return dependencyPinningConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Dependency Pinning control is disabled or not configured")
+	}
+
+	// 7. Run Environment URL Allowlist control (if enabled)
+	environmentUrlConf := &GitlabPipelineEnvironmentUrlConf{}
+	if err := environmentUrlConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load EnvironmentUrlAllowlist config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if environmentUrlConf.Enabled {
+		l.Info("Running Environment URL Allowlist control")
+		result.EnvironmentUrlAllowlistResult = runControl(result, "environmentUrlAllowlist", func() *GitlabPipelineEnvironmentUrlResult {
+			return environmentUrlConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Environment URL Allowlist control is disabled or not configured")
+	}
+
+	// 8. Run Cache Key Isolation control (if enabled)
+	cacheKeyIsolationConf := &GitlabPipelineCacheKeyIsolationConf{}
+	if err := cacheKeyIsolationConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load CacheKeyIsolation config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if cacheKeyIsolationConf.Enabled {
+		l.Info("Running Cache Key Isolation control")
+		result.CacheKeyIsolationResult = runControl(result, "cacheKeyIsolation", func() *GitlabPipelineCacheKeyIsolationResult {
+			return cacheKeyIsolationConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Cache Key Isolation control is disabled or not configured")
+	}
+
+	// 9. Run Minimum Maintainers control (if enabled)
+	if minimumMaintainersConfig.IsEnabled() {
+		l.Info("Running Minimum Maintainers control")
+
+		if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.MinimumMaintainersResult = &GitlabMinimumMaintainersResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionMinimumMaintainersVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			minimumMaintainersControl := NewGitlabMinimumMaintainersControl(minimumMaintainersConfig)
+			result.MinimumMaintainersResult = runControl(result, "minimumMaintainers", func() *GitlabMinimumMaintainersResult {
+				return minimumMaintainersControl.Run(protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Minimum Maintainers control is disabled or not configured")
+	}
+
+	// 10. Run Trigger Allowlist control (if enabled)
+	triggerAllowlistConf := &GitlabPipelineTriggerAllowlistConf{}
+	if err := triggerAllowlistConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load TriggerAllowlist config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if triggerAllowlistConf.Enabled {
+		l.Info("Running Trigger Allowlist control")
+		result.TriggerAllowlistResult = runControl(result, "triggerAllowlist", func() *GitlabPipelineTriggerAllowlistResult {
+			return triggerAllowlistConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Trigger Allowlist control is disabled or not configured")
+	}
+
+	// 11. Run Security Job Change Rules control (if enabled)
+	securityJobChangeRulesConf := &GitlabPipelineSecurityJobChangeRulesConf{}
+	if err := securityJobChangeRulesConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load SecurityJobChangeRules config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if securityJobChangeRulesConf.Enabled {
+		l.Info("Running Security Job Change Rules control")
+		result.SecurityJobChangeRulesResult = runControl(result, "securityJobChangeRules", func() *GitlabPipelineSecurityJobChangeRulesResult {
+			return securityJobChangeRulesConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Security Job Change Rules control is disabled or not configured")
+	}
+
+	// 12. Run OIDC Preferred control (if enabled)
+	oidcPreferredConf := &GitlabPipelineOidcPreferredConf{}
+	if err := oidcPreferredConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load OidcPreferred config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if oidcPreferredConf.Enabled {
+		l.Info("Running OIDC Preferred control")
+		result.OidcPreferredResult = runControl(result, "oidcPreferred", func() *GitlabPipelineOidcPreferredResult {
+			return oidcPreferredConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("OIDC Preferred control is disabled or not configured")
+	}
+
+	// 13. Run Secrets Manager Required control (if enabled)
+	secretsManagerRequiredConf := &GitlabPipelineSecretsManagerRequiredConf{}
+	if err := secretsManagerRequiredConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load SecretsManagerRequired config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if secretsManagerRequiredConf.Enabled {
+		l.Info("Running Secrets Manager Required control")
+		result.SecretsManagerRequiredResult = runControl(result, "secretsManagerRequired", func() *GitlabPipelineSecretsManagerRequiredResult {
+			return secretsManagerRequiredConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Secrets Manager Required control is disabled or not configured")
+	}
+
+	// 14. Run Component Inputs Valid control (if enabled)
+	componentInputsValidConf := &GitlabPipelineComponentInputsValidConf{}
+	if err := componentInputsValidConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load ComponentInputsValid config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if componentInputsValidConf.Enabled {
+		l.Info("Running Component Inputs Valid control")
+		result.ComponentInputsValidResult = runControl(result, "componentInputsValid", func() *GitlabPipelineComponentInputsValidResult {
+			return componentInputsValidConf.Run(pipelineOriginData)
+		})
+	} else {
+		l.Debug("Component Inputs Valid control is disabled or not configured")
+	}
+
+	// 15. Run Pipeline Complexity Budget control (if enabled)
+	pipelineComplexityBudgetConf := &GitlabPipelineComplexityBudgetConf{}
+	if err := pipelineComplexityBudgetConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load PipelineComplexityBudget config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if pipelineComplexityBudgetConf.Enabled {
+		l.Info("Running Pipeline Complexity Budget control")
+		result.PipelineComplexityBudgetResult = runControl(result, "pipelineComplexityBudget", func() *GitlabPipelineComplexityBudgetResult {
+			return pipelineComplexityBudgetConf.Run(pipelineOriginData)
+		})
+	} else {
+		l.Debug("Pipeline Complexity Budget control is disabled or not configured")
+	}
+
+	// 16. Run Default Branch Name control (if enabled)
+	defaultBranchNameConfig := conf.PlumberConfig.GetDefaultBranchNameConfig()
+	if defaultBranchNameConfig.IsEnabled() {
+		l.Info("Running Default Branch Name control")
+		defaultBranchNameControl := NewGitlabDefaultBranchNameControl(defaultBranchNameConfig)
+		result.DefaultBranchNameResult = runControl(result, "defaultBranchName", func() *GitlabDefaultBranchNameResult {
+			return defaultBranchNameControl.Run(projectInfo)
+		})
+	} else {
+		l.Debug("Default Branch Name control is disabled or not configured")
+	}
+
+	// 17. Run Pipeline Schedules control (if enabled)
+	if pipelineSchedulesConfig.IsEnabled() {
+		l.Info("Running Pipeline Schedules control")
+
+		if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.PipelineSchedulesResult = &GitlabPipelineSchedulesResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionPipelineSchedulesVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			pipelineSchedulesControl := NewGitlabPipelineSchedulesControl(pipelineSchedulesConfig)
+			result.PipelineSchedulesResult = runControl(result, "pipelineSchedules", func() *GitlabPipelineSchedulesResult {
+				return pipelineSchedulesControl.Run(protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Pipeline Schedules control is disabled or not configured")
+	}
+
+	// 18. Run Webhook Allowlist control (if enabled)
+	if webhookAllowlistConfig.IsEnabled() {
+		l.Info("Running Webhook Allowlist control")
+
+		if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.WebhookAllowlistResult = &GitlabWebhookAllowlistResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionWebhookAllowlistVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			webhookAllowlistControl := NewGitlabWebhookAllowlistControl(webhookAllowlistConfig)
+			result.WebhookAllowlistResult = runControl(result, "webhookAllowlist", func() *GitlabWebhookAllowlistResult {
+				return webhookAllowlistControl.Run(protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Webhook Allowlist control is disabled or not configured")
+	}
+
+	// 19. Run Deploy Tokens control (if enabled)
+	if deployTokensConfig.IsEnabled() {
+		l.Info("Running Deploy Tokens control")
+
+		if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.DeployTokensResult = &GitlabDeployTokensResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionDeployTokensVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			deployTokensControl := NewGitlabDeployTokensControl(deployTokensConfig)
+			result.DeployTokensResult = runControl(result, "deployTokens", func() *GitlabDeployTokensResult {
+				return deployTokensControl.Run(protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Deploy Tokens control is disabled or not configured")
+	}
+
+	// 20. Run Consistent Component Versions control (if enabled)
+	consistentComponentVersionsConf := &GitlabPipelineConsistentComponentVersionsConf{}
+	if err := consistentComponentVersionsConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load ConsistentComponentVersions config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if consistentComponentVersionsConf.Enabled {
+		l.Info("Running Consistent Component Versions control")
+		result.ConsistentComponentVersionsResult = runControl(result, "consistentComponentVersions", func() *GitlabPipelineConsistentComponentVersionsResult {
+			return consistentComponentVersionsConf.Run(pipelineOriginData)
+		})
+	} else {
+		l.Debug("Consistent Component Versions control is disabled or not configured")
+	}
+
+	// 21. Run Root User Discouraged control (if enabled)
+	rootUserDiscouragedConf := &GitlabImageRootUserConf{}
+	if err := rootUserDiscouragedConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load RootUserDiscouraged config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if rootUserDiscouragedConf.Enabled {
+		l.Info("Running Root User Discouraged control")
+		result.RootUserDiscouragedResult = runControl(result, "rootUserDiscouraged", func() *GitlabImageRootUserResult {
+			return rootUserDiscouragedConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Root User Discouraged control is disabled or not configured")
+	}
+
+	// 22. Run Rules Over Only/Except control (if enabled)
+	rulesOverOnlyExceptConf := &GitlabPipelineRulesOverOnlyExceptConf{}
+	if err := rulesOverOnlyExceptConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load RulesOverOnlyExcept config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if rulesOverOnlyExceptConf.Enabled {
+		l.Info("Running Rules Over Only/Except control")
+		result.RulesOverOnlyExceptResult = runControl(result, "rulesOverOnlyExcept", func() *GitlabPipelineRulesOverOnlyExceptResult {
+			return rulesOverOnlyExceptConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Rules Over Only/Except control is disabled or not configured")
+	}
+
+	// 23. Run README Required control (if enabled)
+	readmeRequiredConfig := conf.PlumberConfig.GetReadmeRequiredConfig()
+	if readmeRequiredConfig.IsEnabled() {
+		l.Info("Running README Required control")
+		readmeRequiredControl := NewGitlabReadmeRequiredControl(readmeRequiredConfig)
+
+		filesDC := &collector.GitlabRepositoryFilesDataCollection{}
+		filesData, err := filesDC.Run(projectInfo, readmeRequiredControl.Paths(), nil, conf.GitlabToken, conf)
+		if err != nil {
+			// Data collection failed - set compliance to 0 but continue
+			l.WithError(err).Error("Repository files data collection failed")
+			result.ReadmeRequiredResult = &GitlabReadmeRequiredResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProjectReadmeRequiredVersion,
+				Error:      err.Error(),
+			}
+		} else {
+			result.ReadmeRequiredResult = runControl(result, "readmeRequired", func() *GitlabReadmeRequiredResult {
+				return readmeRequiredControl.Run(filesData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("README Required control is disabled or not configured")
+	}
+
+	// 24. Run Merge Access Groups control (if enabled)
+	if mergeAccessGroupsConfig.IsEnabled() {
+		l.Info("Running Merge Access Groups control")
+
+		if !enterpriseFeaturesAvailable(result.InstanceEdition) {
+			// Users and groups allowed to merge are an EE feature
+			l.Warn("mergeAccessGroups skipped: " + SkippedReasonNotAvailableOnCE)
+			result.Diagnostics = append(result.Diagnostics, "mergeAccessGroups skipped: "+SkippedReasonNotAvailableOnCE)
+			result.MergeAccessGroupsResult = &GitlabMergeAccessGroupsResult{
+				Issues:     []GitlabMergeAccessGroupsIssue{},
+				Compliance: 100.0,
+				Version:    ControlTypeGitlabProtectionMergeAccessGroupsVersion,
+				Skipped:    true,
+			}
+		} else if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.MergeAccessGroupsResult = &GitlabMergeAccessGroupsResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionMergeAccessGroupsVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			mergeAccessGroupsControl := NewGitlabMergeAccessGroupsControl(mergeAccessGroupsConfig)
+			result.MergeAccessGroupsResult = runControl(result, "mergeAccessGroups", func() *GitlabMergeAccessGroupsResult {
+				return mergeAccessGroupsControl.Run(protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Merge Access Groups control is disabled or not configured")
+	}
+
+	// 25. Run Variable Expansion Policy control (if enabled)
+	variableExpansionConf := &GitlabPipelineVariableExpansionPolicyConf{}
+	if err := variableExpansionConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load VariableExpansionPolicy config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if variableExpansionConf.Enabled {
+		l.Info("Running Variable Expansion Policy control")
+		result.VariableExpansionPolicyResult = runControl(result, "variableExpansionPolicy", func() *GitlabPipelineVariableExpansionPolicyResult {
+			return variableExpansionConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Variable Expansion Policy control is disabled or not configured")
+	}
+
+	// 26. Run No Insecure Transport control (if enabled)
+	noInsecureTransportConf := &GitlabPipelineNoInsecureTransportConf{}
+	if err := noInsecureTransportConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load NoInsecureTransport config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if noInsecureTransportConf.Enabled {
+		l.Info("Running No Insecure Transport control")
+		result.NoInsecureTransportResult = runControl(result, "noInsecureTransport", func() *GitlabPipelineNoInsecureTransportResult {
+			return noInsecureTransportConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("No Insecure Transport control is disabled or not configured")
+	}
+
+	// 27. Run Artifacts Must Be Private control (if enabled)
+	artifactsMustBePrivateConf := &GitlabPipelineArtifactsMustBePrivateConf{}
+	if err := artifactsMustBePrivateConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load ArtifactsMustBePrivate config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if artifactsMustBePrivateConf.Enabled {
+		l.Info("Running Artifacts Must Be Private control")
+		result.ArtifactsMustBePrivateResult = runControl(result, "artifactsMustBePrivate", func() *GitlabPipelineArtifactsMustBePrivateResult {
+			return artifactsMustBePrivateConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Artifacts Must Be Private control is disabled or not configured")
+	}
+
+	// 28. Run Retry Policy control (if enabled)
+	retryPolicyConf := &GitlabPipelineRetryPolicyConf{}
+	if err := retryPolicyConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load RetryPolicy config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if retryPolicyConf.Enabled {
+		l.Info("Running Retry Policy control")
+		result.RetryPolicyResult = runControl(result, "retryPolicy", func() *GitlabPipelineRetryPolicyResult {
+			return retryPolicyConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Retry Policy control is disabled or not configured")
+	}
+
+	// 29. Run Registry Push Gating control (if enabled)
+	registryPushGatingConf := &GitlabPipelineRegistryPushGatingConf{}
+	if err := registryPushGatingConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load RegistryPushGating config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if registryPushGatingConf.Enabled {
+		l.Info("Running Registry Push Gating control")
+		result.RegistryPushGatingResult = runControl(result, "registryPushGating", func() *GitlabPipelineRegistryPushGatingResult {
+			return registryPushGatingConf.Run(pipelineImageData, projectInfo)
+		})
+	} else {
+		l.Debug("Registry Push Gating control is disabled or not configured")
+	}
+
+	// 30. Run Job Timeout Policy control (if enabled)
+	jobTimeoutPolicyConf := &GitlabPipelineJobTimeoutPolicyConf{}
+	if err := jobTimeoutPolicyConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load JobTimeoutPolicy config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if jobTimeoutPolicyConf.Enabled {
+		l.Info("Running Job Timeout Policy control")
+		result.JobTimeoutPolicyResult = runControl(result, "jobTimeoutPolicy", func() *GitlabPipelineJobTimeoutPolicyResult {
+			return jobTimeoutPolicyConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Job Timeout Policy control is disabled or not configured")
+	}
+
+	// 31. Run Debug Trace Forbidden control (if enabled)
+	debugTraceForbiddenConf := &GitlabPipelineDebugTraceForbiddenConf{}
+	if err := debugTraceForbiddenConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load DebugTraceForbidden config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if debugTraceForbiddenConf.Enabled {
+		l.Info("Running Debug Trace Forbidden control")
+		result.DebugTraceForbiddenResult = runControl(result, "debugTraceForbidden", func() *GitlabPipelineDebugTraceForbiddenResult {
+			return debugTraceForbiddenConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Debug Trace Forbidden control is disabled or not configured")
+	}
+
+	// 32. Run Max Includes control (if enabled)
+	maxIncludesConf := &GitlabPipelineMaxIncludesConf{}
+	if err := maxIncludesConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load MaxIncludes config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if maxIncludesConf.Enabled {
+		l.Info("Running Max Includes control")
+		result.MaxIncludesResult = runControl(result, "maxIncludes", func() *GitlabPipelineMaxIncludesResult {
+			return maxIncludesConf.Run(pipelineOriginData)
+		})
+	} else {
+		l.Debug("Max Includes control is disabled or not configured")
+	}
+
+	// 33. Run No Direct Elevated Members control (if enabled)
+	if noDirectElevatedMembersConfig.IsEnabled() {
+		l.Info("Running No Direct Elevated Members control")
+
+		if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.NoDirectElevatedMembersResult = &GitlabNoDirectElevatedMembersResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionNoDirectElevatedMembersVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			noDirectElevatedMembersControl := NewGitlabNoDirectElevatedMembersControl(noDirectElevatedMembersConfig)
+			result.NoDirectElevatedMembersResult = runControl(result, "noDirectElevatedMembers", func() *GitlabNoDirectElevatedMembersResult {
+				return noDirectElevatedMembersControl.Run(protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("No Direct Elevated Members control is disabled or not configured")
+	}
+
+	// 34. Run Merge Train Approvals control (if enabled)
+	if mergeTrainApprovalsConfig.IsEnabled() {
+		l.Info("Running Merge Train Approvals control")
+
+		if !enterpriseFeaturesAvailable(result.InstanceEdition) {
+			// Merge trains and approval rules are EE features
+			l.Warn("mergeTrainApprovals skipped: " + SkippedReasonNotAvailableOnCE)
+			result.Diagnostics = append(result.Diagnostics, "mergeTrainApprovals skipped: "+SkippedReasonNotAvailableOnCE)
+			result.MergeTrainApprovalsResult = &GitlabMergeTrainApprovalsResult{
+				Issues:     []GitlabMergeTrainApprovalsIssue{},
+				Compliance: 100.0,
+				Version:    ControlTypeGitlabProtectionMergeTrainApprovalsVersion,
+				Skipped:    true,
+			}
+		} else if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.MergeTrainApprovalsResult = &GitlabMergeTrainApprovalsResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionMergeTrainApprovalsVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			mergeTrainApprovalsControl := NewGitlabMergeTrainApprovalsControl(mergeTrainApprovalsConfig)
+			result.MergeTrainApprovalsResult = runControl(result, "mergeTrainApprovals", func() *GitlabMergeTrainApprovalsResult {
+				return mergeTrainApprovalsControl.Run(protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Merge Train Approvals control is disabled or not configured")
+	}
+
+	// 35. Run Components Must Be Released control (if enabled)
+	componentsReleasedConf := &GitlabPipelineComponentsMustBeReleasedConf{}
+	if err := componentsReleasedConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load ComponentsMustBeReleased config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if componentsReleasedConf.Enabled {
+		l.Info("Running Components Must Be Released control")
+		result.ComponentsMustBeReleasedResult = runControl(result, "componentsMustBeReleased", func() *GitlabPipelineComponentsMustBeReleasedResult {
+			return componentsReleasedConf.Run(pipelineOriginData)
+		})
+	} else {
+		l.Debug("Components Must Be Released control is disabled or not configured")
+	}
+
+	// 36. Run Variable Count Budget control (if enabled)
+	variableCountConf := &GitlabPipelineVariableCountBudgetConf{}
+	if err := variableCountConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load VariableCountBudget config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if variableCountConf.Enabled {
+		l.Info("Running Variable Count Budget control")
+		result.VariableCountBudgetResult = runControl(result, "variableCountBudget", func() *GitlabPipelineVariableCountBudgetResult {
+			return variableCountConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Variable Count Budget control is disabled or not configured")
+	}
+
+	// 37. Run Manual Job Access control (if enabled)
+	if manualJobAccessConfig.IsEnabled() {
+		l.Info("Running Manual Job Access control")
+
+		if !enterpriseFeaturesAvailable(result.InstanceEdition) {
+			// Protected environments are an EE feature
+			l.Warn("manualJobAccess skipped: " + SkippedReasonNotAvailableOnCE)
+			result.Diagnostics = append(result.Diagnostics, "manualJobAccess skipped: "+SkippedReasonNotAvailableOnCE)
+			result.ManualJobAccessResult = &GitlabManualJobAccessResult{
+				Issues:     []GitlabManualJobAccessIssue{},
+				Compliance: 100.0,
+				Version:    ControlTypeGitlabProtectionManualJobAccessVersion,
+				Skipped:    true,
+			}
+		} else if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.ManualJobAccessResult = &GitlabManualJobAccessResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionManualJobAccessVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			if protectionData.ProtectedEnvironments == nil {
+				result.Diagnostics = append(result.Diagnostics, "manualJobAccess skipped: protected environments are not available")
+			}
+			manualJobAccessControl := NewGitlabManualJobAccessControl(manualJobAccessConfig)
+			result.ManualJobAccessResult = runControl(result, "manualJobAccess", func() *GitlabManualJobAccessResult {
+				return manualJobAccessControl.Run(pipelineImageData, protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Manual Job Access control is disabled or not configured")
+	}
+
+	// 38. Run Push Rules Policy control (if enabled)
+	if pushRulesPolicyConfig.IsEnabled() {
+		l.Info("Running Push Rules Policy control")
+
+		if !enterpriseFeaturesAvailable(result.InstanceEdition) {
+			// Push rules are an EE feature
+			l.Warn("pushRulesPolicy skipped: " + SkippedReasonNotAvailableOnCE)
+			result.Diagnostics = append(result.Diagnostics, "pushRulesPolicy skipped: "+SkippedReasonNotAvailableOnCE)
+			result.PushRulesPolicyResult = &GitlabPushRulesPolicyResult{
+				Issues:     []GitlabPushRulesPolicyIssue{},
+				Compliance: 100.0,
+				Version:    ControlTypeGitlabProtectionPushRulesPolicyVersion,
+				Skipped:    true,
+			}
+		} else if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.PushRulesPolicyResult = &GitlabPushRulesPolicyResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionPushRulesPolicyVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			if protectionData.PushRules == nil {
+				result.Diagnostics = append(result.Diagnostics, "pushRulesPolicy skipped: push rules are not available")
+			}
+			pushRulesPolicyControl := NewGitlabPushRulesPolicyControl(pushRulesPolicyConfig)
+			result.PushRulesPolicyResult = runControl(result, "pushRulesPolicy", func() *GitlabPushRulesPolicyResult {
+				return pushRulesPolicyControl.Run(protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Push Rules Policy control is disabled or not configured")
+	}
+
+	// 39. Run Security Policy File Required control (if enabled)
+	securityPolicyFileConfig := conf.PlumberConfig.GetSecurityPolicyFileRequiredConfig()
+	if securityPolicyFileConfig.IsEnabled() {
+		l.Info("Running Security Policy File Required control")
+		securityPolicyFileControl := NewGitlabSecurityPolicyFileRequiredControl(securityPolicyFileConfig)
+
+		filesDC := &collector.GitlabRepositoryFilesDataCollection{}
+		filesData, err := filesDC.Run(projectInfo, securityPolicyFileControl.Paths(), nil, conf.GitlabToken, conf)
+		if err != nil {
+			// Data collection failed - set compliance to 0 but continue
+			l.WithError(err).Error("Repository files data collection failed")
+			result.SecurityPolicyFileRequiredResult = &GitlabSecurityPolicyFileRequiredResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProjectSecurityPolicyFileRequiredVersion,
+				Error:      err.Error(),
+			}
+		} else {
+			result.SecurityPolicyFileRequiredResult = runControl(result, "securityPolicyFileRequired", func() *GitlabSecurityPolicyFileRequiredResult {
+				return securityPolicyFileControl.Run(filesData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Security Policy File Required control is disabled or not configured")
+	}
+
+	// 40. Run Runner Feature Flags control (if enabled)
+	runnerFeatureFlagsConf := &GitlabPipelineRunnerFeatureFlagsConf{}
+	if err := runnerFeatureFlagsConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load RunnerFeatureFlags config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if runnerFeatureFlagsConf.Enabled {
+		l.Info("Running Runner Feature Flags control")
+		result.RunnerFeatureFlagsResult = runControl(result, "runnerFeatureFlags", func() *GitlabPipelineRunnerFeatureFlagsResult {
+			return runnerFeatureFlagsConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Runner Feature Flags control is disabled or not configured")
+	}
+
+	// 41. Run Trusted Include Projects control (if enabled)
+	trustedIncludeProjectsConf := &GitlabPipelineTrustedIncludeProjectsConf{}
+	if err := trustedIncludeProjectsConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load TrustedIncludeProjects config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if trustedIncludeProjectsConf.Enabled {
+		l.Info("Running Trusted Include Projects control")
+		result.TrustedIncludeProjectsResult = runControl(result, "trustedIncludeProjects", func() *GitlabPipelineTrustedIncludeProjectsResult {
+			return trustedIncludeProjectsConf.Run(pipelineOriginData)
+		})
+	} else {
+		l.Debug("Trusted Include Projects control is disabled or not configured")
+	}
+
+	// 42. Run Protected Environments control (if enabled)
+	if protectedEnvironmentsConfig.IsEnabled() {
+		l.Info("Running Protected Environments control")
+
+		if !enterpriseFeaturesAvailable(result.InstanceEdition) {
+			// Protected environments are an EE feature
+			l.Warn("protectedEnvironments skipped: " + SkippedReasonNotAvailableOnCE)
+			result.Diagnostics = append(result.Diagnostics, "protectedEnvironments skipped: "+SkippedReasonNotAvailableOnCE)
+			result.ProtectedEnvironmentsResult = &GitlabProtectedEnvironmentsResult{
+				Issues:     []GitlabProtectedEnvironmentsIssue{},
+				Compliance: 100.0,
+				Version:    ControlTypeGitlabProtectionProtectedEnvironmentsVersion,
+				Skipped:    true,
+			}
+		} else if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.ProtectedEnvironmentsResult = &GitlabProtectedEnvironmentsResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionProtectedEnvironmentsVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			if protectionData.ProtectedEnvironments == nil {
+				result.Diagnostics = append(result.Diagnostics, "protectedEnvironments skipped: protected environments are not available")
+			}
+			protectedEnvironmentsControl := NewGitlabProtectedEnvironmentsControl(protectedEnvironmentsConfig)
+			result.ProtectedEnvironmentsResult = runControl(result, "protectedEnvironments", func() *GitlabProtectedEnvironmentsResult {
+				return protectedEnvironmentsControl.Run(protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Protected Environments control is disabled or not configured")
+	}
+
+	// 43. Run Deprecated JWT Usage control (if enabled)
+	deprecatedJwtUsageConf := &GitlabPipelineDeprecatedJwtUsageConf{}
+	if err := deprecatedJwtUsageConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load DeprecatedJwtUsage config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if deprecatedJwtUsageConf.Enabled {
+		l.Info("Running Deprecated JWT Usage control")
+		result.DeprecatedJwtUsageResult = runControl(result, "deprecatedJwtUsage", func() *GitlabPipelineDeprecatedJwtUsageResult {
+			return deprecatedJwtUsageConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Deprecated JWT Usage control is disabled or not configured")
+	}
+
+	// 44. Run Local Include Globs control (if enabled)
+	localIncludeGlobsConf := &GitlabPipelineLocalIncludeGlobsConf{}
+	if err := localIncludeGlobsConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load LocalIncludeGlobs config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if localIncludeGlobsConf.Enabled {
+		l.Info("Running Local Include Globs control")
+		result.LocalIncludeGlobsResult = runControl(result, "localIncludeGlobs", func() *GitlabPipelineLocalIncludeGlobsResult {
+			return localIncludeGlobsConf.Run(pipelineOriginData)
+		})
+	} else {
+		l.Debug("Local Include Globs control is disabled or not configured")
+	}
+
+	// 45. Run Dead Jobs control (if enabled)
+	deadJobsConf := &GitlabPipelineDeadJobsConf{}
+	if err := deadJobsConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load DeadJobs config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if deadJobsConf.Enabled {
+		l.Info("Running Dead Jobs control")
+		result.DeadJobsResult = runControl(result, "deadJobs", func() *GitlabPipelineDeadJobsResult {
+			return deadJobsConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Dead Jobs control is disabled or not configured")
+	}
+
+	// 46. Run Repository Structure control (if enabled)
+	repoStructureConfig := conf.PlumberConfig.GetRepoStructureConfig()
+	if repoStructureConfig.IsEnabled() {
+		l.Info("Running Repository Structure control")
+		repoStructureControl := NewGitlabRepoStructureControl(repoStructureConfig)
+
+		filesDC := &collector.GitlabRepositoryFilesDataCollection{}
+		filesData, err := filesDC.Run(projectInfo, repoStructureControl.Paths(), repoStructureControl.Folders(), conf.GitlabToken, conf)
+		if err != nil {
+			// Data collection failed - set compliance to 0 but continue
+			l.WithError(err).Error("Repository files data collection failed")
+			result.RepoStructureResult = &GitlabRepoStructureResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProjectRepoStructureVersion,
+				Error:      err.Error(),
+			}
+		} else {
+			result.RepoStructureResult = runControl(result, "repoStructure", func() *GitlabRepoStructureResult {
+				return repoStructureControl.Run(filesData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Repository Structure control is disabled or not configured")
+	}
+
+	// 47. Run Tag Must Not Be Branch Name control (if enabled)
+	if tagMustNotBeBranchNameConfig.IsEnabled() {
+		l.Info("Running Tag Must Not Be Branch Name control")
+
+		if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.TagMustNotBeBranchNameResult = &GitlabTagMustNotBeBranchNameResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabImageTagMustNotBeBranchNameVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			tagMustNotBeBranchNameControl := NewGitlabTagMustNotBeBranchNameControl(tagMustNotBeBranchNameConfig)
+			result.TagMustNotBeBranchNameResult = runControl(result, "tagMustNotBeBranchName", func() *GitlabTagMustNotBeBranchNameResult {
+				return tagMustNotBeBranchNameControl.Run(pipelineImageData, protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Tag Must Not Be Branch Name control is disabled or not configured")
+	}
+
+	// 48. Run Rule Condition Safety control (if enabled)
+	ruleConditionSafetyConf := &GitlabPipelineRuleConditionSafetyConf{}
+	if err := ruleConditionSafetyConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load RuleConditionSafety config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if ruleConditionSafetyConf.Enabled {
+		l.Info("Running Rule Condition Safety control")
+		result.RuleConditionSafetyResult = runControl(result, "ruleConditionSafety", func() *GitlabPipelineRuleConditionSafetyResult {
+			return ruleConditionSafetyConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Rule Condition Safety control is disabled or not configured")
+	}
+
+	// 49. Run Same Instance Includes Only control (if enabled)
+	sameInstanceIncludesOnlyConf := &GitlabPipelineSameInstanceIncludesOnlyConf{}
+	if err := sameInstanceIncludesOnlyConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load SameInstanceIncludesOnly config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if sameInstanceIncludesOnlyConf.Enabled {
+		l.Info("Running Same Instance Includes Only control")
+		result.SameInstanceIncludesOnlyResult = runControl(result, "sameInstanceIncludesOnly", func() *GitlabPipelineSameInstanceIncludesOnlyResult {
+			return sameInstanceIncludesOnlyConf.Run(pipelineOriginData, conf.GitlabURL)
+		})
+	} else {
+		l.Debug("Same Instance Includes Only control is disabled or not configured")
+	}
+
+	// 50. Run Image Name Allowlist control (if enabled)
+	imageNameAllowlistConf := &GitlabImageNameAllowlistConf{}
+	if err := imageNameAllowlistConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load ImageNameAllowlist config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if imageNameAllowlistConf.Enabled {
+		l.Info("Running Image Name Allowlist control")
+		result.ImageNameAllowlistResult = runControl(result, "imageNameAllowlist", func() *GitlabImageNameAllowlistResult {
+			return imageNameAllowlistConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Image Name Allowlist control is disabled or not configured")
+	}
+
+	// 51. Run Path Scoped Approvals control (if enabled)
+	if pathScopedApprovalsConfig.IsEnabled() {
+		l.Info("Running Path Scoped Approvals control")
+
+		if !enterpriseFeaturesAvailable(result.InstanceEdition) {
+			// Approval rules and code owner approvals are EE features
+			l.Warn("pathScopedApprovals skipped: " + SkippedReasonNotAvailableOnCE)
+			result.Diagnostics = append(result.Diagnostics, "pathScopedApprovals skipped: "+SkippedReasonNotAvailableOnCE)
+			result.PathScopedApprovalsResult = &GitlabPathScopedApprovalsResult{
+				Issues:     []GitlabPathScopedApprovalsIssue{},
+				Compliance: 100.0,
+				Version:    ControlTypeGitlabProtectionPathScopedApprovalsVersion,
+				Skipped:    true,
+			}
+		} else if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.PathScopedApprovalsResult = &GitlabPathScopedApprovalsResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionPathScopedApprovalsVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			pathScopedApprovalsControl := NewGitlabPathScopedApprovalsControl(pathScopedApprovalsConfig)
+
+			filesDC := &collector.GitlabRepositoryFilesDataCollection{}
+			filesData, err := filesDC.Run(projectInfo, pathScopedApprovalsControl.Paths(), nil, conf.GitlabToken, conf)
+			if err != nil {
+				// Data collection failed - set compliance to 0 but continue
+				l.WithError(err).Error("Repository files data collection failed")
+				result.PathScopedApprovalsResult = &GitlabPathScopedApprovalsResult{
+					Compliance: 0,
+					Version:    ControlTypeGitlabProtectionPathScopedApprovalsVersion,
+					Error:      err.Error(),
+				}
+			} else {
+				result.PathScopedApprovalsResult = runControl(result, "pathScopedApprovals", func() *GitlabPathScopedApprovalsResult {
+					return pathScopedApprovalsControl.Run(filesData, protectionData, projectInfo)
+				})
+			}
+		}
+	} else {
+		l.Debug("Path Scoped Approvals control is disabled or not configured")
+	}
+
+	// 52. Run No Public Catalog Components control (if enabled)
+	noPublicCatalogComponentsConf := &GitlabPipelineNoPublicCatalogComponentsConf{}
+	if err := noPublicCatalogComponentsConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load NoPublicCatalogComponents config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if noPublicCatalogComponentsConf.Enabled {
+		l.Info("Running No Public Catalog Components control")
+		result.NoPublicCatalogComponentsResult = runControl(result, "noPublicCatalogComponents", func() *GitlabPipelineNoPublicCatalogComponentsResult {
+			return noPublicCatalogComponentsConf.Run(pipelineOriginData, conf.GitlabURL)
+		})
+	} else {
+		l.Debug("No Public Catalog Components control is disabled or not configured")
+	}
+
+	// 53. Run Package Registry Allowlist control (if enabled)
+	packageRegistryAllowlistConf := &GitlabPipelinePackageRegistryAllowlistConf{}
+	if err := packageRegistryAllowlistConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load PackageRegistryAllowlist config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if packageRegistryAllowlistConf.Enabled {
+		l.Info("Running Package Registry Allowlist control")
+		result.PackageRegistryAllowlistResult = runControl(result, "packageRegistryAllowlist", func() *GitlabPipelinePackageRegistryAllowlistResult {
+			return packageRegistryAllowlistConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Package Registry Allowlist control is disabled or not configured")
+	}
+
+	// 54. Run Job Image Override Trust control (if enabled)
+	jobImageOverrideTrustConf := &GitlabImageJobOverrideTrustConf{}
+	if err := jobImageOverrideTrustConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load JobImageOverrideTrust config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if jobImageOverrideTrustConf.Enabled {
+		l.Info("Running Job Image Override Trust control")
+		result.JobImageOverrideTrustResult = runControl(result, "jobImageOverrideTrust", func() *GitlabImageJobOverrideTrustResult {
+			return jobImageOverrideTrustConf.Run(pipelineImageData)
+		})
+	} else {
+		l.Debug("Job Image Override Trust control is disabled or not configured")
+	}
+
+	// 55. Run Separation Of Duties control (if enabled)
+	if separationOfDutiesConfig.IsEnabled() {
+		l.Info("Running Separation Of Duties control")
+
+		if !enterpriseFeaturesAvailable(result.InstanceEdition) {
+			// Approval settings and rules are EE features
+			reason := SkippedReasonNotAvailableOnCE + ", " + separationOfDutiesSkippedNote
+			l.Warn("separationOfDuties skipped: " + reason)
+			result.Diagnostics = append(result.Diagnostics, "separationOfDuties skipped: "+reason)
+			result.SeparationOfDutiesResult = &GitlabSeparationOfDutiesResult{
+				Issues:     []GitlabSeparationOfDutiesIssue{},
+				Compliance: 100.0,
+				Version:    ControlTypeGitlabProtectionSeparationOfDutiesVersion,
+				Skipped:    true,
+				Error:      separationOfDutiesSkippedNote,
+			}
+		} else if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.SeparationOfDutiesResult = &GitlabSeparationOfDutiesResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionSeparationOfDutiesVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			separationOfDutiesControl := NewGitlabSeparationOfDutiesControl(separationOfDutiesConfig)
+			result.SeparationOfDutiesResult = runControl(result, "separationOfDuties", func() *GitlabSeparationOfDutiesResult {
+				return separationOfDutiesControl.Run(protectionData, projectInfo)
+			})
+			if result.SeparationOfDutiesResult.Skipped && result.SeparationOfDutiesResult.Error != "" {
+				result.Diagnostics = append(result.Diagnostics, "separationOfDuties skipped: "+result.SeparationOfDutiesResult.Error)
+			}
+		}
+	} else {
+		l.Debug("Separation Of Duties control is disabled or not configured")
+	}
+
+	// 56. Run Default Branch No Deletion control (if enabled)
+	if defaultBranchNoDeletionConfig.IsEnabled() {
+		l.Info("Running Default Branch No Deletion control")
+
+		if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.DefaultBranchNoDeletionResult = &GitlabDefaultBranchNoDeletionResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionDefaultBranchNoDeletionVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			defaultBranchNoDeletionControl := NewGitlabDefaultBranchNoDeletionControl(defaultBranchNoDeletionConfig)
+			result.DefaultBranchNoDeletionResult = runControl(result, "defaultBranchNoDeletion", func() *GitlabDefaultBranchNoDeletionResult {
+				return defaultBranchNoDeletionControl.Run(protectionData, projectInfo)
+			})
+		}
+	} else {
+		l.Debug("Default Branch No Deletion control is disabled or not configured")
+	}
+
+	// 57. Run Merge Request Approval control (if enabled)
+	if mergeRequestApprovalConfig.IsEnabled() {
+		l.Info("Running Merge Request Approval control")
+
+		if !enterpriseFeaturesAvailable(result.InstanceEdition) {
+			// Approval settings and rules are EE features
+			l.Warn("mergeRequestApproval skipped: " + SkippedReasonNotAvailableOnCE)
+			result.Diagnostics = append(result.Diagnostics, "mergeRequestApproval skipped: "+SkippedReasonNotAvailableOnCE)
+			result.MergeRequestApprovalResult = &GitlabMRApprovalResult{
+				Issues:     []GitlabMRApprovalIssue{},
+				Compliance: 100.0,
+				Version:    ControlTypeGitlabProtectionMRApprovalVersion,
+				Skipped:    true,
+				Error:      SkippedReasonNotAvailableOnCE,
+			}
+		} else if protectionErr != nil {
+			// Data collection failed - set compliance to 0 but continue
+			result.MergeRequestApprovalResult = &GitlabMRApprovalResult{
+				Compliance: 0,
+				Version:    ControlTypeGitlabProtectionMRApprovalVersion,
+				Error:      protectionErr.Error(),
+			}
+		} else {
+			mergeRequestApprovalControl := NewGitlabMRApprovalControl(mergeRequestApprovalConfig)
+			result.MergeRequestApprovalResult = runControl(result, "mergeRequestApproval", func() *GitlabMRApprovalResult {
+				return mergeRequestApprovalControl.Run(protectionData, projectInfo)
+			})
+			if result.MergeRequestApprovalResult.Skipped && result.MergeRequestApprovalResult.Error != "" {
+				result.Diagnostics = append(result.Diagnostics, "mergeRequestApproval skipped: "+result.MergeRequestApprovalResult.Error)
+			}
+		}
+	} else {
+		l.Debug("Merge Request Approval control is disabled or not configured")
+	}
+
+	// 58. Run Hardcoded Jobs control (if enabled)
+	hardcodedJobsConf := &GitlabPipelineHardcodedJobsConf{}
+	if err := hardcodedJobsConf.GetConf(conf.PlumberConfig); err != nil {
+		l.WithError(err).Error("Failed to load HardcodedJobs config from .plumber.yaml file")
+		return result, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if hardcodedJobsConf.Enabled {
+		l.Info("Running Hardcoded Jobs control")
+		result.HardcodedJobsResult = runControl(result, "hardcodedJobs", func() *GitlabPipelineHardcodedJobsResult {
+			return hardcodedJobsConf.Run(pipelineOriginData)
+		})
+	} else {
+		l.Debug("Hardcoded Jobs control is disabled or not configured")
+	}
+
 	l.WithFields(logrus.Fields{
 		"ciValid":   result.CiValid,
 		"ciMissing": result.CiMissing,
@@ -193,3 +1332,12 @@ func RunAnalysis(conf *configuration.Configuration) (*AnalysisResult, error) {
 
 	return result, nil
 }
+
+// limitedAnalysisReason explains why the CI configuration couldn't be
+// analyzed, reported as the failure of a strict CI analysis
+func limitedAnalysisReason(pipelineOriginData *collector.GitlabPipelineOriginData) string {
+	if pipelineOriginData.CiMissing {
+		return "CI configuration is missing"
+	}
+	return "CI configuration is invalid or could not be retrieved"
+}
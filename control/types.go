@@ -10,20 +10,106 @@ type AnalysisResult struct {
 	ProjectPath string `json:"projectPath"`
 	ProjectID   int    `json:"projectId"`
 
+	// Archived is true if the project is archived. Archived projects are
+	// skipped (SkippedArchived) unless --include-archived is set.
+	Archived        bool `json:"archived"`
+	SkippedArchived bool `json:"skippedArchived,omitempty"`
+
+	// InstanceEdition is the edition of the GitLab instance (EE, CE or unknown)
+	InstanceEdition string `json:"instanceEdition,omitempty"`
+
 	// CI configuration status
 	CiValid   bool `json:"ciValid"`
 	CiMissing bool `json:"ciMissing"`
 
+	// LimitedAnalysis is true if the CI configuration is missing or invalid,
+	// no control ran. With strict CI, StrictCiFailure gives the reason the
+	// analysis fails.
+	LimitedAnalysis bool   `json:"limitedAnalysis,omitempty"`
+	StrictCiFailure string `json:"strictCiFailure,omitempty"`
+
+	// ThresholdFailures are the controls below the threshold set in their
+	// configuration, or below the global threshold when they have none. Any
+	// of them fails the analysis.
+	ThresholdFailures []ControlThresholdFailure `json:"thresholdFailures,omitempty"`
+
 	// Pipeline origin data
 	PipelineOriginMetrics *PipelineOriginMetricsSummary `json:"pipelineOriginMetrics,omitempty"`
 
+	// Inventory lists every job with its origin and image, only set with --include-inventory
+	Inventory []JobInventoryEntry `json:"inventory,omitempty"`
+
+	// Diagnostics are internal inconsistencies found while collecting data
+	// (e.g., jobs of an include missing from the merged configuration)
+	Diagnostics []string `json:"diagnostics,omitempty"`
+
+	// ControlErrors are the controls that failed unexpectedly (e.g., a bug on
+	// unusual data), their result only holds the error
+	ControlErrors []ControlError `json:"controlErrors,omitempty"`
+
 	// Pipeline image data
 	PipelineImageMetrics *PipelineImageMetricsSummary `json:"pipelineImageMetrics,omitempty"`
 
+	// Rules simulation (only set when --simulate-ref or --simulate-source is used)
+	RulesSimulation *RulesSimulationResult `json:"rulesSimulation,omitempty"`
+
 	// Control results
-	ImageForbiddenTagsResult     *GitlabImageForbiddenTagsResult     `json:"imageForbiddenTagsResult,omitempty"`
-	ImageAuthorizedSourcesResult *GitlabImageAuthorizedSourcesResult `json:"imageAuthorizedSourcesResult,omitempty"`
-	BranchProtectionResult       *GitlabBranchProtectionResult       `json:"branchProtectionResult,omitempty"`
+	ImageForbiddenTagsResult          *GitlabImageForbiddenTagsResult                  `json:"imageForbiddenTagsResult,omitempty"`
+	ImageAuthorizedSourcesResult      *GitlabImageAuthorizedSourcesResult              `json:"imageAuthorizedSourcesResult,omitempty"`
+	BranchProtectionResult            *GitlabBranchProtectionResult                    `json:"branchProtectionResult,omitempty"`
+	DependencyPinningResult           *GitlabPipelineDependencyPinningResult           `json:"dependencyPinningResult,omitempty"`
+	EnvironmentUrlAllowlistResult     *GitlabPipelineEnvironmentUrlResult              `json:"environmentUrlAllowlistResult,omitempty"`
+	CacheKeyIsolationResult           *GitlabPipelineCacheKeyIsolationResult           `json:"cacheKeyIsolationResult,omitempty"`
+	MinimumMaintainersResult          *GitlabMinimumMaintainersResult                  `json:"minimumMaintainersResult,omitempty"`
+	TriggerAllowlistResult            *GitlabPipelineTriggerAllowlistResult            `json:"triggerAllowlistResult,omitempty"`
+	SecurityJobChangeRulesResult      *GitlabPipelineSecurityJobChangeRulesResult      `json:"securityJobChangeRulesResult,omitempty"`
+	OidcPreferredResult               *GitlabPipelineOidcPreferredResult               `json:"oidcPreferredResult,omitempty"`
+	SecretsManagerRequiredResult      *GitlabPipelineSecretsManagerRequiredResult      `json:"secretsManagerRequiredResult,omitempty"`
+	ComponentInputsValidResult        *GitlabPipelineComponentInputsValidResult        `json:"componentInputsValidResult,omitempty"`
+	PipelineComplexityBudgetResult    *GitlabPipelineComplexityBudgetResult            `json:"pipelineComplexityBudgetResult,omitempty"`
+	DefaultBranchNameResult           *GitlabDefaultBranchNameResult                   `json:"defaultBranchNameResult,omitempty"`
+	PipelineSchedulesResult           *GitlabPipelineSchedulesResult                   `json:"pipelineSchedulesResult,omitempty"`
+	WebhookAllowlistResult            *GitlabWebhookAllowlistResult                    `json:"webhookAllowlistResult,omitempty"`
+	DeployTokensResult                *GitlabDeployTokensResult                        `json:"deployTokensResult,omitempty"`
+	ConsistentComponentVersionsResult *GitlabPipelineConsistentComponentVersionsResult `json:"consistentComponentVersionsResult,omitempty"`
+	RootUserDiscouragedResult         *GitlabImageRootUserResult                       `json:"rootUserDiscouragedResult,omitempty"`
+	RulesOverOnlyExceptResult         *GitlabPipelineRulesOverOnlyExceptResult         `json:"rulesOverOnlyExceptResult,omitempty"`
+	ReadmeRequiredResult              *GitlabReadmeRequiredResult                      `json:"readmeRequiredResult,omitempty"`
+	MergeAccessGroupsResult           *GitlabMergeAccessGroupsResult                   `json:"mergeAccessGroupsResult,omitempty"`
+	VariableExpansionPolicyResult     *GitlabPipelineVariableExpansionPolicyResult     `json:"variableExpansionPolicyResult,omitempty"`
+	NoInsecureTransportResult         *GitlabPipelineNoInsecureTransportResult         `json:"noInsecureTransportResult,omitempty"`
+	ArtifactsMustBePrivateResult      *GitlabPipelineArtifactsMustBePrivateResult      `json:"artifactsMustBePrivateResult,omitempty"`
+	RetryPolicyResult                 *GitlabPipelineRetryPolicyResult                 `json:"retryPolicyResult,omitempty"`
+	RegistryPushGatingResult          *GitlabPipelineRegistryPushGatingResult          `json:"registryPushGatingResult,omitempty"`
+	JobTimeoutPolicyResult            *GitlabPipelineJobTimeoutPolicyResult            `json:"jobTimeoutPolicyResult,omitempty"`
+	DebugTraceForbiddenResult         *GitlabPipelineDebugTraceForbiddenResult         `json:"debugTraceForbiddenResult,omitempty"`
+	MaxIncludesResult                 *GitlabPipelineMaxIncludesResult                 `json:"maxIncludesResult,omitempty"`
+	NoDirectElevatedMembersResult     *GitlabNoDirectElevatedMembersResult             `json:"noDirectElevatedMembersResult,omitempty"`
+	MergeTrainApprovalsResult         *GitlabMergeTrainApprovalsResult                 `json:"mergeTrainApprovalsResult,omitempty"`
+	ComponentsMustBeReleasedResult    *GitlabPipelineComponentsMustBeReleasedResult    `json:"componentsMustBeReleasedResult,omitempty"`
+	VariableCountBudgetResult         *GitlabPipelineVariableCountBudgetResult         `json:"variableCountBudgetResult,omitempty"`
+	ManualJobAccessResult             *GitlabManualJobAccessResult                     `json:"manualJobAccessResult,omitempty"`
+	PushRulesPolicyResult             *GitlabPushRulesPolicyResult                     `json:"pushRulesPolicyResult,omitempty"`
+	SecurityPolicyFileRequiredResult  *GitlabSecurityPolicyFileRequiredResult          `json:"securityPolicyFileRequiredResult,omitempty"`
+	RunnerFeatureFlagsResult          *GitlabPipelineRunnerFeatureFlagsResult          `json:"runnerFeatureFlagsResult,omitempty"`
+	TrustedIncludeProjectsResult      *GitlabPipelineTrustedIncludeProjectsResult      `json:"trustedIncludeProjectsResult,omitempty"`
+	ProtectedEnvironmentsResult       *GitlabProtectedEnvironmentsResult               `json:"protectedEnvironmentsResult,omitempty"`
+	DeprecatedJwtUsageResult          *GitlabPipelineDeprecatedJwtUsageResult          `json:"deprecatedJwtUsageResult,omitempty"`
+	LocalIncludeGlobsResult           *GitlabPipelineLocalIncludeGlobsResult           `json:"localIncludeGlobsResult,omitempty"`
+	DeadJobsResult                    *GitlabPipelineDeadJobsResult                    `json:"deadJobsResult,omitempty"`
+	RepoStructureResult               *GitlabRepoStructureResult                       `json:"repoStructureResult,omitempty"`
+	TagMustNotBeBranchNameResult      *GitlabTagMustNotBeBranchNameResult              `json:"tagMustNotBeBranchNameResult,omitempty"`
+	RuleConditionSafetyResult         *GitlabPipelineRuleConditionSafetyResult         `json:"ruleConditionSafetyResult,omitempty"`
+	SameInstanceIncludesOnlyResult    *GitlabPipelineSameInstanceIncludesOnlyResult    `json:"sameInstanceIncludesOnlyResult,omitempty"`
+	ImageNameAllowlistResult          *GitlabImageNameAllowlistResult                  `json:"imageNameAllowlistResult,omitempty"`
+	PathScopedApprovalsResult         *GitlabPathScopedApprovalsResult                 `json:"pathScopedApprovalsResult,omitempty"`
+	NoPublicCatalogComponentsResult   *GitlabPipelineNoPublicCatalogComponentsResult   `json:"noPublicCatalogComponentsResult,omitempty"`
+	PackageRegistryAllowlistResult    *GitlabPipelinePackageRegistryAllowlistResult    `json:"packageRegistryAllowlistResult,omitempty"`
+	JobImageOverrideTrustResult       *GitlabImageJobOverrideTrustResult               `json:"jobImageOverrideTrustResult,omitempty"`
+	SeparationOfDutiesResult          *GitlabSeparationOfDutiesResult                  `json:"separationOfDutiesResult,omitempty"`
+	DefaultBranchNoDeletionResult     *GitlabDefaultBranchNoDeletionResult             `json:"defaultBranchNoDeletionResult,omitempty"`
+	MergeRequestApprovalResult        *GitlabMRApprovalResult                          `json:"mergeRequestApprovalResult,omitempty"`
+	HardcodedJobsResult               *GitlabPipelineHardcodedJobsResult               `json:"hardcodedJobsResult,omitempty"`
 }
 
 // PipelineOriginMetricsSummary is a simplified version of origin metrics for output
@@ -38,6 +124,7 @@ type PipelineOriginMetricsSummary struct {
 	OriginTemplate      uint `json:"originTemplate"`
 	OriginGitLabCatalog uint `json:"originGitLabCatalog"`
 	OriginOutdated      uint `json:"originOutdated"`
+	OriginOfficial      uint `json:"originOfficial"`
 }
 
 // PipelineImageMetricsSummary is a simplified version of image metrics for output
@@ -0,0 +1,111 @@
+package control
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getplumber/plumber/gitlab"
+)
+
+// Scopes a CI/CD variable can be defined in, reported by the controls on
+// variables
+const (
+	variableScopeInstance = "instance"
+	variableScopeGroup    = "group"
+	variableScopeProject  = "project"
+	variableScopeGlobal   = "global"
+	variableScopeJob      = "job"
+)
+
+// pipelineJob holds a job parsed from the merged CI configuration
+type pipelineJob struct {
+	Name string
+	Job  *gitlab.GitlabJob
+}
+
+// parsePipelineJobs parses all jobs of a merged CI configuration and returns
+// them sorted by name. Hidden jobs (names starting with ".") are skipped as
+// they are only templates and never run.
+func parsePipelineJobs(conf *gitlab.GitlabCIConf) ([]pipelineJob, error) {
+	jobs := []pipelineJob{}
+	if conf == nil {
+		return jobs, nil
+	}
+
+	for name, content := range conf.GitlabJobs {
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		job, err := gitlab.ParseGitlabCIJob(content)
+		if err != nil {
+			return jobs, err
+		}
+		jobs = append(jobs, pipelineJob{Name: name, Job: job})
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+
+	return jobs, nil
+}
+
+// normalizeScript converts a script entry parsed from a CI configuration (a
+// string, a literal block scalar or a list of them) into a flat list of
+// trimmed, non-empty lines
+func normalizeScript(script interface{}) []string {
+	lines := []string{}
+
+	switch s := script.(type) {
+	case string:
+		for _, line := range strings.Split(s, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+	case []interface{}:
+		for _, item := range s {
+			lines = append(lines, normalizeScript(item)...)
+		}
+	case []string:
+		for _, item := range s {
+			lines = append(lines, normalizeScript(item)...)
+		}
+	case nil:
+	default:
+		lines = append(lines, normalizeScript(fmt.Sprintf("%v", s))...)
+	}
+
+	return lines
+}
+
+// jobScriptLines returns all script lines executed by a job: before_script,
+// script and after_script. When the job doesn't define its own
+// before_script/after_script, the ones from default (or root level) are used
+// as GitLab does.
+func jobScriptLines(conf *gitlab.GitlabCIConf, job *gitlab.GitlabJob) []string {
+	beforeScript := job.BeforeScript
+	afterScript := job.AfterScript
+
+	if conf != nil {
+		if beforeScript == nil {
+			beforeScript = conf.Default.BeforeScript
+		}
+		if beforeScript == nil {
+			beforeScript = conf.BeforeScript
+		}
+		if afterScript == nil {
+			afterScript = conf.Default.AfterScript
+		}
+		if afterScript == nil {
+			afterScript = conf.AfterScript
+		}
+	}
+
+	lines := normalizeScript(beforeScript)
+	lines = append(lines, normalizeScript(job.Script)...)
+	lines = append(lines, normalizeScript(afterScript)...)
+	return lines
+}
@@ -0,0 +1,110 @@
+package gitlab
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab/gitlabtest"
+)
+
+const (
+	testToken       = "glpat-test"
+	testProjectPath = "group/project"
+)
+
+// newTestServer starts a fake GitLab instance serving the branches of the test project
+func newTestServer(t *testing.T) (*gitlabtest.Server, *configuration.Configuration) {
+	t.Helper()
+	server := gitlabtest.NewServer()
+	t.Cleanup(server.Close)
+
+	branches := []map[string]interface{}{{"name": "main"}, {"name": "develop"}}
+	if err := server.HandleREST(http.MethodGet, "/api/v4/projects/"+testProjectPath+"/repository/branches", http.StatusOK, branches); err != nil {
+		t.Fatal(err)
+	}
+
+	conf := configuration.NewDefaultConfiguration()
+	conf.GitlabURL = server.URL
+	conf.GitlabRetryMaxRetries = 0
+	return server, conf
+}
+
+func TestFetchBranchData(t *testing.T) {
+	server, conf := newTestServer(t)
+	protections := []map[string]interface{}{
+		{
+			"name":             "main",
+			"allow_force_push": false,
+			"push_access_levels": []map[string]interface{}{
+				{"access_level": AccessLevelMaintainer},
+			},
+			"merge_access_levels": []map[string]interface{}{
+				{"access_level": AccessLevelDeveloper},
+			},
+		},
+	}
+	if err := server.HandleREST(http.MethodGet, "/api/v4/projects/"+testProjectPath+"/protected_branches", http.StatusOK, protections); err != nil {
+		t.Fatal(err)
+	}
+
+	branches, branchProtections, err := FetchBranchData(testProjectPath, testToken, server.URL, conf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(branches, []string{"main", "develop"}) {
+		t.Errorf("branches = %v, want [main develop]", branches)
+	}
+	if len(branchProtections) != 1 {
+		t.Fatalf("protections = %+v, want 1 protection", branchProtections)
+	}
+	protection := branchProtections[0]
+	if protection.ProtectionPattern != "main" || len(protection.PushAccessLevels) != 1 || protection.PushAccessLevels[0].AccessLevel != AccessLevelMaintainer ||
+		len(protection.MergeAccessLevels) != 1 || protection.MergeAccessLevels[0].AccessLevel != AccessLevelDeveloper {
+		t.Errorf("unexpected protection: %+v", protection)
+	}
+	if unmatched := server.Unmatched(); len(unmatched) != 0 {
+		t.Errorf("unexpected requests: %v", unmatched)
+	}
+}
+
+func TestFetchBranchDataProtectionsUnavailable(t *testing.T) {
+	// Protections can't be read (e.g., premium feature or missing permission,
+	// any other error): branches are returned without protections. 5xx are
+	// not used, the GitLab client retries them.
+	for _, status := range []int{http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server, conf := newTestServer(t)
+			if err := server.HandleREST(http.MethodGet, "/api/v4/projects/"+testProjectPath+"/protected_branches", status, map[string]string{"message": http.StatusText(status)}); err != nil {
+				t.Fatal(err)
+			}
+
+			branches, branchProtections, err := FetchBranchData(testProjectPath, testToken, server.URL, conf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(branches, []string{"main", "develop"}) {
+				t.Errorf("branches = %v, want [main develop]", branches)
+			}
+			if branchProtections != nil {
+				t.Errorf("protections = %+v, want none", branchProtections)
+			}
+		})
+	}
+}
+
+func TestFetchBranchDataBranchesError(t *testing.T) {
+	server, conf := newTestServer(t)
+	if err := server.HandleREST(http.MethodGet, "/api/v4/projects/"+testProjectPath+"/repository/branches", http.StatusForbidden, map[string]string{"message": "403 Forbidden"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.HandleREST(http.MethodGet, "/api/v4/projects/"+testProjectPath+"/protected_branches", http.StatusOK, []interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := FetchBranchData(testProjectPath, testToken, server.URL, conf); err == nil {
+		t.Error("expected an error when branches can't be listed")
+	}
+}
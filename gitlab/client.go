@@ -1,6 +1,8 @@
 package gitlab
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"regexp"
 	"strings"
@@ -72,13 +74,50 @@ func GetGraphQLClient(url string, conf *configuration.Configuration) *graphql.Cl
 	// Optionally add logging for debugging GraphQL queries
 	// Mask sensitive data like Authorization headers
 	client.Log = func(s string) {
-		masked := maskSensitiveData(s)
+		masked := MaskSensitiveData(s)
 		logrus.WithField("context", "GraphQL").Debug(masked)
 	}
 
 	return client
 }
 
+// ErrGraphQLDisabled is returned when the GraphQL API of the instance is not reachable
+var ErrGraphQLDisabled = errors.New("GraphQL API appears disabled on this instance; Plumber requires it (merged CI configuration, CI/CD variables, CI/CD catalog)")
+
+// CheckGraphQLAvailability sends a minimal GraphQL query to detect instances
+// where the GraphQL API is disabled. It returns ErrGraphQLDisabled in that case;
+// other failures (network, authentication) are left to the actual requests.
+func CheckGraphQLAvailability(token string, instanceUrl string, conf *configuration.Configuration) error {
+	l := logger.WithFields(logrus.Fields{
+		"action":      "CheckGraphQLAvailability",
+		"instanceUrl": instanceUrl,
+	})
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(instanceUrl, "/")+gitlabGraphQLPath, strings.NewReader(`{"query":"{ __typename }"}`))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := GetHTTPClient(conf).Do(req)
+	if err != nil {
+		l.WithError(err).Debug("Unable to check GraphQL API availability")
+		return nil
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		l.WithField("statusCode", resp.StatusCode).Error("GraphQL API is not available")
+		return ErrGraphQLDisabled
+	}
+
+	l.WithField("statusCode", resp.StatusCode).Debug("GraphQL API is available")
+	return nil
+}
+
 // GetHTTPClient creates a simple HTTP client with retry logic
 func GetHTTPClient(conf *configuration.Configuration) *http.Client {
 	timeout := 30 * time.Second
@@ -92,9 +131,9 @@ func GetHTTPClient(conf *configuration.Configuration) *http.Client {
 	}
 }
 
-// maskSensitiveData masks sensitive information in log strings
+// MaskSensitiveData masks sensitive information in log strings and payloads
 // This prevents accidental exposure of tokens in debug logs
-func maskSensitiveData(s string) string {
+func MaskSensitiveData(s string) string {
 	// Mask Authorization header values (Bearer tokens, etc.)
 	// Matches: Authorization:[Bearer glpat-xxx...] or Authorization:[glpat-xxx...]
 	// This catches both PATs and CI_JOB_TOKENs when used in headers
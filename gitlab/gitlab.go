@@ -1,5 +1,7 @@
 package gitlab
 
+import "strconv"
+
 // Access level constants for GitLab
 const (
 	AccessLevelNo         = 0
@@ -25,3 +27,30 @@ const (
 	OwnerText      = "Owner"
 	AdminText      = "Admin"
 )
+
+// AccessLevelText returns the text description of an access level, or its
+// number for unknown levels
+func AccessLevelText(level int) string {
+	switch level {
+	case AccessLevelNo:
+		return NoText
+	case AccessLevelMinimal:
+		return MinimalText
+	case AccessLevelGuest:
+		return GuestText
+	case AccessLevelPlanner:
+		return PlannerText
+	case AccessLevelReporter:
+		return ReporterText
+	case AccessLevelDeveloper:
+		return DeveloperText
+	case AccessLevelMaintainer:
+		return MaintainerText
+	case AccessLevelOwner:
+		return OwnerText
+	case AccessLevelAdmin:
+		return AdminText
+	default:
+		return strconv.Itoa(level)
+	}
+}
@@ -0,0 +1,233 @@
+// Package gitlabtest provides a fake GitLab instance serving canned REST and
+// GraphQL responses, to run collectors and controls without a real GitLab.
+//
+// Responses are registered with HandleGraphQL and HandleREST, or loaded from
+// a fixture file with LoadFixture:
+//
+//	server := gitlabtest.NewServer()
+//	defer server.Close()
+//	if err := server.LoadFixture("testdata/project.json"); err != nil {
+//		t.Fatal(err)
+//	}
+//	conf := configuration.NewDefaultConfiguration()
+//	conf.GitlabURL = server.URL
+//
+// A fixture file holds the GraphQL and REST routes of the instance:
+//
+//	{
+//	  "graphql": [
+//	    {"match": "ciConfig", "response": {"ciConfig": {"status": "VALID", "mergedYaml": "..."}}}
+//	  ],
+//	  "rest": [
+//	    {"method": "GET", "path": "/api/v4/projects/42/protected_branches", "status": 200, "body": []}
+//	  ]
+//	}
+//
+// Requests without a matching route get a 404 and are listed by Unmatched.
+package gitlabtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+)
+
+// graphQLPath is the path of the GraphQL API of a GitLab instance
+const graphQLPath = "/api/graphql"
+
+// GraphQLRoute is a canned GraphQL response, served to queries containing Match
+// (e.g., "ciConfig", "ciCatalogResources" or "ciVariables")
+type GraphQLRoute struct {
+	Match    string          `json:"match"`
+	Response json.RawMessage `json:"response"` // Content of the data field of the response
+}
+
+// RESTRoute is a canned REST response, served to requests with the method and
+// unescaped path (e.g., /api/v4/projects/group/project for an encoded path)
+type RESTRoute struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Status int             `json:"status"` // Defaults to 200
+	Body   json.RawMessage `json:"body"`
+}
+
+// Fixture is the content of a fixture file
+type Fixture struct {
+	GraphQL []GraphQLRoute `json:"graphql"`
+	REST    []RESTRoute    `json:"rest"`
+}
+
+// Server is a fake GitLab instance. Its URL can be used as the GitLab URL of
+// a configuration.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	graphql   []GraphQLRoute
+	rest      []RESTRoute
+	requests  []string
+	unmatched []string
+}
+
+// NewServer starts a fake GitLab instance without any route. The GraphQL
+// availability check is always answered.
+func NewServer() *Server {
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// HandleGraphQL serves data to GraphQL queries containing match. Routes are
+// tried in the order they were added.
+func (s *Server) HandleGraphQL(match string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("unable to marshal GraphQL response for %q: %w", match, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.graphql = append(s.graphql, GraphQLRoute{Match: match, Response: raw})
+	return nil
+}
+
+// HandleREST serves body as JSON with status to requests with the method and
+// path. The last route added for a method and path wins.
+func (s *Server) HandleREST(method, path string, status int, body interface{}) error {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("unable to marshal REST response for %s %s: %w", method, path, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rest = append(s.rest, RESTRoute{Method: method, Path: path, Status: status, Body: raw})
+	return nil
+}
+
+// LoadFixture adds the routes of a fixture file
+func (s *Server) LoadFixture(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read fixture %s: %w", path, err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(content, &fixture); err != nil {
+		return fmt.Errorf("unable to parse fixture %s: %w", path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.graphql = append(s.graphql, fixture.GraphQL...)
+	s.rest = append(s.rest, fixture.REST...)
+	return nil
+}
+
+// Requests returns the requests received, as "METHOD path" for REST requests
+// and "GraphQL match" for GraphQL queries
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+// Unmatched returns the requests that had no route, as "METHOD path" for REST
+// requests and "GraphQL query" for GraphQL queries
+func (s *Server) Unmatched() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.unmatched...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == graphQLPath && r.Method == http.MethodPost {
+		s.serveGraphQL(w, r)
+		return
+	}
+	s.serveREST(w, r)
+}
+
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Query string `json:"query"`
+	}
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid GraphQL request"})
+		return
+	}
+
+	s.mu.Lock()
+	var route *GraphQLRoute
+	for i := range s.graphql {
+		if strings.Contains(request.Query, s.graphql[i].Match) {
+			route = &s.graphql[i]
+			break
+		}
+	}
+	if route != nil {
+		s.requests = append(s.requests, "GraphQL "+route.Match)
+	} else if !strings.Contains(request.Query, "__typename") {
+		s.unmatched = append(s.unmatched, "GraphQL "+strings.Join(strings.Fields(request.Query), " "))
+	}
+	s.mu.Unlock()
+
+	switch {
+	case route != nil:
+		writeJSON(w, http.StatusOK, map[string]json.RawMessage{"data": route.Response})
+	case strings.Contains(request.Query, "__typename"):
+		// Availability check sent before any analysis
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]string{"__typename": "Query"}})
+	default:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data":   nil,
+			"errors": []map[string]string{{"message": "no canned response for this query"}},
+		})
+	}
+}
+
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request) {
+	request := r.Method + " " + r.URL.Path
+
+	s.mu.Lock()
+	var route *RESTRoute
+	for i := len(s.rest) - 1; i >= 0; i-- {
+		if strings.EqualFold(s.rest[i].Method, r.Method) && s.rest[i].Path == r.URL.Path {
+			route = &s.rest[i]
+			break
+		}
+	}
+	if route != nil {
+		s.requests = append(s.requests, request)
+	} else {
+		s.unmatched = append(s.unmatched, request)
+	}
+	s.mu.Unlock()
+
+	if route == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "404 Not Found"})
+		return
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if len(route.Body) > 0 {
+		_, _ = w.Write(route.Body)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
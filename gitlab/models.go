@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -84,11 +85,65 @@ type BranchProtection struct {
 	MinMergeAccessLevel       int                           `json:"minMergeAccessLevel"`
 	PushAccessLevels          []BranchProtectionAccessLevel `json:"pushAccessLevels"`
 	MergeAccessLevels         []BranchProtectionAccessLevel `json:"mergeAccessLevels"`
+
+	// UnprotectAccessLevels can unprotect the branch, and then delete it (EE
+	// only, Maintainers when empty). Protected branches can't be deleted with
+	// git push, only by users allowed to unprotect them.
+	UnprotectAccessLevels []BranchProtectionAccessLevel `json:"unprotectAccessLevels"`
+}
+
+// PipelineScheduleInfo is a pipeline schedule of a project
+type PipelineScheduleInfo struct {
+	ID            int    `json:"id"`
+	Description   string `json:"description"`
+	Ref           string `json:"ref"`
+	Cron          string `json:"cron"`
+	Active        bool   `json:"active"`
+	OwnerID       int    `json:"ownerId"`
+	OwnerUsername string `json:"ownerUsername"`
+}
+
+// WebhookInfo is a webhook of a project. Only the scheme and host of its URL
+// are kept, the full URL may contain secrets (e.g., tokens in query strings).
+type WebhookInfo struct {
+	ID                    int    `json:"id"`
+	Name                  string `json:"name"`
+	Scheme                string `json:"scheme"`
+	Host                  string `json:"host"`
+	EnableSSLVerification bool   `json:"enableSslVerification"`
+}
+
+// DeployTokenInfo is a deploy token of a project. The token secret is never kept.
+type DeployTokenInfo struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Username  string     `json:"username"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"` // Nil if the token never expires
+	Revoked   bool       `json:"revoked"`
+	Expired   bool       `json:"expired"`
+}
+
+// ProtectedEnvironmentInfo is a protected environment of a project
+type ProtectedEnvironmentInfo struct {
+	Name               string                        `json:"name"`
+	DeployAccessLevels []BranchProtectionAccessLevel `json:"deployAccessLevels"` // Roles, users or groups allowed to deploy
+	RequiredApprovals  int                           `json:"requiredApprovals"`  // Approvals required before deploying, from all approval rules
+}
+
+// PushRulesInfo holds the push rules of a project enforced on commits
+type PushRulesInfo struct {
+	AuthorEmailRegex      string `json:"authorEmailRegex"`
+	CommitMessageRegex    string `json:"commitMessageRegex"`
+	RejectUnsignedCommits bool   `json:"rejectUnsignedCommits"`
 }
 
 type BranchProtectionAccessLevel struct {
 	AccessLevel            int    `json:"accessLevel"`
-	AccessLevelDescription string `json:"accessLevelDescription"`
+	AccessLevelDescription string `json:"accessLevelDescription"`  // Role, user or group name
+	UserID                 int    `json:"userId,omitempty"`        // Set if access is given to a specific user
+	GroupID                int    `json:"groupId,omitempty"`       // Set if access is given to a specific group
+	GroupFullPath          string `json:"groupFullPath,omitempty"` // Resolved from GroupID only when a control needs it
 }
 
 type SecurityPolicyProject struct {
@@ -134,6 +189,8 @@ type CICatalogResource struct {
 	LatestReleasedAt    string                     `json:"latestReleasedAt"`
 	WebPath             string                     `json:"webPath"`
 	Versions            []CICatalogResourceVersion `json:"versions"`
+	//Note: IsOfficialCatalogResource is not returned by gitlab, we set it ourselves
+	IsOfficialCatalogResource bool `json:"isOfficialCatalogResource"`
 }
 
 type CICatalogResourceVersion struct {
@@ -197,16 +254,28 @@ type GitlabJob struct {
 	Needs        interface{}            `yaml:"needs,omitempty"`
 	Rules        interface{}            `yaml:"rules,omitempty"`
 	Artifacts    interface{}            `yaml:"artifacts,omitempty"`
+	Retry        interface{}            `yaml:"retry,omitempty"`   // Can be a number of retries or a map with max and when
+	Timeout      interface{}            `yaml:"timeout,omitempty"` // Duration like "1h 30m", a number is in seconds
 	Environment  interface{}            `yaml:"environment,omitempty"`
 	When         interface{}            `yaml:"when,omitempty"`
 	AllowFailure interface{}            `yaml:"allow_failure,omitempty"`
 	Extends      interface{}            `yaml:"extends,omitempty"`
+	Trigger      interface{}            `yaml:"trigger,omitempty"`   // Can be a project path or a map with project or include
+	IdTokens     interface{}            `yaml:"id_tokens,omitempty"` // Map of OIDC ID tokens (name: {aud: ...})
+	Secrets      interface{}            `yaml:"secrets,omitempty"`   // Map of secrets fetched from a secrets manager (name: {vault: ...})
 }
 
 type Image struct {
 	Name       string        `yaml:"name,omitempty"`
 	Entrypoint []string      `yaml:"entrypoint,omitempty"`
 	PullPolicy StringOrSlice `yaml:"pull_policy,omitempty"`
+	Docker     *ImageDocker  `yaml:"docker,omitempty"`
+}
+
+// ImageDocker holds the Docker executor options of an image (image:docker)
+type ImageDocker struct {
+	Platform string `yaml:"platform,omitempty"`
+	User     string `yaml:"user,omitempty"` // User (and group) the container runs as, e.g., "1000" or "app:app"
 }
 
 type Service struct {
@@ -218,12 +287,47 @@ type Service struct {
 }
 
 type Rule struct {
-	// NOTE: this type must be updated if we need to use it !
 	// See https://docs.gitlab.com/ee/ci/yaml/#rules
-	If           string   `yaml:"if"`
-	ChangesFrom  []string `yaml:"changes"`
-	When         string   `yaml:"when"`
-	AllowFailure bool     `yaml:"allow_failure"`
+	If           string      `yaml:"if"`
+	ChangesFrom  []string    `yaml:"changes"` // Paths of 'changes', declared as a list or as 'changes:paths'
+	HasChanges   bool        `yaml:"-"`       // True if the rule declares 'changes', even empty
+	When         string      `yaml:"when"`
+	AllowFailure interface{} `yaml:"allow_failure"`
+}
+
+// UnmarshalYAML supports both forms of 'changes': a list of paths or a map
+// with 'paths' (and 'compare_to')
+func (r *Rule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	raw := struct {
+		If           string      `yaml:"if"`
+		Changes      interface{} `yaml:"changes"`
+		When         string      `yaml:"when"`
+		AllowFailure interface{} `yaml:"allow_failure"`
+	}{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	r.If = raw.If
+	r.When = raw.When
+	r.AllowFailure = raw.AllowFailure
+	r.ChangesFrom = []string{}
+	r.HasChanges = raw.Changes != nil
+
+	paths := raw.Changes
+	if changes, ok := raw.Changes.(map[interface{}]interface{}); ok {
+		paths = changes["paths"]
+	}
+	switch p := paths.(type) {
+	case []interface{}:
+		for _, path := range p {
+			r.ChangesFrom = append(r.ChangesFrom, fmt.Sprintf("%v", path))
+		}
+	case string:
+		r.ChangesFrom = append(r.ChangesFrom, p)
+	}
+
+	return nil
 }
 
 type Cache struct {
@@ -237,12 +341,23 @@ type Cache struct {
 }
 
 type Artifacts struct {
-	// NOTE: this type must be verified if we need to use it !
-	// See https://docs.gitlab.com/ee/ci/yaml/#rules
+	// See https://docs.gitlab.com/ee/ci/yaml/#artifacts
 	Paths     []string `yaml:"paths,omitempty"`
 	When      string   `yaml:"when,omitempty"`
 	Name      string   `yaml:"name,omitempty"`
 	Untracked bool     `yaml:"untracked,omitempty"`
+	ExpireIn  string   `yaml:"expire_in,omitempty"`
+	Public    *bool    `yaml:"public,omitempty"` // Artifacts are public if not set (deprecated in favor of access)
+	Access    string   `yaml:"access,omitempty"` // "all" (default), "developer", "maintainer" or "none"
+}
+
+// IsPrivate reports whether the artifacts are restricted to project members,
+// with 'public: false' or an 'access' other than "all"
+func (a *Artifacts) IsPrivate() bool {
+	if a.Access != "" && a.Access != "all" {
+		return true
+	}
+	return a.Public != nil && !*a.Public
 }
 
 type Environment struct {
@@ -306,8 +421,15 @@ type CIConfVariable struct {
 	Description string   `yaml:"description,omitempty"`
 	Value       string   `yaml:"value,omitempty"`
 	Options     []string `yaml:"options,omitempty"`
+	Expand      *bool    `yaml:"expand,omitempty"` // Variable references are expanded if not set
 }
 
 type CIConfDefault struct {
-	Image interface{} `yaml:"image,omitempty"`
+	Image        interface{} `yaml:"image,omitempty"`
+	BeforeScript interface{} `yaml:"before_script,omitempty"`
+	AfterScript  interface{} `yaml:"after_script,omitempty"`
+	Cache        interface{} `yaml:"cache,omitempty"`
+	Artifacts    interface{} `yaml:"artifacts,omitempty"`
+	Retry        interface{} `yaml:"retry,omitempty"`
+	Timeout      interface{} `yaml:"timeout,omitempty"`
 }
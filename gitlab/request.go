@@ -3,6 +3,7 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/getplumber/plumber/configuration"
 	"github.com/machinebox/graphql"
@@ -179,6 +180,17 @@ func FetchGitlabMergedCIConf(projectPath string, confContent string, sha string,
 		"sha":         sha,
 	})
 
+	// The same content is often merged more than once in an analysis (e.g.,
+	// includes shared by several files), successful merges are cached
+	cache := mergedCIConfCache(conf)
+	cacheKey := mergedCIConfCacheKey{projectPath: projectPath, confContent: confContent, sha: sha}
+	if cache != nil {
+		if cached, ok := cache.Load(cacheKey); ok {
+			l.Debug("Merged CI configuration found in cache")
+			return cached.(MergedCIConfResponse), nil
+		}
+	}
+
 	request := `
 	query getCiConfig($projectPath: ID!, $content: String!, $sha: String!, $dryRun: Boolean!) {
 		ciConfig(projectPath: $projectPath, content: $content, sha: $sha, dryRun: $dryRun) {
@@ -229,9 +241,30 @@ func FetchGitlabMergedCIConf(projectPath string, confContent string, sha string,
 		return response, err
 	}
 
+	if cache != nil {
+		cache.Store(cacheKey, response)
+	}
 	return response, nil
 }
 
+// mergedCIConfCache returns the cache of merged CI configurations of the
+// analysis, nil if it's disabled
+func mergedCIConfCache(conf *configuration.Configuration) *sync.Map {
+	if conf == nil || conf.DisableMergedCIConfCache {
+		return nil
+	}
+	return conf.MergedCIConfCache
+}
+
+// mergedCIConfCacheKey identifies the merge of a CI configuration content in
+// a project at a sha. The content itself is part of the key, not a hash of
+// it, so that two contents can never share a cached merge.
+type mergedCIConfCacheKey struct {
+	projectPath string
+	confContent string
+	sha         string
+}
+
 // GetGitlabProjectVariables returns all project variables
 func GetGitlabProjectVariables(fullPath string, token string, instanceUrl string, conf *configuration.Configuration) ([]CICDVariable, error) {
 	l := logrus.WithFields(logrus.Fields{
@@ -428,6 +461,7 @@ func GetGitlabCIComponentResources(isGroup bool, token string, instanceUrl strin
 				name
 				fullPath
 				webPath
+				latestReleasedAt
 				versions{
 					nodes{
 						name
@@ -469,11 +503,12 @@ func GetGitlabCIComponentResources(isGroup bool, token string, instanceUrl strin
 	}
 
 	type resourceNode struct {
-		ID       string        `json:"id"`
-		Name     string        `json:"name"`
-		FullPath string        `json:"fullPath"`
-		WebPath  string        `json:"webPath"`
-		Versions versionsNodes `json:"versions"`
+		ID               string        `json:"id"`
+		Name             string        `json:"name"`
+		FullPath         string        `json:"fullPath"`
+		WebPath          string        `json:"webPath"`
+		LatestReleasedAt string        `json:"latestReleasedAt"`
+		Versions         versionsNodes `json:"versions"`
 	}
 
 	type ciResourcesResponse struct {
@@ -491,11 +526,12 @@ func GetGitlabCIComponentResources(isGroup bool, token string, instanceUrl strin
 	resources := make([]CICatalogResource, 0, len(graphqlResp.CICatalogResources.Nodes))
 	for _, node := range graphqlResp.CICatalogResources.Nodes {
 		resource := CICatalogResource{
-			ID:       node.ID,
-			Name:     node.Name,
-			FullPath: node.FullPath,
-			WebPath:  node.WebPath,
-			Versions: make([]CICatalogResourceVersion, 0, len(node.Versions.Nodes)),
+			ID:               node.ID,
+			Name:             node.Name,
+			FullPath:         node.FullPath,
+			WebPath:          node.WebPath,
+			LatestReleasedAt: node.LatestReleasedAt,
+			Versions:         make([]CICatalogResourceVersion, 0, len(node.Versions.Nodes)),
 		}
 
 		for _, vNode := range node.Versions.Nodes {
@@ -0,0 +1,87 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/getplumber/plumber/configuration"
+	"github.com/getplumber/plumber/gitlab/gitlabtest"
+)
+
+// countRequests returns the number of requests received for a route
+func countRequests(server *gitlabtest.Server, request string) int {
+	count := 0
+	for _, r := range server.Requests() {
+		if r == request {
+			count++
+		}
+	}
+	return count
+}
+
+func TestFetchGitlabMergedCIConfCache(t *testing.T) {
+	server, conf := newTestServer(t)
+	ciConfig := map[string]interface{}{
+		"ciConfig": map[string]interface{}{"mergedYaml": "build:\n  script: make\n", "status": "VALID"},
+	}
+	if err := server.HandleGraphQL("ciConfig", ciConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	fetches := []struct {
+		name        string
+		projectPath string
+		content     string
+		sha         string
+		wantHit     bool
+	}{
+		{name: "first merge", projectPath: testProjectPath, content: "include: a.yml", sha: "abc", wantHit: false},
+		{name: "same merge", projectPath: testProjectPath, content: "include: a.yml", sha: "abc", wantHit: true},
+		{name: "other content", projectPath: testProjectPath, content: "include: b.yml", sha: "abc", wantHit: false},
+		{name: "other sha", projectPath: testProjectPath, content: "include: a.yml", sha: "def", wantHit: false},
+		{name: "other project", projectPath: "group/other", content: "include: a.yml", sha: "abc", wantHit: false},
+		{name: "parts shifted between fields", projectPath: testProjectPath, content: "include: a.ym", sha: "labc", wantHit: false},
+		{name: "other content merged again", projectPath: testProjectPath, content: "include: b.yml", sha: "abc", wantHit: true},
+	}
+
+	for _, fetch := range fetches {
+		before := countRequests(server, "GraphQL ciConfig")
+		response, err := FetchGitlabMergedCIConf(fetch.projectPath, fetch.content, fetch.sha, testToken, server.URL, conf)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", fetch.name, err)
+		}
+		if response.CiConfig.Status != "VALID" {
+			t.Errorf("%s: status = %q, want VALID", fetch.name, response.CiConfig.Status)
+		}
+		if hit := countRequests(server, "GraphQL ciConfig") == before; hit != fetch.wantHit {
+			t.Errorf("%s: cache hit = %v, want %v", fetch.name, hit, fetch.wantHit)
+		}
+	}
+}
+
+func TestFetchGitlabMergedCIConfCacheDisabled(t *testing.T) {
+	for _, disabled := range []bool{true, false} {
+		server := gitlabtest.NewServer()
+		defer server.Close()
+		if err := server.HandleGraphQL("ciConfig", map[string]interface{}{"ciConfig": map[string]interface{}{"status": "VALID"}}); err != nil {
+			t.Fatal(err)
+		}
+		conf := configuration.NewDefaultConfiguration()
+		conf.GitlabURL = server.URL
+		conf.GitlabRetryMaxRetries = 0
+		conf.DisableMergedCIConfCache = disabled
+
+		for i := 0; i < 2; i++ {
+			if _, err := FetchGitlabMergedCIConf(testProjectPath, "include: a.yml", "abc", testToken, server.URL, conf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		want := 1
+		if disabled {
+			want = 2
+		}
+		if got := countRequests(server, "GraphQL ciConfig"); got != want {
+			t.Errorf("cache disabled = %v: %d merge requests, want %d", disabled, got, want)
+		}
+	}
+}
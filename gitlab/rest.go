@@ -1,10 +1,14 @@
 package gitlab
 
 import (
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/getplumber/plumber/configuration"
+	gover "github.com/hashicorp/go-version"
 	"github.com/sirupsen/logrus"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 )
@@ -148,10 +152,151 @@ func SearchTags(projectPath string, token string, APIURL string, conf *configura
 	return allTags, nil, nil
 }
 
-// FetchProjectBranches retrieves all branches for a project
-func FetchProjectBranches(projectID int, token string, APIURL string, conf *configuration.Configuration) ([]string, error) {
+// FetchBranchData fetches the branches of a project and their protection
+// settings concurrently. Protections may not be readable (e.g., 403/404 with
+// the token permissions or the GitLab tier, a server error): branches are
+// then returned without protections.
+func FetchBranchData(projectPath string, token string, APIURL string, conf *configuration.Configuration) ([]string, []BranchProtection, error) {
 	l := logger.WithFields(logrus.Fields{
-		"action":    "FetchProjectBranches",
+		"action":      "FetchBranchData",
+		"projectPath": projectPath,
+		"APIURL":      APIURL,
+	})
+
+	glab, err := GetNewGitlabClient(token, APIURL, conf)
+	if err != nil {
+		l.WithError(err).Error("Unable to get a Gitlab client")
+		return nil, nil, err
+	}
+
+	var branches []string
+	var protections []BranchProtection
+	var protectionsStatus int
+	var branchesErr, protectionsErr error
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		branches, branchesErr = fetchAllBranches(glab, projectPath)
+	}()
+	go func() {
+		defer wg.Done()
+		protections, protectionsStatus, protectionsErr = fetchAllBranchProtections(glab, projectPath)
+	}()
+	wg.Wait()
+
+	if branchesErr != nil {
+		l.WithError(branchesErr).Error("Failed to fetch branches")
+		return nil, nil, branchesErr
+	}
+	if protectionsErr != nil {
+		l := l.WithError(protectionsErr).WithField("statusCode", protectionsStatus)
+		switch protectionsStatus {
+		case http.StatusForbidden, http.StatusNotFound:
+			l.Warn("Branch protections not available (may require permissions or premium), continuing without them")
+		default:
+			l.Warn("Failed to fetch branch protections, continuing without them")
+		}
+		protections = nil
+	}
+
+	l.WithFields(logrus.Fields{
+		"branchCount":     len(branches),
+		"protectionCount": len(protections),
+	}).Debug("Fetched branch data")
+
+	return branches, protections, nil
+}
+
+// fetchAllBranches returns the names of all branches of a project
+func fetchAllBranches(glab *gitlab.Client, projectPath string) ([]string, error) {
+	allBranches := []string{}
+	options := &gitlab.ListBranchesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	for {
+		branches, resp, err := glab.Branches.ListBranches(projectPath, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			allBranches = append(allBranches, branch.Name)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	return allBranches, nil
+}
+
+// fetchAllBranchProtections returns all branch protections of a project. On
+// error, the HTTP status code of the failed request is returned, 0 if there
+// was no response.
+func fetchAllBranchProtections(glab *gitlab.Client, projectPath string) ([]BranchProtection, int, error) {
+	allProtections := []BranchProtection{}
+	options := &gitlab.ListProtectedBranchesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	for {
+		protections, resp, err := glab.ProtectedBranches.ListProtectedBranches(projectPath, options)
+		if err != nil {
+			if resp != nil {
+				return nil, resp.StatusCode, err
+			}
+			return nil, 0, err
+		}
+		for _, p := range protections {
+			allProtections = append(allProtections, toBranchProtection(p))
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	return allProtections, 0, nil
+}
+
+// toBranchProtection converts a protected branch from the GitLab API
+func toBranchProtection(p *gitlab.ProtectedBranch) BranchProtection {
+	return BranchProtection{
+		ProtectionPattern:         p.Name,
+		AllowForcePush:            p.AllowForcePush,
+		CodeOwnerApprovalRequired: p.CodeOwnerApprovalRequired,
+		PushAccessLevels:          toBranchProtectionAccessLevels(p.PushAccessLevels),
+		MergeAccessLevels:         toBranchProtectionAccessLevels(p.MergeAccessLevels),
+		UnprotectAccessLevels:     toBranchProtectionAccessLevels(p.UnprotectAccessLevels),
+	}
+}
+
+// toBranchProtectionAccessLevels extracts access levels, including the users
+// and groups given access (EE only)
+func toBranchProtectionAccessLevels(levels []*gitlab.BranchAccessDescription) []BranchProtectionAccessLevel {
+	var accessLevels []BranchProtectionAccessLevel
+	for _, level := range levels {
+		accessLevels = append(accessLevels, BranchProtectionAccessLevel{
+			AccessLevel:            int(level.AccessLevel),
+			AccessLevelDescription: level.AccessLevelDescription,
+			UserID:                 int(level.UserID),
+			GroupID:                int(level.GroupID),
+		})
+	}
+	return accessLevels
+}
+
+// FetchPipelineSchedules retrieves all pipeline schedules of a project
+func FetchPipelineSchedules(projectID int, token string, APIURL string, conf *configuration.Configuration) ([]PipelineScheduleInfo, error) {
+	l := logger.WithFields(logrus.Fields{
+		"action":    "FetchPipelineSchedules",
 		"projectID": projectID,
 		"APIURL":    APIURL,
 	})
@@ -162,9 +307,9 @@ func FetchProjectBranches(projectID int, token string, APIURL string, conf *conf
 		return nil, err
 	}
 
-	var allBranches []string
+	allSchedules := []PipelineScheduleInfo{}
 	var perPage int64 = 100
-	options := &gitlab.ListBranchesOptions{
+	options := &gitlab.ListPipelineSchedulesOptions{
 		ListOptions: gitlab.ListOptions{
 			PerPage: perPage,
 		},
@@ -172,29 +317,40 @@ func FetchProjectBranches(projectID int, token string, APIURL string, conf *conf
 
 	for page := int64(1); ; page++ {
 		options.Page = page
-		branches, _, err := glab.Branches.ListBranches(projectID, options)
+		schedules, _, err := glab.PipelineSchedules.ListPipelineSchedules(projectID, options)
 		if err != nil {
-			l.WithError(err).Error("Failed to fetch branches")
+			l.WithError(err).Warn("Failed to fetch pipeline schedules")
 			return nil, err
 		}
 
-		for _, branch := range branches {
-			allBranches = append(allBranches, branch.Name)
+		for _, s := range schedules {
+			schedule := PipelineScheduleInfo{
+				ID:          int(s.ID),
+				Description: s.Description,
+				Ref:         s.Ref,
+				Cron:        s.Cron,
+				Active:      s.Active,
+			}
+			if s.Owner != nil {
+				schedule.OwnerID = int(s.Owner.ID)
+				schedule.OwnerUsername = s.Owner.Username
+			}
+			allSchedules = append(allSchedules, schedule)
 		}
 
-		if int64(len(branches)) < perPage {
+		if int64(len(schedules)) < perPage {
 			break
 		}
 	}
 
-	l.WithField("branchCount", len(allBranches)).Debug("Fetched branches")
-	return allBranches, nil
+	l.WithField("scheduleCount", len(allSchedules)).Debug("Fetched pipeline schedules")
+	return allSchedules, nil
 }
 
-// FetchBranchProtections retrieves branch protection settings for a project
-func FetchBranchProtections(projectID int, token string, APIURL string, conf *configuration.Configuration) ([]BranchProtection, error) {
+// FetchProjectWebhooks retrieves all webhooks of a project
+func FetchProjectWebhooks(projectID int, token string, APIURL string, conf *configuration.Configuration) ([]WebhookInfo, error) {
 	l := logger.WithFields(logrus.Fields{
-		"action":    "FetchBranchProtections",
+		"action":    "FetchProjectWebhooks",
 		"projectID": projectID,
 		"APIURL":    APIURL,
 	})
@@ -205,9 +361,9 @@ func FetchBranchProtections(projectID int, token string, APIURL string, conf *co
 		return nil, err
 	}
 
-	var allProtections []BranchProtection
+	allWebhooks := []WebhookInfo{}
 	var perPage int64 = 100
-	options := &gitlab.ListProtectedBranchesOptions{
+	options := &gitlab.ListProjectHooksOptions{
 		ListOptions: gitlab.ListOptions{
 			PerPage: perPage,
 		},
@@ -215,43 +371,181 @@ func FetchBranchProtections(projectID int, token string, APIURL string, conf *co
 
 	for page := int64(1); ; page++ {
 		options.Page = page
-		protections, _, err := glab.ProtectedBranches.ListProtectedBranches(projectID, options)
+		hooks, _, err := glab.Projects.ListProjectHooks(projectID, options)
 		if err != nil {
-			l.WithError(err).Warn("Failed to fetch branch protections")
+			l.WithError(err).Warn("Failed to fetch project webhooks")
 			return nil, err
 		}
 
-		for _, p := range protections {
-			bp := BranchProtection{
-				ProtectionPattern:         p.Name,
-				AllowForcePush:            p.AllowForcePush,
-				CodeOwnerApprovalRequired: p.CodeOwnerApprovalRequired,
+		for _, h := range hooks {
+			webhook := WebhookInfo{
+				ID:                    int(h.ID),
+				Name:                  h.Name,
+				EnableSSLVerification: h.EnableSSLVerification,
+			}
+			// Never keep the full URL, it may contain secrets
+			if hookURL, err := url.Parse(h.URL); err == nil {
+				webhook.Scheme = hookURL.Scheme
+				webhook.Host = strings.ToLower(hookURL.Hostname())
 			}
+			allWebhooks = append(allWebhooks, webhook)
+		}
 
-			// Extract access levels
-			for _, level := range p.PushAccessLevels {
-				bp.PushAccessLevels = append(bp.PushAccessLevels, BranchProtectionAccessLevel{
-					AccessLevel:            int(level.AccessLevel),
-					AccessLevelDescription: level.AccessLevelDescription,
-				})
+		if int64(len(hooks)) < perPage {
+			break
+		}
+	}
+
+	l.WithField("webhookCount", len(allWebhooks)).Debug("Fetched project webhooks")
+	return allWebhooks, nil
+}
+
+// FetchDeployTokens retrieves all deploy tokens of a project
+func FetchDeployTokens(projectID int, token string, APIURL string, conf *configuration.Configuration) ([]DeployTokenInfo, error) {
+	l := logger.WithFields(logrus.Fields{
+		"action":    "FetchDeployTokens",
+		"projectID": projectID,
+		"APIURL":    APIURL,
+	})
+
+	glab, err := GetNewGitlabClient(token, APIURL, conf)
+	if err != nil {
+		l.WithError(err).Error("Unable to get a Gitlab client")
+		return nil, err
+	}
+
+	allTokens := []DeployTokenInfo{}
+	var perPage int64 = 100
+	options := &gitlab.ListProjectDeployTokensOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: perPage,
+		},
+	}
+
+	for page := int64(1); ; page++ {
+		options.Page = page
+		deployTokens, _, err := glab.DeployTokens.ListProjectDeployTokens(projectID, options)
+		if err != nil {
+			l.WithError(err).Warn("Failed to fetch deploy tokens")
+			return nil, err
+		}
+
+		for _, t := range deployTokens {
+			// The token secret is not copied
+			allTokens = append(allTokens, DeployTokenInfo{
+				ID:        int(t.ID),
+				Name:      t.Name,
+				Username:  t.Username,
+				Scopes:    t.Scopes,
+				ExpiresAt: t.ExpiresAt,
+				Revoked:   t.Revoked,
+				Expired:   t.Expired,
+			})
+		}
+
+		if int64(len(deployTokens)) < perPage {
+			break
+		}
+	}
+
+	l.WithField("deployTokenCount", len(allTokens)).Debug("Fetched deploy tokens")
+	return allTokens, nil
+}
+
+// FetchProtectedEnvironments retrieves all protected environments of a project
+func FetchProtectedEnvironments(projectID int, token string, APIURL string, conf *configuration.Configuration) ([]ProtectedEnvironmentInfo, error) {
+	l := logger.WithFields(logrus.Fields{
+		"action":    "FetchProtectedEnvironments",
+		"projectID": projectID,
+		"APIURL":    APIURL,
+	})
+
+	glab, err := GetNewGitlabClient(token, APIURL, conf)
+	if err != nil {
+		l.WithError(err).Error("Unable to get a Gitlab client")
+		return nil, err
+	}
+
+	allEnvironments := []ProtectedEnvironmentInfo{}
+	var perPage int64 = 100
+	options := &gitlab.ListProtectedEnvironmentsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: perPage,
+		},
+	}
+
+	for page := int64(1); ; page++ {
+		options.Page = page
+		environments, _, err := glab.ProtectedEnvironments.ListProtectedEnvironments(projectID, options)
+		if err != nil {
+			l.WithError(err).Warn("Failed to fetch protected environments")
+			return nil, err
+		}
+
+		for _, e := range environments {
+			environment := ProtectedEnvironmentInfo{
+				Name:               e.Name,
+				DeployAccessLevels: []BranchProtectionAccessLevel{},
+				RequiredApprovals:  int(e.RequiredApprovalCount),
 			}
-			for _, level := range p.MergeAccessLevels {
-				bp.MergeAccessLevels = append(bp.MergeAccessLevels, BranchProtectionAccessLevel{
+			for _, level := range e.DeployAccessLevels {
+				if level == nil {
+					continue
+				}
+				environment.DeployAccessLevels = append(environment.DeployAccessLevels, BranchProtectionAccessLevel{
 					AccessLevel:            int(level.AccessLevel),
 					AccessLevelDescription: level.AccessLevelDescription,
+					UserID:                 int(level.UserID),
+					GroupID:                int(level.GroupID),
 				})
 			}
-
-			allProtections = append(allProtections, bp)
+			for _, rule := range e.ApprovalRules {
+				if rule != nil {
+					environment.RequiredApprovals += int(rule.RequiredApprovalCount)
+				}
+			}
+			allEnvironments = append(allEnvironments, environment)
 		}
 
-		if int64(len(protections)) < perPage {
+		if int64(len(environments)) < perPage {
 			break
 		}
 	}
 
-	l.WithField("protectionCount", len(allProtections)).Debug("Fetched branch protections")
-	return allProtections, nil
+	l.WithField("protectedEnvironmentCount", len(allEnvironments)).Debug("Fetched protected environments")
+	return allEnvironments, nil
+}
+
+// FetchProjectPushRules retrieves the push rules of a project. Projects
+// without push rules get empty rules.
+func FetchProjectPushRules(projectID int, token string, APIURL string, conf *configuration.Configuration) (*PushRulesInfo, error) {
+	l := logger.WithFields(logrus.Fields{
+		"action":    "FetchProjectPushRules",
+		"projectID": projectID,
+		"APIURL":    APIURL,
+	})
+
+	glab, err := GetNewGitlabClient(token, APIURL, conf)
+	if err != nil {
+		l.WithError(err).Error("Unable to get a Gitlab client")
+		return nil, err
+	}
+
+	rules, _, err := glab.Projects.GetProjectPushRules(projectID)
+	if err != nil {
+		l.WithError(err).Warn("Failed to fetch push rules")
+		return nil, err
+	}
+
+	pushRules := &PushRulesInfo{}
+	if rules != nil {
+		pushRules.AuthorEmailRegex = rules.AuthorEmailRegex
+		pushRules.CommitMessageRegex = rules.CommitMessageRegex
+		pushRules.RejectUnsignedCommits = rules.RejectUnsignedCommits
+	}
+
+	l.Debug("Fetched push rules")
+	return pushRules, nil
 }
 
 // FetchProjectMRApprovalRules retrieves MR approval rules for a project
@@ -302,12 +596,26 @@ func FetchProjectMRApprovalSettings(projectID int, token string, APIURL string,
 	return settings, nil
 }
 
-// FetchProjectMembers retrieves all members of a project
+// FetchProjectMembers retrieves all members of a project, including members
+// inherited from parent groups
 func FetchProjectMembers(projectID int, token string, APIURL string, conf *configuration.Configuration) ([]GitlabMemberInfo, error) {
+	return fetchProjectMembers(projectID, token, APIURL, conf, true)
+}
+
+// FetchDirectProjectMembers retrieves the members added directly to a
+// project, without members inherited from parent groups
+func FetchDirectProjectMembers(projectID int, token string, APIURL string, conf *configuration.Configuration) ([]GitlabMemberInfo, error) {
+	return fetchProjectMembers(projectID, token, APIURL, conf, false)
+}
+
+// fetchProjectMembers retrieves the members of a project, inherited ones
+// included if inherited is true. Bot users are skipped.
+func fetchProjectMembers(projectID int, token string, APIURL string, conf *configuration.Configuration, inherited bool) ([]GitlabMemberInfo, error) {
 	l := logger.WithFields(logrus.Fields{
 		"action":    "FetchProjectMembers",
 		"projectID": projectID,
 		"APIURL":    APIURL,
+		"inherited": inherited,
 	})
 
 	glab, err := GetNewGitlabClient(token, APIURL, conf)
@@ -316,6 +624,11 @@ func FetchProjectMembers(projectID int, token string, APIURL string, conf *confi
 		return nil, err
 	}
 
+	listMembers := glab.ProjectMembers.ListProjectMembers
+	if inherited {
+		listMembers = glab.ProjectMembers.ListAllProjectMembers
+	}
+
 	var allMembers []GitlabMemberInfo
 	var perPage int64 = 100
 	options := &gitlab.ListProjectMembersOptions{
@@ -326,7 +639,7 @@ func FetchProjectMembers(projectID int, token string, APIURL string, conf *confi
 
 	for page := int64(1); ; page++ {
 		options.Page = page
-		members, _, err := glab.ProjectMembers.ListAllProjectMembers(projectID, options)
+		members, _, err := listMembers(projectID, options)
 		if err != nil {
 			l.WithError(err).Warn("Failed to fetch project members")
 			return nil, err
@@ -416,99 +729,6 @@ func FetchGroupMembers(groupID int, token string, APIURL string, conf *configura
 	return allMembers, nil
 }
 
-// FetchProjectBranchData fetches branches and their protection settings
-func FetchProjectBranchData(projectPath string, token string, APIURL string, conf *configuration.Configuration) ([]string, []BranchProtection, error) {
-	l := logger.WithFields(logrus.Fields{
-		"action":      "FetchProjectBranchData",
-		"projectPath": projectPath,
-		"APIURL":      APIURL,
-	})
-
-	glab, err := GetNewGitlabClient(token, APIURL, conf)
-	if err != nil {
-		l.WithError(err).Error("Unable to get a Gitlab client")
-		return nil, nil, err
-	}
-
-	// Fetch branches
-	var allBranches []string
-	var perPage int64 = 100
-	branchOptions := &gitlab.ListBranchesOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: perPage,
-		},
-	}
-
-	for page := int64(1); ; page++ {
-		branchOptions.Page = page
-		branches, _, err := glab.Branches.ListBranches(projectPath, branchOptions)
-		if err != nil {
-			l.WithError(err).Error("Failed to fetch branches")
-			return nil, nil, err
-		}
-
-		for _, branch := range branches {
-			allBranches = append(allBranches, branch.Name)
-		}
-
-		if int64(len(branches)) < perPage {
-			break
-		}
-	}
-
-	// Fetch branch protections
-	var allProtections []BranchProtection
-	protOptions := &gitlab.ListProtectedBranchesOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: perPage,
-		},
-	}
-
-	for page := int64(1); ; page++ {
-		protOptions.Page = page
-		protections, _, err := glab.ProtectedBranches.ListProtectedBranches(projectPath, protOptions)
-		if err != nil {
-			l.WithError(err).Warn("Failed to fetch branch protections (may require premium)")
-			// Return branches without protections
-			return allBranches, nil, nil
-		}
-
-		for _, p := range protections {
-			bp := BranchProtection{
-				ProtectionPattern:         p.Name,
-				AllowForcePush:            p.AllowForcePush,
-				CodeOwnerApprovalRequired: p.CodeOwnerApprovalRequired,
-			}
-
-			for _, level := range p.PushAccessLevels {
-				bp.PushAccessLevels = append(bp.PushAccessLevels, BranchProtectionAccessLevel{
-					AccessLevel:            int(level.AccessLevel),
-					AccessLevelDescription: level.AccessLevelDescription,
-				})
-			}
-			for _, level := range p.MergeAccessLevels {
-				bp.MergeAccessLevels = append(bp.MergeAccessLevels, BranchProtectionAccessLevel{
-					AccessLevel:            int(level.AccessLevel),
-					AccessLevelDescription: level.AccessLevelDescription,
-				})
-			}
-
-			allProtections = append(allProtections, bp)
-		}
-
-		if int64(len(protections)) < perPage {
-			break
-		}
-	}
-
-	l.WithFields(logrus.Fields{
-		"branchCount":     len(allBranches),
-		"protectionCount": len(allProtections),
-	}).Debug("Fetched branch data")
-
-	return allBranches, allProtections, nil
-}
-
 // GetGroupFullPath returns gitlab group fullPath from id
 func GetGroupFullPath(groupID int, token string, APIURL string, conf *configuration.Configuration) (string, error) {
 	l := logrus.WithFields(logrus.Fields{
@@ -594,50 +814,25 @@ func RepoHasFolder(projectPath string, folderPath string, token string, APIURL s
 	return err == nil && len(tree) > 0
 }
 
-// FetchRepositoryBranches fetches all branches from a repository, respecting a maxPage limit
-func FetchRepositoryBranches(client *gitlab.Client, projectID string, maxPage int) ([]*gitlab.Branch, error) {
-	l := logrus.WithFields(logrus.Fields{
-		"action":    "FetchRepositoryBranches",
-		"projectID": projectID,
-		"maxPage":   maxPage,
-	})
-
-	var allBranches []*gitlab.Branch
-	page := int64(1)
-
-	for int(page) <= maxPage {
-		options := &gitlab.ListBranchesOptions{}
-		options.Page = page
-		options.PerPage = 100
-
-		l.WithField("page", page).Debug("Fetching branches from GitLab")
-
-		branches, resp, err := client.Branches.ListBranches(projectID, options)
-		if err != nil {
-			l.WithError(err).Error("Failed to fetch branches from GitLab")
-			return nil, err
-		}
-
-		allBranches = append(allBranches, branches...)
-
-		// Break if no more pages are available
-		if resp.NextPage == 0 {
-			break
-		}
-
-		page = resp.NextPage
-	}
-
-	l.WithField("totalBranchCount", len(allBranches)).Debug("Fetched all branches successfully")
-	return allBranches, nil
-}
+// instanceEnterpriseCache holds the edition of each GitLab instance already fetched
+var (
+	instanceEnterpriseCache   = map[string]bool{}
+	instanceEnterpriseCacheMu sync.Mutex
+)
 
-// IsGitlabInstanceEnterprise checks if the GitLab instance is enterprise edition
+// IsGitlabInstanceEnterprise checks if the GitLab instance is enterprise edition.
+// The edition is fetched once per instance and cached for the rest of the run.
 func IsGitlabInstanceEnterprise(token, APIURL string, conf *configuration.Configuration) (bool, error) {
 	l := logger.WithFields(logrus.Fields{
 		"action": "IsGitlabInstanceEnterprise",
 	})
 
+	instanceEnterpriseCacheMu.Lock()
+	defer instanceEnterpriseCacheMu.Unlock()
+	if enterprise, ok := instanceEnterpriseCache[APIURL]; ok {
+		return enterprise, nil
+	}
+
 	glab, err := GetNewGitlabClient(token, APIURL, conf)
 	if err != nil {
 		l.WithError(err).Error("Failed to create GitLab client")
@@ -650,6 +845,7 @@ func IsGitlabInstanceEnterprise(token, APIURL string, conf *configuration.Config
 		return false, apiErr
 	}
 
+	instanceEnterpriseCache[APIURL] = metadata.Enterprise
 	return metadata.Enterprise, nil
 }
 
@@ -677,7 +873,10 @@ func GetGitlabInstanceVersion(token, APIURL string, conf *configuration.Configur
 }
 
 // IsVersionGreaterOrEqual compares GitLab version strings
-// Returns true if the given version is greater than or equal to the required version
+// Returns true if the given version is greater than or equal to the required version.
+// Pre-release and build suffixes (e.g., "-ee" or "+ce.0") are ignored, any
+// number of components is compared and missing ones are zeros ("17.6" equals
+// "17.6.0"). Returns false if a version can't be parsed.
 func IsVersionGreaterOrEqual(version, requiredVersion string) bool {
 	l := logrus.WithFields(logrus.Fields{
 		"action":          "IsVersionGreaterOrEqual",
@@ -685,49 +884,27 @@ func IsVersionGreaterOrEqual(version, requiredVersion string) bool {
 		"requiredVersion": requiredVersion,
 	})
 
-	// Remove any suffix (like "-ee" in "17.6.0-ee")
-	version = strings.Split(version, "-")[0]
-	requiredVersion = strings.Split(requiredVersion, "-")[0]
-
-	// Split version strings into components
-	vParts := strings.Split(version, ".")
-	reqParts := strings.Split(requiredVersion, ".")
-
-	// Parse up to 3 components (major.minor.patch)
-	// Fill with zeros if missing
-	vComponents := make([]int, 3)
-	reqComponents := make([]int, 3)
-
-	// Parse current version components
-	for i := 0; i < len(vParts) && i < 3; i++ {
-		num, err := strconv.Atoi(vParts[i])
-		if err != nil {
-			l.WithError(err).WithField("component", vParts[i]).Warn("Failed to parse version component")
-			return false
-		}
-		vComponents[i] = num
+	v, err := gover.NewVersion(version)
+	if err != nil {
+		l.WithError(err).Warn("Failed to parse version")
+		return false
 	}
-
-	// Parse required version components
-	for i := 0; i < len(reqParts) && i < 3; i++ {
-		num, err := strconv.Atoi(reqParts[i])
-		if err != nil {
-			l.WithError(err).WithField("component", reqParts[i]).Warn("Failed to parse required version component")
-			return false
-		}
-		reqComponents[i] = num
+	required, err := gover.NewVersion(requiredVersion)
+	if err != nil {
+		l.WithError(err).Warn("Failed to parse required version")
+		return false
 	}
 
-	// Compare major.minor.patch in sequence
-	for i := 0; i < 3; i++ {
-		if vComponents[i] > reqComponents[i] {
-			return true
-		}
-		if vComponents[i] < reqComponents[i] {
-			return false
-		}
-	}
+	return versionSegmentsOnly(v).GreaterThanOrEqual(versionSegmentsOnly(required))
+}
 
-	// All components are equal
-	return true
+// versionSegmentsOnly returns a version without its pre-release and build
+// metadata (e.g., "17.6.0-ee" gives 17.6.0). Unlike Core, all segments are
+// kept.
+func versionSegmentsOnly(v *gover.Version) *gover.Version {
+	segments := []string{}
+	for _, segment := range v.Segments64() {
+		segments = append(segments, strconv.FormatInt(segment, 10))
+	}
+	return gover.Must(gover.NewVersion(strings.Join(segments, ".")))
 }
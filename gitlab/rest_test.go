@@ -0,0 +1,52 @@
+package gitlab
+
+import "testing"
+
+func TestIsVersionGreaterOrEqual(t *testing.T) {
+	tests := []struct {
+		version, required string
+		expected          bool
+	}{
+		// 3 components
+		{"17.6.0", "17.6.0", true},
+		{"17.6.1", "17.6.0", true},
+		{"17.5.9", "17.6.0", false},
+		{"18.0.0", "17.10.0", true},
+		{"17.10.0", "17.9.0", true},
+		{"17.6", "17.6.0", true},
+		{"17", "17.0.1", false},
+		{"17.10", "17.9", true},
+
+		// 4 components
+		{"17.6.1.2", "17.6.1", true},
+		{"17.6.1.2", "17.6.1.3", false},
+		{"17.6.1.10", "17.6.1.9", true},
+		{"17.6.1", "17.6.1.1", false},
+		{"17.6.0", "17.6.0.0", true},
+
+		// Pre-release suffixes are ignored
+		{"17.6.0-ee", "17.6.0", true},
+		{"17.6.0-pre", "17.6.0", true},
+		{"17.5.0-ee", "17.6.0", false},
+		{"17.6.1-rc2", "17.6.1-ee", true},
+		{"16.11.5-ee", "17.0.0", false},
+
+		// Build metadata is ignored
+		{"17.6.0+ce.0", "17.6.0", true},
+		{"17.6.0-ee+build.5", "17.6.1", false},
+		{"17.6.1.2+build", "17.6.1.2", true},
+
+		// Unparsable versions
+		{"", "17.6.0", false},
+		{"unknown", "17.6.0", false},
+		{"17.6.0", "not-a-version", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+" >= "+tt.required, func(t *testing.T) {
+			if got := IsVersionGreaterOrEqual(tt.version, tt.required); got != tt.expected {
+				t.Errorf("IsVersionGreaterOrEqual(%q, %q) = %v, want %v", tt.version, tt.required, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,535 @@
+package gitlab
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RulesEvaluation is the outcome of evaluating the rules (or only/except) of
+// a job or of the workflow
+type RulesEvaluation struct {
+	// Run is true if the job (or pipeline) would run
+	Run bool
+
+	// Reason explains why the job (or pipeline) would or would not run
+	Reason string
+
+	// Variables are the variables defined by the matching rule, if any
+	Variables map[string]string
+}
+
+// EvaluateRules evaluates a rules: list against a set of variables.
+// Rules are evaluated in order and the first matching rule decides: the job
+// runs unless that rule has 'when: never'. If no rule matches, the job doesn't
+// run. 'changes' and 'exists' clauses cannot be evaluated statically and are
+// assumed to match.
+func EvaluateRules(rules interface{}, vars map[string]string) (RulesEvaluation, error) {
+	ruleList, ok := rules.([]interface{})
+	if !ok {
+		return RulesEvaluation{}, fmt.Errorf("rules must be a list, got %T", rules)
+	}
+
+	for index, ruleInterface := range ruleList {
+		rule, ok := ruleInterface.(map[interface{}]interface{})
+		if !ok {
+			return RulesEvaluation{}, fmt.Errorf("rule #%d must be a map, got %T", index+1, ruleInterface)
+		}
+
+		condition := ""
+		if ifInterface, ok := rule["if"]; ok {
+			condition, ok = ifInterface.(string)
+			if !ok {
+				return RulesEvaluation{}, fmt.Errorf("rule #%d: 'if' must be a string, got %T", index+1, ifInterface)
+			}
+		}
+
+		if condition != "" {
+			matches, err := EvaluateRuleExpression(condition, vars)
+			if err != nil {
+				return RulesEvaluation{}, fmt.Errorf("rule #%d: %w", index+1, err)
+			}
+			if !matches {
+				continue
+			}
+		}
+
+		when, _ := rule["when"].(string)
+		ruleVariables := map[string]string{}
+		if variables, ok := rule["variables"].(map[interface{}]interface{}); ok {
+			for key, value := range variables {
+				if stringValue, err := GetVariableValue(value); err == nil {
+					ruleVariables[fmt.Sprintf("%v", key)] = stringValue
+				}
+			}
+		}
+
+		description := fmt.Sprintf("rule #%d", index+1)
+		if condition != "" {
+			description = fmt.Sprintf("rule #%d (%s)", index+1, condition)
+		}
+
+		if when == "never" {
+			return RulesEvaluation{Run: false, Reason: fmt.Sprintf("%s matched with 'when: never'", description)}, nil
+		}
+		return RulesEvaluation{Run: true, Reason: fmt.Sprintf("%s matched", description), Variables: ruleVariables}, nil
+	}
+
+	return RulesEvaluation{Run: false, Reason: "no rule matched"}, nil
+}
+
+// pipelineSourceKeywords maps only/except keywords to pipeline sources
+var pipelineSourceKeywords = map[string]string{
+	"api":                    "api",
+	"chat":                   "chat",
+	"external":               "external",
+	"external_pull_requests": "external_pull_request_event",
+	"merge_requests":         "merge_request_event",
+	"pipelines":              "pipeline",
+	"pushes":                 "push",
+	"schedules":              "schedule",
+	"triggers":               "trigger",
+	"web":                    "web",
+}
+
+// EvaluateOnlyExcept evaluates the only/except keywords of a job against a set
+// of variables. Only refs and variables are supported; other clauses
+// (changes, kubernetes) are assumed to match. A job without only/except runs
+// on branches and tags, as GitLab does.
+func EvaluateOnlyExcept(only, except interface{}, vars map[string]string) (RulesEvaluation, error) {
+	if only == nil {
+		only = []interface{}{"branches", "tags"}
+	}
+
+	onlyMatches, err := matchOnlyExceptClause(only, vars)
+	if err != nil {
+		return RulesEvaluation{}, fmt.Errorf("only: %w", err)
+	}
+	if !onlyMatches {
+		return RulesEvaluation{Run: false, Reason: "'only' does not match"}, nil
+	}
+
+	if except != nil {
+		exceptMatches, err := matchOnlyExceptClause(except, vars)
+		if err != nil {
+			return RulesEvaluation{}, fmt.Errorf("except: %w", err)
+		}
+		if exceptMatches {
+			return RulesEvaluation{Run: false, Reason: "'except' matches"}, nil
+		}
+	}
+
+	return RulesEvaluation{Run: true, Reason: "'only'/'except' match"}, nil
+}
+
+// matchOnlyExceptClause reports whether an only/except clause matches
+func matchOnlyExceptClause(clause interface{}, vars map[string]string) (bool, error) {
+	switch c := clause.(type) {
+	case string:
+		return matchRefs([]interface{}{c}, vars)
+	case []interface{}:
+		return matchRefs(c, vars)
+	case map[interface{}]interface{}:
+		matches := true
+		if refs, ok := c["refs"]; ok {
+			refList, ok := refs.([]interface{})
+			if !ok {
+				refList = []interface{}{refs}
+			}
+			refsMatch, err := matchRefs(refList, vars)
+			if err != nil {
+				return false, err
+			}
+			matches = matches && refsMatch
+		}
+		if variables, ok := c["variables"]; ok {
+			variableList, ok := variables.([]interface{})
+			if !ok {
+				return false, fmt.Errorf("variables must be a list, got %T", variables)
+			}
+			variablesMatch := false
+			for _, expression := range variableList {
+				expressionString, ok := expression.(string)
+				if !ok {
+					return false, fmt.Errorf("variables expression must be a string, got %T", expression)
+				}
+				result, err := EvaluateRuleExpression(expressionString, vars)
+				if err != nil {
+					return false, err
+				}
+				if result {
+					variablesMatch = true
+					break
+				}
+			}
+			matches = matches && variablesMatch
+		}
+		return matches, nil
+	}
+
+	return false, fmt.Errorf("unsupported clause type %T", clause)
+}
+
+// matchRefs reports whether one of the only/except refs matches the simulated
+// ref and pipeline source
+func matchRefs(refs []interface{}, vars map[string]string) (bool, error) {
+	refName := vars["CI_COMMIT_REF_NAME"]
+	_, isTag := vars["CI_COMMIT_TAG"]
+	source := vars["CI_PIPELINE_SOURCE"]
+
+	for _, refInterface := range refs {
+		ref, ok := refInterface.(string)
+		if !ok {
+			return false, fmt.Errorf("ref must be a string, got %T", refInterface)
+		}
+
+		switch {
+		case ref == "branches":
+			if !isTag && source != "merge_request_event" {
+				return true, nil
+			}
+		case ref == "tags":
+			if isTag {
+				return true, nil
+			}
+		case pipelineSourceKeywords[ref] != "":
+			if source == pipelineSourceKeywords[ref] {
+				return true, nil
+			}
+		case len(ref) > 1 && strings.HasPrefix(ref, "/"):
+			re, err := compileRuleRegex(ref)
+			if err != nil {
+				return false, err
+			}
+			if re.MatchString(refName) {
+				return true, nil
+			}
+		default:
+			// A ref may be suffixed with @namespace/project, which we ignore
+			name := strings.SplitN(ref, "@", 2)[0]
+			if name == refName {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+////////////////////////////
+// Expression evaluation  //
+////////////////////////////
+
+type ruleTokenType int
+
+const (
+	ruleTokenVariable ruleTokenType = iota
+	ruleTokenString
+	ruleTokenRegex
+	ruleTokenNull
+	ruleTokenOperator
+	ruleTokenOpenParen
+	ruleTokenCloseParen
+)
+
+type ruleToken struct {
+	kind  ruleTokenType
+	value string
+}
+
+// ruleValue is the value of an operand in a rule expression. A nil value is
+// used for undefined variables and null.
+type ruleValue struct {
+	value   *string
+	isRegex bool
+}
+
+// EvaluateRuleExpression evaluates a rules:if expression against a set of
+// variables. It supports variables ($VAR, ${VAR}), string literals, null,
+// regex literals, the ==, !=, =~ and !~ operators, && and || and parentheses.
+func EvaluateRuleExpression(expression string, vars map[string]string) (bool, error) {
+	tokens, err := tokenizeRuleExpression(expression)
+	if err != nil {
+		return false, fmt.Errorf("invalid expression '%s': %w", expression, err)
+	}
+
+	parser := &ruleParser{tokens: tokens, vars: vars}
+	result, err := parser.parseOr()
+	if err != nil {
+		return false, fmt.Errorf("invalid expression '%s': %w", expression, err)
+	}
+	if parser.pos != len(tokens) {
+		return false, fmt.Errorf("invalid expression '%s': unexpected token '%s'", expression, tokens[parser.pos].value)
+	}
+
+	return result, nil
+}
+
+// tokenizeRuleExpression splits a rule expression into tokens
+func tokenizeRuleExpression(expression string) ([]ruleToken, error) {
+	tokens := []ruleToken{}
+	lastIsOperand := false
+
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, ruleToken{kind: ruleTokenOpenParen, value: "("})
+			lastIsOperand = false
+			i++
+		case c == ')':
+			tokens = append(tokens, ruleToken{kind: ruleTokenCloseParen, value: ")"})
+			lastIsOperand = true
+			i++
+		case c == '$':
+			j := i + 1
+			braced := j < len(expression) && expression[j] == '{'
+			if braced {
+				j++
+			}
+			start := j
+			for j < len(expression) && isRuleVariableChar(expression[j]) {
+				j++
+			}
+			if j == start {
+				return nil, fmt.Errorf("empty variable name at position %d", i)
+			}
+			name := expression[start:j]
+			if braced {
+				if j >= len(expression) || expression[j] != '}' {
+					return nil, fmt.Errorf("unterminated variable at position %d", i)
+				}
+				j++
+			}
+			tokens = append(tokens, ruleToken{kind: ruleTokenVariable, value: name})
+			lastIsOperand = true
+			i = j
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expression[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, ruleToken{kind: ruleTokenString, value: expression[i+1 : i+1+end]})
+			lastIsOperand = true
+			i = i + end + 2
+		case c == '/' && !lastIsOperand:
+			j := i + 1
+			for j < len(expression) && expression[j] != '/' {
+				if expression[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(expression) {
+				return nil, fmt.Errorf("unterminated regex at position %d", i)
+			}
+			j++
+			for j < len(expression) && (expression[j] == 'i' || expression[j] == 'm' || expression[j] == 's') {
+				j++
+			}
+			tokens = append(tokens, ruleToken{kind: ruleTokenRegex, value: expression[i:j]})
+			lastIsOperand = true
+			i = j
+		case strings.HasPrefix(expression[i:], "=="), strings.HasPrefix(expression[i:], "!="),
+			strings.HasPrefix(expression[i:], "=~"), strings.HasPrefix(expression[i:], "!~"),
+			strings.HasPrefix(expression[i:], "&&"), strings.HasPrefix(expression[i:], "||"):
+			tokens = append(tokens, ruleToken{kind: ruleTokenOperator, value: expression[i : i+2]})
+			lastIsOperand = false
+			i += 2
+		case strings.HasPrefix(expression[i:], "null"):
+			tokens = append(tokens, ruleToken{kind: ruleTokenNull, value: "null"})
+			lastIsOperand = true
+			i += 4
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' at position %d", c, i)
+		}
+	}
+
+	return tokens, nil
+}
+
+// isRuleVariableChar reports whether a character can be part of a variable name
+func isRuleVariableChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_'
+}
+
+// ruleParser is a recursive descent parser evaluating rule expressions
+type ruleParser struct {
+	tokens []ruleToken
+	pos    int
+	vars   map[string]string
+}
+
+func (p *ruleParser) peek() *ruleToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *ruleParser) parseOr() (bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return false, err
+	}
+	for {
+		token := p.peek()
+		if token == nil || token.kind != ruleTokenOperator || token.value != "||" {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return false, err
+		}
+		left = left || right
+	}
+}
+
+func (p *ruleParser) parseAnd() (bool, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return false, err
+	}
+	for {
+		token := p.peek()
+		if token == nil || token.kind != ruleTokenOperator || token.value != "&&" {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseComparison()
+		if err != nil {
+			return false, err
+		}
+		left = left && right
+	}
+}
+
+func (p *ruleParser) parseComparison() (bool, error) {
+	token := p.peek()
+	if token == nil {
+		return false, fmt.Errorf("unexpected end of expression")
+	}
+
+	if token.kind == ruleTokenOpenParen {
+		p.pos++
+		result, err := p.parseOr()
+		if err != nil {
+			return false, err
+		}
+		closing := p.peek()
+		if closing == nil || closing.kind != ruleTokenCloseParen {
+			return false, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return result, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return false, err
+	}
+
+	operator := p.peek()
+	if operator == nil || operator.kind != ruleTokenOperator || operator.value == "&&" || operator.value == "||" {
+		// A lone operand is true if it is defined and not empty
+		return left.value != nil && *left.value != "", nil
+	}
+	p.pos++
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return false, err
+	}
+
+	switch operator.value {
+	case "==":
+		return ruleValuesEqual(left, right), nil
+	case "!=":
+		return !ruleValuesEqual(left, right), nil
+	case "=~":
+		return ruleValueMatches(left, right)
+	case "!~":
+		matches, err := ruleValueMatches(left, right)
+		return !matches, err
+	}
+
+	return false, fmt.Errorf("unsupported operator '%s'", operator.value)
+}
+
+func (p *ruleParser) parseOperand() (ruleValue, error) {
+	token := p.peek()
+	if token == nil {
+		return ruleValue{}, fmt.Errorf("unexpected end of expression")
+	}
+	p.pos++
+
+	switch token.kind {
+	case ruleTokenVariable:
+		if value, ok := p.vars[token.value]; ok {
+			return ruleValue{value: &value}, nil
+		}
+		return ruleValue{}, nil
+	case ruleTokenString:
+		value := token.value
+		return ruleValue{value: &value}, nil
+	case ruleTokenRegex:
+		value := token.value
+		return ruleValue{value: &value, isRegex: true}, nil
+	case ruleTokenNull:
+		return ruleValue{}, nil
+	}
+
+	return ruleValue{}, fmt.Errorf("unexpected token '%s'", token.value)
+}
+
+// ruleValuesEqual compares two operands, null being only equal to null
+func ruleValuesEqual(left, right ruleValue) bool {
+	if left.value == nil || right.value == nil {
+		return left.value == nil && right.value == nil
+	}
+	return *left.value == *right.value
+}
+
+// ruleValueMatches matches the left operand against the regex of the right
+// operand. The right operand may be a regex literal or a variable holding one.
+func ruleValueMatches(left, right ruleValue) (bool, error) {
+	if right.value == nil {
+		return false, nil
+	}
+	pattern := *right.value
+	if !right.isRegex && !strings.HasPrefix(pattern, "/") {
+		return false, fmt.Errorf("right operand of a regex match must be a regex, got '%s'", pattern)
+	}
+
+	re, err := compileRuleRegex(pattern)
+	if err != nil {
+		return false, err
+	}
+	if left.value == nil {
+		return false, nil
+	}
+	return re.MatchString(*left.value), nil
+}
+
+// compileRuleRegex compiles a /pattern/flags regex as used in GitLab CI rules
+func compileRuleRegex(pattern string) (*regexp.Regexp, error) {
+	end := strings.LastIndex(pattern, "/")
+	if !strings.HasPrefix(pattern, "/") || end <= 0 {
+		return nil, fmt.Errorf("invalid regex '%s'", pattern)
+	}
+
+	body := pattern[1:end]
+	flags := pattern[end+1:]
+	if flags != "" {
+		body = "(?" + flags + ")" + body
+	}
+
+	re, err := regexp.Compile(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex '%s': %w", pattern, err)
+	}
+	return re, nil
+}
@@ -1,12 +1,16 @@
 package gitlab
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/getplumber/plumber/configuration"
 	"github.com/sirupsen/logrus"
@@ -242,10 +246,27 @@ func ParseGitlabCIJob(jobContent interface{}) (*GitlabJob, error) {
 	return &job, nil
 }
 
+// variableReferenceRegex matches variable references: $VAR, ${VAR} and %VAR%
+var variableReferenceRegex = regexp.MustCompile(`(\$[a-zA-Z_][a-zA-Z0-9_]*|\${[a-zA-Z_][a-zA-Z0-9_]*}|%[a-zA-Z_][a-zA-Z0-9_]*%)`)
+
+// ExtractVariableNames returns the names of the variables referenced in the
+// input string, without duplicates and in order of appearance
+func ExtractVariableNames(input string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, match := range variableReferenceRegex.FindAllString(input, -1) {
+		name := strings.Trim(match, "${}%")
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // ReplaceVariable replaces variables in the input string recursively up to 5 levels
 func ReplaceVariable(input string, project, group, instance, job, defaultJob, predefined map[string]string) string {
-	regex := `(\$[a-zA-Z_][a-zA-Z0-9_]*|\${[a-zA-Z_][a-zA-Z0-9_]*}|%[a-zA-Z_][a-zA-Z0-9_]*%)`
-	r := regexp.MustCompile(regex)
+	r := variableReferenceRegex
 
 	resolveVariables := func(input string) string {
 		return r.ReplaceAllStringFunc(input, func(match string) string {
@@ -298,8 +319,7 @@ func IsRunningInCI() bool {
 // ReplaceVariableFromEnv replaces variables in the input string using environment variables
 // This is used when running in CI mode where all variables are available in the environment
 func ReplaceVariableFromEnv(input string) string {
-	regex := `(\$[a-zA-Z_][a-zA-Z0-9_]*|\${[a-zA-Z_][a-zA-Z0-9_]*}|%[a-zA-Z_][a-zA-Z0-9_]*%)`
-	r := regexp.MustCompile(regex)
+	r := variableReferenceRegex
 
 	resolveFromEnv := func(input string) string {
 		return r.ReplaceAllStringFunc(input, func(match string) string {
@@ -331,8 +351,15 @@ func ReplaceVariableFromEnv(input string) string {
 
 // GetImageName gets the image name from an interface parsed from gitlab ci file
 func GetImageName(imageInterface interface{}) (string, error) {
+	image, err := GetImage(imageInterface)
+	return image.Name, err
+}
+
+// GetImage gets the image declaration from an interface parsed from gitlab ci
+// file. An image declared as a simple string only has a name.
+func GetImage(imageInterface interface{}) (Image, error) {
 	l := logrus.WithFields(logrus.Fields{
-		"action": "GetImageName",
+		"action": "GetImage",
 	})
 
 	switch image := imageInterface.(type) {
@@ -345,7 +372,7 @@ func GetImageName(imageInterface interface{}) (string, error) {
 				"converted": "json-safe",
 				"image":     toJSONSafeMap(image),
 			}).Error("Could not marshal the image")
-			return "", err
+			return Image{}, err
 		}
 		err = yaml.Unmarshal(yamlData, &imageStruct)
 		if err != nil {
@@ -354,17 +381,17 @@ func GetImageName(imageInterface interface{}) (string, error) {
 				"image":     toJSONSafeMap(image),
 				"yamlImage": string(yamlData),
 			}).Error("Could not unmarshal the image")
-			return "", err
+			return Image{}, err
 		}
-		return imageStruct.Name, nil
+		return imageStruct, nil
 
 	case string:
 		l.WithField("image", image).Debug("Found an image declaration as simple string")
-		return image, nil
+		return Image{Name: image}, nil
 
 	case nil:
 		l.Debug("No image declaration")
-		return "", nil
+		return Image{}, nil
 
 	default:
 		l.WithFields(logrus.Fields{
@@ -372,10 +399,29 @@ func GetImageName(imageInterface interface{}) (string, error) {
 			"imageType": fmt.Sprintf("%T", image),
 			"image":     toJSONSafeMap(image),
 		}).Error("Found an image with unknown type")
-		return "", nil
+		return Image{}, nil
 	}
 }
 
+// GetVariableExpand reports whether the references in a variable parsed from
+// gitlab ci file are expanded. Only the map form can disable the expansion.
+func GetVariableExpand(valueInterface interface{}) bool {
+	value, ok := valueInterface.(map[interface{}]interface{})
+	if !ok {
+		return true
+	}
+
+	currentVariable := CIConfVariable{}
+	yamlData, err := yaml.Marshal(value)
+	if err != nil {
+		return true
+	}
+	if err := yaml.Unmarshal(yamlData, &currentVariable); err != nil || currentVariable.Expand == nil {
+		return true
+	}
+	return *currentVariable.Expand
+}
+
 // GetVariableValue gets the variable value from an interface parsed from gitlab ci file
 func GetVariableValue(valueInterface interface{}) (string, error) {
 	l := logrus.WithFields(logrus.Fields{
@@ -469,6 +515,314 @@ func GetExtends(extendsInterface interface{}) ([]string, error) {
 	}
 }
 
+// GetCaches gets the cache entries from an interface parsed from gitlab ci
+// file. A cache can be declared as a map or as a list of maps.
+func GetCaches(cacheInterface interface{}) ([]Cache, error) {
+	l := logrus.WithFields(logrus.Fields{
+		"action": "GetCaches",
+	})
+
+	switch cache := cacheInterface.(type) {
+	case map[interface{}]interface{}:
+		cacheStruct := Cache{}
+		yamlData, err := yaml.Marshal(cache)
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"converted": "json-safe",
+				"cache":     toJSONSafeMap(cache),
+			}).Error("Could not marshal the cache")
+			return []Cache{}, err
+		}
+		err = yaml.Unmarshal(yamlData, &cacheStruct)
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"converted": "json-safe",
+				"cache":     toJSONSafeMap(cache),
+				"yamlCache": string(yamlData),
+			}).Error("Could not unmarshal the cache")
+			return []Cache{}, err
+		}
+		return []Cache{cacheStruct}, nil
+
+	case []interface{}:
+		caches := []Cache{}
+		for _, item := range cache {
+			itemCaches, err := GetCaches(item)
+			if err != nil {
+				return []Cache{}, err
+			}
+			caches = append(caches, itemCaches...)
+		}
+		return caches, nil
+
+	case nil:
+		l.Debug("No cache declaration")
+		return []Cache{}, nil
+
+	default:
+		l.WithFields(logrus.Fields{
+			"converted": "json-safe",
+			"cacheType": fmt.Sprintf("%T", cache),
+			"cache":     toJSONSafeMap(cache),
+		}).Error("Found a cache with unknown type")
+		return []Cache{}, nil
+	}
+}
+
+// GetArtifacts gets the artifacts declaration from an interface parsed from
+// gitlab ci file. It returns nil if there is no artifacts declaration.
+func GetArtifacts(artifactsInterface interface{}) (*Artifacts, error) {
+	l := logrus.WithFields(logrus.Fields{
+		"action": "GetArtifacts",
+	})
+
+	switch artifacts := artifactsInterface.(type) {
+	case map[interface{}]interface{}:
+		artifactsStruct := Artifacts{}
+		yamlData, err := yaml.Marshal(artifacts)
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"converted": "json-safe",
+				"artifacts": toJSONSafeMap(artifacts),
+			}).Error("Could not marshal the artifacts")
+			return nil, err
+		}
+		err = yaml.Unmarshal(yamlData, &artifactsStruct)
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"converted":     "json-safe",
+				"artifacts":     toJSONSafeMap(artifacts),
+				"yamlArtifacts": string(yamlData),
+			}).Error("Could not unmarshal the artifacts")
+			return nil, err
+		}
+		return &artifactsStruct, nil
+
+	case nil:
+		l.Debug("No artifacts declaration")
+		return nil, nil
+
+	default:
+		l.WithFields(logrus.Fields{
+			"converted":     "json-safe",
+			"artifactsType": fmt.Sprintf("%T", artifacts),
+			"artifacts":     toJSONSafeMap(artifacts),
+		}).Error("Found artifacts with unknown type")
+		return nil, nil
+	}
+}
+
+// GetRetryMax gets the maximum number of retries from a retry entry parsed
+// from gitlab ci file, which can be a number or a map with 'max'. The boolean
+// is false when the entry is missing or can't be read.
+func GetRetryMax(retryInterface interface{}) (int, bool) {
+	l := logrus.WithFields(logrus.Fields{
+		"action": "GetRetryMax",
+	})
+
+	switch retry := retryInterface.(type) {
+	case int:
+		return retry, true
+
+	case map[interface{}]interface{}:
+		// A retry map without max doesn't retry the job
+		maxRetries, exists := retry["max"]
+		if !exists {
+			return 0, true
+		}
+		if maxInt, ok := maxRetries.(int); ok {
+			return maxInt, true
+		}
+		l.WithField("maxType", fmt.Sprintf("%T", maxRetries)).Error("Found a retry max with unknown type")
+		return 0, false
+
+	case nil:
+		l.Debug("No retry declaration")
+		return 0, false
+
+	default:
+		l.WithFields(logrus.Fields{
+			"converted": "json-safe",
+			"retryType": fmt.Sprintf("%T", retry),
+			"retry":     toJSONSafeMap(retry),
+		}).Error("Found retry with unknown type")
+		return 0, false
+	}
+}
+
+// durationUnits maps the units of GitLab durations to their length
+var durationUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "wk": 7 * 24 * time.Hour, "wks": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+	"mo": 30 * 24 * time.Hour, "mos": 30 * 24 * time.Hour, "month": 30 * 24 * time.Hour, "months": 30 * 24 * time.Hour,
+	"y": 365 * 24 * time.Hour, "yr": 365 * 24 * time.Hour, "yrs": 365 * 24 * time.Hour, "year": 365 * 24 * time.Hour, "years": 365 * 24 * time.Hour,
+}
+
+// durationPartRegex matches a number followed by an optional unit
+var durationPartRegex = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([a-z]*)`)
+
+// ParseDuration parses a human readable duration as used by GitLab in
+// 'timeout' and 'artifacts:expire_in' (e.g., "1h 30m", "2 days", "3600").
+// A number without unit is in seconds. Parts can be separated by spaces,
+// commas or "and".
+func ParseDuration(value string) (time.Duration, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	normalized = strings.ReplaceAll(normalized, ",", " ")
+	normalized = strings.ReplaceAll(normalized, " and ", " ")
+	if normalized == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	// Everything must be a number and unit pair: "1h30m" -> "1h", "30m"
+	if strings.TrimSpace(durationPartRegex.ReplaceAllString(normalized, "")) != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	total := time.Duration(0)
+	for _, matches := range durationPartRegex.FindAllStringSubmatch(normalized, -1) {
+		number, err := strconv.ParseFloat(matches[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+		unit := time.Second
+		if matches[2] != "" {
+			var ok bool
+			unit, ok = durationUnits[matches[2]]
+			if !ok {
+				return 0, fmt.Errorf("invalid duration %q: unknown unit %q", value, matches[2])
+			}
+		}
+		total += time.Duration(number * float64(unit))
+	}
+
+	return total, nil
+}
+
+// GetTimeout gets the duration of a timeout parsed from gitlab ci file, which
+// can be a duration string or a number of seconds. The boolean is false when
+// the timeout is missing.
+func GetTimeout(timeoutInterface interface{}) (time.Duration, bool, error) {
+	switch timeout := timeoutInterface.(type) {
+	case string:
+		duration, err := ParseDuration(timeout)
+		return duration, true, err
+	case int:
+		return time.Duration(timeout) * time.Second, true, nil
+	case nil:
+		return 0, false, nil
+	default:
+		return 0, true, fmt.Errorf("timeout with unknown type %T", timeout)
+	}
+}
+
+// GetRules gets the rules of a job from an interface parsed from gitlab ci file
+func GetRules(rulesInterface interface{}) ([]Rule, error) {
+	l := logrus.WithFields(logrus.Fields{
+		"action": "GetRules",
+	})
+
+	switch rules := rulesInterface.(type) {
+	case []interface{}:
+		yamlData, err := yaml.Marshal(rules)
+		if err != nil {
+			l.WithError(err).Error("Could not marshal the rules")
+			return []Rule{}, err
+		}
+		rulesStruct := []Rule{}
+		if err := yaml.Unmarshal(yamlData, &rulesStruct); err != nil {
+			l.WithError(err).WithField("yamlRules", string(yamlData)).Error("Could not unmarshal the rules")
+			return []Rule{}, err
+		}
+		return rulesStruct, nil
+
+	case nil:
+		l.Debug("No rules declaration")
+		return []Rule{}, nil
+
+	default:
+		return []Rule{}, fmt.Errorf("rules must be a list, got %T", rules)
+	}
+}
+
+// ComponentSpecInput is an input declared in the spec:inputs header of a component
+type ComponentSpecInput struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"` // True if the input has no default value
+}
+
+// ParseComponentSpecInputs parses the spec:inputs header of a component file.
+// The header is the first YAML document of the file, followed by '---'.
+// Inputs are returned sorted by name; a file without header declares no input.
+func ParseComponentSpecInputs(content []byte) ([]ComponentSpecInput, error) {
+	inputs := []ComponentSpecInput{}
+
+	header := struct {
+		Spec *struct {
+			Inputs map[string]interface{} `yaml:"inputs"`
+		} `yaml:"spec"`
+	}{}
+	if err := yaml.NewDecoder(bytes.NewReader(content)).Decode(&header); err != nil && err != io.EOF {
+		return inputs, err
+	}
+	if header.Spec == nil {
+		return inputs, nil
+	}
+
+	for name, definition := range header.Spec.Inputs {
+		required := true
+		if definitionMap, ok := definition.(map[interface{}]interface{}); ok {
+			_, hasDefault := definitionMap["default"]
+			required = !hasDefault
+		}
+		inputs = append(inputs, ComponentSpecInput{Name: name, Required: required})
+	}
+	sort.Slice(inputs, func(i, j int) bool {
+		return inputs[i].Name < inputs[j].Name
+	})
+
+	return inputs, nil
+}
+
+// FetchComponentSpecInputs fetches the file of a component include at the
+// exact commit used by the pipeline (from its raw URL in the merged
+// configuration) and returns the inputs declared in its spec
+func FetchComponentSpecInputs(include MergedCIConfResponseInclude, token, APIURL string, conf *configuration.Configuration) ([]ComponentSpecInput, error) {
+	l := logrus.WithFields(logrus.Fields{
+		"action":  "FetchComponentSpecInputs",
+		"include": include.Location,
+		"raw":     include.Raw,
+	})
+
+	// Raw URL format: <instance>/<project path>/-/raw/<sha>/<file path>
+	rawPath := strings.TrimPrefix(include.Raw, strings.TrimSuffix(APIURL, "/")+"/")
+	projectPath, filePart, found := strings.Cut(rawPath, "/-/raw/")
+	if !found {
+		return nil, fmt.Errorf("unable to locate the file of component '%s'", include.Location)
+	}
+	ref, filePath, found := strings.Cut(filePart, "/")
+	if !found {
+		return nil, fmt.Errorf("unable to locate the file of component '%s'", include.Location)
+	}
+
+	content, notFoundErr, err := FetchGitlabFile(projectPath, filePath, ref, token, APIURL, conf)
+	if err != nil {
+		return nil, err
+	}
+	if notFoundErr != nil {
+		return nil, fmt.Errorf("unable to fetch the file of component '%s': %w", include.Location, notFoundErr)
+	}
+
+	inputs, err := ParseComponentSpecInputs(content)
+	if err != nil {
+		l.WithError(err).Warn("Unable to parse the spec of the component")
+		return nil, fmt.Errorf("unable to parse the spec of component '%s': %w", include.Location, err)
+	}
+	return inputs, nil
+}
+
 // ParseGitlabCI parses a .gitlab-ci.yml file
 func ParseGitlabCI(fileContent []byte) (*GitlabCIConf, error) {
 	l := logrus.WithFields(logrus.Fields{
@@ -2,10 +2,18 @@ module github.com/getplumber/plumber
 
 go 1.25
 
-require github.com/spf13/cobra v1.8.1
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/IGLOU-EU/go-wildcard/v2 v2.1.0
+	github.com/hashicorp/go-version v1.8.0
+	github.com/machinebox/graphql v0.2.2
+	github.com/sirupsen/logrus v1.9.3
+	github.com/spf13/cobra v1.8.1
+	gitlab.com/gitlab-org/api/client-go v1.13.0
+	gopkg.in/yaml.v2 v2.4.0
+)
 
 require (
-	github.com/IGLOU-EU/go-wildcard/v2 v2.1.0 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/felixge/httpsnoop v1.0.4 // indirect
 	github.com/go-logr/logr v1.4.3 // indirect
@@ -13,13 +21,9 @@ require (
 	github.com/google/go-querystring v1.2.0 // indirect
 	github.com/hashicorp/go-cleanhttp v0.5.2 // indirect
 	github.com/hashicorp/go-retryablehttp v0.7.8 // indirect
-	github.com/hashicorp/go-version v1.8.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/machinebox/graphql v0.2.2 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
-	github.com/sirupsen/logrus v1.9.3 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
-	gitlab.com/gitlab-org/api/client-go v1.13.0 // indirect
 	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.64.0 // indirect
 	go.opentelemetry.io/otel v1.39.0 // indirect
@@ -28,5 +32,4 @@ require (
 	golang.org/x/oauth2 v0.33.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
 	golang.org/x/time v0.14.0 // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
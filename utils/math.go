@@ -0,0 +1,13 @@
+package utils
+
+import "math"
+
+// RoundToPrecision rounds a value to the given number of decimals
+// A negative precision is treated as 0
+func RoundToPrecision(value float64, precision int) float64 {
+	if precision < 0 {
+		precision = 0
+	}
+	factor := math.Pow(10, float64(precision))
+	return math.Round(value*factor) / factor
+}